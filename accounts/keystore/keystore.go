@@ -230,9 +230,9 @@ func (ks *KeyStore) SignTx(a accounts.Account, tx *types.Transaction, chainID *b
 	if !found {
 		return nil, ErrLocked
 	}
-	// Depending on the presence of the chain ID, sign with EIP155 or homestead
+	// Depending on the presence of the chain ID, sign with EIP2930 or homestead
 	if chainID != nil {
-		return types.SignTx(tx, types.NewEIP155Signer(chainID), unlockedKey.PrivateKey)
+		return types.SignTx(tx, types.LatestSignerForChainID(chainID), unlockedKey.PrivateKey)
 	}
 	return types.SignTx(tx, types.HomesteadSigner{}, unlockedKey.PrivateKey)
 }
@@ -247,9 +247,9 @@ func (ks *KeyStore) SignEthTx(a accounts.Account, tx *types.EthTransaction, chai
 	if !found {
 		return nil, ErrLocked
 	}
-	// Depending on the presence of the chain ID, sign with EIP155 or homestead
+	// Depending on the presence of the chain ID, sign with EIP2930 or homestead
 	if chainID != nil {
-		return types.SignEthTx(tx, types.NewEIP155Signer(chainID), unlockedKey.PrivateKey)
+		return types.SignEthTx(tx, types.LatestSignerForChainID(chainID), unlockedKey.PrivateKey)
 	}
 	return types.SignEthTx(tx, types.HomesteadSigner{}, unlockedKey.PrivateKey)
 }
@@ -289,9 +289,9 @@ func (ks *KeyStore) SignTxWithPassphrase(a accounts.Account, passphrase string,
 	}
 	defer zeroKey(key.PrivateKey)
 
-	// Depending on the presence of the chain ID, sign with EIP155 or homestead
+	// Depending on the presence of the chain ID, sign with EIP2930 or homestead
 	if chainID != nil {
-		return types.SignTx(tx, types.NewEIP155Signer(chainID), key.PrivateKey)
+		return types.SignTx(tx, types.LatestSignerForChainID(chainID), key.PrivateKey)
 	}
 	return types.SignTx(tx, types.HomesteadSigner{}, key.PrivateKey)
 }
@@ -305,9 +305,9 @@ func (ks *KeyStore) SignEthTxWithPassphrase(a accounts.Account, passphrase strin
 	}
 	defer zeroKey(key.PrivateKey)
 
-	// Depending on the presence of the chain ID, sign with EIP155 or homestead
+	// Depending on the presence of the chain ID, sign with EIP2930 or homestead
 	if chainID != nil {
-		return types.SignEthTx(tx, types.NewEIP155Signer(chainID), key.PrivateKey)
+		return types.SignEthTx(tx, types.LatestSignerForChainID(chainID), key.PrivateKey)
 	}
 	return types.SignEthTx(tx, types.HomesteadSigner{}, key.PrivateKey)
 }
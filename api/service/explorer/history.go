@@ -0,0 +1,134 @@
+package explorer
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// TxHistoryQuery is the query of a page of the transaction history of an address
+type TxHistoryQuery struct {
+	Address   string
+	Staking   bool   // query the staking transactions instead of the normal ones
+	Direction string // SENT, RECEIVED, or ALL
+	FromBlock uint64
+	ToBlock   uint64 // inclusive
+	Desc      bool
+	PageSize  int
+	Offset    int    // number of entries skipped before the page
+	Cursor    string // continuation token returned with the previous page
+}
+
+// txHistoryFilter is the parsed TxHistoryQuery passed to explorerDB
+type txHistoryFilter struct {
+	staking   bool
+	direction TxType // txUnknown for both directions
+	fromBlock uint64
+	toBlock   uint64
+	desc      bool
+	limit     int
+	offset    int
+	cursor    *txCursor
+}
+
+func (q TxHistoryQuery) toFilter() (txHistoryFilter, error) {
+	f := txHistoryFilter{
+		staking:   q.Staking,
+		fromBlock: q.FromBlock,
+		toBlock:   q.ToBlock,
+		desc:      q.Desc,
+		limit:     q.PageSize,
+		offset:    q.Offset,
+	}
+	switch q.Direction {
+	case "", "ALL":
+	case txSentStr:
+		f.direction = txSent
+	case txReceivedStr:
+		f.direction = txReceived
+	default:
+		return f, errors.Errorf("unknown transaction direction: %v", q.Direction)
+	}
+	if f.fromBlock > f.toBlock {
+		return f, errors.Errorf("from block %v after to block %v", f.fromBlock, f.toBlock)
+	}
+	if f.limit <= 0 {
+		return f, errors.Errorf("invalid page size: %v", f.limit)
+	}
+	if f.offset < 0 {
+		return f, errors.Errorf("invalid offset: %v", f.offset)
+	}
+	if q.Cursor != "" {
+		c, err := decodeTxCursor(q.Cursor)
+		if err != nil {
+			return f, err
+		}
+		if c.staking != f.staking || c.desc != f.desc {
+			return f, errors.New("cursor does not match the query")
+		}
+		f.cursor = c
+	}
+	return f, nil
+}
+
+// match returns whether the entry at the position with the direction is in the page
+func (f txHistoryFilter) match(bn uint64, tt TxType) bool {
+	if bn < f.fromBlock || bn > f.toBlock {
+		return false
+	}
+	return f.direction == txUnknown || f.direction == tt
+}
+
+// sqlToBlock returns the to block which fits in the signed BIGINT of SQL
+func (f txHistoryFilter) sqlToBlock() uint64 {
+	if f.toBlock > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return f.toBlock
+}
+
+const (
+	txCursorVersion = 1
+	txCursorLen     = 1 + 1 + 8 + 8 // version, flags, block number, tx index
+
+	txCursorFlagStaking = 1 << 0
+	txCursorFlagDesc    = 1 << 1
+)
+
+// txCursor is the position of the first entry of the next page in the transaction
+// history. It is returned to the client as an opaque continuation token.
+type txCursor struct {
+	staking     bool
+	desc        bool
+	blockNumber uint64
+	txIndex     uint64
+}
+
+func (c *txCursor) encode() string {
+	b := make([]byte, txCursorLen)
+	b[0] = txCursorVersion
+	if c.staking {
+		b[1] |= txCursorFlagStaking
+	}
+	if c.desc {
+		b[1] |= txCursorFlagDesc
+	}
+	binary.BigEndian.PutUint64(b[2:10], c.blockNumber)
+	binary.BigEndian.PutUint64(b[10:18], c.txIndex)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeTxCursor(s string) (*txCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != txCursorLen || b[0] != txCursorVersion {
+		return nil, errors.New("invalid cursor")
+	}
+	return &txCursor{
+		staking:     b[1]&txCursorFlagStaking != 0,
+		desc:        b[1]&txCursorFlagDesc != 0,
+		blockNumber: binary.BigEndian.Uint64(b[2:10]),
+		txIndex:     binary.BigEndian.Uint64(b[10:18]),
+	}, nil
+}
@@ -0,0 +1,147 @@
+package explorer
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTxCursor(t *testing.T) {
+	c := &txCursor{staking: true, desc: true, blockNumber: 100, txIndex: 2}
+	got, err := decodeTxCursor(c.encode())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("unexpected cursor %+v / %+v", got, c)
+	}
+	for _, s := range []string{"", "invalid", c.encode()[1:]} {
+		if _, err := decodeTxCursor(s); err == nil {
+			t.Errorf("expect error for cursor %q", s)
+		}
+	}
+}
+
+func TestTxHistoryQuery_toFilter(t *testing.T) {
+	cursor := (&txCursor{desc: true, blockNumber: 1}).encode()
+	tests := []struct {
+		q      TxHistoryQuery
+		expErr bool
+	}{
+		{TxHistoryQuery{Direction: "SENT", ToBlock: 10, PageSize: 1}, false},
+		{TxHistoryQuery{Direction: "ALL", ToBlock: 10, PageSize: 1, Desc: true, Cursor: cursor}, false},
+		{TxHistoryQuery{Direction: "UNKNOWN", ToBlock: 10, PageSize: 1}, true},
+		{TxHistoryQuery{FromBlock: 11, ToBlock: 10, PageSize: 1}, true},
+		{TxHistoryQuery{ToBlock: 10}, true},
+		// cursor of descending order used in ascending order
+		{TxHistoryQuery{ToBlock: 10, PageSize: 1, Cursor: cursor}, true},
+	}
+	for i, test := range tests {
+		_, err := test.q.toFilter()
+		if (err != nil) != test.expErr {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestExplorerDB_getTxHistory(t *testing.T) {
+	dbs := map[string]explorerDB{
+		BackendLevelDB: &kvDB{newTestLevelDB(t, 0)},
+		BackendSQL:     newTestSQLDB(t, 1),
+	}
+	for name, db := range dbs {
+		tb := makeTestBlock(t)
+		bc := &blockComputer{db: db, bc: tb}
+		bd, err := bc.computeBlock(tb.block)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if err := db.writeBlock(bd); err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		txs := tb.block.Transactions()
+
+		tests := []struct {
+			q         TxHistoryQuery
+			expHashes []common.Hash
+		}{
+			{
+				q:         TxHistoryQuery{PageSize: 10},
+				expHashes: []common.Hash{txs[0].Hash(), txs[1].Hash(), txs[2].Hash()},
+			},
+			{
+				q:         TxHistoryQuery{PageSize: 10, Desc: true},
+				expHashes: []common.Hash{txs[2].Hash(), txs[1].Hash(), txs[0].Hash()},
+			},
+			{
+				q:         TxHistoryQuery{PageSize: 10, Direction: "SENT"},
+				expHashes: []common.Hash{txs[0].Hash(), txs[2].Hash()},
+			},
+			{
+				q:         TxHistoryQuery{PageSize: 10, Direction: "RECEIVED", Desc: true},
+				expHashes: []common.Hash{txs[1].Hash()},
+			},
+			{
+				q:         TxHistoryQuery{PageSize: 10, FromBlock: testBlockNumber + 1},
+				expHashes: nil,
+			},
+			{
+				q:         TxHistoryQuery{PageSize: 10, Staking: true},
+				expHashes: nil,
+			},
+		}
+		for i, test := range tests {
+			test.q.Address = string(tb.addrs[0])
+			if test.q.ToBlock == 0 {
+				test.q.ToBlock = math.MaxUint64
+			}
+			f, err := test.q.toFilter()
+			if err != nil {
+				t.Fatalf("%v test %v: %v", name, i, err)
+			}
+			// Walk the pages one entry each
+			pageF := f
+			pageF.limit = 1
+			var hashes []common.Hash
+			for {
+				page, _, next, err := db.getTxHistory(tb.addrs[0], pageF)
+				if err != nil {
+					t.Fatalf("%v test %v: %v", name, i, err)
+				}
+				hashes = append(hashes, page...)
+				if next == nil {
+					break
+				}
+				if len(page) != 1 {
+					t.Fatalf("%v test %v: unexpected page size %v", name, i, len(page))
+				}
+				pageF.cursor = next
+			}
+			if !reflect.DeepEqual(hashes, test.expHashes) {
+				t.Errorf("%v test %v: unexpected paged hashes %v / %v", name, i, hashes, test.expHashes)
+			}
+			// All in one page
+			hashes, _, next, err := db.getTxHistory(tb.addrs[0], f)
+			if err != nil {
+				t.Fatalf("%v test %v: %v", name, i, err)
+			}
+			if next != nil || !reflect.DeepEqual(hashes, test.expHashes) {
+				t.Errorf("%v test %v: unexpected hashes %v / %v", name, i, hashes, test.expHashes)
+			}
+			// The page after the first entry
+			if len(test.expHashes) > 1 {
+				offsetF := f
+				offsetF.offset = 1
+				hashes, _, _, err := db.getTxHistory(tb.addrs[0], offsetF)
+				if err != nil {
+					t.Fatalf("%v test %v: %v", name, i, err)
+				}
+				if !reflect.DeepEqual(hashes, test.expHashes[1:]) {
+					t.Errorf("%v test %v: unexpected hashes with offset %v / %v", name, i, hashes, test.expHashes[1:])
+				}
+			}
+		}
+	}
+}
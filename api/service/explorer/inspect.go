@@ -0,0 +1,62 @@
+package explorer
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/pebble"
+	"github.com/harmony-one/harmony/internal/shardchain"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// explorerDBCategories are the categories of the entries of the explorer database
+var explorerDBCategories = []rawdb.KeyCategory{
+	rawdb.PrefixCategory("Addresses", addrPrefix, 0),
+	rawdb.PrefixCategory("Transactions", txnPrefix, 0),
+	rawdb.PrefixCategory("Normal tx indexes", addrNormalTxnIndexPrefix, 0),
+	rawdb.PrefixCategory("Staking tx indexes", addrStakingTxnIndexPrefix, 0),
+	rawdb.PrefixCategory("Token transfers", addrTokenTransferPrefix, 0),
+	rawdb.PrefixCategory("Token holders", tokenHolderPrefix, 0),
+	rawdb.PrefixCategory("Token balances", tokenBalancePrefix, 0),
+	rawdb.PrefixCategory("Checkpoints", []byte(CheckpointPrefix), 0),
+	rawdb.PrefixCategory("Legacy addresses", []byte(LegAddressPrefix), 0),
+	rawdb.PrefixCategory("Version", versionKey, len(versionKey)),
+}
+
+// InspectDB iterates the explorer database of the engine in dbPath, and returns
+// the storage usage by the categories of the entries. The database is opened
+// read only.
+func InspectDB(dbPath string, engine string) ([]rawdb.DatabaseStat, error) {
+	switch engine {
+	case "", shardchain.LevelDBEngine:
+		db, err := leveldb.OpenFile(dbPath, &opt.Options{
+			ErrorIfMissing: true,
+			ReadOnly:       true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+
+		it := db.NewIterator(nil, nil)
+		defer it.Release()
+		return rawdb.InspectIterator(it, explorerDBCategories)
+	case shardchain.PebbleEngine:
+		if _, err := os.Stat(dbPath); err != nil {
+			return nil, err
+		}
+		db, err := pebble.New(dbPath, 0, 0, true)
+		if err != nil {
+			return nil, err
+		}
+		defer db.Close()
+
+		it := db.NewIterator()
+		defer it.Release()
+		return rawdb.InspectIterator(it, explorerDBCategories)
+	default:
+		return nil, fmt.Errorf("unknown database engine: %v", engine)
+	}
+}
@@ -0,0 +1,68 @@
+package explorer
+
+import (
+	"testing"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/shardchain"
+)
+
+func TestInspectDB(t *testing.T) {
+	for i, engine := range []string{shardchain.LevelDBEngine, shardchain.PebbleEngine} {
+		testInspectDB(t, i, engine)
+	}
+}
+
+func testInspectDB(t *testing.T, i int, engine string) {
+	dbDir := tempTestDir(t, i)
+	kv, err := newKVDB(dbDir, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := kv.db
+	if err := writeVersion(db, versionV100); err != nil {
+		t.Fatal(err)
+	}
+	for bn := uint64(0); bn != 3; bn++ {
+		if err := writeCheckpoint(db, bn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writeAddressEntry(db, "one1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("unknown"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	switch db := db.(type) {
+	case *lvlDB:
+		db.db.Close()
+	case *pebbleDB:
+		db.db.Close()
+	}
+
+	stats, err := InspectDB(dbDir, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]uint64)
+	for _, s := range stats {
+		counts[s.Category] = s.Count
+	}
+	expected := map[string]uint64{
+		"Version":                 1,
+		"Checkpoints":             3,
+		"Addresses":               1,
+		"Transactions":            0,
+		rawdb.UnaccountedCategory: 1,
+	}
+	for category, count := range expected {
+		if counts[category] != count {
+			t.Errorf("%v %v: unexpected count %v / %v", engine, category, counts[category], count)
+		}
+	}
+
+	if _, err := InspectDB(tempTestDir(t, i+2), engine); err == nil {
+		t.Errorf("%v: expect error for missing database", engine)
+	}
+}
@@ -2,12 +2,35 @@ package explorer
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/filter"
+	leveldbIterator "github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	levelutil "github.com/syndtr/goleveldb/leveldb/util"
 )
 
+// explorerDB is the storage backend of the explorer. Implemented by kvDB over
+// the key-value database, and sqlDB over the SQL database.
+type explorerDB interface {
+	// migrate brings the db to the latest schema before serving
+	migrate(bc blockChainTxIndexer, closeC chan struct{}) error
+	// isBlockComputed returns whether the block of the number is already written
+	isBlockComputed(bn uint64) (bool, error)
+	// writeBlock writes the data computed from a block atomically
+	writeBlock(bd *blockData) error
+
+	getAddresses(start oneAddress, size int) ([]oneAddress, error)
+	getNormalTxsByAddress(addr oneAddress) ([]common.Hash, []TxType, error)
+	getStakingTxsByAddress(addr oneAddress) ([]common.Hash, []TxType, error)
+	// getTxHistory returns a page of the transaction history of the address
+	// filtered by f, and the position of the first entry of the next page. Nil
+	// position is returned if there is no more entries.
+	getTxHistory(addr oneAddress, f txHistoryFilter) ([]common.Hash, []TxType, *txCursor, error)
+	getTokenTransfersByAddress(addr oneAddress, offset, limit int) ([]*TokenTransfer, error)
+	getTokenHolders(token oneAddress, offset, limit int) ([]*TokenHolder, error)
+}
+
 // database is an adapter for *leveldb.DB
 type database interface {
 	databaseWriter
@@ -17,6 +40,7 @@ type database interface {
 
 type databaseWriter interface {
 	Put(key, val []byte) error
+	Delete(key []byte) error
 }
 
 type databaseReader interface {
@@ -24,6 +48,9 @@ type databaseReader interface {
 	Has(key []byte) (bool, error)
 	NewPrefixIterator(prefix []byte) iterator
 	NewSizedIterator(start []byte, size int) iterator
+	// NewRangeIterator iterates the keys in [start, limit) in ascending order, or
+	// in descending order if reverse. Nil limit means no upper bound.
+	NewRangeIterator(start, limit []byte, reverse bool) iterator
 }
 
 type batch interface {
@@ -57,6 +84,10 @@ func (db *lvlDB) Put(key, val []byte) error {
 	return db.db.Put(key, val, nil)
 }
 
+func (db *lvlDB) Delete(key []byte) error {
+	return db.db.Delete(key, nil)
+}
+
 func (db *lvlDB) Get(key []byte) ([]byte, error) {
 	return db.db.Get(key, nil)
 }
@@ -83,6 +114,29 @@ func (db *lvlDB) NewSizedIterator(start []byte, size int) iterator {
 	return db.newSizedIterator(start, size)
 }
 
+func (db *lvlDB) NewRangeIterator(start, limit []byte, reverse bool) iterator {
+	rng := &levelutil.Range{Start: start, Limit: limit}
+	it := db.db.NewIterator(rng, nil)
+	if reverse {
+		return &reverseIterator{Iterator: it}
+	}
+	return it
+}
+
+// reverseIterator iterates the leveldb iterator from the last entry backwards
+type reverseIterator struct {
+	leveldbIterator.Iterator
+	started bool
+}
+
+func (it *reverseIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		return it.Iterator.Last()
+	}
+	return it.Iterator.Prev()
+}
+
 type sizedIterator struct {
 	it        iterator
 	curIndex  int
@@ -125,6 +179,11 @@ func (b *lvlBatch) Put(key, val []byte) error {
 	return nil
 }
 
+func (b *lvlBatch) Delete(key []byte) error {
+	b.batch.Delete(key)
+	return nil
+}
+
 func (b *lvlBatch) Write() error {
 	if err := b.db.Write(b.batch, nil); err != nil {
 		return err
@@ -150,3 +209,10 @@ type iterator interface {
 type blockChainTxIndexer interface {
 	ReadTxLookupEntry(txID common.Hash) (common.Hash, uint64, uint64)
 }
+
+// blockChainReader is the interface to read the transaction look up entries and
+// the receipts of blocks. Implemented by *core.BlockChain
+type blockChainReader interface {
+	blockChainTxIndexer
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
@@ -117,6 +117,15 @@ func (db *memDB) Put(key, val []byte) error {
 	return nil
 }
 
+func (db *memDB) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	realKey := hex.EncodeToString(key)
+	delete(db.keyValues, realKey)
+	return nil
+}
+
 func (db *memDB) Has(key []byte) (bool, error) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -141,6 +150,7 @@ func (db *memDB) Get(key []byte) ([]byte, error) {
 func (db *memDB) NewBatch() batch {
 	return &memBatch{
 		keyValues: make(map[string][]byte),
+		deletes:   make(map[string]struct{}),
 		db:        db,
 	}
 }
@@ -175,22 +185,65 @@ func (db *memDB) NewSizedIterator(start []byte, size int) iterator {
 	return nil
 }
 
+func (db *memDB) NewRangeIterator(start, limit []byte, reverse bool) iterator {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	var (
+		startStr = hex.EncodeToString(start)
+		limitStr = hex.EncodeToString(limit)
+		keys     = make([]string, 0, len(db.keyValues))
+		values   = make([][]byte, 0, len(db.keyValues))
+	)
+	for key := range db.keyValues {
+		if key >= startStr && (limit == nil || key < limitStr) {
+			keys = append(keys, key)
+		}
+	}
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
+	for _, key := range keys {
+		values = append(values, db.keyValues[key])
+	}
+	return &memPrefixIterator{
+		keys:   keys,
+		values: values,
+		index:  -1,
+	}
+}
+
 type memBatch struct {
 	keyValues map[string][]byte
+	deletes   map[string]struct{}
 	db        *memDB
 	valueSize int
 }
 
 func (b *memBatch) Put(key, val []byte) error {
-	b.keyValues[hex.EncodeToString(key)] = val
+	k := hex.EncodeToString(key)
+	b.keyValues[k] = val
+	delete(b.deletes, k)
 	b.valueSize += len(val)
 	return nil
 }
 
+func (b *memBatch) Delete(key []byte) error {
+	k := hex.EncodeToString(key)
+	b.deletes[k] = struct{}{}
+	delete(b.keyValues, k)
+	return nil
+}
+
 func (b *memBatch) Write() error {
 	for k, v := range b.keyValues {
 		b.db.keyValues[k] = v
 	}
+	for k := range b.deletes {
+		delete(b.db.keyValues, k)
+	}
 	b.valueSize = 0
 	return nil
 }
@@ -0,0 +1,152 @@
+package explorer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/internal/shardchain"
+)
+
+// kvDB is the explorerDB over the key-value database with the schema in schema.go
+type kvDB struct {
+	db database
+}
+
+// newKVDB opens the key-value database of the engine in dbPath
+func newKVDB(dbPath string, engine string) (*kvDB, error) {
+	var (
+		db  database
+		err error
+	)
+	switch engine {
+	case "", shardchain.LevelDBEngine:
+		db, err = newLvlDB(dbPath)
+	case shardchain.PebbleEngine:
+		db, err = newPebbleDB(dbPath)
+	default:
+		err = fmt.Errorf("unknown database engine: %v", engine)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &kvDB{db}, nil
+}
+
+func (kv *kvDB) migrate(bc blockChainTxIndexer, closeC chan struct{}) error {
+	if is, err := isVersionV100(kv.db); is && err == nil {
+		return nil
+	}
+	return migrateToV100(kv.db, bc, closeC)
+}
+
+func (kv *kvDB) isBlockComputed(bn uint64) (bool, error) {
+	return isBlockComputedInDB(kv.db, bn)
+}
+
+func (kv *kvDB) writeBlock(bd *blockData) error {
+	btc := kv.db.NewBatch()
+
+	// Not very sure how this 1000 come from. But it is the logic before migration
+	t := bd.block.Time().Uint64() * 1000
+	tm := time.Unix(int64(t), 0)
+
+	for _, td := range bd.txs {
+		_ = writeAddressEntry(btc, td.from)
+		_ = writeNormalTxnIndex(btc, normalTxnIndex{
+			addr:        td.from,
+			blockNumber: td.blockNumber,
+			txnIndex:    td.index,
+			txnHash:     td.hash,
+		}, txSent)
+		if td.to != "" {
+			_ = writeAddressEntry(btc, td.to)
+			_ = writeNormalTxnIndex(btc, normalTxnIndex{
+				addr:        td.to,
+				blockNumber: td.blockNumber,
+				txnIndex:    td.index,
+				txnHash:     td.hash,
+			}, txReceived)
+		}
+		_ = writeTxn(btc, td.hash, &TxRecord{td.hash, tm})
+	}
+	for _, td := range bd.stakings {
+		_ = writeAddressEntry(btc, td.from)
+		_ = writeStakingTxnIndex(btc, stakingTxnIndex{
+			addr:        td.from,
+			blockNumber: td.blockNumber,
+			txnIndex:    td.index,
+			txnHash:     td.hash,
+		}, txSent)
+		_ = writeTxn(btc, td.hash, &TxRecord{td.hash, tm})
+		if td.to != "" {
+			_ = writeAddressEntry(btc, td.to)
+			_ = writeStakingTxnIndex(btc, stakingTxnIndex{
+				addr:        td.to,
+				blockNumber: td.blockNumber,
+				txnIndex:    td.index,
+				txnHash:     td.hash,
+			}, txReceived)
+		}
+	}
+	for _, tt := range bd.tokenTransfers {
+		_ = writeTokenTransferIndex(btc, ethToOneAddress(tt.From), tt)
+		if tt.To != tt.From {
+			_ = writeTokenTransferIndex(btc, ethToOneAddress(tt.To), tt)
+		}
+	}
+	if err := kv.writeTokenHolders(btc, bd.tokenTransfers); err != nil {
+		return err
+	}
+	_ = writeCheckpoint(btc, bd.block.NumberU64())
+	return btc.Write()
+}
+
+// writeTokenHolders applies the balance changes of the token transfers to the
+// token holders. The signed balances are kept apart from the holders, which are
+// the ones with a positive balance.
+func (kv *kvDB) writeTokenHolders(btc batch, transfers []*TokenTransfer) error {
+	for id, delta := range tokenBalanceChanges(transfers) {
+		token, holder := ethToOneAddress(id.token), ethToOneAddress(id.holder)
+		balance, err := readTokenBalance(kv.db, token, holder)
+		if err != nil {
+			return err
+		}
+		balance.Add(balance, delta)
+		if balance.Sign() != 0 {
+			_ = writeTokenBalance(btc, token, holder, balance)
+		} else {
+			_ = deleteTokenBalance(btc, token, holder)
+		}
+		if isTokenHolder(balance) {
+			_ = writeTokenHolder(btc, token, &TokenHolder{Address: id.holder, Balance: balance})
+		} else {
+			_ = deleteTokenHolder(btc, token, holder)
+		}
+	}
+	return nil
+}
+
+func (kv *kvDB) getAddresses(start oneAddress, size int) ([]oneAddress, error) {
+	return getAddressesInRange(kv.db, start, size)
+}
+
+func (kv *kvDB) getNormalTxsByAddress(addr oneAddress) ([]common.Hash, []TxType, error) {
+	return getNormalTxnHashesByAccount(kv.db, addr)
+}
+
+func (kv *kvDB) getStakingTxsByAddress(addr oneAddress) ([]common.Hash, []TxType, error) {
+	return getStakingTxnHashesByAccount(kv.db, addr)
+}
+
+func (kv *kvDB) getTxHistory(addr oneAddress, f txHistoryFilter) ([]common.Hash, []TxType, *txCursor, error) {
+	return getTxnHistoryByAccount(kv.db, addr, f)
+}
+
+func (kv *kvDB) getTokenTransfersByAddress(addr oneAddress, offset, limit int) ([]*TokenTransfer, error) {
+	return getTokenTransfersByAccount(kv.db, addr, offset, limit)
+}
+
+func (kv *kvDB) getTokenHolders(token oneAddress, offset, limit int) ([]*TokenHolder, error) {
+	return getTokenHolders(kv.db, token, offset, limit)
+}
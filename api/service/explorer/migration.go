@@ -19,16 +19,16 @@ const (
 	legAddressPrefixLen = 3
 )
 
-func (s *storage) migrateToV100() error {
+func migrateToV100(db database, bc blockChainTxIndexer, closeC chan struct{}) error {
 	m := &migrationV100{
-		db:                s.db,
-		bc:                s.bc,
-		btc:               s.db.NewBatch(),
+		db:                db,
+		bc:                bc,
+		btc:               db.NewBatch(),
 		isMigrateFinished: abool.New(),
 		log: utils.Logger().With().
 			Str("module", "explorer DB migration to 1.0.0").Logger(),
 		finishedC: make(chan struct{}),
-		closeC:    s.closeC,
+		closeC:    closeC,
 	}
 	return m.do()
 }
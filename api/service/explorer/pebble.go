@@ -0,0 +1,62 @@
+package explorer
+
+import (
+	"github.com/harmony-one/harmony/internal/pebble"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// pebbleDB is the adapter for pebble.Database
+type pebbleDB struct {
+	db *pebble.Database
+}
+
+func newPebbleDB(dbPath string) (database, error) {
+	// Same cache and file handles as the leveldb
+	db, err := pebble.New(dbPath, 16, 500, false)
+	if err != nil {
+		return nil, err
+	}
+	return &pebbleDB{db}, nil
+}
+
+func (db *pebbleDB) Put(key, val []byte) error {
+	return db.db.Put(key, val)
+}
+
+func (db *pebbleDB) Delete(key []byte) error {
+	return db.db.Delete(key)
+}
+
+// Get returns leveldb.ErrNotFound if the key is not found, which is checked by
+// the schema.
+func (db *pebbleDB) Get(key []byte) ([]byte, error) {
+	val, err := db.db.Get(key)
+	if err == pebble.ErrNotFound {
+		return nil, leveldb.ErrNotFound
+	}
+	return val, err
+}
+
+func (db *pebbleDB) Has(key []byte) (bool, error) {
+	return db.db.Has(key)
+}
+
+func (db *pebbleDB) NewBatch() batch {
+	return db.db.NewBatch()
+}
+
+func (db *pebbleDB) NewPrefixIterator(prefix []byte) iterator {
+	return db.db.NewIteratorWithPrefix(prefix)
+}
+
+func (db *pebbleDB) NewSizedIterator(start []byte, size int) iterator {
+	return &sizedIterator{
+		it:        db.db.NewIteratorWithStart(start),
+		curIndex:  0,
+		sizeLimit: size,
+	}
+}
+
+func (db *pebbleDB) NewRangeIterator(start, limit []byte, reverse bool) iterator {
+	return db.db.NewRangeIterator(start, limit, reverse)
+}
@@ -0,0 +1,85 @@
+package explorer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestPebbleDBPrefixIterator(t *testing.T) {
+	tests := []struct {
+		prefix  []byte
+		expSize int
+	}{
+		{[]byte("00000"), 5},
+		{[]byte("0000"), 7},
+		{[]byte(""), 9},
+		{[]byte("2"), 0},
+	}
+	db := newTestPebbleDB(t, 0)
+	defer db.db.Close()
+	if err := prepareTestLvlDB(db); err != nil {
+		t.Fatal(err)
+	}
+	for i, test := range tests {
+		it := db.NewPrefixIterator(test.prefix)
+		count := 0
+		for it.Next() {
+			count++
+		}
+		it.Release()
+		if count != test.expSize {
+			t.Errorf("Test %v: unexpected iteration size %v / %v", i, count, test.expSize)
+		}
+	}
+}
+
+func TestPebbleDBRangeIterator(t *testing.T) {
+	db := newTestPebbleDB(t, 0)
+	defer db.db.Close()
+	if err := prepareTestLvlDB(db); err != nil {
+		t.Fatal(err)
+	}
+	it := db.NewRangeIterator([]byte("000003"), []byte("100002"), true)
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	it.Release()
+	exp := []string{"100001", "000012", "000011", "000005", "000004", "000003"}
+	if len(keys) != len(exp) {
+		t.Fatalf("unexpected keys %v / %v", keys, exp)
+	}
+	for i := range keys {
+		if keys[i] != exp[i] {
+			t.Errorf("unexpected key %v: %v / %v", i, keys[i], exp[i])
+		}
+	}
+}
+
+func TestPebbleDBNotFound(t *testing.T) {
+	db := newTestPebbleDB(t, 0)
+	defer db.db.Close()
+	if _, err := db.Get([]byte("missing")); !errors.Is(err, leveldb.ErrNotFound) {
+		t.Errorf("unexpected error %v", err)
+	}
+	btc := db.NewBatch()
+	if err := btc.Put([]byte("key"), []byte("val")); err != nil {
+		t.Fatal(err)
+	}
+	if err := btc.Write(); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := db.Get([]byte("key")); err != nil || string(val) != "val" {
+		t.Errorf("unexpected value %s, %v", val, err)
+	}
+}
+
+func newTestPebbleDB(t *testing.T, i int) *pebbleDB {
+	db, err := newPebbleDB(tempTestDir(t, i))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.(*pebbleDB)
+}
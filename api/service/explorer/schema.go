@@ -1,8 +1,10 @@
 package explorer
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -10,6 +12,7 @@ import (
 	goversion "github.com/hashicorp/go-version"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
+	levelutil "github.com/syndtr/goleveldb/leveldb/util"
 	"go.uber.org/zap/buffer"
 )
 
@@ -75,6 +78,9 @@ var (
 	txnPrefix                 = []byte("tx")
 	addrNormalTxnIndexPrefix  = []byte("at")
 	addrStakingTxnIndexPrefix = []byte("stk")
+	addrTokenTransferPrefix   = []byte("tt")
+	tokenHolderPrefix         = []byte("th")
+	tokenBalancePrefix        = []byte("tb")
 )
 
 // bPool is the sync pool for reusing the memory for allocating db keys
@@ -308,6 +314,259 @@ func writeStakingTxnIndex(db databaseWriter, entry stakingTxnIndex, tt TxType) e
 	return db.Put(key, []byte{byte(tt)})
 }
 
+// txnIndexPosition returns the key of the position in the address-transaction
+// index, which is the prefix of the index entry at the position. The returned key
+// is not allocated from bPool since multiple positions are held at a time.
+func txnIndexPosition(prefix []byte, bn, index uint64) []byte {
+	key := make([]byte, len(prefix)+8+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], bn)
+	binary.BigEndian.PutUint64(key[len(prefix)+8:], index)
+	return key
+}
+
+func txnPositionFromIndexKey(key []byte, prefixLen int) (uint64, uint64, common.Hash, error) {
+	txStart := prefixLen + 8 + 8
+	expSize := txStart + common.HashLength
+	if len(key) < expSize {
+		return 0, 0, common.Hash{}, errors.New("unexpected key size")
+	}
+	bn := binary.BigEndian.Uint64(key[prefixLen:])
+	index := binary.BigEndian.Uint64(key[prefixLen+8:])
+	var txHash common.Hash
+	copy(txHash[:], key[txStart:expSize])
+	return bn, index, txHash, nil
+}
+
+// getTxnHistoryByAccount iterates the normal or staking transaction index of the
+// address within the block range of the filter, starting from the cursor.
+func getTxnHistoryByAccount(db databaseReader, addr oneAddress, f txHistoryFilter) ([]common.Hash, []TxType, *txCursor, error) {
+	var prefix []byte
+	if f.staking {
+		prefix = append(prefix, stakingTxnIndexPrefixByAddr(addr)...)
+	} else {
+		prefix = append(prefix, normalTxnIndexPrefixByAddr(addr)...)
+	}
+	start := txnIndexPosition(prefix, f.fromBlock, 0)
+	limit := levelutil.BytesPrefix(prefix).Limit
+	if f.toBlock != math.MaxUint64 {
+		limit = txnIndexPosition(prefix, f.toBlock+1, 0)
+	}
+	if c := f.cursor; c != nil {
+		pos := txnIndexPosition(prefix, c.blockNumber, c.txIndex)
+		if !f.desc && bytes.Compare(pos, start) > 0 {
+			start = pos
+		}
+		if f.desc {
+			if posLimit := levelutil.BytesPrefix(pos).Limit; bytes.Compare(posLimit, limit) < 0 {
+				limit = posLimit
+			}
+		}
+	}
+
+	var (
+		txHashes []common.Hash
+		tts      []TxType
+		next     *txCursor
+		skipped  int
+	)
+	it := db.NewRangeIterator(start, limit, f.desc)
+	defer it.Release()
+	for it.Next() {
+		bn, index, txHash, err := txnPositionFromIndexKey(it.Key(), len(prefix))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(it.Value()) == 0 {
+			return nil, nil, nil, errors.New("val size not expected")
+		}
+		tt := TxType(it.Value()[0])
+		if !f.match(bn, tt) {
+			continue
+		}
+		if skipped < f.offset {
+			skipped++
+			continue
+		}
+		if len(txHashes) == f.limit {
+			next = &txCursor{staking: f.staking, desc: f.desc, blockNumber: bn, txIndex: index}
+			break
+		}
+		txHashes = append(txHashes, txHash)
+		tts = append(tts, tt)
+	}
+	if err := it.Error(); err != nil {
+		return nil, nil, nil, err
+	}
+	return txHashes, tts, next, nil
+}
+
+// tokenTransferIndex is a single entry of address-token transfer index. The key of
+// the entry in db is a combination of addrTokenTransferPrefix, account address,
+// block number, log index and batch index of the transfer. The value is the RLP
+// encoded TokenTransfer.
+type tokenTransferIndex struct {
+	addr       oneAddress
+	blockNum   uint64
+	logIndex   uint64
+	batchIndex uint64
+}
+
+func (index tokenTransferIndex) key() []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrTokenTransferPrefix)
+	_, _ = b.Write([]byte(index.addr))
+	_ = binary.Write(b, binary.BigEndian, index.blockNum)
+	_ = binary.Write(b, binary.BigEndian, index.logIndex)
+	_ = binary.Write(b, binary.BigEndian, index.batchIndex)
+	return b.Bytes()
+}
+
+func tokenTransferIndexPrefixByAddr(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(addrTokenTransferPrefix)
+	_, _ = b.Write([]byte(addr))
+	return b.Bytes()
+}
+
+func writeTokenTransferIndex(db databaseWriter, addr oneAddress, tt *TokenTransfer) error {
+	index := tokenTransferIndex{
+		addr:       addr,
+		blockNum:   tt.BlockNumber,
+		logIndex:   tt.LogIndex,
+		batchIndex: tt.BatchIndex,
+	}
+	val, err := rlp.EncodeToBytes(tt)
+	if err != nil {
+		return err
+	}
+	return db.Put(index.key(), val)
+}
+
+func getTokenTransfersByAccount(db databaseReader, addr oneAddress, offset, limit int) ([]*TokenTransfer, error) {
+	var tts []*TokenTransfer
+	prefix := tokenTransferIndexPrefixByAddr(addr)
+	err := forEachAtPrefixInRange(db, prefix, offset, limit, func(key, val []byte) error {
+		var tt *TokenTransfer
+		if err := rlp.DecodeBytes(val, &tt); err != nil {
+			return err
+		}
+		tts = append(tts, tt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tts, nil
+}
+
+// getTokenHolderKey returns the key of the token holder entry, which is a
+// combination of tokenHolderPrefix, token address and holder address. The value
+// is the RLP encoded TokenHolder.
+func getTokenHolderKey(token, holder oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenHolderPrefix)
+	_, _ = b.Write([]byte(token))
+	_, _ = b.Write([]byte(holder))
+	return b.Bytes()
+}
+
+func tokenHolderPrefixByToken(token oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenHolderPrefix)
+	_, _ = b.Write([]byte(token))
+	return b.Bytes()
+}
+
+// getTokenBalanceKey returns the key of the balance of the token holder, which
+// is a combination of tokenBalancePrefix, token address and holder address. The
+// value is the signed balance in decimal.
+func getTokenBalanceKey(token, holder oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenBalancePrefix)
+	_, _ = b.Write([]byte(token))
+	_, _ = b.Write([]byte(holder))
+	return b.Bytes()
+}
+
+// readTokenBalance returns the signed balance of the holder. Zero is returned if
+// the holder has no balance of the token.
+func readTokenBalance(db databaseReader, token, holder oneAddress) (*big.Int, error) {
+	val, err := db.Get(getTokenBalanceKey(token, holder))
+	if err != nil {
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return new(big.Int), nil
+		}
+		return nil, err
+	}
+	return parseBigInt(string(val))
+}
+
+func writeTokenBalance(db databaseWriter, token, holder oneAddress, balance *big.Int) error {
+	return db.Put(getTokenBalanceKey(token, holder), []byte(balance.String()))
+}
+
+func deleteTokenBalance(db databaseWriter, token, holder oneAddress) error {
+	return db.Delete(getTokenBalanceKey(token, holder))
+}
+
+func writeTokenHolder(db databaseWriter, token oneAddress, th *TokenHolder) error {
+	key := getTokenHolderKey(token, ethToOneAddress(th.Address))
+	val, err := rlp.EncodeToBytes(th)
+	if err != nil {
+		return err
+	}
+	return db.Put(key, val)
+}
+
+func deleteTokenHolder(db databaseWriter, token, holder oneAddress) error {
+	return db.Delete(getTokenHolderKey(token, holder))
+}
+
+func getTokenHolders(db databaseReader, token oneAddress, offset, limit int) ([]*TokenHolder, error) {
+	var ths []*TokenHolder
+	prefix := tokenHolderPrefixByToken(token)
+	err := forEachAtPrefixInRange(db, prefix, offset, limit, func(key, val []byte) error {
+		var th *TokenHolder
+		if err := rlp.DecodeBytes(val, &th); err != nil {
+			return err
+		}
+		ths = append(ths, th)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ths, nil
+}
+
+// forEachAtPrefixInRange calls f on the entries at prefix, skipping the first
+// offset entries and stopping after limit entries.
+func forEachAtPrefixInRange(db databaseReader, prefix []byte, offset, limit int, f func(key, val []byte) error) error {
+	it := db.NewPrefixIterator(prefix)
+	defer it.Release()
+
+	for i := 0; i < offset+limit && it.Next(); i++ {
+		if i < offset {
+			continue
+		}
+		if err := f(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 func forEachAtPrefix(db databaseReader, prefix []byte, f func(key, val []byte) error) error {
 	it := db.NewPrefixIterator(prefix)
 	defer it.Release()
@@ -19,6 +19,7 @@ import (
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/hmy"
 	"github.com/harmony-one/harmony/internal/chain"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/numeric"
@@ -53,9 +54,19 @@ type Service struct {
 }
 
 // New returns explorer service.
-func New(selfPeer *p2p.Peer, bc *core.BlockChain, backend hmy.NodeAPI) *Service {
+func New(harmonyConfig *harmonyconfig.HarmonyConfig, selfPeer *p2p.Peer, bc *core.BlockChain, backend hmy.NodeAPI) *Service {
+	var (
+		cfg      harmonyconfig.ExplorerConfig
+		dbEngine string
+	)
+	if harmonyConfig != nil {
+		if harmonyConfig.Explorer != nil {
+			cfg = *harmonyConfig.Explorer
+		}
+		dbEngine = harmonyConfig.General.DBEngine
+	}
 	dbPath := defaultDBPath(selfPeer.IP, selfPeer.Port)
-	storage, err := newStorage(bc, dbPath)
+	storage, err := newStorage(cfg, bc, dbPath, dbEngine)
 	if err != nil {
 		utils.Logger().Fatal().Err(err).Msg("cannot open explorer DB")
 	}
@@ -184,6 +195,34 @@ func (s *Service) GetStakingTxHashesByAccount(address string) ([]ethCommon.Hash,
 	return s.storage.GetStakingTxsByAddress(address)
 }
 
+// GetTxHistory get a page of the transaction hashes of the address in the query,
+// and the cursor of the next page. Empty cursor is returned for the last page.
+func (s *Service) GetTxHistory(q TxHistoryQuery) ([]ethCommon.Hash, string, error) {
+	f, err := q.toFilter()
+	if err != nil {
+		return nil, "", err
+	}
+	txHashes, _, next, err := s.storage.GetTxHistory(q.Address, f)
+	if err != nil {
+		return nil, "", err
+	}
+	if next == nil {
+		return txHashes, "", nil
+	}
+	return txHashes, next.encode(), nil
+}
+
+// GetTokenTransfersByAccount get the token transfers from or to the account in
+// the page of pageIndex
+func (s *Service) GetTokenTransfersByAccount(address string, pageIndex, pageSize int) ([]*TokenTransfer, error) {
+	return s.storage.GetTokenTransfersByAddress(address, pageIndex*pageSize, pageSize)
+}
+
+// GetTokenHolders get the holders of the token in the page of pageIndex
+func (s *Service) GetTokenHolders(token string, pageIndex, pageSize int) ([]*TokenHolder, error) {
+	return s.storage.GetTokenHolders(token, pageIndex*pageSize, pageSize)
+}
+
 // DumpNewBlock instruct the explorer storage to dump block data in explorer DB
 func (s *Service) DumpNewBlock(b *types.Block) {
 	s.storage.DumpNewBlock(b)
@@ -0,0 +1,601 @@
+package explorer
+
+import (
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/types"
+	common2 "github.com/harmony-one/harmony/internal/common"
+	_ "github.com/lib/pq"           // postgres driver
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
+	"github.com/pkg/errors"
+)
+
+// Drivers supported by the SQL backend
+const (
+	SQLDriverSQLite   = "sqlite3"
+	SQLDriverPostgres = "postgres"
+)
+
+// sqlSchema is the normalized tables of the SQL backend. Hashes and hex data are
+// stored as 0x prefixed hex strings, addresses as one1 bech32 strings, and big
+// integers as decimal strings.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+		number           BIGINT PRIMARY KEY,
+		hash             TEXT NOT NULL,
+		parent_hash      TEXT NOT NULL,
+		shard_id         INTEGER NOT NULL,
+		epoch            BIGINT NOT NULL,
+		view_id          BIGINT NOT NULL,
+		timestamp        BIGINT NOT NULL,
+		miner            TEXT NOT NULL,
+		gas_limit        BIGINT NOT NULL,
+		gas_used         BIGINT NOT NULL,
+		tx_count         INTEGER NOT NULL,
+		staking_tx_count INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS transactions (
+		hash         TEXT PRIMARY KEY,
+		block_number BIGINT NOT NULL,
+		tx_index     INTEGER NOT NULL,
+		from_address TEXT NOT NULL,
+		to_address   TEXT,
+		shard_id     INTEGER NOT NULL,
+		to_shard_id  INTEGER NOT NULL,
+		value        TEXT NOT NULL,
+		nonce        BIGINT NOT NULL,
+		gas_price    TEXT NOT NULL,
+		gas_limit    BIGINT NOT NULL,
+		gas_used     BIGINT NOT NULL,
+		status       INTEGER NOT NULL,
+		input        TEXT NOT NULL,
+		timestamp    BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS transactions_block_number ON transactions (block_number)`,
+	`CREATE TABLE IF NOT EXISTS staking_transactions (
+		hash         TEXT PRIMARY KEY,
+		block_number BIGINT NOT NULL,
+		tx_index     INTEGER NOT NULL,
+		type         TEXT NOT NULL,
+		from_address TEXT NOT NULL,
+		to_address   TEXT,
+		nonce        BIGINT NOT NULL,
+		gas_price    TEXT NOT NULL,
+		gas_limit    BIGINT NOT NULL,
+		gas_used     BIGINT NOT NULL,
+		status       INTEGER NOT NULL,
+		timestamp    BIGINT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS staking_transactions_block_number ON staking_transactions (block_number)`,
+	// direction is the TxType of the transaction to the address: 1 for sent, 2 for received
+	`CREATE TABLE IF NOT EXISTS address_transactions (
+		address      TEXT NOT NULL,
+		tx_hash      TEXT NOT NULL,
+		is_staking   BOOLEAN NOT NULL,
+		block_number BIGINT NOT NULL,
+		tx_index     INTEGER NOT NULL,
+		direction    INTEGER NOT NULL,
+		PRIMARY KEY (address, tx_hash)
+	)`,
+	`CREATE INDEX IF NOT EXISTS address_transactions_history
+		ON address_transactions (address, is_staking, block_number, tx_index)`,
+	`CREATE TABLE IF NOT EXISTS logs (
+		block_number BIGINT NOT NULL,
+		log_index    INTEGER NOT NULL,
+		tx_hash      TEXT NOT NULL,
+		address      TEXT NOT NULL,
+		topic0       TEXT,
+		topic1       TEXT,
+		topic2       TEXT,
+		topic3       TEXT,
+		data         TEXT NOT NULL,
+		PRIMARY KEY (block_number, log_index)
+	)`,
+	`CREATE INDEX IF NOT EXISTS logs_tx_hash ON logs (tx_hash)`,
+	`CREATE INDEX IF NOT EXISTS logs_address ON logs (address, block_number)`,
+	// standard is the TokenStandard, token_id and value are decimal strings
+	`CREATE TABLE IF NOT EXISTS token_transfers (
+		block_number BIGINT NOT NULL,
+		log_index    INTEGER NOT NULL,
+		batch_index  INTEGER NOT NULL,
+		tx_hash      TEXT NOT NULL,
+		token        TEXT NOT NULL,
+		standard     INTEGER NOT NULL,
+		from_address TEXT NOT NULL,
+		to_address   TEXT NOT NULL,
+		token_id     TEXT NOT NULL,
+		value        TEXT NOT NULL,
+		PRIMARY KEY (block_number, log_index, batch_index)
+	)`,
+	`CREATE INDEX IF NOT EXISTS token_transfers_from ON token_transfers (from_address, block_number)`,
+	`CREATE INDEX IF NOT EXISTS token_transfers_to ON token_transfers (to_address, block_number)`,
+	`CREATE INDEX IF NOT EXISTS token_transfers_token ON token_transfers (token, block_number)`,
+	`CREATE TABLE IF NOT EXISTS token_holders (
+		token   TEXT NOT NULL,
+		holder  TEXT NOT NULL,
+		balance TEXT NOT NULL,
+		PRIMARY KEY (token, holder)
+	)`,
+}
+
+// sqlDB is the explorerDB over the SQL database
+type sqlDB struct {
+	db     *sql.DB
+	driver string
+}
+
+func newSQLDB(driver, source string) (*sqlDB, error) {
+	switch driver {
+	case SQLDriverSQLite, SQLDriverPostgres:
+	default:
+		return nil, fmt.Errorf("unsupported explorer sql driver: %v", driver)
+	}
+	if source == "" {
+		return nil, errors.New("empty explorer sql data source")
+	}
+	db, err := sql.Open(driver, source)
+	if err != nil {
+		return nil, errors.Wrap(err, "open sql db")
+	}
+	if driver == SQLDriverSQLite {
+		// sqlite does not support concurrent writes
+		db.SetMaxOpenConns(1)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "connect sql db")
+	}
+	sdb := &sqlDB{db: db, driver: driver}
+	if err := sdb.createTables(); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "create tables")
+	}
+	return sdb, nil
+}
+
+func (sdb *sqlDB) createTables() error {
+	for _, stmt := range sqlSchema {
+		if _, err := sdb.db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebind replaces the ? placeholders in query with $n for postgres
+func (sdb *sqlDB) rebind(query string) string {
+	if sdb.driver != SQLDriverPostgres {
+		return query
+	}
+	var (
+		b strings.Builder
+		n int
+	)
+	for _, c := range query {
+		if c == '?' {
+			n++
+			fmt.Fprintf(&b, "$%d", n)
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// migrate does nothing since the tables are created when the db is opened
+func (sdb *sqlDB) migrate(bc blockChainTxIndexer, closeC chan struct{}) error {
+	return nil
+}
+
+func (sdb *sqlDB) isBlockComputed(bn uint64) (bool, error) {
+	var cnt int
+	query := sdb.rebind(`SELECT COUNT(*) FROM blocks WHERE number = ?`)
+	if err := sdb.db.QueryRow(query, bn).Scan(&cnt); err != nil {
+		return false, err
+	}
+	return cnt > 0, nil
+}
+
+func (sdb *sqlDB) writeBlock(bd *blockData) error {
+	dbTx, err := sdb.db.Begin()
+	if err != nil {
+		return err
+	}
+	// Rollback is no-op after commit
+	defer dbTx.Rollback()
+
+	if err := sdb.insertBlock(dbTx, bd); err != nil {
+		return errors.Wrap(err, "insert block")
+	}
+	if err := sdb.insertTxs(dbTx, bd); err != nil {
+		return errors.Wrap(err, "insert transactions")
+	}
+	if err := sdb.insertStakingTxs(dbTx, bd); err != nil {
+		return errors.Wrap(err, "insert staking transactions")
+	}
+	if err := sdb.insertLogs(dbTx, bd); err != nil {
+		return errors.Wrap(err, "insert logs")
+	}
+	if err := sdb.insertTokenTransfers(dbTx, bd); err != nil {
+		return errors.Wrap(err, "insert token transfers")
+	}
+	if err := sdb.updateTokenHolders(dbTx, bd); err != nil {
+		return errors.Wrap(err, "update token holders")
+	}
+	return dbTx.Commit()
+}
+
+func (sdb *sqlDB) insertBlock(dbTx *sql.Tx, bd *blockData) error {
+	b := bd.block
+	_, err := dbTx.Exec(sdb.rebind(`INSERT INTO blocks (number, hash, parent_hash, shard_id,
+		epoch, view_id, timestamp, miner, gas_limit, gas_used, tx_count, staking_tx_count)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`),
+		b.NumberU64(), b.Hash().Hex(), b.ParentHash().Hex(), b.ShardID(),
+		b.Epoch().Uint64(), b.Header().ViewID().Uint64(), b.Time().Uint64(),
+		string(ethToOneAddress(b.Coinbase())), b.GasLimit(), b.GasUsed(),
+		len(bd.txs), len(bd.stakings),
+	)
+	return err
+}
+
+func (sdb *sqlDB) insertTxs(dbTx *sql.Tx, bd *blockData) error {
+	txs := bd.block.Transactions()
+	for i, td := range bd.txs {
+		tx := txs[i]
+		gasUsed, status := receiptResult(bd.receipts, i)
+		_, err := dbTx.Exec(sdb.rebind(`INSERT INTO transactions (hash, block_number, tx_index,
+			from_address, to_address, shard_id, to_shard_id, value, nonce, gas_price, gas_limit,
+			gas_used, status, input, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`),
+			td.hash.Hex(), td.blockNumber, td.index, string(td.from), nullableAddress(td.to),
+			tx.ShardID(), tx.ToShardID(), tx.Value().String(), tx.Nonce(),
+			tx.GasPrice().String(), tx.GasLimit(), gasUsed, status,
+			hexutil.Encode(tx.Data()), bd.block.Time().Uint64(),
+		)
+		if err != nil {
+			return err
+		}
+		if err := sdb.insertAddressTx(dbTx, td, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sdb *sqlDB) insertStakingTxs(dbTx *sql.Tx, bd *blockData) error {
+	stks := bd.block.StakingTransactions()
+	for i, td := range bd.stakings {
+		stk := stks[i]
+		gasUsed, status := receiptResult(bd.receipts, len(bd.txs)+i)
+		_, err := dbTx.Exec(sdb.rebind(`INSERT INTO staking_transactions (hash, block_number,
+			tx_index, type, from_address, to_address, nonce, gas_price, gas_limit, gas_used,
+			status, timestamp)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`),
+			td.hash.Hex(), td.blockNumber, td.index, stk.StakingType().String(),
+			string(td.from), nullableAddress(td.to), stk.Nonce(), stk.GasPrice().String(),
+			stk.GasLimit(), gasUsed, status, bd.block.Time().Uint64(),
+		)
+		if err != nil {
+			return err
+		}
+		if err := sdb.insertAddressTx(dbTx, td, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertAddressTx links the transaction to the sender and the receiver. Same as
+// the key-value backend, the transaction sent to self is marked as received.
+func (sdb *sqlDB) insertAddressTx(dbTx *sql.Tx, td txData, isStaking bool) error {
+	query := sdb.rebind(`INSERT INTO address_transactions (address, tx_hash, is_staking,
+		block_number, tx_index, direction) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (address, tx_hash) DO UPDATE SET direction = excluded.direction`)
+
+	_, err := dbTx.Exec(query, string(td.from), td.hash.Hex(), isStaking, td.blockNumber,
+		td.index, int(txSent))
+	if err != nil || td.to == "" {
+		return err
+	}
+	_, err = dbTx.Exec(query, string(td.to), td.hash.Hex(), isStaking, td.blockNumber,
+		td.index, int(txReceived))
+	return err
+}
+
+func (sdb *sqlDB) insertLogs(dbTx *sql.Tx, bd *blockData) error {
+	var logIndex int
+	for _, receipt := range bd.receipts {
+		for _, log := range receipt.Logs {
+			var topics [4]interface{}
+			for i := 0; i != len(topics) && i != len(log.Topics); i++ {
+				topics[i] = log.Topics[i].Hex()
+			}
+			_, err := dbTx.Exec(sdb.rebind(`INSERT INTO logs (block_number, log_index, tx_hash,
+				address, topic0, topic1, topic2, topic3, data)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`),
+				bd.block.NumberU64(), logIndex, receipt.TxHash.Hex(),
+				string(ethToOneAddress(log.Address)), topics[0], topics[1], topics[2], topics[3],
+				hexutil.Encode(log.Data),
+			)
+			if err != nil {
+				return err
+			}
+			logIndex++
+		}
+	}
+	return nil
+}
+
+func (sdb *sqlDB) insertTokenTransfers(dbTx *sql.Tx, bd *blockData) error {
+	for _, tt := range bd.tokenTransfers {
+		_, err := dbTx.Exec(sdb.rebind(`INSERT INTO token_transfers (block_number, log_index,
+			batch_index, tx_hash, token, standard, from_address, to_address, token_id, value)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING`),
+			tt.BlockNumber, tt.LogIndex, tt.BatchIndex, tt.TxHash.Hex(),
+			string(ethToOneAddress(tt.Token)), int(tt.Standard),
+			string(ethToOneAddress(tt.From)), string(ethToOneAddress(tt.To)),
+			tt.TokenID.String(), tt.Value.String(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateTokenHolders applies the balance changes of the token transfers to the
+// token holders. The balances are signed, and only the positive ones are listed
+// as the holders.
+func (sdb *sqlDB) updateTokenHolders(dbTx *sql.Tx, bd *blockData) error {
+	for id, delta := range tokenBalanceChanges(bd.tokenTransfers) {
+		token, holder := string(ethToOneAddress(id.token)), string(ethToOneAddress(id.holder))
+
+		var raw string
+		balance := new(big.Int)
+		err := dbTx.QueryRow(sdb.rebind(`SELECT balance FROM token_holders
+			WHERE token = ? AND holder = ?`), token, holder).Scan(&raw)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return err
+		default:
+			if balance, err = parseBigInt(raw); err != nil {
+				return err
+			}
+		}
+
+		balance.Add(balance, delta)
+		if balance.Sign() != 0 {
+			_, err = dbTx.Exec(sdb.rebind(`INSERT INTO token_holders (token, holder, balance)
+				VALUES (?, ?, ?) ON CONFLICT (token, holder) DO UPDATE SET balance = excluded.balance`),
+				token, holder, balance.String())
+		} else {
+			_, err = dbTx.Exec(sdb.rebind(`DELETE FROM token_holders WHERE token = ? AND holder = ?`),
+				token, holder)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sdb *sqlDB) getAddresses(start oneAddress, size int) ([]oneAddress, error) {
+	rows, err := sdb.db.Query(sdb.rebind(`SELECT DISTINCT address FROM address_transactions
+		WHERE address >= ? ORDER BY address LIMIT ?`), string(start), size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addrs []oneAddress
+	for rows.Next() {
+		var addr string
+		if err := rows.Scan(&addr); err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, oneAddress(addr))
+	}
+	return addrs, rows.Err()
+}
+
+func (sdb *sqlDB) getNormalTxsByAddress(addr oneAddress) ([]common.Hash, []TxType, error) {
+	return sdb.getTxsByAddress(addr, false)
+}
+
+func (sdb *sqlDB) getStakingTxsByAddress(addr oneAddress) ([]common.Hash, []TxType, error) {
+	return sdb.getTxsByAddress(addr, true)
+}
+
+func (sdb *sqlDB) getTxsByAddress(addr oneAddress, isStaking bool) ([]common.Hash, []TxType, error) {
+	rows, err := sdb.db.Query(sdb.rebind(`SELECT tx_hash, direction FROM address_transactions
+		WHERE address = ? AND is_staking = ? ORDER BY block_number, tx_index`),
+		string(addr), isStaking)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var (
+		txHashes []common.Hash
+		tts      []TxType
+	)
+	for rows.Next() {
+		var (
+			txHash    string
+			direction int
+		)
+		if err := rows.Scan(&txHash, &direction); err != nil {
+			return nil, nil, err
+		}
+		txHashes = append(txHashes, common.HexToHash(txHash))
+		tts = append(tts, TxType(direction))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return txHashes, tts, nil
+}
+
+func (sdb *sqlDB) getTxHistory(addr oneAddress, f txHistoryFilter) ([]common.Hash, []TxType, *txCursor, error) {
+	var (
+		b    strings.Builder
+		args = []interface{}{string(addr), f.staking, f.fromBlock, f.sqlToBlock()}
+	)
+	b.WriteString(`SELECT tx_hash, direction, block_number, tx_index FROM address_transactions
+		WHERE address = ? AND is_staking = ? AND block_number >= ? AND block_number <= ?`)
+	if f.direction != txUnknown {
+		b.WriteString(` AND direction = ?`)
+		args = append(args, int(f.direction))
+	}
+	if c := f.cursor; c != nil {
+		if f.desc {
+			b.WriteString(` AND (block_number < ? OR (block_number = ? AND tx_index <= ?))`)
+		} else {
+			b.WriteString(` AND (block_number > ? OR (block_number = ? AND tx_index >= ?))`)
+		}
+		args = append(args, c.blockNumber, c.blockNumber, c.txIndex)
+	}
+	if f.desc {
+		b.WriteString(` ORDER BY block_number DESC, tx_index DESC`)
+	} else {
+		b.WriteString(` ORDER BY block_number, tx_index`)
+	}
+	// Query one more entry as the position of the next page
+	b.WriteString(` LIMIT ? OFFSET ?`)
+	args = append(args, f.limit+1, f.offset)
+
+	rows, err := sdb.db.Query(sdb.rebind(b.String()), args...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer rows.Close()
+
+	var (
+		txHashes []common.Hash
+		tts      []TxType
+		next     *txCursor
+	)
+	for rows.Next() {
+		var (
+			txHash      string
+			direction   int
+			bn, txIndex uint64
+		)
+		if err := rows.Scan(&txHash, &direction, &bn, &txIndex); err != nil {
+			return nil, nil, nil, err
+		}
+		if len(txHashes) == f.limit {
+			next = &txCursor{staking: f.staking, desc: f.desc, blockNumber: bn, txIndex: txIndex}
+			break
+		}
+		txHashes = append(txHashes, common.HexToHash(txHash))
+		tts = append(tts, TxType(direction))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, nil, err
+	}
+	return txHashes, tts, next, nil
+}
+
+func (sdb *sqlDB) getTokenTransfersByAddress(addr oneAddress, offset, limit int) ([]*TokenTransfer, error) {
+	rows, err := sdb.db.Query(sdb.rebind(`SELECT block_number, log_index, batch_index, tx_hash,
+		token, standard, from_address, to_address, token_id, value FROM token_transfers
+		WHERE from_address = ? OR to_address = ?
+		ORDER BY block_number, log_index, batch_index LIMIT ? OFFSET ?`),
+		string(addr), string(addr), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tts []*TokenTransfer
+	for rows.Next() {
+		var (
+			tt                      TokenTransfer
+			standard                int
+			txHash, token, from, to string
+			tokenID, value          string
+		)
+		err := rows.Scan(&tt.BlockNumber, &tt.LogIndex, &tt.BatchIndex, &txHash, &token,
+			&standard, &from, &to, &tokenID, &value)
+		if err != nil {
+			return nil, err
+		}
+		tt.TxHash = common.HexToHash(txHash)
+		tt.Standard = TokenStandard(standard)
+		if tt.Token, err = common2.Bech32ToAddress(token); err != nil {
+			return nil, err
+		}
+		if tt.From, err = common2.Bech32ToAddress(from); err != nil {
+			return nil, err
+		}
+		if tt.To, err = common2.Bech32ToAddress(to); err != nil {
+			return nil, err
+		}
+		if tt.TokenID, err = parseBigInt(tokenID); err != nil {
+			return nil, err
+		}
+		if tt.Value, err = parseBigInt(value); err != nil {
+			return nil, err
+		}
+		tts = append(tts, &tt)
+	}
+	return tts, rows.Err()
+}
+
+func (sdb *sqlDB) getTokenHolders(token oneAddress, offset, limit int) ([]*TokenHolder, error) {
+	rows, err := sdb.db.Query(sdb.rebind(`SELECT holder, balance FROM token_holders
+		WHERE token = ? AND balance NOT LIKE '-%' ORDER BY holder LIMIT ? OFFSET ?`), string(token), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ths []*TokenHolder
+	for rows.Next() {
+		var (
+			th              TokenHolder
+			holder, balance string
+		)
+		if err := rows.Scan(&holder, &balance); err != nil {
+			return nil, err
+		}
+		if th.Address, err = common2.Bech32ToAddress(holder); err != nil {
+			return nil, err
+		}
+		if th.Balance, err = parseBigInt(balance); err != nil {
+			return nil, err
+		}
+		ths = append(ths, &th)
+	}
+	return ths, rows.Err()
+}
+
+// receiptResult returns the gas used and the status of the receipt of index
+func receiptResult(receipts types.Receipts, index int) (uint64, uint64) {
+	if index >= len(receipts) {
+		return 0, 0
+	}
+	return receipts[index].GasUsed, receipts[index].Status
+}
+
+func nullableAddress(addr oneAddress) interface{} {
+	if addr == "" {
+		return nil
+	}
+	return string(addr)
+}
+
+func parseBigInt(s string) (*big.Int, error) {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer: %v", s)
+	}
+	return i, nil
+}
@@ -0,0 +1,260 @@
+package explorer
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+	common2 "github.com/harmony-one/harmony/internal/common"
+)
+
+const testBlockNumber = 100
+
+func TestExplorerDB_writeBlock(t *testing.T) {
+	dbs := map[string]explorerDB{
+		BackendLevelDB: &kvDB{newTestLevelDB(t, 0)},
+		BackendSQL:     newTestSQLDB(t, 1),
+	}
+	for name, db := range dbs {
+		tb := makeTestBlock(t)
+		bc := &blockComputer{db: db, bc: tb}
+		bd, err := bc.computeBlock(tb.block)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if err := db.writeBlock(bd); err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		computed, err := db.isBlockComputed(testBlockNumber)
+		if err != nil || !computed {
+			t.Fatalf("%v: block not computed: %v", name, err)
+		}
+		// The block is skipped since computed
+		if bd, err := bc.computeBlock(tb.block); bd != nil || err != nil {
+			t.Fatalf("%v: unexpected block data: %v, %v", name, bd, err)
+		}
+
+		txs := tb.block.Transactions()
+		tests := []struct {
+			addr      oneAddress
+			expHashes []common.Hash
+			expTypes  []TxType
+		}{
+			{
+				addr:      tb.addrs[0],
+				expHashes: []common.Hash{txs[0].Hash(), txs[1].Hash(), txs[2].Hash()},
+				// transaction sent to self is marked as received
+				expTypes: []TxType{txSent, txReceived, txSent},
+			},
+			{
+				addr:      tb.addrs[1],
+				expHashes: []common.Hash{txs[0].Hash(), txs[3].Hash()},
+				expTypes:  []TxType{txReceived, txReceived},
+			},
+			{
+				addr:      tb.addrs[2],
+				expHashes: []common.Hash{txs[3].Hash()},
+				expTypes:  []TxType{txSent},
+			},
+		}
+		for i, test := range tests {
+			hashes, tts, err := db.getNormalTxsByAddress(test.addr)
+			if err != nil {
+				t.Fatalf("%v test %v: %v", name, i, err)
+			}
+			if !reflect.DeepEqual(hashes, test.expHashes) {
+				t.Errorf("%v test %v: unexpected hashes %v / %v", name, i, hashes, test.expHashes)
+			}
+			if !reflect.DeepEqual(tts, test.expTypes) {
+				t.Errorf("%v test %v: unexpected types %v / %v", name, i, tts, test.expTypes)
+			}
+		}
+	}
+}
+
+func TestSQLDB_tables(t *testing.T) {
+	db := newTestSQLDB(t, 0)
+	tb := makeTestBlock(t)
+	bc := &blockComputer{db: db, bc: tb}
+	bd, err := bc.computeBlock(tb.block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.writeBlock(bd); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query  string
+		expCnt int
+	}{
+		{`SELECT COUNT(*) FROM blocks WHERE tx_count = 4`, 1},
+		{`SELECT COUNT(*) FROM transactions WHERE block_number = 100`, 4},
+		{`SELECT COUNT(*) FROM transactions WHERE to_address IS NULL`, 1},
+		{`SELECT COUNT(*) FROM transactions WHERE status = 1 AND gas_used = 21000`, 4},
+		{`SELECT COUNT(*) FROM staking_transactions`, 0},
+		{`SELECT COUNT(*) FROM address_transactions`, 6},
+		{`SELECT COUNT(*) FROM logs`, 2},
+		{`SELECT COUNT(*) FROM logs WHERE topic0 IS NOT NULL AND topic1 IS NULL`, 1},
+	}
+	for i, test := range tests {
+		var cnt int
+		if err := db.db.QueryRow(test.query).Scan(&cnt); err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if cnt != test.expCnt {
+			t.Errorf("Test %v: unexpected count %v / %v", i, cnt, test.expCnt)
+		}
+	}
+}
+
+func TestSQLDB_getAddresses(t *testing.T) {
+	db := newTestSQLDB(t, 0)
+	tb := makeTestBlock(t)
+	bc := &blockComputer{db: db, bc: tb}
+	bd, err := bc.computeBlock(tb.block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.writeBlock(bd); err != nil {
+		t.Fatal(err)
+	}
+	sorted := append([]oneAddress{}, tb.addrs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	tests := []struct {
+		start    oneAddress
+		size     int
+		expAddrs []oneAddress
+	}{
+		{"", 10, sorted},
+		{"", 2, sorted[:2]},
+		{sorted[1], 10, sorted[1:]},
+		{"one1zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz", 10, nil},
+	}
+	for i, test := range tests {
+		addrs, err := db.getAddresses(test.start, test.size)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if !reflect.DeepEqual(addrs, test.expAddrs) {
+			t.Errorf("Test %v: unexpected addresses %v / %v", i, addrs, test.expAddrs)
+		}
+	}
+}
+
+func TestSQLDB_rebind(t *testing.T) {
+	query := `SELECT a FROM t WHERE b = ? AND c = ?`
+	sqlite := &sqlDB{driver: SQLDriverSQLite}
+	if got := sqlite.rebind(query); got != query {
+		t.Errorf("unexpected sqlite query: %v", got)
+	}
+	postgres := &sqlDB{driver: SQLDriverPostgres}
+	if got, exp := postgres.rebind(query), `SELECT a FROM t WHERE b = $1 AND c = $2`; got != exp {
+		t.Errorf("unexpected postgres query: %v / %v", got, exp)
+	}
+}
+
+func newTestSQLDB(t *testing.T, i int) *sqlDB {
+	dir := tempTestDir(t, i)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	db, err := newSQLDB(SQLDriverSQLite, path.Join(dir, "explorer.sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// testBlock is a block with 4 transactions:
+//
+//	addrs[0] -> addrs[1]
+//	addrs[0] -> addrs[0]
+//	addrs[0] contract creation
+//	addrs[2] -> addrs[1]
+type testBlock struct {
+	block    *types.Block
+	receipts types.Receipts
+	addrs    []oneAddress
+}
+
+func makeTestBlock(t *testing.T) *testBlock {
+	var (
+		keys  []*ecdsa.PrivateKey
+		addrs []oneAddress
+	)
+	for i := 0; i != 3; i++ {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		addrs = append(addrs, ethToOneAddress(crypto.PubkeyToAddress(key.PublicKey)))
+	}
+	to := func(i int) common.Address {
+		return crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	unsigned := []struct {
+		tx  *types.Transaction
+		key *ecdsa.PrivateKey
+	}{
+		{types.NewTransaction(0, to(1), 0, big.NewInt(1), 21000, big.NewInt(1), nil), keys[0]},
+		{types.NewTransaction(1, to(0), 0, big.NewInt(1), 21000, big.NewInt(1), nil), keys[0]},
+		{types.NewContractCreation(2, 0, big.NewInt(0), 21000, big.NewInt(1), []byte{0x60}), keys[0]},
+		{types.NewTransaction(0, to(1), 0, big.NewInt(1), 21000, big.NewInt(1), nil), keys[2]},
+	}
+	signer := types.NewEIP155Signer(big.NewInt(2))
+	var (
+		txs      []*types.Transaction
+		receipts types.Receipts
+	)
+	for _, u := range unsigned {
+		tx, err := types.SignTx(u.tx, signer, u.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, &types.Receipt{
+			Status:  types.ReceiptStatusSuccessful,
+			GasUsed: 21000,
+			TxHash:  tx.Hash(),
+		})
+	}
+	receipts[2].Logs = []*types.Log{
+		{Address: to(2), Topics: []common.Hash{{0x1}}, Data: []byte{0x1}},
+		{Address: to(2), Data: []byte{0x2}},
+	}
+	header := blockfactory.NewTestHeader().With().Number(big.NewInt(testBlockNumber)).Header()
+	return &testBlock{
+		block:    types.NewBlock(header, txs, receipts, nil, nil, nil),
+		receipts: receipts,
+		addrs:    addrs,
+	}
+}
+
+func (tb *testBlock) ethAddr(i int) common.Address {
+	addr, _ := common2.Bech32ToAddress(string(tb.addrs[i]))
+	return addr
+}
+
+func (tb *testBlock) ReadTxLookupEntry(txID common.Hash) (common.Hash, uint64, uint64) {
+	for i, tx := range tb.block.Transactions() {
+		if tx.Hash() == txID {
+			return tb.block.Hash(), tb.block.NumberU64(), uint64(i)
+		}
+	}
+	return common.Hash{}, 0, 0
+}
+
+func (tb *testBlock) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	if hash != tb.block.Hash() {
+		return nil
+	}
+	return tb.receipts
+}
@@ -14,6 +14,7 @@ import (
 	core2 "github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/types"
 	common2 "github.com/harmony-one/harmony/internal/common"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	"github.com/harmony-one/harmony/internal/utils"
 	staking "github.com/harmony-one/harmony/staking/types"
 	"github.com/rs/zerolog"
@@ -23,33 +24,53 @@ const (
 	numWorker = 8
 )
 
+// Storage backends of the explorer node
+const (
+	BackendLevelDB = "leveldb"
+	BackendSQL     = "sql"
+)
+
 // ErrExplorerNotReady is the error when querying explorer db data when
 // explorer db is doing migration and unavailable
 var ErrExplorerNotReady = errors.New("explorer db not ready")
 
 type (
 	storage struct {
-		db database
+		db explorerDB
 		bc *core.BlockChain
 
 		// TODO: optimize this with priority queue
 		tm      *taskManager
-		resultC chan blockResult
+		resultC chan *blockData
 
 		available *abool.AtomicBool
 		closeC    chan struct{}
 		log       zerolog.Logger
 	}
 
-	blockResult struct {
-		btc batch
-		bn  uint64
+	// blockData is the data computed from a block to be written to the explorer db
+	blockData struct {
+		block    *types.Block
+		txs      []txData // index entries of block.Transactions()
+		stakings []txData // index entries of block.StakingTransactions()
+		// receipts of the normal transactions followed by the staking transactions
+		receipts types.Receipts
+		// token transfers decoded from the logs of receipts
+		tokenTransfers []*TokenTransfer
+	}
+
+	// txData is the address-transaction index entry of a normal or staking transaction
+	txData struct {
+		hash        common.Hash
+		blockNumber uint64
+		index       uint64
+		from        oneAddress
+		to          oneAddress // empty for contract creation or staking directive without validator
 	}
 )
 
-func newStorage(bc *core.BlockChain, dbPath string) (*storage, error) {
-	utils.Logger().Info().Msg("explorer storage folder: " + dbPath)
-	db, err := newLvlDB(dbPath)
+func newStorage(cfg harmonyconfig.ExplorerConfig, bc *core.BlockChain, dbPath, dbEngine string) (*storage, error) {
+	db, err := openExplorerDB(cfg, dbPath, dbEngine)
 	if err != nil {
 		utils.Logger().Error().Err(err).Msg("Failed to create new database")
 		return nil, err
@@ -58,13 +79,32 @@ func newStorage(bc *core.BlockChain, dbPath string) (*storage, error) {
 		db:        db,
 		bc:        bc,
 		tm:        newTaskManager(),
-		resultC:   make(chan blockResult, numWorker),
+		resultC:   make(chan *blockData, numWorker),
 		available: abool.New(),
 		closeC:    make(chan struct{}),
 		log:       utils.Logger().With().Str("module", "explorer storage").Logger(),
 	}, nil
 }
 
+// openExplorerDB opens the explorer db of the backend in config. The key-value
+// database of dbEngine is used for the leveldb backend.
+func openExplorerDB(cfg harmonyconfig.ExplorerConfig, dbPath, dbEngine string) (explorerDB, error) {
+	switch cfg.Backend {
+	case "", BackendLevelDB:
+		utils.Logger().Info().Msg("explorer storage folder: " + dbPath)
+		return newKVDB(dbPath, dbEngine)
+	case BackendSQL:
+		source := cfg.SQLSource
+		if source == "" && cfg.SQLDriver == SQLDriverSQLite {
+			source = dbPath + ".sqlite3"
+		}
+		utils.Logger().Info().Str("driver", cfg.SQLDriver).Msg("explorer sql storage")
+		return newSQLDB(cfg.SQLDriver, source)
+	default:
+		return nil, fmt.Errorf("unknown explorer storage backend: %v", cfg.Backend)
+	}
+}
+
 func (s *storage) Start() {
 	go s.run()
 }
@@ -88,7 +128,7 @@ func (s *storage) GetAddresses(size int, startAddress oneAddress) ([]string, err
 	if !s.available.IsSet() {
 		return nil, ErrExplorerNotReady
 	}
-	addrs, err := getAddressesInRange(s.db, startAddress, size)
+	addrs, err := s.db.getAddresses(startAddress, size)
 	if err != nil {
 		return nil, err
 	}
@@ -103,25 +143,42 @@ func (s *storage) GetNormalTxsByAddress(addr string) ([]common.Hash, []TxType, e
 	if !s.available.IsSet() {
 		return nil, nil, ErrExplorerNotReady
 	}
-	return getNormalTxnHashesByAccount(s.db, oneAddress(addr))
+	return s.db.getNormalTxsByAddress(oneAddress(addr))
 }
 
 func (s *storage) GetStakingTxsByAddress(addr string) ([]common.Hash, []TxType, error) {
 	if !s.available.IsSet() {
 		return nil, nil, ErrExplorerNotReady
 	}
-	return getStakingTxnHashesByAccount(s.db, oneAddress(addr))
+	return s.db.getStakingTxsByAddress(oneAddress(addr))
+}
+
+func (s *storage) GetTxHistory(addr string, f txHistoryFilter) ([]common.Hash, []TxType, *txCursor, error) {
+	if !s.available.IsSet() {
+		return nil, nil, nil, ErrExplorerNotReady
+	}
+	return s.db.getTxHistory(oneAddress(addr), f)
+}
+
+func (s *storage) GetTokenTransfersByAddress(addr string, offset, limit int) ([]*TokenTransfer, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	return s.db.getTokenTransfersByAddress(oneAddress(addr), offset, limit)
+}
+
+func (s *storage) GetTokenHolders(token string, offset, limit int) ([]*TokenHolder, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	return s.db.getTokenHolders(oneAddress(token), offset, limit)
 }
 
 func (s *storage) run() {
-	if is, err := isVersionV100(s.db); !is || err != nil {
-		s.available.UnSet()
-		err := s.migrateToV100()
-		if err != nil {
-			s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
-			fmt.Println("Failed to migrate explorer DB:", err)
-			os.Exit(1)
-		}
+	if err := s.db.migrate(s.bc, s.closeC); err != nil {
+		s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
+		fmt.Println("Failed to migrate explorer DB:", err)
+		os.Exit(1)
 	}
 	s.available.Set()
 	go s.loop()
@@ -131,9 +188,19 @@ func (s *storage) loop() {
 	s.makeWorkersAndStart()
 	for {
 		select {
-		case res := <-s.resultC:
-			s.log.Info().Uint64("block number", res.bn).Msg("writing explorer DB")
-			if err := res.btc.Write(); err != nil {
+		case bd := <-s.resultC:
+			// The same block might be computed by multiple workers. Skip the written
+			// one so that the token balances are not applied twice.
+			is, err := s.db.isBlockComputed(bd.block.NumberU64())
+			if err != nil {
+				s.log.Error().Err(err).Msg("explorer db failed to read checkpoint")
+				continue
+			}
+			if is {
+				continue
+			}
+			s.log.Info().Uint64("block number", bd.block.NumberU64()).Msg("writing explorer DB")
+			if err := s.db.writeBlock(bd); err != nil {
 				s.log.Error().Err(err).Msg("explorer db failed to write")
 			}
 
@@ -222,9 +289,9 @@ func (s *storage) makeWorkersAndStart() {
 
 type blockComputer struct {
 	tm      *taskManager
-	db      database
-	bc      blockChainTxIndexer
-	resultC chan blockResult
+	db      explorerDB
+	bc      blockChainReader
+	resultC chan *blockData
 	closeC  chan struct{}
 	log     zerolog.Logger
 }
@@ -250,7 +317,7 @@ LOOP:
 					continue
 				}
 				select {
-				case bc.resultC <- *res:
+				case bc.resultC <- res:
 				case <-bc.closeC:
 					return
 				}
@@ -262,84 +329,60 @@ LOOP:
 	}
 }
 
-func (bc *blockComputer) computeBlock(b *types.Block) (*blockResult, error) {
-	is, err := isBlockComputedInDB(bc.db, b.NumberU64())
+func (bc *blockComputer) computeBlock(b *types.Block) (*blockData, error) {
+	is, err := bc.db.isBlockComputed(b.NumberU64())
 	if is || err != nil {
 		return nil, err
 	}
-	btc := bc.db.NewBatch()
-
+	bd := &blockData{
+		block:    b,
+		txs:      make([]txData, 0, len(b.Transactions())),
+		stakings: make([]txData, 0, len(b.StakingTransactions())),
+	}
 	for _, tx := range b.Transactions() {
-		bc.computeNormalTx(btc, b, tx)
+		bd.txs = append(bd.txs, bc.computeNormalTx(tx))
 	}
 	for _, stk := range b.StakingTransactions() {
-		bc.computeStakingTx(btc, b, stk)
+		bd.stakings = append(bd.stakings, bc.computeStakingTx(b, stk))
 	}
-	_ = writeCheckpoint(btc, b.NumberU64())
-	return &blockResult{
-		btc: btc,
-		bn:  b.NumberU64(),
-	}, nil
+	bd.receipts = bc.bc.GetReceiptsByHash(b.Hash())
+	if expNum := len(bd.txs) + len(bd.stakings); len(bd.receipts) != expNum {
+		return nil, fmt.Errorf("unexpected number of receipts: %v / %v", len(bd.receipts), expNum)
+	}
+	bd.tokenTransfers = decodeTokenTransfers(b.NumberU64(), bd.receipts)
+	return bd, nil
 }
 
-func (bc *blockComputer) computeNormalTx(btc batch, b *types.Block, tx *types.Transaction) {
+func (bc *blockComputer) computeNormalTx(tx *types.Transaction) txData {
 	ethFrom, _ := tx.SenderAddress()
-	from := ethToOneAddress(ethFrom)
-
-	_ = writeAddressEntry(btc, from)
-
 	_, bn, index := bc.bc.ReadTxLookupEntry(tx.HashByType())
-	_ = writeNormalTxnIndex(btc, normalTxnIndex{
-		addr:        from,
+
+	td := txData{
+		hash:        tx.HashByType(),
 		blockNumber: bn,
-		txnIndex:    index,
-		txnHash:     tx.HashByType(),
-	}, txSent)
-
-	ethTo := tx.To()
-	if ethTo != nil { // Skip for contract creation
-		to := ethToOneAddress(*ethTo)
-		_ = writeAddressEntry(btc, to)
-		_ = writeNormalTxnIndex(btc, normalTxnIndex{
-			addr:        to,
-			blockNumber: bn,
-			txnIndex:    index,
-			txnHash:     tx.HashByType(),
-		}, txReceived)
-	}
-	// Not very sure how this 1000 come from. But it is the logic before migration
-	t := b.Time().Uint64() * 1000
-	tr := &TxRecord{tx.HashByType(), time.Unix(int64(t), 0)}
-	_ = writeTxn(btc, tx.HashByType(), tr)
+		index:       index,
+		from:        ethToOneAddress(ethFrom),
+	}
+	if ethTo := tx.To(); ethTo != nil { // Skip for contract creation
+		td.to = ethToOneAddress(*ethTo)
+	}
+	return td
 }
 
-func (bc *blockComputer) computeStakingTx(btc batch, b *types.Block, tx *staking.StakingTransaction) {
+func (bc *blockComputer) computeStakingTx(b *types.Block, tx *staking.StakingTransaction) txData {
 	ethFrom, _ := tx.SenderAddress()
-	from := ethToOneAddress(ethFrom)
-	_ = writeAddressEntry(btc, from)
 	_, bn, index := bc.bc.ReadTxLookupEntry(tx.Hash())
-	_ = writeStakingTxnIndex(btc, stakingTxnIndex{
-		addr:        from,
-		blockNumber: bn,
-		txnIndex:    index,
-		txnHash:     tx.Hash(),
-	}, txSent)
-	t := b.Time().Uint64() * 1000
-	tr := &TxRecord{tx.Hash(), time.Unix(int64(t), 0)}
-	_ = writeTxn(btc, tx.Hash(), tr)
-
-	ethTo, _ := toFromStakingTx(tx, b)
-	if ethTo == (common.Address{}) {
-		return
-	}
-	to := ethToOneAddress(ethTo)
-	_ = writeAddressEntry(btc, to)
-	_ = writeStakingTxnIndex(btc, stakingTxnIndex{
-		addr:        to,
+
+	td := txData{
+		hash:        tx.Hash(),
 		blockNumber: bn,
-		txnIndex:    index,
-		txnHash:     tx.Hash(),
-	}, txReceived)
+		index:       index,
+		from:        ethToOneAddress(ethFrom),
+	}
+	if ethTo, _ := toFromStakingTx(tx, b); ethTo != (common.Address{}) {
+		td.to = ethToOneAddress(ethTo)
+	}
+	return td
 }
 
 func ethToOneAddress(ethAddr common.Address) oneAddress {
@@ -0,0 +1,223 @@
+package explorer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// TokenStandard is the standard of the token contract emitting the transfer event
+type TokenStandard byte
+
+// Token standards
+const (
+	TokenUnknown TokenStandard = iota
+	TokenHRC20
+	TokenHRC721
+	TokenHRC1155
+)
+
+func (ts TokenStandard) String() string {
+	switch ts {
+	case TokenHRC20:
+		return "HRC20"
+	case TokenHRC721:
+		return "HRC721"
+	case TokenHRC1155:
+		return "HRC1155"
+	}
+	return "UNKNOWN"
+}
+
+var (
+	// Transfer(address indexed from, address indexed to, uint256 value) of HRC20, and
+	// Transfer(address indexed from, address indexed to, uint256 indexed tokenId) of HRC721
+	transferEventSig = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)
+	transferSingleEventSig = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	// TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)
+	transferBatchEventSig = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+// TokenTransfer is a token transfer decoded from the event logs of a block
+type TokenTransfer struct {
+	Token       common.Address
+	Standard    TokenStandard
+	From        common.Address
+	To          common.Address
+	TokenID     *big.Int // zero for HRC20
+	Value       *big.Int // one for HRC721
+	TxHash      common.Hash
+	BlockNumber uint64
+	LogIndex    uint64 // index of the log in the block
+	BatchIndex  uint64 // index of the transfer in HRC1155 TransferBatch
+}
+
+// TokenHolder is a holder of the token with the balance computed from the
+// indexed transfers. For HRC721, the balance is the number of tokens owned. For
+// HRC1155, the balance is the total amount of all token IDs.
+type TokenHolder struct {
+	Address common.Address
+	Balance *big.Int
+}
+
+// decodeTokenTransfers decodes the token transfers from the logs of the receipts
+// of a block. The logs are indexed by the order in the block.
+func decodeTokenTransfers(bn uint64, receipts types.Receipts) []*TokenTransfer {
+	var (
+		transfers []*TokenTransfer
+		logIndex  uint64
+	)
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			tts := decodeTokenTransferLog(log)
+			for _, tt := range tts {
+				tt.TxHash = receipt.TxHash
+				tt.BlockNumber = bn
+				tt.LogIndex = logIndex
+			}
+			transfers = append(transfers, tts...)
+			logIndex++
+		}
+	}
+	return transfers
+}
+
+// decodeTokenTransferLog decodes the token transfers from a single log. Nil is
+// returned if the log is not a token transfer event.
+func decodeTokenTransferLog(log *types.Log) []*TokenTransfer {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	switch log.Topics[0] {
+	case transferEventSig:
+		// HRC20 and HRC721 share the same signature, and are distinguished by
+		// whether the third argument is indexed.
+		if len(log.Topics) == 3 && len(log.Data) == 32 {
+			return []*TokenTransfer{{
+				Token:    log.Address,
+				Standard: TokenHRC20,
+				From:     topicToAddress(log.Topics[1]),
+				To:       topicToAddress(log.Topics[2]),
+				TokenID:  new(big.Int),
+				Value:    new(big.Int).SetBytes(log.Data),
+			}}
+		}
+		if len(log.Topics) == 4 && len(log.Data) == 0 {
+			return []*TokenTransfer{{
+				Token:    log.Address,
+				Standard: TokenHRC721,
+				From:     topicToAddress(log.Topics[1]),
+				To:       topicToAddress(log.Topics[2]),
+				TokenID:  log.Topics[3].Big(),
+				Value:    big.NewInt(1),
+			}}
+		}
+
+	case transferSingleEventSig:
+		if len(log.Topics) == 4 && len(log.Data) == 64 {
+			return []*TokenTransfer{{
+				Token:    log.Address,
+				Standard: TokenHRC1155,
+				From:     topicToAddress(log.Topics[2]),
+				To:       topicToAddress(log.Topics[3]),
+				TokenID:  new(big.Int).SetBytes(log.Data[:32]),
+				Value:    new(big.Int).SetBytes(log.Data[32:]),
+			}}
+		}
+
+	case transferBatchEventSig:
+		if len(log.Topics) != 4 {
+			return nil
+		}
+		ids, ok := decodeUint256Array(log.Data, 0)
+		if !ok {
+			return nil
+		}
+		values, ok := decodeUint256Array(log.Data, 1)
+		if !ok || len(ids) != len(values) {
+			return nil
+		}
+		transfers := make([]*TokenTransfer, 0, len(ids))
+		for i := range ids {
+			transfers = append(transfers, &TokenTransfer{
+				Token:      log.Address,
+				Standard:   TokenHRC1155,
+				From:       topicToAddress(log.Topics[2]),
+				To:         topicToAddress(log.Topics[3]),
+				TokenID:    ids[i],
+				Value:      values[i],
+				BatchIndex: uint64(i),
+			})
+		}
+		return transfers
+	}
+	return nil
+}
+
+func topicToAddress(topic common.Hash) common.Address {
+	return common.BytesToAddress(topic.Bytes())
+}
+
+// decodeUint256Array decodes the ABI encoded uint256[] which is the argument of
+// index argIndex in data.
+func decodeUint256Array(data []byte, argIndex int) ([]*big.Int, bool) {
+	head := argIndex * 32
+	if len(data) < head+32 {
+		return nil, false
+	}
+	offset := new(big.Int).SetBytes(data[head : head+32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(data))-32 {
+		return nil, false
+	}
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(data[start-32 : start])
+	if !length.IsUint64() || length.Uint64() > (uint64(len(data))-start)/32 {
+		return nil, false
+	}
+	res := make([]*big.Int, 0, length.Uint64())
+	for i := uint64(0); i != length.Uint64(); i++ {
+		pos := start + i*32
+		res = append(res, new(big.Int).SetBytes(data[pos:pos+32]))
+	}
+	return res, true
+}
+
+// tokenHolderID is the key of the balance of the holder of the token
+type tokenHolderID struct {
+	token  common.Address
+	holder common.Address
+}
+
+// tokenBalanceChanges returns the balance changes of the token holders by the
+// transfers. The zero address, which is the source of mint and the sink of burn,
+// is not a holder.
+func tokenBalanceChanges(transfers []*TokenTransfer) map[tokenHolderID]*big.Int {
+	changes := make(map[tokenHolderID]*big.Int)
+	change := func(token, holder common.Address, delta *big.Int) {
+		if holder == (common.Address{}) {
+			return
+		}
+		id := tokenHolderID{token, holder}
+		if _, ok := changes[id]; !ok {
+			changes[id] = new(big.Int)
+		}
+		changes[id].Add(changes[id], delta)
+	}
+	for _, tt := range transfers {
+		change(tt.Token, tt.From, new(big.Int).Neg(tt.Value))
+		change(tt.Token, tt.To, tt.Value)
+	}
+	return changes
+}
+
+// isTokenHolder returns whether the signed balance makes a token holder. The
+// balance is not floored at zero, since the blocks are written in any order and
+// an outflow might be applied before the inflow which funds it. A negative
+// balance is also left by the transfers before the explorer db was created,
+// which are not indexed.
+func isTokenHolder(balance *big.Int) bool {
+	return balance.Sign() > 0
+}
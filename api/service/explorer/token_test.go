@@ -0,0 +1,312 @@
+package explorer
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+var (
+	testToken    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testOperator = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	testFrom     = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	testTo       = common.HexToAddress("0x4000000000000000000000000000000000000004")
+)
+
+func TestDecodeTokenTransferLog(t *testing.T) {
+	tests := []struct {
+		log *types.Log
+		exp []*TokenTransfer
+	}{
+		{
+			log: makeHRC20TransferLog(testToken, testFrom, testTo, 100),
+			exp: []*TokenTransfer{{
+				Token: testToken, Standard: TokenHRC20, From: testFrom, To: testTo,
+				TokenID: big.NewInt(0), Value: big.NewInt(100),
+			}},
+		},
+		{
+			log: makeHRC721TransferLog(testToken, testFrom, testTo, 7),
+			exp: []*TokenTransfer{{
+				Token: testToken, Standard: TokenHRC721, From: testFrom, To: testTo,
+				TokenID: big.NewInt(7), Value: big.NewInt(1),
+			}},
+		},
+		{
+			log: makeTransferSingleLog(testToken, testFrom, testTo, 7, 100),
+			exp: []*TokenTransfer{{
+				Token: testToken, Standard: TokenHRC1155, From: testFrom, To: testTo,
+				TokenID: big.NewInt(7), Value: big.NewInt(100),
+			}},
+		},
+		{
+			log: makeTransferBatchLog(testToken, testFrom, testTo, []int64{7, 8}, []int64{100, 200}),
+			exp: []*TokenTransfer{
+				{
+					Token: testToken, Standard: TokenHRC1155, From: testFrom, To: testTo,
+					TokenID: big.NewInt(7), Value: big.NewInt(100),
+				},
+				{
+					Token: testToken, Standard: TokenHRC1155, From: testFrom, To: testTo,
+					TokenID: big.NewInt(8), Value: big.NewInt(200), BatchIndex: 1,
+				},
+			},
+		},
+		{
+			// ids and values of different lengths
+			log: makeTransferBatchLog(testToken, testFrom, testTo, []int64{7, 8}, []int64{100}),
+			exp: nil,
+		},
+		{
+			// truncated data
+			log: func() *types.Log {
+				log := makeTransferBatchLog(testToken, testFrom, testTo, []int64{7}, []int64{100})
+				log.Data = log.Data[:len(log.Data)-1]
+				return log
+			}(),
+			exp: nil,
+		},
+		{
+			// Transfer without value
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferEventSig, addressToTopic(testFrom), addressToTopic(testTo)},
+			},
+			exp: nil,
+		},
+		{
+			log: &types.Log{Address: testToken, Topics: []common.Hash{{0x1}}},
+			exp: nil,
+		},
+	}
+	for i, test := range tests {
+		tts := decodeTokenTransferLog(test.log)
+		if !reflect.DeepEqual(tts, test.exp) {
+			t.Errorf("Test %v: unexpected transfers %v / %v", i, tts, test.exp)
+		}
+	}
+}
+
+func TestTokenBalanceChanges(t *testing.T) {
+	var zero common.Address
+	transfers := []*TokenTransfer{
+		{Token: testToken, From: zero, To: testFrom, Value: big.NewInt(100)},
+		{Token: testToken, From: testFrom, To: testTo, Value: big.NewInt(30)},
+		{Token: testToken, From: testTo, To: zero, Value: big.NewInt(10)},
+	}
+	changes := tokenBalanceChanges(transfers)
+	exp := map[tokenHolderID]*big.Int{
+		{testToken, testFrom}: big.NewInt(70),
+		{testToken, testTo}:   big.NewInt(20),
+	}
+	if !reflect.DeepEqual(changes, exp) {
+		t.Errorf("unexpected changes %v / %v", changes, exp)
+	}
+}
+
+func TestExplorerDB_tokenTransfers(t *testing.T) {
+	dbs := map[string]explorerDB{
+		BackendLevelDB: &kvDB{newTestLevelDB(t, 2)},
+		BackendSQL:     newTestSQLDB(t, 3),
+	}
+	for name, db := range dbs {
+		tb := makeTestBlock(t)
+		a0, a1 := tb.ethAddr(0), tb.ethAddr(1)
+		tb.receipts[0].Logs = []*types.Log{
+			makeHRC20TransferLog(testToken, common.Address{}, a0, 100),
+			makeHRC20TransferLog(testToken, a0, a1, 30),
+		}
+		tb.receipts[1].Logs = []*types.Log{
+			makeHRC20TransferLog(testToken, a1, a1, 5),
+		}
+
+		bc := &blockComputer{db: db, bc: tb}
+		bd, err := bc.computeBlock(tb.block)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if err := db.writeBlock(bd); err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+
+		tts, err := db.getTokenTransfersByAddress(tb.addrs[0], 0, 10)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if len(tts) != 2 || tts[0].LogIndex != 0 || tts[1].LogIndex != 1 {
+			t.Fatalf("%v: unexpected transfers %v", name, tts)
+		}
+		exp := &TokenTransfer{
+			Token: testToken, Standard: TokenHRC20, From: a0, To: a1,
+			TokenID: big.NewInt(0), Value: big.NewInt(30),
+			TxHash: tb.block.Transactions()[0].Hash(), BlockNumber: testBlockNumber, LogIndex: 1,
+		}
+		if !reflect.DeepEqual(tts[1], exp) {
+			t.Errorf("%v: unexpected transfer %+v / %+v", name, tts[1], exp)
+		}
+		// transfer to self is indexed once
+		tts, err = db.getTokenTransfersByAddress(tb.addrs[1], 0, 10)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if len(tts) != 2 {
+			t.Errorf("%v: unexpected transfers %v", name, tts)
+		}
+		tts, err = db.getTokenTransfersByAddress(tb.addrs[0], 1, 10)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if len(tts) != 1 || tts[0].LogIndex != 1 {
+			t.Errorf("%v: unexpected transfers %v", name, tts)
+		}
+
+		expHolders := []*TokenHolder{
+			{Address: a0, Balance: big.NewInt(70)},
+			{Address: a1, Balance: big.NewInt(30)},
+		}
+		sort.Slice(expHolders, func(i, j int) bool {
+			return ethToOneAddress(expHolders[i].Address) < ethToOneAddress(expHolders[j].Address)
+		})
+		ths, err := db.getTokenHolders(ethToOneAddress(testToken), 0, 10)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if !reflect.DeepEqual(ths, expHolders) {
+			t.Errorf("%v: unexpected holders %v / %v", name, ths, expHolders)
+		}
+		ths, err = db.getTokenHolders(ethToOneAddress(testToken), 1, 10)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if !reflect.DeepEqual(ths, expHolders[1:]) {
+			t.Errorf("%v: unexpected holders %v / %v", name, ths, expHolders[1:])
+		}
+	}
+}
+
+func TestExplorerDB_tokenHoldersOutOfOrder(t *testing.T) {
+	dbs := map[string]explorerDB{
+		BackendLevelDB: &kvDB{newTestLevelDB(t, 4)},
+		BackendSQL:     newTestSQLDB(t, 5),
+	}
+	var zero common.Address
+	// the outflow of testFrom is written before the mint which funds it
+	blocks := [][]*TokenTransfer{
+		{{Token: testToken, From: testFrom, To: testTo, Value: big.NewInt(30)}},
+		{{Token: testToken, From: zero, To: testFrom, Value: big.NewInt(100)}},
+	}
+	for name, db := range dbs {
+		for i, transfers := range blocks {
+			if err := writeTestTokenHolders(db, transfers); err != nil {
+				t.Fatalf("%v: %v", name, err)
+			}
+			ths, err := db.getTokenHolders(ethToOneAddress(testToken), 0, 10)
+			if err != nil {
+				t.Fatalf("%v: %v", name, err)
+			}
+			if i == 0 && (len(ths) != 1 || ths[0].Address != testTo) {
+				t.Errorf("%v: unexpected holders %v", name, ths)
+			}
+		}
+		expHolders := []*TokenHolder{
+			{Address: testFrom, Balance: big.NewInt(70)},
+			{Address: testTo, Balance: big.NewInt(30)},
+		}
+		sort.Slice(expHolders, func(i, j int) bool {
+			return ethToOneAddress(expHolders[i].Address) < ethToOneAddress(expHolders[j].Address)
+		})
+		ths, err := db.getTokenHolders(ethToOneAddress(testToken), 0, 10)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if !reflect.DeepEqual(ths, expHolders) {
+			t.Errorf("%v: unexpected holders %v / %v", name, ths, expHolders)
+		}
+	}
+}
+
+func writeTestTokenHolders(db explorerDB, transfers []*TokenTransfer) error {
+	switch db := db.(type) {
+	case *kvDB:
+		btc := db.db.NewBatch()
+		if err := db.writeTokenHolders(btc, transfers); err != nil {
+			return err
+		}
+		return btc.Write()
+	case *sqlDB:
+		dbTx, err := db.db.Begin()
+		if err != nil {
+			return err
+		}
+		defer dbTx.Rollback()
+		if err := db.updateTokenHolders(dbTx, &blockData{tokenTransfers: transfers}); err != nil {
+			return err
+		}
+		return dbTx.Commit()
+	}
+	return nil
+}
+
+func makeHRC20TransferLog(token, from, to common.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics:  []common.Hash{transferEventSig, addressToTopic(from), addressToTopic(to)},
+		Data:    common.BigToHash(big.NewInt(value)).Bytes(),
+	}
+}
+
+func makeHRC721TransferLog(token, from, to common.Address, tokenID int64) *types.Log {
+	return &types.Log{
+		Address: token,
+		Topics: []common.Hash{
+			transferEventSig, addressToTopic(from), addressToTopic(to),
+			common.BigToHash(big.NewInt(tokenID)),
+		},
+	}
+}
+
+func makeTransferSingleLog(token, from, to common.Address, id, value int64) *types.Log {
+	var data []byte
+	data = append(data, common.BigToHash(big.NewInt(id)).Bytes()...)
+	data = append(data, common.BigToHash(big.NewInt(value)).Bytes()...)
+	return &types.Log{
+		Address: token,
+		Topics: []common.Hash{
+			transferSingleEventSig, addressToTopic(testOperator), addressToTopic(from),
+			addressToTopic(to),
+		},
+		Data: data,
+	}
+}
+
+func makeTransferBatchLog(token, from, to common.Address, ids, values []int64) *types.Log {
+	word := func(i int64) []byte {
+		return common.BigToHash(big.NewInt(i)).Bytes()
+	}
+	var data []byte
+	// offsets of the two dynamic arrays
+	data = append(data, word(64)...)
+	data = append(data, word(int64(64+32+32*len(ids)))...)
+	for _, arr := range [][]int64{ids, values} {
+		data = append(data, word(int64(len(arr)))...)
+		for _, v := range arr {
+			data = append(data, word(v)...)
+		}
+	}
+	return &types.Log{
+		Address: token,
+		Topics: []common.Hash{
+			transferBatchEventSig, addressToTopic(testOperator), addressToTopic(from),
+			addressToTopic(to),
+		},
+		Data: data,
+	}
+}
+
+func addressToTopic(addr common.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
@@ -379,6 +379,21 @@ func (h *Header) SetSlashes(newSlashes []byte) {
 		Msg("cannot store slashes in V1 header")
 }
 
+// BaseFee is the EIP-1559 base fee per gas, which is always nil in V1 header.
+func (h *Header) BaseFee() *big.Int {
+	return nil
+}
+
+// SetBaseFee sets the EIP-1559 base fee per gas, which is not supported in V1
+// header.
+func (h *Header) SetBaseFee(newBaseFee *big.Int) {
+	if newBaseFee != nil {
+		h.Logger(utils.Logger()).Error().
+			Str("baseFee", newBaseFee.String()).
+			Msg("cannot store base fee in V1 header")
+	}
+}
+
 // field type overrides for gencodec
 type headerMarshaling struct {
 	Difficulty *hexutil.Big
@@ -378,6 +378,21 @@ func (h *Header) SetSlashes(newSlashes []byte) {
 		Msg("cannot store slashes in V2 header")
 }
 
+// BaseFee is the EIP-1559 base fee per gas, which is always nil in V2 header.
+func (h *Header) BaseFee() *big.Int {
+	return nil
+}
+
+// SetBaseFee sets the EIP-1559 base fee per gas, which is not supported in V2
+// header.
+func (h *Header) SetBaseFee(newBaseFee *big.Int) {
+	if newBaseFee != nil {
+		h.Logger(utils.Logger()).Error().
+			Str("baseFee", newBaseFee.String()).
+			Msg("cannot store base fee in V2 header")
+	}
+}
+
 // field type overrides for gencodec
 type headerMarshaling struct {
 	Difficulty *hexutil.Big
@@ -8,9 +8,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/harmony-one/harmony/api/service/explorer"
 	"github.com/harmony-one/harmony/internal/cli"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/shardchain"
 	"github.com/pelletier/go-toml"
 	"github.com/spf13/cobra"
 )
@@ -47,6 +49,40 @@ func validateHarmonyConfig(config harmonyconfig.HarmonyConfig) error {
 		return errors.New("flag --run.shard must be specified for explorer node")
 	}
 
+	if config.Explorer != nil {
+		accepts = []string{explorer.BackendLevelDB, explorer.BackendSQL}
+		if err := checkStringAccepted("--explorer.backend", config.Explorer.Backend, accepts); err != nil {
+			return err
+		}
+		if config.Explorer.Backend == explorer.BackendSQL {
+			accepts = []string{explorer.SQLDriverSQLite, explorer.SQLDriverPostgres}
+			if err := checkStringAccepted("--explorer.sql.driver", config.Explorer.SQLDriver, accepts); err != nil {
+				return err
+			}
+			if config.Explorer.SQLDriver == explorer.SQLDriverPostgres && config.Explorer.SQLSource == "" {
+				return errors.New("flag --explorer.sql.source must be specified for postgres")
+			}
+		}
+	}
+
+	accepts = []string{shardchain.LevelDBEngine, shardchain.PebbleEngine}
+	if err := checkStringAccepted("--db.engine", config.General.DBEngine, accepts); err != nil {
+		return err
+	}
+
+	if config.Freezer != nil && config.Freezer.Enabled && config.Freezer.Depth < minFreezerDepth {
+		return fmt.Errorf("flag --freezer.depth must be at least %v", minFreezerDepth)
+	}
+
+	if config.TraceIndex != nil && config.TraceIndex.Enabled {
+		if !config.General.IsArchival {
+			return errors.New("flag --traceindex.enabled must run on an archival node")
+		}
+		if config.TraceIndex.From < 0 {
+			return errors.New("flag --traceindex.from must not be negative")
+		}
+	}
+
 	if config.General.IsOffline && config.P2P.IP != nodeconfig.DefaultLocalListenIP {
 		return fmt.Errorf("flag --run.offline must have p2p IP be %v", nodeconfig.DefaultLocalListenIP)
 	}
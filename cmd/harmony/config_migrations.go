@@ -220,4 +220,13 @@ func init() {
 		confTree.Set("Version", "2.5.0")
 		return confTree
 	}
+
+	migrations["2.5.0"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("General.DBEngine") == nil {
+			confTree.Set("General.DBEngine", defaultConfig.General.DBEngine)
+		}
+
+		confTree.Set("Version", "2.5.1")
+		return confTree
+	}
 }
@@ -0,0 +1,508 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/api/service/explorer"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state/pruner"
+	"github.com/harmony-one/harmony/internal/cli"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/pebble"
+	"github.com/harmony-one/harmony/internal/shardchain"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "offline maintenance of the chain database",
+	Long:  "offline maintenance of the chain database. The node must be stopped before running the commands.",
+}
+
+var pruneStateCmd = &cobra.Command{
+	Use:   "prune-state",
+	Short: "delete the stale state trie nodes from the chain database",
+	Long: `delete the stale state trie nodes from the chain database
+
+The state of the head block, and the state of the given epoch blocks are kept. All the
+other trie nodes and contract code are deleted. The pruning can be resumed by running
+the command again if interrupted, and the node refuses to start until it is finished.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := pruneState(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var inspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "report the storage usage of the chain database by the kinds of the entries",
+	Long: `report the storage usage of the chain database by the kinds of the entries
+
+All the entries of the chain database are iterated and categorized by the key prefixes,
+and the number and total size of the entries of each category are reported. The frozen
+blocks in the freezer are reported if any. The explorer database is inspected instead
+if --explorer-db is given.`,
+	Example: "harmony db inspect --datadir ./ --shard 0 --json",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := inspectDB(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var migratePebbleCmd = &cobra.Command{
+	Use:   "migrate-pebble",
+	Short: "copy the leveldb chain database into a new pebble database",
+	Long: `copy the leveldb chain database into a new pebble database
+
+All the entries of the leveldb are copied into a new pebble database, which then
+replaces the leveldb in the data directory. The leveldb is kept with the .leveldb
+suffix, and can be deleted after the node runs well with --db.engine pebble. The
+explorer database is migrated instead if --explorer-db is given.`,
+	Example: "harmony db migrate-pebble --datadir ./ --shard 0",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := migratePebble(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var verifyChainCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "check the integrity of the canonical chain in the chain database",
+	Long: `check the integrity of the canonical chain in the chain database
+
+The header linkage, the presence of the bodies and receipts, the transaction lookup
+entries, the commit signatures, the epoch block numbers, the shard states, and the
+state of the head block are checked. The validator list and the delegation indexes
+are checked as well if verifying from the genesis block. The issues marked as
+reindexable can be fixed by the reindex command.`,
+	Example: "harmony db verify --network mainnet --shard 0",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := verifyChain(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var reindexChainCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "regenerate the indexes derivable from the blocks in the chain database",
+	Long: `regenerate the indexes derivable from the blocks in the chain database
+
+The transaction lookup entries, the epoch block numbers, the shard states and the
+missing commit signatures are regenerated from the blocks. The validator list and
+the delegation indexes are regenerated as well if reindexing from the genesis block,
+which requires the state of the head block.`,
+	Example: "harmony db reindex --network mainnet --shard 0",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := reindexChain(cmd); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	dbDataDirFlag = cli.StringFlag{
+		Name:     "datadir",
+		Usage:    "directory of the chain databases",
+		DefValue: defaultConfig.General.DataDir,
+	}
+	dbShardIDFlag = cli.IntFlag{
+		Name:     "shard",
+		Usage:    "shard ID of the chain database",
+		DefValue: 0,
+	}
+	dbEngineCmdFlag = cli.StringFlag{
+		Name:     "engine",
+		Usage:    "database engine of the chain and explorer databases (leveldb, pebble)",
+		DefValue: defaultConfig.General.DBEngine,
+	}
+	pruneBloomSizeFlag = cli.IntFlag{
+		Name:     "bloom-size",
+		Usage:    "size in megabytes of the bloom filter of the state to keep",
+		DefValue: 2048,
+	}
+	pruneKeepEpochBlocksFlag = cli.IntSliceFlag{
+		Name:     "keep-epoch-blocks",
+		Usage:    "numbers of the epoch blocks of which the state is kept",
+		DefValue: []int{},
+	}
+	inspectFreezerDirFlag = cli.StringFlag{
+		Name:     "freezer-dir",
+		Usage:    "directory of the freezers (the data directory if empty)",
+		DefValue: "",
+	}
+	inspectExplorerDBFlag = cli.StringFlag{
+		Name:     "explorer-db",
+		Usage:    "path of the explorer database to inspect instead of the chain database",
+		DefValue: "",
+	}
+	migrateExplorerDBFlag = cli.StringFlag{
+		Name:     "explorer-db",
+		Usage:    "path of the explorer database to migrate instead of the chain database",
+		DefValue: "",
+	}
+	inspectJSONFlag = cli.BoolFlag{
+		Name:     "json",
+		Usage:    "output the report in JSON",
+		DefValue: false,
+	}
+	dbFromFlag = cli.IntFlag{
+		Name:     "from",
+		Usage:    "number of the first block to verify or reindex",
+		DefValue: 0,
+	}
+	dbToFlag = cli.IntFlag{
+		Name:     "to",
+		Usage:    "number of the last block to verify or reindex (the head block if negative)",
+		DefValue: -1,
+	}
+)
+
+func registerDBCmdFlags() error {
+	if err := cli.RegisterFlags(pruneStateCmd, []cli.Flag{
+		dbDataDirFlag,
+		dbShardIDFlag,
+		dbEngineCmdFlag,
+		pruneBloomSizeFlag,
+		pruneKeepEpochBlocksFlag,
+	}); err != nil {
+		return err
+	}
+	if err := cli.RegisterFlags(inspectCmd, []cli.Flag{
+		dbDataDirFlag,
+		dbShardIDFlag,
+		dbEngineCmdFlag,
+		inspectFreezerDirFlag,
+		inspectExplorerDBFlag,
+		inspectJSONFlag,
+	}); err != nil {
+		return err
+	}
+	if err := cli.RegisterFlags(migratePebbleCmd, []cli.Flag{
+		dbDataDirFlag,
+		dbShardIDFlag,
+		migrateExplorerDBFlag,
+	}); err != nil {
+		return err
+	}
+	chainFlags := []cli.Flag{
+		configFlag,
+		networkTypeFlag,
+		dbDataDirFlag,
+		dbShardIDFlag,
+		dbFromFlag,
+		dbToFlag,
+	}
+	if err := cli.RegisterFlags(verifyChainCmd, chainFlags); err != nil {
+		return err
+	}
+	return cli.RegisterFlags(reindexChainCmd, chainFlags)
+}
+
+func pruneState(cmd *cobra.Command) error {
+	factory, err := newDBCmdFactory(cmd)
+	if err != nil {
+		return err
+	}
+	var (
+		shardID   = uint32(cli.GetIntFlagValue(cmd, dbShardIDFlag))
+		bloomSize = cli.GetIntFlagValue(cmd, pruneBloomSizeFlag)
+		dbDir     = factory.ChainDBDir(shardID)
+	)
+	if _, err := os.Stat(dbDir); err != nil {
+		return errors.Wrap(err, "open chain db")
+	}
+	if bloomSize <= 0 {
+		return fmt.Errorf("invalid bloom filter size: %v", bloomSize)
+	}
+	db, err := factory.NewChainDB(shardID)
+	if err != nil {
+		return errors.Wrap(err, "open chain db")
+	}
+	defer db.Close()
+
+	// The head block is always kept
+	headHash := rawdb.ReadHeadBlockHash(db)
+	headNum := rawdb.ReadHeaderNumber(db, headHash)
+	if headNum == nil {
+		return errors.New("head block not found")
+	}
+	head := rawdb.ReadHeader(db, headHash, *headNum)
+	if head == nil {
+		return errors.New("head block not found")
+	}
+	roots := []common.Hash{head.Root()}
+	for _, bn := range cli.GetIntSliceFlagValue(cmd, pruneKeepEpochBlocksFlag) {
+		if bn < 0 || uint64(bn) > *headNum {
+			return fmt.Errorf("invalid epoch block number: %v", bn)
+		}
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, uint64(bn)), uint64(bn))
+		if header == nil {
+			return fmt.Errorf("block %v not found", bn)
+		}
+		roots = append(roots, header.Root())
+	}
+
+	p, err := pruner.NewPruner(db, dbDir, roots, uint64(bloomSize))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Pruning the state of shard %v, keeping %v state roots\n", shardID, len(roots))
+	if err := p.Prune(); err != nil {
+		return err
+	}
+	fmt.Println("State pruning finished")
+	return nil
+}
+
+func inspectDB(cmd *cobra.Command) error {
+	var (
+		stats []rawdb.DatabaseStat
+		err   error
+	)
+	if cli.IsFlagChanged(cmd, inspectExplorerDBFlag) {
+		dbPath := cli.GetStringFlagValue(cmd, inspectExplorerDBFlag)
+		engine := cli.GetStringFlagValue(cmd, dbEngineCmdFlag)
+		if stats, err = explorer.InspectDB(dbPath, engine); err != nil {
+			return errors.Wrap(err, "inspect explorer db")
+		}
+	} else {
+		if stats, err = inspectChainDB(cmd); err != nil {
+			return err
+		}
+	}
+
+	if cli.GetBoolFlagValue(cmd, inspectJSONFlag) {
+		b, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+	var count, size uint64
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Category\tCount\tSize\t")
+	for _, s := range stats {
+		fmt.Fprintf(w, "%v\t%v\t%v\t\n", s.Category, s.Count, common.StorageSize(s.Size))
+		count += s.Count
+		size += s.Size
+	}
+	fmt.Fprintf(w, "Total\t%v\t%v\t\n", count, common.StorageSize(size))
+	return w.Flush()
+}
+
+// inspectChainDB returns the storage usage of the chain database and the freezer
+// of the shard given by the command flags.
+func inspectChainDB(cmd *cobra.Command) ([]rawdb.DatabaseStat, error) {
+	factory, err := newDBCmdFactory(cmd)
+	if err != nil {
+		return nil, err
+	}
+	shardID := uint32(cli.GetIntFlagValue(cmd, dbShardIDFlag))
+	if _, err := os.Stat(factory.ChainDBDir(shardID)); err != nil {
+		return nil, errors.Wrap(err, "open chain db")
+	}
+	// The chain db is opened without the freezer, so that no block is frozen during
+	// the inspection. The freezer is inspected separately.
+	db, err := factory.NewChainDB(shardID)
+	if err != nil {
+		return nil, errors.Wrap(err, "open chain db")
+	}
+	defer db.Close()
+
+	stats, err := rawdb.InspectDatabase(db)
+	if err != nil {
+		return nil, errors.Wrap(err, "inspect chain db")
+	}
+	freezer := shardchain.LDBFactory{FreezerDir: cli.GetStringFlagValue(cmd, inspectFreezerDirFlag)}
+	if freezer.FreezerDir == "" {
+		freezer.FreezerDir = cli.GetStringFlagValue(cmd, dbDataDirFlag)
+	}
+	if _, err := os.Stat(freezer.FreezerDBDir(shardID)); err == nil {
+		ancientStats, err := rawdb.InspectFreezer(freezer.FreezerDBDir(shardID))
+		if err != nil {
+			return nil, errors.Wrap(err, "inspect freezer")
+		}
+		stats = append(stats, ancientStats...)
+	}
+	return stats, nil
+}
+
+func migratePebble(cmd *cobra.Command) error {
+	var (
+		factory = &shardchain.LDBFactory{RootDir: cli.GetStringFlagValue(cmd, dbDataDirFlag)}
+		shardID = uint32(cli.GetIntFlagValue(cmd, dbShardIDFlag))
+		dbDir   = factory.ChainDBDir(shardID)
+	)
+	if cli.IsFlagChanged(cmd, migrateExplorerDBFlag) {
+		dbDir = cli.GetStringFlagValue(cmd, migrateExplorerDBFlag)
+	} else if err := checkStatePruning(factory, shardID); err != nil {
+		return err
+	}
+	var (
+		pebbleDir  = dbDir + ".pebble"
+		leveldbDir = dbDir + ".leveldb"
+	)
+	if _, err := os.Stat(leveldbDir); err == nil {
+		return fmt.Errorf("%v already exists", leveldbDir)
+	}
+	if _, err := os.Stat(pebbleDir); err == nil {
+		return fmt.Errorf("%v already exists, delete it to restart the migration", pebbleDir)
+	}
+
+	fmt.Printf("Copying %v into %v\n", dbDir, pebbleDir)
+	count, err := pebble.MigrateLevelDB(dbDir, pebbleDir)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(dbDir, leveldbDir); err != nil {
+		return err
+	}
+	if err := os.Rename(pebbleDir, dbDir); err != nil {
+		return err
+	}
+	fmt.Printf("Migrated %v entries, the leveldb is moved to %v\n", count, leveldbDir)
+	fmt.Println("Run the node with --db.engine pebble, or set DBEngine = \"pebble\" in the General section of the config")
+	return nil
+}
+
+func verifyChain(cmd *cobra.Command) error {
+	db, config, first, last, err := openChainDBWithRange(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	fmt.Printf("Verifying blocks [%v, %v]\n", first, last)
+	var issues, reindexable int
+	err = core.VerifyChain(db, config, first, last, func(issue core.ChainIssue) {
+		fmt.Println(issue)
+		issues++
+		if issue.Reindexable {
+			reindexable++
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if issues == 0 {
+		fmt.Println("No issue found")
+		return nil
+	}
+	return fmt.Errorf("%v issues found, %v of which can be fixed with ./harmony db reindex",
+		issues, reindexable)
+}
+
+func reindexChain(cmd *cobra.Command) error {
+	db, config, first, last, err := openChainDBWithRange(cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	fmt.Printf("Reindexing blocks [%v, %v]\n", first, last)
+	if err := core.ReindexChain(db, config, first, last); err != nil {
+		return err
+	}
+	fmt.Println("Reindexing finished")
+	return nil
+}
+
+// openChainDBWithRange opens the chain database of the shard given by the command
+// flags, and returns it with the chain config and the block range of the flags.
+func openChainDBWithRange(cmd *cobra.Command) (ethdb.Database, *params.ChainConfig, uint64, uint64, error) {
+	hc, shardID, err := setupShardChainCmd(cmd)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	factory, err := newChainDBFactory(hc, hc.General.DataDir)
+	if err != nil {
+		return nil, nil, 0, 0, err
+	}
+	if _, err := os.Stat(factory.ChainDBDir(shardID)); err != nil {
+		return nil, nil, 0, 0, errors.Wrap(err, "open chain db")
+	}
+	if err := checkStatePruning(factory, shardID); err != nil {
+		return nil, nil, 0, 0, err
+	}
+	db, err := factory.NewChainDB(shardID)
+	if err != nil {
+		return nil, nil, 0, 0, errors.Wrap(err, "open chain db")
+	}
+
+	head := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadBlockHash(db))
+	if head == nil {
+		db.Close()
+		return nil, nil, 0, 0, errors.New("head block not found")
+	}
+	var (
+		from = cli.GetIntFlagValue(cmd, dbFromFlag)
+		to   = cli.GetIntFlagValue(cmd, dbToFlag)
+	)
+	if to < 0 {
+		to = int(*head)
+	}
+	if from < 0 || from > to || uint64(to) > *head {
+		db.Close()
+		return nil, nil, 0, 0, fmt.Errorf("invalid block range [%v, %v] of chain with head %v",
+			from, to, *head)
+	}
+	config := core.NewGenesisSpec(nodeconfig.NetworkType(hc.Network.NetworkType), shardID).Config
+	return db, config, uint64(from), uint64(to), nil
+}
+
+// newDBCmdFactory returns the factory of the chain databases of the engine and
+// in the data directory given by the command flags.
+func newDBCmdFactory(cmd *cobra.Command) (shardchain.DiskDBFactory, error) {
+	dirs := shardchain.LDBFactory{RootDir: cli.GetStringFlagValue(cmd, dbDataDirFlag)}
+	return shardchain.NewDiskDBFactory(cli.GetStringFlagValue(cmd, dbEngineCmdFlag), dirs)
+}
+
+// newChainDBFactory returns the factory of the chain databases of the engine in
+// the config in dbDir, with the freezer if enabled in the config.
+func newChainDBFactory(hc harmonyconfig.HarmonyConfig, dbDir string) (shardchain.DiskDBFactory, error) {
+	dirs := shardchain.LDBFactory{RootDir: dbDir}
+	if hc.Freezer != nil && hc.Freezer.Enabled {
+		dirs.FreezerDir = hc.Freezer.Dir
+		if dirs.FreezerDir == "" {
+			dirs.FreezerDir = dbDir
+		}
+		dirs.FreezerDepth = uint64(hc.Freezer.Depth)
+	}
+	return shardchain.NewDiskDBFactory(hc.General.DBEngine, dirs)
+}
+
+// checkStatePruning returns error if the state pruning of any of the chain db of
+// the shards is interrupted.
+func checkStatePruning(factory shardchain.DiskDBFactory, shardIDs ...uint32) error {
+	for _, shardID := range shardIDs {
+		if pruner.IsInterrupted(factory.ChainDBDir(shardID)) {
+			return fmt.Errorf("state pruning of shard %v is interrupted, "+
+				"resume it with ./harmony db prune-state --shard %v", shardID, shardID)
+		}
+	}
+	return nil
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"github.com/harmony-one/harmony/api/service/explorer"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/shardchain"
 )
 
-const tomlConfigVersion = "2.5.0"
+const tomlConfigVersion = "2.5.1"
 
 const (
 	defNetworkType = nodeconfig.Mainnet
@@ -21,6 +23,7 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		IsBeaconArchival: false,
 		IsOffline:        false,
 		DataDir:          "./",
+		DBEngine:         shardchain.LevelDBEngine,
 	},
 	Network: getDefaultNetworkConfig(defNetworkType),
 	P2P: harmonyconfig.P2pConfig{
@@ -122,6 +125,23 @@ var defaultPrometheusConfig = harmonyconfig.PrometheusConfig{
 	Gateway:    "https://gateway.harmony.one",
 }
 
+var defaultExplorerConfig = harmonyconfig.ExplorerConfig{
+	Backend:   explorer.BackendLevelDB,
+	SQLDriver: explorer.SQLDriverSQLite,
+	SQLSource: "",
+}
+
+var defaultFreezerConfig = harmonyconfig.FreezerConfig{
+	Enabled: false,
+	Dir:     "",
+	Depth:   90000,
+}
+
+var defaultTraceIndexConfig = harmonyconfig.TraceIndexConfig{
+	Enabled: false,
+	From:    0,
+}
+
 var (
 	defaultMainnetSyncConfig = harmonyconfig.SyncConfig{
 		Enabled:        false,
@@ -220,6 +240,25 @@ func getDefaultPrometheusConfigCopy() harmonyconfig.PrometheusConfig {
 	return config
 }
 
+func getDefaultExplorerConfigCopy() harmonyconfig.ExplorerConfig {
+	config := defaultExplorerConfig
+	return config
+}
+
+func getDefaultFreezerConfigCopy() harmonyconfig.FreezerConfig {
+	config := defaultFreezerConfig
+	return config
+}
+
+func getDefaultTraceIndexConfigCopy() harmonyconfig.TraceIndexConfig {
+	config := defaultTraceIndexConfig
+	return config
+}
+
+// minFreezerDepth is the min number of the recent blocks kept in the chain db, so
+// that the blocks to be rolled back in a short reorg are never frozen.
+const minFreezerDepth = 128
+
 const (
 	nodeTypeValidator = "validator"
 	nodeTypeExplorer  = "explorer"
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/internal/cli"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/node"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// exportBatchSize is the number of blocks exported between the progress outputs
+	exportBatchSize = 10000
+
+	// importProgressInterval is the interval of the import progress outputs
+	importProgressInterval = 8 * time.Second
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "export the blocks of a shard chain with the commit signatures to file",
+	Long: `export the blocks of a shard chain with the commit signatures to file
+
+The file is a stream of RLP encoded blocks with the commit signatures, which can be
+imported with the import command. The file is gzip compressed if the name ends with
+.gz. The node must be stopped before running the command.`,
+	Example: "harmony export --network mainnet --shard 0 --from 0 --to 100000 shard0.rlp.gz",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportChain(cmd, args[0]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "import the blocks of a shard chain with the commit signatures from file",
+	Long: `import the blocks of a shard chain with the commit signatures from file
+
+The commit signature of each block is verified against the committee before the
+block is inserted, and the blocks already in the chain are skipped. The chain
+database is initialized with the genesis block if not exist. The file is gzip
+compressed if the name ends with .gz. The node must be stopped before running the
+command.`,
+	Example: "harmony import --network mainnet --shard 0 shard0.rlp.gz",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importChain(cmd, args[0]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	exportFromFlag = cli.IntFlag{
+		Name:     "from",
+		Usage:    "number of the first block to export",
+		DefValue: 0,
+	}
+	exportToFlag = cli.IntFlag{
+		Name:     "to",
+		Usage:    "number of the last block to export (the head block if negative)",
+		DefValue: -1,
+	}
+)
+
+func registerChainFileCmdFlags() error {
+	chainFlags := []cli.Flag{
+		configFlag,
+		networkTypeFlag,
+		dbDataDirFlag,
+		dbShardIDFlag,
+	}
+	if err := cli.RegisterFlags(exportCmd, append(chainFlags, exportFromFlag, exportToFlag)); err != nil {
+		return err
+	}
+	return cli.RegisterFlags(importCmd, chainFlags)
+}
+
+func exportChain(cmd *cobra.Command, file string) error {
+	bc, closeChain, err := openShardChain(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeChain()
+
+	var (
+		head = bc.CurrentBlock().NumberU64()
+		from = cli.GetIntFlagValue(cmd, exportFromFlag)
+		to   = cli.GetIntFlagValue(cmd, exportToFlag)
+	)
+	if to < 0 {
+		to = int(head)
+	}
+	if from < 0 || from > to || uint64(to) > head {
+		return fmt.Errorf("invalid block range [%v, %v] of chain with head %v", from, to, head)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var (
+		w  io.Writer = f
+		gw *gzip.Writer
+	)
+	if strings.HasSuffix(file, ".gz") {
+		gw = gzip.NewWriter(f)
+		w = gw
+	}
+
+	fmt.Printf("Exporting blocks [%v, %v] of shard %v to %v\n", from, to, bc.ShardID(), file)
+	start := time.Now()
+	for first := uint64(from); first <= uint64(to); first += exportBatchSize {
+		last := first + exportBatchSize - 1
+		if last > uint64(to) {
+			last = uint64(to)
+		}
+		if err := bc.ExportNWithSig(w, first, last); err != nil {
+			return err
+		}
+		fmt.Printf("Exported blocks up to %v (%.2f%%), elapsed %v\n", last,
+			float64(last-uint64(from)+1)/float64(to-from+1)*100, time.Since(start).Round(time.Second))
+	}
+	if gw != nil {
+		return gw.Close()
+	}
+	return nil
+}
+
+func importChain(cmd *cobra.Command, file string) error {
+	bc, closeChain, err := openShardChain(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeChain()
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var r io.Reader = bufio.NewReader(f)
+	if strings.HasSuffix(file, ".gz") {
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	fmt.Printf("Importing blocks of shard %v from %v\n", bc.ShardID(), file)
+	var (
+		stream            = rlp.NewStream(r, 0)
+		imported, skipped uint64
+		start, reported   = time.Now(), time.Now()
+	)
+	for {
+		var entry core.BlockWithSig
+		if err := stream.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "decode block at %v", imported+skipped)
+		}
+		b := entry.Block
+		if b.ShardID() != bc.ShardID() {
+			return fmt.Errorf("block %v of shard %v found in chain of shard %v",
+				b.NumberU64(), b.ShardID(), bc.ShardID())
+		}
+		if bc.HasBlock(b.Hash(), b.NumberU64()) {
+			skipped++
+			continue
+		}
+		if err := bc.InsertBlockWithSig(&entry); err != nil {
+			return errors.Wrapf(err, "import block %v", b.NumberU64())
+		}
+		imported++
+
+		if time.Since(reported) >= importProgressInterval {
+			fmt.Printf("Imported %v blocks, skipped %v, head %v, elapsed %v\n", imported, skipped,
+				bc.CurrentBlock().NumberU64(), time.Since(start).Round(time.Second))
+			reported = time.Now()
+		}
+	}
+	fmt.Printf("Import finished: imported %v blocks, skipped %v, head %v\n", imported, skipped,
+		bc.CurrentBlock().NumberU64())
+	return nil
+}
+
+// openShardChain opens the blockchain of the shard given by the command flags, and
+// returns the function to close it. The chain database is initialized with the
+// genesis block of the network if not exist.
+func openShardChain(cmd *cobra.Command) (*core.BlockChain, func(), error) {
+	hc, shardID, err := setupShardChainCmd(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nodeConfig := nodeconfig.GetDefaultConfig()
+	nodeConfig.SetShardID(shardID)
+	nodeConfig.SetArchival(hc.General.IsBeaconArchival, hc.General.IsArchival)
+	nodeConfig.DBDir = hc.General.DataDir
+
+	factory, err := newChainDBFactory(hc, nodeConfig.DBDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkStatePruning(factory, shardID, shard.BeaconChainShardID); err != nil {
+		return nil, nil, err
+	}
+	n := node.New(nil, nil, factory, nil, nodeConfig.ArchiveModes(), &hc)
+	bc, beacon := n.Blockchain(), n.Beaconchain()
+	if bc == nil || beacon == nil {
+		return nil, nil, errors.New("cannot open the chain database")
+	}
+	bc.Engine().SetBeaconchain(beacon)
+
+	closeChain := func() {
+		bc.Stop()
+		bc.ChainDb().Close()
+		if beacon != bc {
+			beacon.Stop()
+			beacon.ChainDb().Close()
+		}
+	}
+	return bc, closeChain, nil
+}
+
+// setupShardChainCmd returns the node config and the shard ID given by the command
+// flags, and sets up the sharding schedule and the network of the shard.
+func setupShardChainCmd(cmd *cobra.Command) (harmonyconfig.HarmonyConfig, uint32, error) {
+	hc, err := getChainFileCmdConfig(cmd)
+	if err != nil {
+		return hc, 0, err
+	}
+	shardID := uint32(cli.GetIntFlagValue(cmd, dbShardIDFlag))
+
+	nodeconfigSetShardSchedule(hc)
+	nodeconfig.SetShardingSchedule(shard.Schedule)
+	nodeconfig.SetNetworkType(nodeconfig.NetworkType(hc.Network.NetworkType))
+	if shardID >= shard.Schedule.InstanceForEpoch(ethCommon.Big0).NumShards() {
+		return hc, 0, fmt.Errorf("invalid shard: %v", shardID)
+	}
+	params.UpdateEthChainIDByShard(shardID)
+	return hc, shardID, nil
+}
+
+// getChainFileCmdConfig returns the node config of the commands on the shard chain,
+// which is loaded from the config file if given, or the default config of the
+// network otherwise. The data directory is overridden by the flag.
+func getChainFileCmdConfig(cmd *cobra.Command) (harmonyconfig.HarmonyConfig, error) {
+	var hc harmonyconfig.HarmonyConfig
+	if cli.IsFlagChanged(cmd, configFlag) {
+		var err error
+		if hc, _, err = loadHarmonyConfig(cli.GetStringFlagValue(cmd, configFlag)); err != nil {
+			return hc, err
+		}
+		if cli.IsFlagChanged(cmd, networkTypeFlag) {
+			hc.Network.NetworkType = string(getNetworkType(cmd))
+		}
+	} else {
+		hc = getDefaultHmyConfigCopy(getNetworkType(cmd))
+	}
+	if hc.Network.NetworkType == "" {
+		return hc, errors.New("unknown network type")
+	}
+	if cli.IsFlagChanged(cmd, dbDataDirFlag) || !cli.IsFlagChanged(cmd, configFlag) {
+		hc.General.DataDir = cli.GetStringFlagValue(cmd, dbDataDirFlag)
+	}
+	return hc, nil
+}
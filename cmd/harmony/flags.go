@@ -23,6 +23,7 @@ var (
 		isBeaconArchiveFlag,
 		isOfflineFlag,
 		dataDirFlag,
+		dbEngineFlag,
 
 		legacyNodeTypeFlag,
 		legacyIsStakingFlag,
@@ -204,6 +205,7 @@ var (
 	syncFlags = []cli.Flag{
 		syncStreamEnabledFlag,
 		syncDownloaderFlag,
+		syncFastFlag,
 		syncConcurrencyFlag,
 		syncMinPeersFlag,
 		syncInitStreamsFlag,
@@ -212,6 +214,23 @@ var (
 		syncDiscHighFlag,
 		syncDiscBatchFlag,
 	}
+
+	explorerFlags = []cli.Flag{
+		explorerBackendFlag,
+		explorerSQLDriverFlag,
+		explorerSQLSourceFlag,
+	}
+
+	freezerFlags = []cli.Flag{
+		freezerEnabledFlag,
+		freezerDirFlag,
+		freezerDepthFlag,
+	}
+
+	traceIndexFlags = []cli.Flag{
+		traceIndexEnabledFlag,
+		traceIndexFromFlag,
+	}
 )
 
 var (
@@ -256,6 +275,11 @@ var (
 		Usage:    "directory of chain database",
 		DefValue: defaultConfig.General.DataDir,
 	}
+	dbEngineFlag = cli.StringFlag{
+		Name:     "db.engine",
+		Usage:    "database engine of the chain and explorer databases (leveldb, pebble)",
+		DefValue: defaultConfig.General.DBEngine,
+	}
 	legacyNodeTypeFlag = cli.StringFlag{
 		Name:       "node_type",
 		Usage:      "run node type (validator, explorer)",
@@ -311,6 +335,9 @@ func getRootFlags() []cli.Flag {
 	flags = append(flags, legacyMiscFlags...)
 	flags = append(flags, prometheusFlags...)
 	flags = append(flags, syncFlags...)
+	flags = append(flags, explorerFlags...)
+	flags = append(flags, freezerFlags...)
+	flags = append(flags, traceIndexFlags...)
 
 	return flags
 }
@@ -350,6 +377,10 @@ func applyGeneralFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig)
 		config.General.DataDir = cli.GetStringFlagValue(cmd, legacyDataDirFlag)
 	}
 
+	if cli.IsFlagChanged(cmd, dbEngineFlag) {
+		config.General.DBEngine = cli.GetStringFlagValue(cmd, dbEngineFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, isOfflineFlag) {
 		config.General.IsOffline = cli.GetBoolFlagValue(cmd, isOfflineFlag)
 	}
@@ -1511,6 +1542,12 @@ var (
 		Hidden:   true,
 		DefValue: false,
 	}
+	syncFastFlag = cli.BoolFlag{
+		Name:     "sync.fast",
+		Usage:    "Download the state of the last epoch instead of executing all blocks on a fresh shard chain",
+		Hidden:   true,
+		DefValue: false,
+	}
 	syncConcurrencyFlag = cli.IntFlag{
 		Name:   "sync.concurrency",
 		Usage:  "Concurrency when doing p2p sync requests",
@@ -1558,6 +1595,10 @@ func applySyncFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		config.Sync.Downloader = cli.GetBoolFlagValue(cmd, syncDownloaderFlag)
 	}
 
+	if cli.IsFlagChanged(cmd, syncFastFlag) {
+		config.Sync.FastSync = cli.GetBoolFlagValue(cmd, syncFastFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, syncConcurrencyFlag) {
 		config.Sync.Concurrency = cli.GetIntFlagValue(cmd, syncConcurrencyFlag)
 	}
@@ -1586,3 +1627,108 @@ func applySyncFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		config.Sync.DiscBatch = cli.GetIntFlagValue(cmd, syncDiscBatchFlag)
 	}
 }
+
+var (
+	explorerBackendFlag = cli.StringFlag{
+		Name:     "explorer.backend",
+		Usage:    "storage backend of the explorer node (leveldb, sql)",
+		DefValue: defaultExplorerConfig.Backend,
+	}
+	explorerSQLDriverFlag = cli.StringFlag{
+		Name:     "explorer.sql.driver",
+		Usage:    "driver of the explorer sql backend (sqlite3, postgres)",
+		DefValue: defaultExplorerConfig.SQLDriver,
+	}
+	explorerSQLSourceFlag = cli.StringFlag{
+		Name:     "explorer.sql.source",
+		Usage:    "data source name of the explorer sql backend (a file in the data directory by default for sqlite3)",
+		DefValue: defaultExplorerConfig.SQLSource,
+	}
+)
+
+// applyExplorerFlags apply the explorer flags.
+func applyExplorerFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if config.Explorer == nil && cli.HasFlagsChanged(cmd, explorerFlags) {
+		cfg := getDefaultExplorerConfigCopy()
+		config.Explorer = &cfg
+	}
+
+	if cli.IsFlagChanged(cmd, explorerBackendFlag) {
+		config.Explorer.Backend = cli.GetStringFlagValue(cmd, explorerBackendFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, explorerSQLDriverFlag) {
+		config.Explorer.SQLDriver = cli.GetStringFlagValue(cmd, explorerSQLDriverFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, explorerSQLSourceFlag) {
+		config.Explorer.SQLSource = cli.GetStringFlagValue(cmd, explorerSQLSourceFlag)
+	}
+}
+
+var (
+	freezerEnabledFlag = cli.BoolFlag{
+		Name:     "freezer.enabled",
+		Usage:    "move the blocks older than the depth from the chain db into the freezer",
+		DefValue: defaultFreezerConfig.Enabled,
+	}
+	freezerDirFlag = cli.StringFlag{
+		Name:     "freezer.dir",
+		Usage:    "directory of the freezers (the data directory by default)",
+		DefValue: defaultFreezerConfig.Dir,
+	}
+	freezerDepthFlag = cli.IntFlag{
+		Name:     "freezer.depth",
+		Usage:    "number of the recent blocks kept in the chain db",
+		DefValue: defaultFreezerConfig.Depth,
+	}
+)
+
+// applyFreezerFlags apply the freezer flags.
+func applyFreezerFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if config.Freezer == nil && cli.HasFlagsChanged(cmd, freezerFlags) {
+		cfg := getDefaultFreezerConfigCopy()
+		config.Freezer = &cfg
+	}
+
+	if cli.IsFlagChanged(cmd, freezerEnabledFlag) {
+		config.Freezer.Enabled = cli.GetBoolFlagValue(cmd, freezerEnabledFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, freezerDirFlag) {
+		config.Freezer.Dir = cli.GetStringFlagValue(cmd, freezerDirFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, freezerDepthFlag) {
+		config.Freezer.Depth = cli.GetIntFlagValue(cmd, freezerDepthFlag)
+	}
+}
+
+var (
+	traceIndexEnabledFlag = cli.BoolFlag{
+		Name:     "traceindex.enabled",
+		Usage:    "store the call traces of the blocks indexed by address to serve trace_filter (archival node only)",
+		DefValue: defaultTraceIndexConfig.Enabled,
+	}
+	traceIndexFromFlag = cli.IntFlag{
+		Name:     "traceindex.from",
+		Usage:    "number of the first block of the trace index",
+		DefValue: defaultTraceIndexConfig.From,
+	}
+)
+
+// applyTraceIndexFlags apply the trace index flags.
+func applyTraceIndexFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
+	if config.TraceIndex == nil && cli.HasFlagsChanged(cmd, traceIndexFlags) {
+		cfg := getDefaultTraceIndexConfigCopy()
+		config.TraceIndex = &cfg
+	}
+
+	if cli.IsFlagChanged(cmd, traceIndexEnabledFlag) {
+		config.TraceIndex.Enabled = cli.GetBoolFlagValue(cmd, traceIndexEnabledFlag)
+	}
+
+	if cli.IsFlagChanged(cmd, traceIndexFromFlag) {
+		config.TraceIndex.From = cli.GetIntFlagValue(cmd, traceIndexFromFlag)
+	}
+}
@@ -40,6 +40,7 @@ func TestHarmonyFlags(t *testing.T) {
 					ShardID:    -1,
 					IsArchival: false,
 					DataDir:    "./",
+					DBEngine:   "leveldb",
 				},
 				Network: harmonyconfig.NetworkConfig{
 					NetworkType: "mainnet",
@@ -169,6 +170,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    -1,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -180,6 +182,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: true,
 				DataDir:    "./.hmy",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -191,6 +194,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: true,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -201,6 +205,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    -1,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -211,6 +216,7 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
 			},
 		},
 		{
@@ -221,6 +227,18 @@ func TestGeneralFlags(t *testing.T) {
 				ShardID:    0,
 				IsArchival: false,
 				DataDir:    "./",
+				DBEngine:   "leveldb",
+			},
+		},
+		{
+			args: []string{"--db.engine", "pebble"},
+			expConfig: harmonyconfig.GeneralConfig{
+				NodeType:   "validator",
+				NoStaking:  false,
+				ShardID:    -1,
+				IsArchival: false,
+				DataDir:    "./",
+				DBEngine:   "pebble",
 			},
 		},
 	}
@@ -1188,7 +1206,7 @@ func TestSyncFlags(t *testing.T) {
 		expErr    error
 	}{
 		{
-			args: []string{"--sync", "--sync.downloader", "--sync.concurrency", "10", "--sync.min-peers", "10",
+			args: []string{"--sync", "--sync.downloader", "--sync.fast", "--sync.concurrency", "10", "--sync.min-peers", "10",
 				"--sync.init-peers", "10", "--sync.disc.soft-low-cap", "10",
 				"--sync.disc.hard-low-cap", "10", "--sync.disc.hi-cap", "10",
 				"--sync.disc.batch", "10",
@@ -1198,6 +1216,7 @@ func TestSyncFlags(t *testing.T) {
 				cfgSync := defaultMainnetSyncConfig
 				cfgSync.Enabled = true
 				cfgSync.Downloader = true
+				cfgSync.FastSync = true
 				cfgSync.Concurrency = 10
 				cfgSync.MinPeers = 10
 				cfgSync.InitStreams = 10
@@ -1229,6 +1248,137 @@ func TestSyncFlags(t *testing.T) {
 	}
 }
 
+func TestExplorerFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig *harmonyconfig.ExplorerConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: nil,
+		},
+		{
+			args: []string{"--explorer.backend", "sql"},
+			expConfig: &harmonyconfig.ExplorerConfig{
+				Backend:   "sql",
+				SQLDriver: defaultExplorerConfig.SQLDriver,
+				SQLSource: defaultExplorerConfig.SQLSource,
+			},
+		},
+		{
+			args: []string{"--explorer.backend", "sql", "--explorer.sql.driver", "postgres",
+				"--explorer.sql.source", "postgres://localhost/explorer"},
+			expConfig: &harmonyconfig.ExplorerConfig{
+				Backend:   "sql",
+				SQLDriver: "postgres",
+				SQLSource: "postgres://localhost/explorer",
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, explorerFlags, applyExplorerFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.Explorer, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Explorer, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
+func TestFreezerFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig *harmonyconfig.FreezerConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: nil,
+		},
+		{
+			args: []string{"--freezer.enabled"},
+			expConfig: &harmonyconfig.FreezerConfig{
+				Enabled: true,
+				Dir:     defaultFreezerConfig.Dir,
+				Depth:   defaultFreezerConfig.Depth,
+			},
+		},
+		{
+			args: []string{"--freezer.enabled", "--freezer.dir", "/mnt/ancient", "--freezer.depth", "1000"},
+			expConfig: &harmonyconfig.FreezerConfig{
+				Enabled: true,
+				Dir:     "/mnt/ancient",
+				Depth:   1000,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, freezerFlags, applyFreezerFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.Freezer, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Freezer, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
+func TestTraceIndexFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig *harmonyconfig.TraceIndexConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: nil,
+		},
+		{
+			args: []string{"--traceindex.enabled"},
+			expConfig: &harmonyconfig.TraceIndexConfig{
+				Enabled: true,
+				From:    defaultTraceIndexConfig.From,
+			},
+		},
+		{
+			args: []string{"--traceindex.enabled", "--traceindex.from", "1000"},
+			expConfig: &harmonyconfig.TraceIndexConfig{
+				Enabled: true,
+				From:    1000,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, traceIndexFlags, applyTraceIndexFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+		if !reflect.DeepEqual(hc.TraceIndex, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.TraceIndex, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
 type flagTestSuite struct {
 	t *testing.T
 
@@ -41,7 +41,6 @@ import (
 	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
 	"github.com/harmony-one/harmony/internal/genesis"
 	"github.com/harmony-one/harmony/internal/params"
-	"github.com/harmony-one/harmony/internal/shardchain"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/multibls"
 	"github.com/harmony-one/harmony/node"
@@ -99,6 +98,14 @@ func init() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(dumpConfigLegacyCmd)
+	dbCmd.AddCommand(pruneStateCmd)
+	dbCmd.AddCommand(inspectCmd)
+	dbCmd.AddCommand(migratePebbleCmd)
+	dbCmd.AddCommand(verifyChainCmd)
+	dbCmd.AddCommand(reindexChainCmd)
+	rootCmd.AddCommand(dbCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
 
 	if err := registerRootCmdFlags(); err != nil {
 		os.Exit(2)
@@ -106,6 +113,12 @@ func init() {
 	if err := registerDumpConfigFlags(); err != nil {
 		os.Exit(2)
 	}
+	if err := registerDBCmdFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerChainFileCmdFlags(); err != nil {
+		os.Exit(2)
+	}
 }
 
 func main() {
@@ -229,6 +242,9 @@ func applyRootFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	applyRevertFlags(cmd, config)
 	applyPrometheusFlags(cmd, config)
 	applySyncFlags(cmd, config)
+	applyExplorerFlags(cmd, config)
+	applyFreezerFlags(cmd, config)
+	applyTraceIndexFlags(cmd, config)
 }
 
 func setupNodeLog(config harmonyconfig.HarmonyConfig) {
@@ -425,6 +441,8 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 			Msg("Start Rosetta failed")
 	}
 
+	currentNode.StartWebHookMonitor()
+
 	go listenOSSigAndShutDown(currentNode)
 
 	if !hc.General.IsOffline {
@@ -605,6 +623,14 @@ func createGlobalConfig(hc harmonyconfig.HarmonyConfig) (*nodeconfig.ConfigType,
 			os.Exit(1)
 		}
 		nodeConfig.WebHooks.Hooks = config
+
+		outboxDir := filepath.Join(hc.General.DataDir, webhooks.DefaultOutboxDir)
+		deliverer, err := webhooks.NewDeliverer(config, outboxDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot create webhook deliverer")
+		}
+		deliverer.Start()
+		nodeConfig.WebHooks.Deliverer = deliverer
 	}
 
 	nodeConfig.NtpServer = hc.Sys.NtpServer
@@ -648,7 +674,15 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 	}
 
 	// Current node.
-	chainDBFactory := &shardchain.LDBFactory{RootDir: nodeConfig.DBDir}
+	chainDBFactory, err := newChainDBFactory(hc, nodeConfig.DBDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := checkStatePruning(chainDBFactory, nodeConfig.ShardID, shard.BeaconChainShardID); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
 	currentNode := node.New(myHost, currentConsensus, chainDBFactory, blacklist, nodeConfig.ArchiveModes(), &hc)
 
@@ -706,6 +740,7 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 	// Assign closure functions to the consensus object
 	currentConsensus.SetBlockVerifier(currentNode.VerifyNewBlock)
 	currentConsensus.PostConsensusJob = currentNode.PostConsensusProcessing
+	currentConsensus.LeaderViewChangeJob = currentNode.PostLeaderViewChange
 	// update consensus information based on the blockchain
 	currentConsensus.SetMode(currentConsensus.UpdateConsensusInformation())
 	currentConsensus.NextBlockDue = time.Now()
@@ -766,6 +801,7 @@ func setupSyncService(node *node.Node, host p2p.Host, hc harmonyconfig.HarmonyCo
 
 	dConfig := downloader.Config{
 		ServerOnly:   !hc.Sync.Downloader,
+		FastSync:     hc.Sync.FastSync,
 		Network:      nodeconfig.NetworkType(hc.Network.NetworkType),
 		Concurrency:  hc.Sync.Concurrency,
 		MinStreams:   hc.Sync.MinPeers,
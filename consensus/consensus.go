@@ -95,6 +95,9 @@ type Consensus struct {
 	// The post-consensus job func passed from Node object
 	// Called when consensus on a new block is done
 	PostConsensusJob func(*types.Block) error
+	// The job func passed from Node object
+	// Called in a new goroutine when view change starts while the node is the leader
+	LeaderViewChangeJob func(LeaderViewChange)
 	// The verifier func passed from Node object
 	BlockVerifier VerifyBlockFunc
 	// verified block to state sync broadcast
@@ -0,0 +1,80 @@
+// Package misc implements the consensus rules which are not specific to the
+// FBFT consensus, such as the EIP-1559 base fee.
+package misc
+
+import (
+	"math/big"
+
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrMissingBaseFee is returned if the header of an EIP-1559 block has no base fee
+	ErrMissingBaseFee = errors.New("header is missing base fee")
+	// ErrInvalidBaseFee is returned if the base fee of the header is not the one
+	// calculated from the parent
+	ErrInvalidBaseFee = errors.New("invalid base fee")
+)
+
+// VerifyEIP1559Header verifies the base fee of the header against its parent,
+// which is only done at or after the EIP-1559 epoch.
+func VerifyEIP1559Header(config *params.ChainConfig, parent, header *block.Header) error {
+	if !config.IsEIP1559(header.Epoch()) {
+		return nil
+	}
+	baseFee := header.BaseFee()
+	if baseFee == nil {
+		return ErrMissingBaseFee
+	}
+	if expected := CalcBaseFee(config, parent); baseFee.Cmp(expected) != 0 {
+		return errors.Wrapf(ErrInvalidBaseFee, "have %v, want %v, parentBaseFee %v, parentGasUsed %v",
+			baseFee, expected, parent.BaseFee(), parent.GasUsed())
+	}
+	return nil
+}
+
+// CalcBaseFee calculates the base fee of the block following the parent. The
+// base fee moves towards keeping the gas used at half of the gas limit, by at
+// most 1/8 per block, and never goes below the legacy gas price floor.
+func CalcBaseFee(config *params.ChainConfig, parent *block.Header) *big.Int {
+	parentBaseFee := parent.BaseFee()
+	// The first EIP-1559 block uses the initial base fee
+	if !config.IsEIP1559(parent.Epoch()) || parentBaseFee == nil {
+		return new(big.Int).SetUint64(params.InitialBaseFee)
+	}
+
+	var (
+		parentGasTarget          = parent.GasLimit() / params.ElasticityMultiplier
+		parentGasTargetBig       = new(big.Int).SetUint64(parentGasTarget)
+		baseFeeChangeDenominator = new(big.Int).SetUint64(params.BaseFeeChangeDenominator)
+		baseFee                  *big.Int
+	)
+	switch {
+	case parentGasTarget == 0 || parent.GasUsed() == parentGasTarget:
+		baseFee = parentBaseFee
+	case parent.GasUsed() > parentGasTarget:
+		// If the parent block used more gas than its target, the base fee
+		// increases by at least 1.
+		gasUsedDelta := new(big.Int).SetUint64(parent.GasUsed() - parentGasTarget)
+		x := new(big.Int).Mul(parentBaseFee, gasUsedDelta)
+		y := x.Div(x, parentGasTargetBig)
+		baseFeeDelta := y.Div(y, baseFeeChangeDenominator)
+		if baseFeeDelta.Sign() == 0 {
+			baseFeeDelta.SetUint64(1)
+		}
+		baseFee = x.Add(parentBaseFee, baseFeeDelta)
+	default:
+		// Otherwise the base fee decreases
+		gasUsedDelta := new(big.Int).SetUint64(parentGasTarget - parent.GasUsed())
+		x := new(big.Int).Mul(parentBaseFee, gasUsedDelta)
+		y := x.Div(x, parentGasTargetBig)
+		baseFeeDelta := y.Div(y, baseFeeChangeDenominator)
+		baseFee = x.Sub(parentBaseFee, baseFeeDelta)
+	}
+	if minBaseFee := new(big.Int).SetUint64(params.MinBaseFee); baseFee.Cmp(minBaseFee) < 0 {
+		baseFee = minBaseFee
+	}
+	return baseFee
+}
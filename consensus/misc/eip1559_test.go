@@ -0,0 +1,65 @@
+package misc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/pkg/errors"
+)
+
+func newHeader(gasLimit, gasUsed uint64, baseFee *big.Int) *block.Header {
+	return blockfactory.ForTest.NewHeader(new(big.Int)).With().
+		GasLimit(gasLimit).
+		GasUsed(gasUsed).
+		BaseFee(baseFee).
+		Header()
+}
+
+func TestCalcBaseFee(t *testing.T) {
+	initial := int64(params.InitialBaseFee)
+	tests := []struct {
+		parentBaseFee int64
+		gasLimit      uint64
+		gasUsed       uint64
+		expected      int64
+	}{
+		{initial, 20000000, 10000000, initial},                 // usage == target
+		{initial, 20000000, 9000000, initial},                  // usage below target, floored
+		{2 * initial, 20000000, 9000000, 2*initial - 25000000}, // usage below target
+		{initial, 20000000, 11000000, initial + 12500000},      // usage above target
+		{initial, 20000000, 20000000, initial + initial/8},     // full block
+		{2 * initial, 20000000, 0, 2*initial - 2*initial/8},    // empty block
+		{initial, 0, 0, initial},                               // no gas target
+		{initial, 20000000000, 10000000001, initial + 1},       // increases by at least 1
+	}
+	for i, test := range tests {
+		parent := newHeader(test.gasLimit, test.gasUsed, big.NewInt(test.parentBaseFee))
+		if have, want := CalcBaseFee(params.TestChainConfig, parent), big.NewInt(test.expected); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d want %d", i, have, want)
+		}
+	}
+}
+
+func TestCalcBaseFeeInitial(t *testing.T) {
+	parent := newHeader(20000000, 20000000, nil)
+	if have := CalcBaseFee(params.TestChainConfig, parent); have.Uint64() != params.InitialBaseFee {
+		t.Errorf("have %d want %d", have, params.InitialBaseFee)
+	}
+}
+
+func TestVerifyEIP1559Header(t *testing.T) {
+	parent := newHeader(20000000, 20000000, big.NewInt(params.InitialBaseFee))
+	expected := CalcBaseFee(params.TestChainConfig, parent)
+
+	if err := VerifyEIP1559Header(params.TestChainConfig, parent, newHeader(20000000, 0, expected)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	wrong := new(big.Int).Add(expected, big.NewInt(1))
+	err := VerifyEIP1559Header(params.TestChainConfig, parent, newHeader(20000000, 0, wrong))
+	if errors.Cause(err) != ErrInvalidBaseFee {
+		t.Errorf("have %v want %v", err, ErrInvalidBaseFee)
+	}
+}
@@ -23,6 +23,16 @@ import (
 // MaxViewIDDiff limits the received view ID to only 249 further from the current view ID
 const MaxViewIDDiff = 249
 
+// LeaderViewChange is the information of a view change started while one of the
+// keys of the node is the leader
+type LeaderViewChange struct {
+	BlockNum      uint64
+	ViewID        uint64
+	NextViewID    uint64
+	LeaderPubKey  *bls.PublicKeyWrapper
+	NextLeaderKey *bls.PublicKeyWrapper
+}
+
 // State contains current mode and current viewID
 type State struct {
 	mode    Mode
@@ -247,6 +257,8 @@ func (consensus *Consensus) startViewChange() {
 	consensus.consensusTimeout[timeoutConsensus].Stop()
 	consensus.consensusTimeout[timeoutBootstrap].Stop()
 	consensus.current.SetMode(ViewChanging)
+	curViewID, leaderPubKey := consensus.GetCurBlockViewID(), consensus.LeaderPubKey
+	isLeader := leaderPubKey != nil && consensus.IsLeader()
 	nextViewID, duration := consensus.getNextViewID()
 	consensus.SetViewChangingID(nextViewID)
 	// TODO: set the Leader PubKey to the next leader for view change
@@ -265,6 +277,16 @@ func (consensus *Consensus) startViewChange() {
 		Msg("[startViewChange]")
 	consensusVCCounterVec.With(prometheus.Labels{"viewchange": "started"}).Inc()
 
+	if isLeader && consensus.LeaderViewChangeJob != nil {
+		go consensus.LeaderViewChangeJob(LeaderViewChange{
+			BlockNum:      consensus.blockNum,
+			ViewID:        curViewID,
+			NextViewID:    nextViewID,
+			LeaderPubKey:  leaderPubKey,
+			NextLeaderKey: consensus.LeaderPubKey,
+		})
+	}
+
 	consensus.consensusTimeout[timeoutViewChange].SetDuration(duration)
 	defer consensus.consensusTimeout[timeoutViewChange].Start()
 
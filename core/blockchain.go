@@ -27,6 +27,7 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -45,7 +46,9 @@ import (
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/pebble"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/numeric"
 	"github.com/harmony-one/harmony/shard"
@@ -56,6 +59,9 @@ import (
 	staking "github.com/harmony-one/harmony/staking/types"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/storage"
 )
 
 var (
@@ -395,6 +401,12 @@ func (bc *BlockChain) SetHead(head uint64) error {
 	if err := bc.hc.SetHead(head, delFn); err != nil {
 		return errors.Wrap(err, "headerChain SetHeader")
 	}
+	// Discard the frozen blocks above the new head, if any
+	if frozen, err := bc.db.Ancients(); err == nil && frozen > head+1 {
+		if err := bc.db.TruncateAncients(head + 1); err != nil {
+			return errors.Wrap(err, "truncate ancients")
+		}
+	}
 	currentHeader := bc.hc.CurrentHeader()
 
 	// Clear out any stale content from the caches
@@ -500,6 +512,11 @@ func (bc *BlockChain) StateAt(root common.Hash) (*state.DB, error) {
 	return state.New(root, bc.stateCache)
 }
 
+// StateCache returns the caching database underpinning the blockchain instance.
+func (bc *BlockChain) StateCache() state.Database {
+	return bc.stateCache
+}
+
 // Reset purges the entire blockchain, restoring it to its genesis state.
 func (bc *BlockChain) Reset() error {
 	return bc.ResetWithGenesisBlock(bc.genesisBlock)
@@ -603,6 +620,23 @@ func (bc *BlockChain) Export(w io.Writer) error {
 
 // ExportN writes a subset of the active chain to the given writer.
 func (bc *BlockChain) ExportN(w io.Writer, first uint64, last uint64) error {
+	return bc.exportN(w, first, last, false)
+}
+
+// BlockWithSig is an entry of the chain segment exported with the commit
+// signatures, which is the block and the commit signature and bitmap on it.
+type BlockWithSig struct {
+	Block              *types.Block
+	CommitSigAndBitmap []byte
+}
+
+// ExportNWithSig writes a subset of the active chain with the commit signatures
+// to the given writer, as a stream of RLP encoded BlockWithSig.
+func (bc *BlockChain) ExportNWithSig(w io.Writer, first uint64, last uint64) error {
+	return bc.exportN(w, first, last, true)
+}
+
+func (bc *BlockChain) exportN(w io.Writer, first uint64, last uint64, withSig bool) error {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
 
@@ -617,7 +651,15 @@ func (bc *BlockChain) ExportN(w io.Writer, first uint64, last uint64) error {
 		if block == nil {
 			return fmt.Errorf("export failed on #%d: not found", nr)
 		}
-		if err := block.EncodeRLP(w); err != nil {
+		if withSig {
+			sig, err := bc.readCommitSigOf(block)
+			if err != nil {
+				return fmt.Errorf("export failed on #%d: %v", nr, err)
+			}
+			if err := rlp.Encode(w, &BlockWithSig{block, sig}); err != nil {
+				return err
+			}
+		} else if err := block.EncodeRLP(w); err != nil {
 			return err
 		}
 		if time.Since(reported) >= statsReportLimit {
@@ -632,6 +674,42 @@ func (bc *BlockChain) ExportN(w io.Writer, first uint64, last uint64) error {
 	return nil
 }
 
+// InsertBlockWithSig verifies the block of an exported chain segment against its
+// commit signature and bitmap, and inserts it into the chain, where the commit
+// signature is stored along with the block.
+func (bc *BlockChain) InsertBlockWithSig(entry *BlockWithSig) error {
+	b, sigAndBitmap := entry.Block, entry.CommitSigAndBitmap
+	if len(sigAndBitmap) < bls.BLSSignatureSizeInBytes {
+		return errors.New("commit signature too short")
+	}
+	var sig bls.SerializedSignature
+	copy(sig[:], sigAndBitmap)
+	bitmap := sigAndBitmap[bls.BLSSignatureSizeInBytes:]
+
+	if err := bc.engine.VerifyHeaderSignature(bc, b.Header(), sig, bitmap); err != nil {
+		return errors.Wrap(err, "verify commit signature")
+	}
+	if err := bc.engine.VerifyHeader(bc, b.Header(), true); err != nil {
+		return errors.Wrap(err, "verify header")
+	}
+	b.SetCurrentCommitSig(sigAndBitmap)
+	if _, err := bc.InsertChain(types.Blocks{b}, false); err != nil {
+		return errors.Wrap(err, "insert block")
+	}
+	return nil
+}
+
+// readCommitSigOf returns the commit signature and bitmap on the block. The last
+// commit signature in the header of the next block is preferred, and the stored
+// commit signature is used for the head block.
+func (bc *BlockChain) readCommitSigOf(block *types.Block) ([]byte, error) {
+	if next := bc.GetHeaderByNumber(block.NumberU64() + 1); next != nil {
+		sig := next.LastCommitSignature()
+		return append(sig[:], next.LastCommitBitmap()...), nil
+	}
+	return bc.ReadCommitSig(block.NumberU64())
+}
+
 // writeHeadBlock writes a new head block
 func (bc *BlockChain) writeHeadBlock(block *types.Block) error {
 	// If the block is on a side chain or an unknown one, force other heads onto it too
@@ -961,7 +1039,7 @@ func (bc *BlockChain) Rollback(chain []common.Hash) error {
 // SetReceiptsData computes all the non-consensus fields of the receipts
 func SetReceiptsData(config *params.ChainConfig, block *types.Block, receipts types.Receipts) error {
 	signer := types.MakeSigner(config, block.Epoch())
-	ethSigner := types.NewEIP155Signer(config.EthCompatibleChainID)
+	ethSigner := types.MakeEthSigner(config, block.Epoch())
 
 	transactions, stakingTransactions, logIndex := block.Transactions(), block.StakingTransactions(), uint(0)
 	if len(transactions)+len(stakingTransactions) != len(receipts) {
@@ -972,6 +1050,10 @@ func SetReceiptsData(config *params.ChainConfig, block *types.Block, receipts ty
 	if len(receipts) > 0 && len(transactions) > 0 {
 		receipts[0].GasUsed = receipts[0].CumulativeGasUsed
 	}
+	// The receipt type is not stored, but it is the type of the transaction
+	for j, tx := range transactions {
+		receipts[j].Type = tx.Type()
+	}
 	for j := 1; j < len(transactions); j++ {
 		// The transaction hash can be retrieved from the transaction itself
 		receipts[j].TxHash = transactions[j].Hash()
@@ -1141,6 +1223,85 @@ func (bc *BlockChain) WriteBlockWithoutState(block *types.Block, td *big.Int) (e
 	return nil
 }
 
+// InsertFastSyncPivot writes the pivot block of fast sync with its receipts as the
+// head of the chain. The state of the pivot block shall already be fully downloaded
+// into the database. The pivot block is expected to be the last block of an epoch,
+// so that the shard state of the next epoch is carried by the header. The shard
+// state of the epoch of the pivot block, which the commit signature of the pivot
+// block is verified with when the block after the pivot is inserted, shall be
+// given from the beacon chain.
+// Fast sync is only supported on non-beacon shards, since the staking metadata
+// (validator list, snapshots, delegations, rewards) kept by the beacon chain can't
+// be rebuilt from the state. The ancestors of the pivot block are not available
+// after fast sync.
+func (bc *BlockChain) InsertFastSyncPivot(
+	block *types.Block, receipts types.Receipts, commitSig []byte, epochShardState *shard.State,
+) error {
+	bc.wg.Add(1)
+	defer bc.wg.Done()
+
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if bc.ShardID() == shard.BeaconChainShardID {
+		return errors.New("fast sync is not supported on beacon chain")
+	}
+	if !block.IsLastBlockInEpoch() {
+		return errors.New("fast sync pivot is not the last block of an epoch")
+	}
+	if _, err := state.New(block.Root(), bc.stateCache); err != nil {
+		return errors.Wrap(err, "state of fast sync pivot not available")
+	}
+	if epochShardState == nil {
+		return errors.New("shard state of fast sync pivot epoch not available")
+	}
+	if epochShardState.Epoch != nil && epochShardState.Epoch.Cmp(block.Epoch()) != 0 {
+		return errors.Errorf("shard state of epoch %v given for fast sync pivot of epoch %v",
+			epochShardState.Epoch, block.Epoch())
+	}
+	epochShardStateBytes, err := shard.EncodeWrapper(*epochShardState, bc.chainConfig.IsStaking(block.Epoch()))
+	if err != nil {
+		return errors.Wrap(err, "cannot encode shard state of pivot epoch")
+	}
+	nextBlockEpoch, err := bc.getNextBlockEpoch(block.Header())
+	if err != nil {
+		return err
+	}
+
+	batch := bc.db.NewBatch()
+	if err := rawdb.WriteBlock(batch, block); err != nil {
+		return err
+	}
+	if err := rawdb.WriteReceipts(batch, block.Hash(), block.NumberU64(), receipts); err != nil {
+		return err
+	}
+	if err := rawdb.WriteBlockTxLookUpEntries(batch, block); err != nil {
+		return err
+	}
+	if err := rawdb.WriteBlockStxLookUpEntries(batch, block); err != nil {
+		return err
+	}
+	if err := rawdb.WriteCxLookupEntries(batch, block); err != nil {
+		return err
+	}
+	if _, err := bc.WriteShardStateBytes(batch, block.Epoch(), epochShardStateBytes); err != nil {
+		return errors.Wrap(err, "cannot store shard state of pivot epoch")
+	}
+	if _, err := bc.WriteShardStateBytes(batch, nextBlockEpoch, block.Header().ShardState()); err != nil {
+		return errors.Wrap(err, "cannot store shard state")
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	if err := bc.WriteCommitSig(block.NumberU64(), commitSig); err != nil {
+		return errors.Wrap(err, "WriteCommitSig")
+	}
+	if err := bc.writeHeadBlock(block); err != nil {
+		return errors.Wrap(err, "writeHeadBlock")
+	}
+	return nil
+}
+
 // WriteBlockWithState writes the block and all associated state to the database.
 func (bc *BlockChain) WriteBlockWithState(
 	block *types.Block, receipts []*types.Receipt,
@@ -2674,10 +2835,10 @@ func (bc *BlockChain) writeDelegationsByDelegator(
 // including the full validator list and delegation indexes.
 // Note: this should only be called within the blockchain insert process.
 func (bc *BlockChain) UpdateStakingMetaData(
-	batch rawdb.DatabaseWriter, block *types.Block,
+	batch rawdb.DatabaseWriter, block *types.Block, receipts []*types.Receipt,
 	state *state.DB, epoch, newEpoch *big.Int,
 ) (newValidators []common.Address, err error) {
-	newValidators, newDelegations, err := bc.prepareStakingMetaData(block, state)
+	newValidators, newDelegations, err := bc.prepareStakingMetaData(block, receipts, state)
 	if err != nil {
 		utils.Logger().Warn().Msgf("oops, prepareStakingMetaData failed, err: %+v", err)
 		return newValidators, err
@@ -2734,14 +2895,15 @@ func (bc *BlockChain) UpdateStakingMetaData(
 }
 
 // prepareStakingMetaData prepare the updates of validator's
-// and the delegator's meta data according to staking transaction.
+// and the delegator's meta data according to staking transaction,
+// and the delegations of the staking precompile logged in the receipts.
 // The following return values are cached end state to be written to DB.
 // The reason for the cached state is to solve the issue that batch DB changes
 // won't be reflected immediately so the intermediary state can't be read from DB.
 // newValidators - the addresses of the newly created validators
 // newDelegations - the map of delegator address and their updated delegation indexes
 func (bc *BlockChain) prepareStakingMetaData(
-	block *types.Block, state *state.DB,
+	block *types.Block, receipts []*types.Receipt, state *state.DB,
 ) (newValidators []common.Address,
 	newDelegations map[common.Address]staking.DelegationIndexes,
 	err error,
@@ -2810,6 +2972,24 @@ func (bc *BlockChain) prepareStakingMetaData(
 		}
 	}
 
+	for _, receipt := range receipts {
+		for _, delegate := range vm.ParseStakingDelegations(receipt.Logs) {
+			delegations, ok := newDelegations[delegate.DelegatorAddress]
+			if !ok {
+				delegations, err = bc.ReadDelegationsByDelegator(delegate.DelegatorAddress)
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+			if delegations, err = bc.addDelegationIndex(
+				delegations, delegate.DelegatorAddress, delegate.ValidatorAddress, state, blockNum,
+			); err != nil {
+				return nil, nil, err
+			}
+			newDelegations[delegate.DelegatorAddress] = delegations
+		}
+	}
+
 	return newValidators, newDelegations, nil
 }
 
@@ -3031,25 +3211,42 @@ func (bc *BlockChain) SuperCommitteeForNextEpoch(
 	return nextCommittee, err
 }
 
-var (
-	leveldbErrSpec         = "leveldb"
-	tooManyOpenFilesErrStr = "Too many open files"
-)
-
 // isUnrecoverableErr check whether the input error is not recoverable.
-// When writing db, there could be some possible errors from storage level (leveldb).
-// Known possible leveldb errors are:
-//  1. Leveldb is already closed. (leveldb.ErrClosed)
-//  2. ldb file missing from disk. (leveldb.ErrNotFound)
-//  3. Corrupted db data. (leveldb.errors.ErrCorrupted)
-//  4. OS error when open file (too many open files, ...)
-//  5. OS error when write file (read-only, not enough disk space, ...)
-// Among all the above leveldb errors, only `too many open files` error is known to be recoverable,
-// thus the unrecoverable errors refers to error that is
-//  1. The error is from the lower storage level (from module leveldb)
+// When writing db, there could be some possible errors from storage level (leveldb
+// or pebble). Known possible storage errors are:
+//  1. The database is already closed. (leveldb.ErrClosed, pebble.ErrClosed)
+//  2. Database file missing from disk. (leveldb.errors.ErrMissingFiles)
+//  3. Corrupted db data. (leveldb.errors.ErrCorrupted, pebble.ErrCorruption)
+//  4. The database is opened in read-only mode. (leveldb.ErrReadOnly)
+//  5. OS error when open file (too many open files, ...)
+//  6. OS error when write file (read-only, not enough disk space, ...)
+//
+// Among all the above storage errors, only `too many open files` error is known to
+// be recoverable, thus the unrecoverable errors refers to error that is
+//  1. The error is from the lower storage level, recognized by its type.
 //  2. The error is not too many files error.
 func isUnrecoverableErr(err error) bool {
-	isLeveldbErr := strings.Contains(err.Error(), leveldbErrSpec)
-	isTooManyOpenFiles := strings.Contains(err.Error(), tooManyOpenFilesErrStr)
-	return isLeveldbErr && !isTooManyOpenFiles
+	if errors.Is(err, syscall.EMFILE) {
+		return false
+	}
+	return isLeveldbErr(err) || pebble.IsStorageError(err) || isOSErr(err)
+}
+
+func isLeveldbErr(err error) bool {
+	var (
+		corrupted        *leveldbErrors.ErrCorrupted
+		storageCorrupted *storage.ErrCorrupted
+		missingFiles     *leveldbErrors.ErrMissingFiles
+	)
+	return errors.Is(err, leveldb.ErrClosed) || errors.Is(err, leveldb.ErrReadOnly) ||
+		errors.As(err, &corrupted) || errors.As(err, &storageCorrupted) ||
+		errors.As(err, &missingFiles)
+}
+
+func isOSErr(err error) bool {
+	var (
+		pathErr *os.PathError
+		errno   syscall.Errno
+	)
+	return errors.As(err, &pathErr) || errors.As(err, &errno)
 }
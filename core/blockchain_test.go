@@ -0,0 +1,214 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/consensus/reward"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/pebble"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking/network"
+	"github.com/harmony-one/harmony/staking/slash"
+	staking "github.com/harmony-one/harmony/staking/types"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	leveldbErrors "github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+func TestIsUnrecoverableErr(t *testing.T) {
+	ldb, err := leveldb.Open(storage.NewMemStorage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ldb.Close()
+	dir, err := ioutil.TempDir("", "pebble")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	pdb, err := pebble.New(dir, 0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pdb.Close()
+
+	tests := []struct {
+		err           error
+		unrecoverable bool
+	}{
+		{ldb.Put([]byte("key"), []byte("value"), nil), true},
+		{pdb.Put([]byte("key"), []byte("value")), true},
+		{errors.Wrap(leveldbErrors.NewErrCorrupted(storage.FileDesc{}, errors.New("bad block")), "write"), true},
+		{errors.Wrap(&storage.ErrCorrupted{Err: errors.New("bad journal")}, "write"), true},
+		{errors.Wrap(&os.PathError{Op: "write", Path: "000001.log", Err: syscall.ENOSPC}, "write"), true},
+		{errors.Wrap(&os.PathError{Op: "open", Path: "000001.ldb", Err: syscall.EMFILE}, "write"), false},
+		{errors.New("insufficient balance"), false},
+	}
+	for i, test := range tests {
+		if test.err == nil {
+			t.Fatalf("test %d: no error", i)
+		}
+		if got := isUnrecoverableErr(test.err); got != test.unrecoverable {
+			t.Errorf("test %d: unexpected unrecoverable %v for %v", i, got, test.err)
+		}
+	}
+}
+
+func TestExportAndInsertBlocksWithSig(t *testing.T) {
+	const head = 4
+	// The coinbase of the blocks is the only member of the committee
+	gspec := Genesis{
+		Config:   params.TestChainConfig,
+		Factory:  blockfactory.ForTest,
+		GasLimit: 1e18,
+		ShardState: shard.State{
+			Epoch:  big.NewInt(0),
+			Shards: []shard.Committee{{ShardID: 0, Slots: shard.SlotList{{}}}},
+		},
+	}
+	newChain := func() *BlockChain {
+		db := rawdb.NewMemoryDatabase()
+		gspec.MustCommit(db)
+		bc, err := NewBlockChain(db, nil, gspec.Config, sigTestEngine{}, vm.Config{}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bc
+	}
+
+	// Build the chain, of which the head block only has the stored commit sig
+	src := newChain()
+	defer src.Stop()
+	blocks, _ := GenerateChain(gspec.Config, src.Genesis(), sigTestEngine{}, src.ChainDb(), head,
+		func(i int, gen *BlockGen) {
+			gen.header.SetLastCommitSignature(testCommitSig(uint64(i)))
+			gen.header.SetLastCommitBitmap(testCommitBitmap)
+		})
+	if _, err := src.InsertChain(blocks, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.WriteCommitSig(head, testCommitSigAndBitmap(head)); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := src.ExportNWithSig(&buf, 1, head); err != nil {
+		t.Fatal(err)
+	}
+	var entries []BlockWithSig
+	for stream := rlp.NewStream(&buf, 0); ; {
+		var entry BlockWithSig
+		if err := stream.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != head {
+		t.Fatalf("unexpected number of exported blocks %v", len(entries))
+	}
+	for i, entry := range entries {
+		number := uint64(i + 1)
+		if entry.Block.Hash() != blocks[i].Hash() {
+			t.Errorf("block %d: unexpected exported block %x", number, entry.Block.Hash())
+		}
+		if !bytes.Equal(entry.CommitSigAndBitmap, testCommitSigAndBitmap(number)) {
+			t.Errorf("block %d: unexpected exported commit sig %x", number, entry.CommitSigAndBitmap)
+		}
+	}
+
+	dst := newChain()
+	defer dst.Stop()
+	for _, entry := range entries[:head-1] {
+		if err := dst.InsertBlockWithSig(&entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The block with a corrupted commit sig is rejected
+	corrupted := entries[head-1]
+	corrupted.CommitSigAndBitmap = common.CopyBytes(corrupted.CommitSigAndBitmap)
+	corrupted.CommitSigAndBitmap[0] ^= 0xff
+	if err := dst.InsertBlockWithSig(&corrupted); err == nil {
+		t.Error("block with corrupted commit sig inserted")
+	}
+	if number := dst.CurrentBlock().NumberU64(); number != head-1 {
+		t.Errorf("unexpected head %v after rejected block", number)
+	}
+	if err := dst.InsertBlockWithSig(&entries[head-1]); err != nil {
+		t.Fatal(err)
+	}
+	if hash := dst.CurrentBlock().Hash(); hash != blocks[head-1].Hash() {
+		t.Errorf("unexpected head %x", hash)
+	}
+	if sig, err := dst.ReadCommitSig(head); err != nil || !bytes.Equal(sig, testCommitSigAndBitmap(head)) {
+		t.Errorf("unexpected commit sig of head block %x, %v", sig, err)
+	}
+}
+
+var testCommitBitmap = []byte{0xff}
+
+func testCommitSig(number uint64) bls.SerializedSignature {
+	return bls.SerializedSignature{byte(number + 1)}
+}
+
+func testCommitSigAndBitmap(number uint64) []byte {
+	sig := testCommitSig(number)
+	return append(sig[:], testCommitBitmap...)
+}
+
+// sigTestEngine is the consensus engine accepting the commit signatures given by
+// testCommitSig, without block rewards.
+type sigTestEngine struct{}
+
+func (sigTestEngine) VerifyHeader(engine.ChainReader, *block.Header, bool) error {
+	return nil
+}
+func (sigTestEngine) VerifyHeaderSignature(
+	_ engine.ChainReader, header *block.Header, commitSig bls.SerializedSignature, commitBitmap []byte,
+) error {
+	if commitSig != testCommitSig(header.Number().Uint64()) || !bytes.Equal(commitBitmap, testCommitBitmap) {
+		return errors.New("invalid commit signature")
+	}
+	return nil
+}
+func (sigTestEngine) VerifyCrossLink(engine.ChainReader, types.CrossLink) error {
+	return nil
+}
+func (sigTestEngine) VerifyHeaders(engine.ChainReader, []*block.Header, []bool) (chan<- struct{}, <-chan error) {
+	return nil, nil
+}
+func (sigTestEngine) VerifySeal(engine.ChainReader, *block.Header) error { return nil }
+func (sigTestEngine) VerifyShardState(engine.ChainReader, engine.ChainReader, *block.Header) error {
+	return nil
+}
+func (sigTestEngine) VerifyVRF(engine.ChainReader, *block.Header) error { return nil }
+func (sigTestEngine) Beaconchain() engine.ChainReader                   { return nil }
+func (sigTestEngine) SetBeaconchain(engine.ChainReader)                 {}
+func (sigTestEngine) Finalize(
+	chain engine.ChainReader, header *block.Header,
+	state *state.DB, txs []*types.Transaction,
+	receipts []*types.Receipt, outcxs []*types.CXReceipt,
+	incxs []*types.CXReceiptsProof, stks staking.StakingTransactions,
+	doubleSigners slash.Records, sigsReady chan bool, viewID func() uint64,
+) (*types.Block, reward.Reader, error) {
+	header.SetRoot(state.IntermediateRoot(chain.Config().IsS3(header.Epoch())))
+	return types.NewBlock(header, txs, receipts, outcxs, incxs, stks), network.EmptyPayout, nil
+}
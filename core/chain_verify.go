@@ -0,0 +1,459 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/utils"
+	staking "github.com/harmony-one/harmony/staking/types"
+	"github.com/pkg/errors"
+)
+
+// chainCheckLogInterval is the interval of the progress logs of VerifyChain and
+// ReindexChain.
+const chainCheckLogInterval = 8 * time.Second
+
+// ChainIssue is an inconsistency of the chain database found by VerifyChain
+type ChainIssue struct {
+	Number uint64
+	Reason string
+	// Reindexable is whether the issue can be fixed by ReindexChain
+	Reindexable bool
+}
+
+func (issue ChainIssue) String() string {
+	s := fmt.Sprintf("block %v: %v", issue.Number, issue.Reason)
+	if issue.Reindexable {
+		s += " (reindexable)"
+	}
+	return s
+}
+
+// VerifyChain checks the canonical blocks [first, last] in the chain database,
+// including the header linkage, the presence of the body and receipts, the
+// transaction lookup entries, the commit signatures, and the epoch block number
+// and shard state records. The state of the head block is checked as well. If
+// first is 0, the validator list and the delegation indexes are checked against
+// the staking transactions of the chain. The issues found are passed to onIssue.
+func VerifyChain(
+	db ethdb.Database, config *params.ChainConfig, first, last uint64, onIssue func(ChainIssue),
+) error {
+	head, err := readHeadHeader(db)
+	if err != nil {
+		return err
+	}
+	if first > last || last > head.Number().Uint64() {
+		return errors.Errorf("invalid block range [%v, %v] of chain with head %v",
+			first, last, head.Number())
+	}
+	headState, err := state.New(head.Root(), state.NewDatabase(db))
+	if err != nil {
+		onIssue(ChainIssue{Number: head.Number().Uint64(), Reason: "state of head block not found"})
+	}
+	if first == 0 {
+		if _, err := rawdb.ReadShardState(db, common.Big0); err != nil {
+			onIssue(ChainIssue{Number: 0, Reason: "shard state of genesis epoch not found"})
+		}
+	}
+
+	var (
+		indexer = newStakingIndexer()
+		prev    *block.Header
+		lastLog = time.Now()
+	)
+	if first > 0 {
+		prev = readCanonicalHeader(db, first-1)
+	}
+	for n := first; n <= last; n++ {
+		issue := func(reindexable bool, format string, args ...interface{}) {
+			onIssue(ChainIssue{Number: n, Reason: fmt.Sprintf(format, args...), Reindexable: reindexable})
+		}
+		hash := rawdb.ReadCanonicalHash(db, n)
+		if hash == (common.Hash{}) {
+			issue(false, "canonical hash not found")
+			prev = nil
+			continue
+		}
+		header := rawdb.ReadHeader(db, hash, n)
+		if header == nil {
+			issue(false, "header %v not found", hash.Hex())
+			prev = nil
+			continue
+		}
+		if n > 0 && prev != nil && header.ParentHash() != prev.Hash() {
+			issue(false, "parent hash %v mismatches the canonical hash %v of the previous block",
+				header.ParentHash().Hex(), prev.Hash().Hex())
+		}
+		if n > 0 {
+			if !rawdb.HasBlockCommitSig(db, n) {
+				issue(n < head.Number().Uint64(), "commit signature not found")
+			}
+		}
+		if isEpochBlock(prev, header) {
+			if bn, err := rawdb.ReadEpochBlockNumber(db, header.Epoch()); err != nil || bn.Uint64() != n {
+				issue(true, "epoch block number of epoch %v not found", header.Epoch())
+			}
+		}
+		if header.IsLastBlockInEpoch() {
+			if epoch, err := nextBlockEpoch(config, header); err != nil {
+				issue(false, "cannot decode shard state in header: %v", err)
+			} else if _, err := rawdb.ReadShardState(db, epoch); err != nil {
+				issue(true, "shard state of epoch %v not found", epoch)
+			}
+		}
+		prev = header
+
+		if !rawdb.HasBody(db, hash, n) {
+			issue(false, "body not found")
+			continue
+		}
+		b := rawdb.ReadBlock(db, hash, n)
+		if b == nil {
+			issue(false, "cannot decode body")
+			continue
+		}
+		txs, stxs := b.Transactions(), b.StakingTransactions()
+		if len(txs)+len(stxs) > 0 && len(rawdb.ReadReceiptsRLP(db, hash, n)) == 0 {
+			issue(false, "receipts not found")
+		}
+		for i, tx := range txs {
+			for _, txHash := range []common.Hash{tx.Hash(), tx.ConvertToEth().Hash()} {
+				if !hasTxLookupEntry(db, txHash, hash, n, uint64(i)) {
+					issue(true, "lookup entry of transaction %v not found", txHash.Hex())
+				}
+			}
+		}
+		for i, stx := range stxs {
+			if !hasTxLookupEntry(db, stx.Hash(), hash, n, uint64(i)) {
+				issue(true, "lookup entry of staking transaction %v not found", stx.Hash().Hex())
+			}
+		}
+		if first == 0 {
+			if err := indexer.addBlock(b, rawdb.ReadReceipts(db, hash, n)); err != nil {
+				issue(false, "cannot decode staking transactions: %v", err)
+			}
+		}
+
+		if time.Since(lastLog) > chainCheckLogInterval {
+			utils.Logger().Info().Uint64("number", n).Uint64("last", last).Msg("Verifying chain")
+			lastLog = time.Now()
+		}
+	}
+
+	if first != 0 || headState == nil {
+		return nil
+	}
+	validators, delegations, err := indexer.indexes(headState)
+	if err != nil {
+		return err
+	}
+	headNum := head.Number().Uint64()
+	if list, err := rawdb.ReadValidatorList(db); err != nil || !equalAddresses(list, validators) {
+		onIssue(ChainIssue{Number: headNum, Reason: "validator list mismatches the chain", Reindexable: true})
+	}
+	for delegator, indexes := range delegations {
+		stored, err := rawdb.ReadDelegationsByDelegator(db, delegator)
+		if err != nil || !equalDelegationIndexes(stored, indexes) {
+			onIssue(ChainIssue{
+				Number:      headNum,
+				Reason:      fmt.Sprintf("delegation indexes of %v mismatch the chain", delegator.Hex()),
+				Reindexable: true,
+			})
+		}
+	}
+	return nil
+}
+
+// ReindexChain regenerates the indexes derivable from the canonical blocks [first,
+// last], including the transaction and cross shard receipt lookup entries, the
+// epoch block numbers, the shard states and the missing commit signatures. If
+// first is 0, the validator list and the delegation indexes are regenerated as
+// well from the staking transactions of the chain and the state of the head block.
+func ReindexChain(db ethdb.Database, config *params.ChainConfig, first, last uint64) error {
+	head, err := readHeadHeader(db)
+	if err != nil {
+		return err
+	}
+	if first > last || last > head.Number().Uint64() {
+		return errors.Errorf("invalid block range [%v, %v] of chain with head %v",
+			first, last, head.Number())
+	}
+	var headState *state.DB
+	if first == 0 {
+		// Fail early before the whole chain is iterated
+		if headState, err = state.New(head.Root(), state.NewDatabase(db)); err != nil {
+			return errors.Wrap(err, "state of head block not found")
+		}
+	}
+
+	var (
+		batch   = db.NewBatch()
+		indexer = newStakingIndexer()
+		prev    *block.Header
+		lastLog = time.Now()
+	)
+	if first > 0 {
+		prev = readCanonicalHeader(db, first-1)
+	}
+	for n := first; n <= last; n++ {
+		b := rawdb.ReadBlock(db, rawdb.ReadCanonicalHash(db, n), n)
+		if b == nil {
+			return errors.Errorf("block %v not found", n)
+		}
+		if err := rawdb.WriteBlockTxLookUpEntries(batch, b); err != nil {
+			return err
+		}
+		if err := rawdb.WriteBlockStxLookUpEntries(batch, b); err != nil {
+			return err
+		}
+		if err := rawdb.WriteCxLookupEntries(batch, b); err != nil {
+			return err
+		}
+		header := b.Header()
+		if isEpochBlock(prev, header) {
+			if err := rawdb.WriteEpochBlockNumber(batch, header.Epoch(), b.Number()); err != nil {
+				return err
+			}
+		}
+		if header.IsLastBlockInEpoch() {
+			epoch, err := nextBlockEpoch(config, header)
+			if err != nil {
+				return errors.Wrapf(err, "decode shard state of block %v", n)
+			}
+			if err := rawdb.WriteShardStateBytes(batch, epoch, header.ShardState()); err != nil {
+				return err
+			}
+		}
+		// The commit signature of the previous block is in the header
+		if n > 0 {
+			if !rawdb.HasBlockCommitSig(db, n-1) {
+				lastSig := header.LastCommitSignature()
+				sigAndBitmap := append(lastSig[:], header.LastCommitBitmap()...)
+				if err := rawdb.WriteBlockCommitSig(batch, n-1, sigAndBitmap); err != nil {
+					return err
+				}
+			}
+		}
+		if first == 0 {
+			if err := indexer.addBlock(b, rawdb.ReadReceipts(db, b.Hash(), n)); err != nil {
+				return errors.Wrapf(err, "block %v", n)
+			}
+		}
+		prev = header
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if time.Since(lastLog) > chainCheckLogInterval {
+			utils.Logger().Info().Uint64("number", n).Uint64("last", last).Msg("Reindexing chain")
+			lastLog = time.Now()
+		}
+	}
+
+	if headState != nil {
+		validators, delegations, err := indexer.indexes(headState)
+		if err != nil {
+			return err
+		}
+		if err := rawdb.WriteValidatorList(batch, validators); err != nil {
+			return err
+		}
+		for delegator, indexes := range delegations {
+			if err := rawdb.WriteDelegationsByDelegator(batch, delegator, indexes); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.Write()
+}
+
+// readHeadHeader returns the header of the head block in db
+func readHeadHeader(db rawdb.DatabaseReader) (*block.Header, error) {
+	hash := rawdb.ReadHeadBlockHash(db)
+	number := rawdb.ReadHeaderNumber(db, hash)
+	if number == nil {
+		return nil, errors.New("head block not found")
+	}
+	header := rawdb.ReadHeader(db, hash, *number)
+	if header == nil {
+		return nil, errors.New("head block not found")
+	}
+	return header, nil
+}
+
+// readCanonicalHeader returns the canonical header of number, or nil if not found.
+func readCanonicalHeader(db rawdb.DatabaseReader, number uint64) *block.Header {
+	return rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, number), number)
+}
+
+// isEpochBlock returns whether header is the first block of the epoch, where prev
+// is the header of the previous block, or nil if unknown.
+func isEpochBlock(prev, header *block.Header) bool {
+	if header.Number().Sign() == 0 {
+		return true
+	}
+	return prev != nil && prev.Epoch().Cmp(header.Epoch()) != 0
+}
+
+// hasTxLookupEntry returns whether the lookup entry of the transaction points to
+// the position in the block.
+func hasTxLookupEntry(
+	db rawdb.DatabaseReader, txHash, blockHash common.Hash, number, index uint64,
+) bool {
+	bh, bn, i := rawdb.ReadTxLookupEntry(db, txHash)
+	return bh == blockHash && bn == number && i == index
+}
+
+// stakingIndexer collects the validators created and the delegations made by the
+// staking transactions of the chain, to derive the validator list and the
+// delegation indexes as UpdateStakingMetaData does.
+type stakingIndexer struct {
+	validators  []common.Address
+	delegations map[common.Address][]stakingDelegation // by delegator in order
+}
+
+// stakingDelegation is the first delegation of a delegator to a validator
+type stakingDelegation struct {
+	validator common.Address
+	blockNum  *big.Int
+	// self is whether it is the self delegation of the validator creation, of
+	// which the index is always 0.
+	self bool
+}
+
+func newStakingIndexer() *stakingIndexer {
+	return &stakingIndexer{
+		delegations: make(map[common.Address][]stakingDelegation),
+	}
+}
+
+// addBlock collects the staking transactions of the block and the delegations of
+// the staking precompile logged in its receipts
+func (si *stakingIndexer) addBlock(b *types.Block, receipts types.Receipts) error {
+	for _, txn := range b.StakingTransactions() {
+		payload, err := txn.RLPEncodeStakeMsg()
+		if err != nil {
+			return err
+		}
+		decodePayload, err := staking.RLPDecodeStakeMsg(payload, txn.StakingType())
+		if err != nil {
+			return err
+		}
+		switch txn.StakingType() {
+		case staking.DirectiveCreateValidator:
+			addr := decodePayload.(*staking.CreateValidator).ValidatorAddress
+			si.validators, _ = utils.AppendIfMissing(si.validators, addr)
+			si.delegations[addr] = append(si.delegations[addr], stakingDelegation{
+				validator: addr,
+				blockNum:  b.Number(),
+				self:      true,
+			})
+		case staking.DirectiveDelegate:
+			delegate := decodePayload.(*staking.Delegate)
+			if !si.hasDelegation(delegate.DelegatorAddress, delegate.ValidatorAddress) {
+				si.delegations[delegate.DelegatorAddress] = append(
+					si.delegations[delegate.DelegatorAddress], stakingDelegation{
+						validator: delegate.ValidatorAddress,
+						blockNum:  b.Number(),
+					},
+				)
+			}
+		}
+	}
+	for _, receipt := range receipts {
+		for _, delegate := range vm.ParseStakingDelegations(receipt.Logs) {
+			if !si.hasDelegation(delegate.DelegatorAddress, delegate.ValidatorAddress) {
+				si.delegations[delegate.DelegatorAddress] = append(
+					si.delegations[delegate.DelegatorAddress], stakingDelegation{
+						validator: delegate.ValidatorAddress,
+						blockNum:  b.Number(),
+					},
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (si *stakingIndexer) hasDelegation(delegator, validator common.Address) bool {
+	for _, d := range si.delegations[delegator] {
+		if d.validator == validator {
+			return true
+		}
+	}
+	return false
+}
+
+// indexes returns the validator list and the delegation indexes of the delegators,
+// where the indexes of the delegations are looked up in the state.
+func (si *stakingIndexer) indexes(
+	st *state.DB,
+) ([]common.Address, map[common.Address]staking.DelegationIndexes, error) {
+	result := make(map[common.Address]staking.DelegationIndexes, len(si.delegations))
+	for delegator, delegations := range si.delegations {
+		indexes := staking.DelegationIndexes{}
+		for _, d := range delegations {
+			if d.self {
+				indexes = append(indexes, staking.DelegationIndex{
+					ValidatorAddress: d.validator,
+					Index:            0,
+					BlockNum:         d.blockNum,
+				})
+				continue
+			}
+			wrapper, err := st.ValidatorWrapper(d.validator)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "validator %v", d.validator.Hex())
+			}
+			for i := range wrapper.Delegations {
+				if bytes.Equal(wrapper.Delegations[i].DelegatorAddress[:], delegator[:]) {
+					indexes = append(indexes, staking.DelegationIndex{
+						ValidatorAddress: d.validator,
+						Index:            uint64(i),
+						BlockNum:         d.blockNum,
+					})
+				}
+			}
+		}
+		result[delegator] = indexes
+	}
+	return si.validators, result, nil
+}
+
+func equalAddresses(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalDelegationIndexes(a, b staking.DelegationIndexes) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ValidatorAddress != b[i].ValidatorAddress || a[i].Index != b[i].Index ||
+			a[i].BlockNum.Cmp(b[i].BlockNum) != 0 {
+			return false
+		}
+	}
+	return true
+}
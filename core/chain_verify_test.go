@@ -0,0 +1,155 @@
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/shard"
+)
+
+func TestVerifyAndReindexChain(t *testing.T) {
+	db := ethRawDB.NewMemoryDatabase()
+	blocks := writeVerifyTestChain(t, db)
+	config := params.TestChainConfig
+
+	verify := func() (issues []ChainIssue) {
+		err := VerifyChain(db, config, 0, 2, func(issue ChainIssue) {
+			issues = append(issues, issue)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return issues
+	}
+	// The legacy last commits must not be taken as the commit signature of block 1
+	lastCommits := []byte("last commits")
+	if err := db.Put([]byte("LastCommits"), lastCommits); err != nil {
+		t.Fatal(err)
+	}
+	// The epoch block numbers, the tx lookup entries of the tx and eth tx hashes,
+	// the shard state of epoch 1 and the commit signature of block 1 are missing.
+	issues := verify()
+	if len(issues) != 6 {
+		t.Fatalf("unexpected issues %v", issues)
+	}
+	for _, issue := range issues {
+		if !issue.Reindexable {
+			t.Errorf("unexpected issue %v", issue)
+		}
+	}
+
+	if err := ReindexChain(db, config, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if issues := verify(); len(issues) != 0 {
+		t.Errorf("unexpected issues after reindex %v", issues)
+	}
+	if _, _, index := rawdb.ReadTxLookupEntry(db, blocks[1].Transactions()[0].Hash()); index != 0 {
+		t.Errorf("unexpected tx lookup index %v", index)
+	}
+	lastSig := blocks[2].Header().LastCommitSignature()
+	wantSig := append(lastSig[:], blocks[2].Header().LastCommitBitmap()...)
+	if sig, err := rawdb.ReadBlockCommitSig(db, 1); err != nil || !bytes.Equal(sig, wantSig) {
+		t.Errorf("unexpected commit sig %x, %v", sig, err)
+	}
+
+	// Break the header linkage
+	rawdb.WriteCanonicalHash(db, common.Hash{1}, 1)
+	issues = verify()
+	if len(issues) == 0 || issues[0].Number != 1 || issues[0].Reindexable {
+		t.Errorf("unexpected issues of broken chain %v", issues)
+	}
+	if err := ReindexChain(db, config, 0, 2); err == nil {
+		t.Error("expect error for reindexing broken chain")
+	}
+}
+
+// writeVerifyTestChain writes the canonical chain of 3 blocks without the indexes,
+// where block 1 is the last block of epoch 0 with a transaction.
+func writeVerifyTestChain(t *testing.T, db ethdb.Database) []*types.Block {
+	genesisShardState, _ := shard.EncodeWrapper(shard.State{Epoch: big.NewInt(0)}, false)
+	nextShardState, _ := shard.EncodeWrapper(shard.State{Epoch: big.NewInt(1)}, false)
+	if err := rawdb.WriteShardStateBytes(db, big.NewInt(0), genesisShardState); err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTransaction(0, common.Address{1}, 0, big.NewInt(1), 21000, big.NewInt(1), nil)
+
+	var (
+		blocks     []*types.Block
+		parentHash common.Hash
+	)
+	for i := int64(0); i < 3; i++ {
+		h := blockfactory.NewTestHeader().With().
+			Number(big.NewInt(i)).
+			ParentHash(parentHash).
+			Epoch(big.NewInt(i / 2))
+		var b *types.Block
+		if i == 1 {
+			b = types.NewBlock(h.ShardState(nextShardState).Header(),
+				[]*types.Transaction{tx}, []*types.Receipt{{}}, nil, nil, nil)
+		} else {
+			b = types.NewBlock(h.Header(), nil, nil, nil, nil, nil)
+		}
+		hash, num := b.Hash(), b.NumberU64()
+		if err := rawdb.WriteBlock(db, b); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdb.WriteCanonicalHash(db, hash, num); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdb.WriteReceipts(db, hash, num, types.Receipts{{Logs: []*types.Log{}}}); err != nil {
+			t.Fatal(err)
+		}
+		if num != 1 {
+			if err := rawdb.WriteBlockCommitSig(db, num, []byte{1}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := rawdb.WriteHeadBlockHash(db, hash); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, b)
+		parentHash = hash
+	}
+	return blocks
+}
+
+func TestStakingIndexerPrecompileDelegations(t *testing.T) {
+	var (
+		delegator = common.Address{1}
+		validator = common.Address{2}
+	)
+	b := types.NewBlock(blockfactory.NewTestHeader().With().Number(big.NewInt(5)).Header(), nil, nil, nil, nil, nil)
+	receipts := types.Receipts{{Logs: []*types.Log{
+		{
+			Address: vm.StakingPrecompileAddress,
+			Topics:  []common.Hash{vm.StakingDelegatedTopic, delegator.Hash(), validator.Hash()},
+			Data:    common.LeftPadBytes([]byte{100}, 32),
+		},
+		{
+			Address: vm.StakingPrecompileAddress,
+			Topics:  []common.Hash{vm.StakingUndelegatedTopic, delegator.Hash(), common.Address{3}.Hash()},
+			Data:    common.LeftPadBytes([]byte{100}, 32),
+		},
+	}}}
+
+	si := newStakingIndexer()
+	for i := 0; i < 2; i++ {
+		if err := si.addBlock(b, receipts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	delegations := si.delegations[delegator]
+	if len(delegations) != 1 || delegations[0].validator != validator || delegations[0].blockNum.Uint64() != 5 {
+		t.Errorf("unexpected delegations %+v", delegations)
+	}
+}
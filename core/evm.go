@@ -26,6 +26,7 @@ import (
 	consensus_engine "github.com/harmony-one/harmony/consensus/engine"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/shard"
 	staking "github.com/harmony-one/harmony/staking/types"
 )
 
@@ -65,7 +66,7 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		vrfAndProof := header.Vrf()
 		copy(vrf[:], vrfAndProof[:32])
 	}
-	return vm.Context{
+	ctx := vm.Context{
 		CanTransfer: CanTransfer,
 		Transfer:    Transfer,
 		IsValidator: IsValidator,
@@ -76,9 +77,42 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		BlockNumber: header.Number(),
 		EpochNumber: header.Epoch(),
 		VRF:         vrf,
+		ShardID:     header.ShardID(),
 		Time:        header.Time(),
 		GasLimit:    header.GasLimit(),
 		GasPrice:    new(big.Int).Set(msg.GasPrice()),
+		BaseFee:     header.BaseFee(),
+	}
+	// Staking is only supported on the beacon chain
+	if header.ShardID() == shard.BeaconChainShardID && chain != nil {
+		ctx.Delegate = DelegateFn(header, chain)
+		ctx.Undelegate = UndelegateFn(header)
+		ctx.CollectRewards = CollectRewardsFn(header, chain)
+	}
+	return ctx
+}
+
+// DelegateFn returns a DelegateFunc which applies the delegations of the staking
+// precompile in the block
+func DelegateFn(ref *block.Header, chain ChainContext) vm.DelegateFunc {
+	return func(db vm.StateDB, delegate *staking.Delegate) error {
+		return applyDelegate(db, chain, chain.Config(), ref.Epoch(), ref.Number(), delegate)
+	}
+}
+
+// UndelegateFn returns an UndelegateFunc which applies the undelegations of the
+// staking precompile in the block
+func UndelegateFn(ref *block.Header) vm.UndelegateFunc {
+	return func(db vm.StateDB, undelegate *staking.Undelegate) error {
+		return applyUndelegate(db, ref.Epoch(), undelegate)
+	}
+}
+
+// CollectRewardsFn returns a CollectRewardsFunc which applies the reward
+// collections of the staking precompile in the block
+func CollectRewardsFn(ref *block.Header, chain ChainContext) vm.CollectRewardsFunc {
+	return func(db vm.StateDB, collectRewards *staking.CollectRewards) (*big.Int, error) {
+		return applyCollectRewards(db, chain, ref.Number(), collectRewards)
 	}
 }
 
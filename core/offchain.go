@@ -15,6 +15,7 @@ import (
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/shard"
 	"github.com/harmony-one/harmony/staking/slash"
@@ -118,7 +119,7 @@ func (bc *BlockChain) CommitOffChainData(
 
 	// Do bookkeeping for new staking txns
 	newVals, err := bc.UpdateStakingMetaData(
-		batch, block, state, epoch, nextBlockEpoch,
+		batch, block, receipts, state, epoch, nextBlockEpoch,
 	)
 	if err != nil {
 		utils.Logger().Err(err).Msg("UpdateStakingMetaData failed")
@@ -311,6 +312,11 @@ func (bc *BlockChain) writeValidatorStats(
 }
 
 func (bc *BlockChain) getNextBlockEpoch(header *block.Header) (*big.Int, error) {
+	return nextBlockEpoch(bc.chainConfig, header)
+}
+
+// nextBlockEpoch returns the epoch of the block next to header
+func nextBlockEpoch(config *params.ChainConfig, header *block.Header) (*big.Int, error) {
 	nextBlockEpoch := header.Epoch()
 	if header.IsLastBlockInEpoch() {
 		nextBlockEpoch = new(big.Int).Add(header.Epoch(), common.Big1)
@@ -318,7 +324,7 @@ func (bc *BlockChain) getNextBlockEpoch(header *block.Header) (*big.Int, error)
 		if err != nil {
 			return nil, err
 		}
-		if decodeShardState.Epoch != nil && bc.chainConfig.IsStaking(decodeShardState.Epoch) {
+		if decodeShardState.Epoch != nil && config.IsStaking(decodeShardState.Epoch) {
 			// After staking, the epoch will be decided by the epoch in the shard state.
 			nextBlockEpoch = new(big.Int).Set(decodeShardState.Epoch)
 		}
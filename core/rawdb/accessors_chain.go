@@ -22,6 +22,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/core/types"
@@ -38,9 +39,41 @@ const (
 	NAByte // not exist
 )
 
+// readAncient retrieves the frozen item of kind of a block number if the
+// database is backed by a freezer.
+//
+// The blocks are synced to the freezer before deleted from the key-value store,
+// so the freezer is always read after the key-value store.
+func readAncient(db DatabaseReader, kind string, number uint64) []byte {
+	adb, ok := db.(ethdb.AncientReader)
+	if !ok {
+		return nil
+	}
+	data, _ := adb.Ancient(kind, number)
+	return data
+}
+
+// isFrozen returns whether the block corresponding to the hash is in the freezer.
+func isFrozen(db DatabaseReader, hash common.Hash, number uint64) bool {
+	data := readAncient(db, freezerHashTable, number)
+	return len(data) != 0 && common.BytesToHash(data) == hash
+}
+
+// readAncientByHash retrieves the frozen item of kind of the block corresponding
+// to the hash.
+func readAncientByHash(db DatabaseReader, kind string, hash common.Hash, number uint64) []byte {
+	if !isFrozen(db, hash, number) {
+		return nil
+	}
+	return readAncient(db, kind, number)
+}
+
 // ReadCanonicalHash retrieves the hash assigned to a canonical block number.
 func ReadCanonicalHash(db DatabaseReader, number uint64) common.Hash {
 	data, _ := db.Get(headerHashKey(number))
+	if len(data) == 0 {
+		data = readAncient(db, freezerHashTable, number)
+	}
 	if len(data) == 0 {
 		return common.Hash{}
 	}
@@ -132,13 +165,16 @@ func WriteHeadFastBlockHash(db DatabaseWriter, hash common.Hash) error {
 // ReadHeaderRLP retrieves a block header in its raw RLP database encoding.
 func ReadHeaderRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
 	data, _ := db.Get(headerKey(number, hash))
+	if len(data) == 0 {
+		data = readAncientByHash(db, freezerHeaderTable, hash, number)
+	}
 	return data
 }
 
 // HasHeader verifies the existence of a block header corresponding to the hash.
 func HasHeader(db DatabaseReader, hash common.Hash, number uint64) bool {
 	if has, err := db.Has(headerKey(number, hash)); !has || err != nil {
-		return false
+		return isFrozen(db, hash, number)
 	}
 	return true
 }
@@ -201,6 +237,9 @@ func DeleteHeader(db DatabaseDeleter, hash common.Hash, number uint64) error {
 // ReadBodyRLP retrieves the block body (transactions and uncles) in RLP encoding.
 func ReadBodyRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
 	data, _ := db.Get(blockBodyKey(number, hash))
+	if len(data) == 0 {
+		data = readAncientByHash(db, freezerBodiesTable, hash, number)
+	}
 	return data
 }
 
@@ -216,7 +255,7 @@ func WriteBodyRLP(db DatabaseWriter, hash common.Hash, number uint64, rlp rlp.Ra
 // HasBody verifies the existence of a block body corresponding to the hash.
 func HasBody(db DatabaseReader, hash common.Hash, number uint64) bool {
 	if has, err := db.Has(blockBodyKey(number, hash)); !has || err != nil {
-		return false
+		return isFrozen(db, hash, number)
 	}
 	return true
 }
@@ -254,9 +293,19 @@ func DeleteBody(db DatabaseDeleter, hash common.Hash, number uint64) error {
 	return nil
 }
 
+// ReadTdRLP retrieves a block's total difficulty corresponding to the hash in
+// RLP encoding.
+func ReadTdRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
+	data, _ := db.Get(headerTDKey(number, hash))
+	if len(data) == 0 {
+		data = readAncientByHash(db, freezerDifficultyTable, hash, number)
+	}
+	return data
+}
+
 // ReadTd retrieves a block's total difficulty corresponding to the hash.
 func ReadTd(db DatabaseReader, hash common.Hash, number uint64) *big.Int {
-	data, _ := db.Get(headerTDKey(number, hash))
+	data := ReadTdRLP(db, hash, number)
 	if len(data) == 0 {
 		return nil
 	}
@@ -291,10 +340,20 @@ func DeleteTd(db DatabaseDeleter, hash common.Hash, number uint64) error {
 	return nil
 }
 
+// ReadReceiptsRLP retrieves all the transaction receipts belonging to a block in
+// RLP encoding.
+func ReadReceiptsRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
+	data, _ := db.Get(blockReceiptsKey(number, hash))
+	if len(data) == 0 {
+		data = readAncientByHash(db, freezerReceiptTable, hash, number)
+	}
+	return data
+}
+
 // ReadReceipts retrieves all the transaction receipts belonging to a block.
 func ReadReceipts(db DatabaseReader, hash common.Hash, number uint64) types.Receipts {
 	// Retrieve the flattened receipt slice
-	data, _ := db.Get(blockReceiptsKey(number, hash))
+	data := ReadReceiptsRLP(db, hash, number)
 	if len(data) == 0 {
 		return nil
 	}
@@ -298,6 +298,9 @@ func ReadBlockCommitSig(db DatabaseReader, blockNum uint64) ([]byte, error) {
 	var data []byte
 	data, err := db.Get(blockCommitSigKey(blockNum))
 	if err != nil {
+		if data = readAncient(db, freezerCommitSigTable, blockNum); len(data) != 0 {
+			return data, nil
+		}
 		// TODO: remove this extra seeking of sig after the mainnet is fully upgraded.
 		//       this is only needed for the compatibility in the migration moment.
 		data, err = db.Get(lastCommitsKey)
@@ -308,6 +311,16 @@ func ReadBlockCommitSig(db DatabaseReader, blockNum uint64) ([]byte, error) {
 	return data, nil
 }
 
+// HasBlockCommitSig returns whether the signature signed on a block is stored in
+// the database or the freezer. Unlike ReadBlockCommitSig, the legacy last commits
+// are not taken as the signature of the block.
+func HasBlockCommitSig(db DatabaseReader, blockNum uint64) bool {
+	if data, _ := db.Get(blockCommitSigKey(blockNum)); len(data) != 0 {
+		return true
+	}
+	return len(readAncient(db, freezerCommitSigTable, blockNum)) != 0
+}
+
 // WriteBlockCommitSig ..
 func WriteBlockCommitSig(db DatabaseWriter, blockNum uint64, sigAndBitmap []byte) error {
 	return db.Put(blockCommitSigKey(blockNum), sigAndBitmap)
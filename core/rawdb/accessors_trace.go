@@ -0,0 +1,179 @@
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// TraceIndexEntry is the position of a call trace in the trace index.
+type TraceIndexEntry struct {
+	BlockNumber uint64 // number of the block containing the trace
+	Index       uint32 // index of the trace in the call traces of the block
+}
+
+// ReadTraceIndexTail retrieves the number of the first block of the trace index.
+func ReadTraceIndexTail(db DatabaseReader) *uint64 {
+	return readTraceIndexNumber(db, traceIndexTailKey)
+}
+
+// WriteTraceIndexTail stores the number of the first block of the trace index.
+func WriteTraceIndexTail(db DatabaseWriter, number uint64) error {
+	return db.Put(traceIndexTailKey, encodeBlockNumber(number))
+}
+
+// ReadTraceIndexHead retrieves the number of the last block of the trace index.
+func ReadTraceIndexHead(db DatabaseReader) *uint64 {
+	return readTraceIndexNumber(db, traceIndexHeadKey)
+}
+
+// WriteTraceIndexHead stores the number of the last block of the trace index.
+func WriteTraceIndexHead(db DatabaseWriter, number uint64) error {
+	return db.Put(traceIndexHeadKey, encodeBlockNumber(number))
+}
+
+// DeleteTraceIndexHead removes the number of the last block of the trace index,
+// so that no block is indexed.
+func DeleteTraceIndexHead(db DatabaseDeleter) error {
+	return db.Delete(traceIndexHeadKey)
+}
+
+// DeleteTraceIndex removes the call traces of the blocks and their index by
+// address, along with the head of the trace index. Only the keys of the trace
+// index are removed, which are told apart from the other keys sharing the
+// prefixes by their length.
+func DeleteTraceIndex(db ethdb.KeyValueStore) error {
+	if err := DeleteTraceIndexHead(db); err != nil {
+		return err
+	}
+	batch := db.NewBatch()
+	deletePrefix := func(prefix []byte, keyLen int) error {
+		it := db.NewIteratorWithPrefix(prefix)
+		defer it.Release()
+
+		for it.Next() {
+			if len(it.Key()) != keyLen {
+				continue
+			}
+			if err := batch.Delete(common.CopyBytes(it.Key())); err != nil {
+				return err
+			}
+			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		return it.Error()
+	}
+	for _, prefix := range [][]byte{blockTracesPrefix, traceHashPrefix} {
+		if err := deletePrefix(prefix, len(prefix)+8); err != nil {
+			return err
+		}
+	}
+	for _, prefix := range [][]byte{traceFromPrefix, traceToPrefix} {
+		if err := deletePrefix(prefix, len(prefix)+common.AddressLength+8+4); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func readTraceIndexNumber(db DatabaseReader, key []byte) *uint64 {
+	data, _ := db.Get(key)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// ReadBlockTraces retrieves the encoded call traces of a block.
+func ReadBlockTraces(db DatabaseReader, number uint64) []byte {
+	data, _ := db.Get(blockTracesKey(number))
+	return data
+}
+
+// WriteBlockTraces stores the encoded call traces of a block.
+func WriteBlockTraces(db DatabaseWriter, number uint64, data []byte) error {
+	return db.Put(blockTracesKey(number), data)
+}
+
+// DeleteBlockTraces removes the encoded call traces of a block, along with the
+// hash of the indexed block.
+func DeleteBlockTraces(db DatabaseDeleter, number uint64) error {
+	if err := db.Delete(blockTracesKey(number)); err != nil {
+		return err
+	}
+	return db.Delete(traceHashKey(number))
+}
+
+// ReadTraceIndexHash retrieves the hash of the indexed block with the given
+// number, which tells whether the block is still canonical.
+func ReadTraceIndexHash(db DatabaseReader, number uint64) common.Hash {
+	data, _ := db.Get(traceHashKey(number))
+	return common.BytesToHash(data)
+}
+
+// WriteTraceIndexHash stores the hash of the indexed block with the given number.
+func WriteTraceIndexHash(db DatabaseWriter, number uint64, hash common.Hash) error {
+	return db.Put(traceHashKey(number), hash.Bytes())
+}
+
+// WriteTraceFromEntry indexes a call trace by its sender.
+func WriteTraceFromEntry(db DatabaseWriter, addr common.Address, entry TraceIndexEntry) error {
+	return db.Put(traceAddressKey(traceFromPrefix, addr, entry.BlockNumber, entry.Index), nil)
+}
+
+// WriteTraceToEntry indexes a call trace by its recipient.
+func WriteTraceToEntry(db DatabaseWriter, addr common.Address, entry TraceIndexEntry) error {
+	return db.Put(traceAddressKey(traceToPrefix, addr, entry.BlockNumber, entry.Index), nil)
+}
+
+// DeleteTraceFromEntry removes a call trace from the index by sender.
+func DeleteTraceFromEntry(db DatabaseDeleter, addr common.Address, entry TraceIndexEntry) error {
+	return db.Delete(traceAddressKey(traceFromPrefix, addr, entry.BlockNumber, entry.Index))
+}
+
+// DeleteTraceToEntry removes a call trace from the index by recipient.
+func DeleteTraceToEntry(db DatabaseDeleter, addr common.Address, entry TraceIndexEntry) error {
+	return db.Delete(traceAddressKey(traceToPrefix, addr, entry.BlockNumber, entry.Index))
+}
+
+// ReadTraceFromEntries retrieves the positions of the call traces sent by the
+// address in the blocks [from, to], in ascending order.
+func ReadTraceFromEntries(db ethdb.Iteratee, addr common.Address, from, to uint64) []TraceIndexEntry {
+	return readTraceEntries(db, traceFromPrefix, addr, from, to)
+}
+
+// ReadTraceToEntries retrieves the positions of the call traces received by the
+// address in the blocks [from, to], in ascending order.
+func ReadTraceToEntries(db ethdb.Iteratee, addr common.Address, from, to uint64) []TraceIndexEntry {
+	return readTraceEntries(db, traceToPrefix, addr, from, to)
+}
+
+func readTraceEntries(db ethdb.Iteratee, prefix []byte, addr common.Address, from, to uint64) []TraceIndexEntry {
+	addrPrefix := append(append([]byte{}, prefix...), addr.Bytes()...)
+	it := db.NewIteratorWithStart(traceAddressKey(prefix, addr, from, 0))
+	defer it.Release()
+
+	var entries []TraceIndexEntry
+	for it.Next() {
+		key := it.Key()
+		if !bytes.HasPrefix(key, addrPrefix) || len(key) != len(addrPrefix)+8+4 {
+			break
+		}
+		number := binary.BigEndian.Uint64(key[len(addrPrefix):])
+		if number > to {
+			break
+		}
+		entries = append(entries, TraceIndexEntry{
+			BlockNumber: number,
+			Index:       binary.BigEndian.Uint32(key[len(addrPrefix)+8:]),
+		})
+	}
+	return entries
+}
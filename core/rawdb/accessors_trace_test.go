@@ -0,0 +1,156 @@
+package rawdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+func TestTraceIndexStorage(t *testing.T) {
+	db := memorydb.New()
+
+	if ReadTraceIndexTail(db) != nil || ReadTraceIndexHead(db) != nil {
+		t.Fatal("trace index bounds returned before written")
+	}
+	if err := WriteTraceIndexTail(db, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteTraceIndexHead(db, 7); err != nil {
+		t.Fatal(err)
+	}
+	if tail := ReadTraceIndexTail(db); tail == nil || *tail != 3 {
+		t.Errorf("unexpected trace index tail %v", tail)
+	}
+	if head := ReadTraceIndexHead(db); head == nil || *head != 7 {
+		t.Errorf("unexpected trace index head %v", head)
+	}
+	if err := DeleteTraceIndexHead(db); err != nil {
+		t.Fatal(err)
+	}
+	if head := ReadTraceIndexHead(db); head != nil {
+		t.Errorf("trace index head returned after deleted: %v", *head)
+	}
+
+	if err := WriteBlockTraces(db, 5, []byte("[]")); err != nil {
+		t.Fatal(err)
+	}
+	if data := ReadBlockTraces(db, 5); string(data) != "[]" {
+		t.Errorf("unexpected block traces %s", data)
+	}
+	if data := ReadBlockTraces(db, 6); data != nil {
+		t.Errorf("block traces returned before written: %s", data)
+	}
+	if err := WriteTraceIndexHash(db, 5, common.Hash{5}); err != nil {
+		t.Fatal(err)
+	}
+	if hash := ReadTraceIndexHash(db, 5); hash != (common.Hash{5}) {
+		t.Errorf("unexpected indexed block hash %x", hash)
+	}
+	if err := WriteBlockTraces(db, 6, []byte("[]")); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteTraceIndexHash(db, 6, common.Hash{6}); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteBlockTraces(db, 6); err != nil {
+		t.Fatal(err)
+	}
+	if data := ReadBlockTraces(db, 6); data != nil {
+		t.Errorf("block traces returned after deleted: %s", data)
+	}
+	if hash := ReadTraceIndexHash(db, 6); hash != (common.Hash{}) {
+		t.Errorf("indexed block hash returned after deleted: %x", hash)
+	}
+
+	addr1 := common.BytesToAddress([]byte{0x11})
+	addr2 := common.BytesToAddress([]byte{0x12})
+	entries := []TraceIndexEntry{{3, 0}, {4, 1}, {4, 256}, {6, 2}, {256, 0}}
+	for _, entry := range entries {
+		if err := WriteTraceFromEntry(db, addr1, entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteTraceToEntry(db, addr2, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The entries of the neighbouring address must not be returned
+	if err := WriteTraceFromEntry(db, addr2, TraceIndexEntry{4, 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		from, to uint64
+		want     []TraceIndexEntry
+	}{
+		{0, 1000, entries},
+		{4, 6, entries[1:4]},
+		{5, 5, nil},
+		{7, 1000, entries[4:]},
+	}
+	for i, test := range tests {
+		if have := ReadTraceFromEntries(db, addr1, test.from, test.to); !reflect.DeepEqual(have, test.want) {
+			t.Errorf("test %d: unexpected from entries %v, want %v", i, have, test.want)
+		}
+		if have := ReadTraceToEntries(db, addr2, test.from, test.to); !reflect.DeepEqual(have, test.want) {
+			t.Errorf("test %d: unexpected to entries %v, want %v", i, have, test.want)
+		}
+	}
+	if have := ReadTraceToEntries(db, addr1, 0, 1000); have != nil {
+		t.Errorf("unexpected to entries of the sender %v", have)
+	}
+	if have := ReadTraceFromEntries(db, addr2, 0, 1000); !reflect.DeepEqual(have, []TraceIndexEntry{{4, 0}}) {
+		t.Errorf("unexpected from entries of the recipient %v", have)
+	}
+
+	// A single entry is deleted
+	if err := WriteTraceFromEntry(db, addr1, TraceIndexEntry{5, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteTraceToEntry(db, addr2, TraceIndexEntry{5, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteTraceFromEntry(db, addr1, TraceIndexEntry{5, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteTraceToEntry(db, addr2, TraceIndexEntry{5, 0}); err != nil {
+		t.Fatal(err)
+	}
+	if have := ReadTraceFromEntries(db, addr1, 5, 5); have != nil {
+		t.Errorf("from entries returned after deleted: %v", have)
+	}
+	if have := ReadTraceToEntries(db, addr2, 5, 5); have != nil {
+		t.Errorf("to entries returned after deleted: %v", have)
+	}
+
+	// Only the keys of the trace index are deleted
+	other := append(append([]byte{}, blockTracesPrefix...), common.Hash{}.Bytes()[:30]...)
+	if err := db.Put(other, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteTraceIndexHead(db, 7); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteTraceIndex(db); err != nil {
+		t.Fatal(err)
+	}
+	if head := ReadTraceIndexHead(db); head != nil {
+		t.Errorf("trace index head returned after deleted: %v", *head)
+	}
+	if data := ReadBlockTraces(db, 5); data != nil {
+		t.Errorf("block traces returned after deleted: %s", data)
+	}
+	if hash := ReadTraceIndexHash(db, 5); hash != (common.Hash{}) {
+		t.Errorf("indexed block hash returned after deleted: %x", hash)
+	}
+	if have := ReadTraceFromEntries(db, addr1, 0, 1000); have != nil {
+		t.Errorf("from entries returned after deleted: %v", have)
+	}
+	if have := ReadTraceToEntries(db, addr2, 0, 1000); have != nil {
+		t.Errorf("to entries returned after deleted: %v", have)
+	}
+	if ok, _ := db.Has(other); !ok {
+		t.Error("key out of the trace index deleted")
+	}
+}
@@ -0,0 +1,97 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/pkg/errors"
+)
+
+// freezerdb is a chain database of the recent blocks in the key-value store and
+// the old blocks in the freezer.
+type freezerdb struct {
+	ethdb.KeyValueStore
+	*freezer
+}
+
+// Close stops the freezer and closes both the freezer and the key-value store
+func (db *freezerdb) Close() error {
+	var errs []error
+	if err := db.freezer.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := db.KeyValueStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if errs != nil {
+		return errors.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// NewDatabaseWithFreezer creates a chain database on top of the key-value store,
+// which moves the canonical blocks older than depth into the freezer at dir in
+// the background. The blocks are read from the key-value store first and then
+// from the freezer with the accessors of this package.
+func NewDatabaseWithFreezer(db ethdb.KeyValueStore, dir string, depth uint64) (ethdb.Database, error) {
+	f, err := newFreezer(dir, depth)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFreezer(db, f); err != nil {
+		f.Close()
+		return nil, err
+	}
+	f.wg.Add(1)
+	go f.freeze(db)
+
+	return &freezerdb{
+		KeyValueStore: db,
+		freezer:       f,
+	}, nil
+}
+
+// NewLevelDBDatabaseWithFreezer creates a chain database of the LevelDB at file
+// and the freezer at freezerDir.
+func NewLevelDBDatabaseWithFreezer(file string, cache, handles int, freezerDir string, depth uint64) (ethdb.Database, error) {
+	kvdb, err := leveldb.New(file, cache, handles, "")
+	if err != nil {
+		return nil, err
+	}
+	db, err := NewDatabaseWithFreezer(kvdb, freezerDir, depth)
+	if err != nil {
+		kvdb.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// checkFreezer checks the freezer is the one of the chain in the key-value store,
+// so that the blocks moved into the freezer are not lost by a wrong freezer
+// directory.
+func checkFreezer(db ethdb.KeyValueStore, f *freezer) error {
+	kvGenesis := ReadCanonicalHash(db, 0)
+	frozen, _ := f.Ancients()
+	if frozen == 0 {
+		// The block 1 is always moved into the freezer first
+		if kvGenesis != (common.Hash{}) && ReadCanonicalHash(db, 1) == (common.Hash{}) &&
+			ReadHeadBlockHash(db) != kvGenesis && ReadHeadBlockHash(db) != (common.Hash{}) {
+			return errors.New("blocks already moved into another freezer, " +
+				"check the freezer directory")
+		}
+		return nil
+	}
+	if kvGenesis == (common.Hash{}) {
+		return errors.New("freezer of another chain found, " +
+			"check the freezer directory")
+	}
+	frozenGenesis, err := f.Ancient(freezerHashTable, 0)
+	if err != nil {
+		return errors.Wrap(err, "read genesis hash from freezer")
+	}
+	if common.BytesToHash(frozenGenesis) != kvGenesis {
+		return errors.Errorf("genesis mismatch: freezer %x, chain db %x",
+			frozenGenesis, kvGenesis)
+	}
+	return nil
+}
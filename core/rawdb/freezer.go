@@ -0,0 +1,369 @@
+package rawdb
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+// The kinds of the frozen items, which are the tables of the freezer
+const (
+	freezerHashTable       = "hashes"
+	freezerHeaderTable     = "headers"
+	freezerBodiesTable     = "bodies"
+	freezerReceiptTable    = "receipts"
+	freezerDifficultyTable = "diffs"
+	freezerCommitSigTable  = "sigs"
+)
+
+var freezerTables = []string{
+	freezerHashTable,
+	freezerHeaderTable,
+	freezerBodiesTable,
+	freezerReceiptTable,
+	freezerDifficultyTable,
+	freezerCommitSigTable,
+}
+
+const (
+	// freezerRecheckInterval is the interval to check the chain for the blocks
+	// old enough to be frozen.
+	freezerRecheckInterval = time.Minute
+
+	// freezerBatchLimit is the max number of blocks frozen in a batch before the
+	// tables are synced and the blocks are deleted from the key-value store.
+	freezerBatchLimit = 30000
+)
+
+var errUnknownTable = errors.New("unknown table")
+
+// freezer is the append-only store of the canonical blocks older than depth. The
+// hash, header, body, receipts, total difficulty and commit signature of block n
+// are the item n of the tables, so the frozen blocks are always [0, frozen).
+type freezer struct {
+	frozen uint64 // number of the frozen blocks, accessed atomically
+
+	depth  uint64 // number of the recent blocks kept in the key-value store
+	tables map[string]*freezerTable
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// newFreezer opens the freezer tables in dir
+func newFreezer(dir string, depth uint64) (*freezer, error) {
+	f := &freezer{
+		depth:  depth,
+		tables: make(map[string]*freezerTable),
+		quit:   make(chan struct{}),
+	}
+	for _, name := range freezerTables {
+		table, err := newFreezerTable(dir, name)
+		if err != nil {
+			f.closeTables()
+			return nil, err
+		}
+		f.tables[name] = table
+	}
+	if err := f.repair(); err != nil {
+		f.closeTables()
+		return nil, err
+	}
+	utils.Logger().Info().Str("dir", dir).Uint64("frozen", f.frozen).Msg("Opened freezer")
+	return f, nil
+}
+
+// repair truncates the tables to the same number of items
+func (f *freezer) repair() error {
+	min := uint64(math.MaxUint64)
+	for _, table := range f.tables {
+		if items := atomic.LoadUint64(&table.items); items < min {
+			min = items
+		}
+	}
+	for _, table := range f.tables {
+		if err := table.truncate(min); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint64(&f.frozen, min)
+	return nil
+}
+
+// HasAncient returns whether the frozen item of kind exists
+func (f *freezer) HasAncient(kind string, number uint64) (bool, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.has(number), nil
+	}
+	return false, nil
+}
+
+// Ancient returns the frozen item of kind of block number
+func (f *freezer) Ancient(kind string, number uint64) ([]byte, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.retrieve(number)
+	}
+	return nil, errUnknownTable
+}
+
+// Ancients returns the number of the frozen blocks
+func (f *freezer) Ancients() (uint64, error) {
+	return atomic.LoadUint64(&f.frozen), nil
+}
+
+// AncientSize returns the size on disk of the table of kind
+func (f *freezer) AncientSize(kind string) (uint64, error) {
+	if table := f.tables[kind]; table != nil {
+		return table.sizeOnDisk()
+	}
+	return 0, errUnknownTable
+}
+
+// AppendAncient appends the block number to the freezer without commit signature
+func (f *freezer) AppendAncient(number uint64, hash, header, body, receipts, td []byte) error {
+	return f.appendAncient(number, hash, header, body, receipts, td, nil)
+}
+
+// appendAncient appends the items of the block number, which must be the next
+// block of the freezer. The tables are rolled back if any of them fails.
+func (f *freezer) appendAncient(number uint64, hash, header, body, receipts, td, sig []byte) (err error) {
+	if atomic.LoadUint64(&f.frozen) != number {
+		return errOutOrderInsertion
+	}
+	defer func() {
+		if err != nil {
+			if rerr := f.repair(); rerr != nil {
+				utils.Logger().Error().Err(rerr).Msg("Failed to repair freezer")
+			}
+		}
+	}()
+	items := map[string][]byte{
+		freezerHashTable:       hash,
+		freezerHeaderTable:     header,
+		freezerBodiesTable:     body,
+		freezerReceiptTable:    receipts,
+		freezerDifficultyTable: td,
+		freezerCommitSigTable:  sig,
+	}
+	for _, name := range freezerTables {
+		if err := f.tables[name].append(number, items[name]); err != nil {
+			return errors.Wrapf(err, "append block %v to freezer table %v", number, name)
+		}
+	}
+	atomic.AddUint64(&f.frozen, 1)
+	return nil
+}
+
+// TruncateAncients discards all but the first items blocks
+func (f *freezer) TruncateAncients(items uint64) error {
+	if atomic.LoadUint64(&f.frozen) <= items {
+		return nil
+	}
+	for _, table := range f.tables {
+		if err := table.truncate(items); err != nil {
+			return err
+		}
+	}
+	atomic.StoreUint64(&f.frozen, items)
+	return nil
+}
+
+// Sync flushes the tables to disk
+func (f *freezer) Sync() error {
+	var errs []error
+	for _, table := range f.tables {
+		if err := table.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errors.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// Close stops the freezing and closes the tables
+func (f *freezer) Close() error {
+	select {
+	case <-f.quit:
+	default:
+		close(f.quit)
+	}
+	f.wg.Wait()
+	return f.closeTables()
+}
+
+func (f *freezer) closeTables() error {
+	var errs []error
+	for _, table := range f.tables {
+		if err := table.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if errs != nil {
+		return errors.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// freeze periodically moves the canonical blocks older than depth from the
+// key-value store into the freezer until the freezer is closed.
+func (f *freezer) freeze(db ethdb.KeyValueStore) {
+	defer f.wg.Done()
+
+	for {
+		frozen, err := f.freezeBatch(db)
+		if err != nil {
+			utils.Logger().Error().Err(err).Msg("Failed to freeze blocks")
+		}
+		// Avoid database thrashing with tiny writes
+		wait := freezerRecheckInterval
+		if frozen >= freezerBatchLimit {
+			wait = 0
+		}
+		select {
+		case <-f.quit:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// freezeBatch moves at most freezerBatchLimit canonical blocks older than depth
+// from the key-value store into the freezer, and returns the number of the blocks
+// frozen. The side chain blocks of the frozen numbers are deleted. The genesis
+// block is always kept in the key-value store.
+func (f *freezer) freezeBatch(db ethdb.KeyValueStore) (uint64, error) {
+	head := ReadHeadBlockHash(db)
+	if head == (common.Hash{}) {
+		return 0, nil
+	}
+	number := ReadHeaderNumber(db, head)
+	if number == nil {
+		return 0, errors.Errorf("number of head block %v not found", head.Hex())
+	}
+	// The recent blocks (head-depth, head] are kept in the key-value store
+	first := atomic.LoadUint64(&f.frozen)
+	if *number+1 < f.depth || *number+1-f.depth <= first {
+		return 0, nil
+	}
+	limit := *number + 1 - f.depth
+	if limit-first > freezerBatchLimit {
+		limit = first + freezerBatchLimit
+	}
+
+	var (
+		start    = time.Now()
+		ancients []common.Hash
+		err      error
+	)
+	for n := first; n < limit; n++ {
+		hash := ReadCanonicalHash(db, n)
+		if hash == (common.Hash{}) {
+			err = errors.Errorf("canonical hash of block %v not found", n)
+			break
+		}
+		header := ReadHeaderRLP(db, hash, n)
+		if len(header) == 0 {
+			err = errors.Errorf("header of block %v not found", n)
+			break
+		}
+		body := ReadBodyRLP(db, hash, n)
+		if len(body) == 0 {
+			err = errors.Errorf("body of block %v not found", n)
+			break
+		}
+		// The blocks might have no receipts, total difficulty or commit signature
+		// stored, which are frozen as empty items.
+		receipts := ReadReceiptsRLP(db, hash, n)
+		td := ReadTdRLP(db, hash, n)
+		sig, _ := db.Get(blockCommitSigKey(n))
+		if err = f.appendAncient(n, hash[:], header, body, receipts, td, sig); err != nil {
+			break
+		}
+		ancients = append(ancients, hash)
+	}
+	if len(ancients) == 0 {
+		return 0, err
+	}
+	// The frozen blocks are synced to disk before deleted from the key-value store
+	if serr := f.Sync(); serr != nil {
+		return 0, errors.Wrap(serr, "sync freezer")
+	}
+
+	batch := db.NewBatch()
+	for i, hash := range ancients {
+		n := first + uint64(i)
+		if n == 0 {
+			continue
+		}
+		if derr := deleteFrozenBlock(batch, hash, n); derr != nil {
+			return 0, derr
+		}
+		for _, sideHash := range readAllHashes(db, n) {
+			if sideHash == hash {
+				continue
+			}
+			if derr := DeleteBlock(batch, sideHash, n); derr != nil {
+				return 0, derr
+			}
+		}
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if werr := batch.Write(); werr != nil {
+				return 0, werr
+			}
+			batch.Reset()
+		}
+	}
+	if werr := batch.Write(); werr != nil {
+		return 0, werr
+	}
+	utils.Logger().Info().
+		Uint64("blocks", uint64(len(ancients))).
+		Uint64("number", first+uint64(len(ancients))-1).
+		Str("elapsed", common.PrettyDuration(time.Since(start)).String()).
+		Msg("Froze chain segment")
+	return uint64(len(ancients)), err
+}
+
+// deleteFrozenBlock deletes the frozen canonical block from the key-value store.
+// The hash to number mapping is kept to look up the frozen block by hash.
+func deleteFrozenBlock(db DatabaseDeleter, hash common.Hash, number uint64) error {
+	keys := [][]byte{
+		headerKey(number, hash),
+		headerTDKey(number, hash),
+		headerHashKey(number),
+		blockBodyKey(number, hash),
+		blockReceiptsKey(number, hash),
+		blockCommitSigKey(number),
+	}
+	for _, key := range keys {
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readAllHashes returns the hashes of all the headers of number in the key-value
+// store, including the canonical one and the side chain ones.
+func readAllHashes(db ethdb.Iteratee, number uint64) []common.Hash {
+	prefix := append(append([]byte{}, headerPrefix...), encodeBlockNumber(number)...)
+
+	it := db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	var hashes []common.Hash
+	for it.Next() {
+		if key := it.Key(); len(key) == len(prefix)+common.HashLength {
+			hashes = append(hashes, common.BytesToHash(key[len(prefix):]))
+		}
+	}
+	return hashes
+}
@@ -0,0 +1,255 @@
+package rawdb
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// errClosed is returned if the freezer table is accessed after it is closed.
+	errClosed = errors.New("closed")
+
+	// errOutOfBounds is returned if the item is not in the freezer table.
+	errOutOfBounds = errors.New("out of bounds")
+
+	// errOutOrderInsertion is returned if the item appended is not the next one.
+	errOutOrderInsertion = errors.New("the append operation is out-order")
+)
+
+// indexEntrySize is the size of an entry of the index file, which is the end
+// offset (uint64 big endian) of the item in the data file.
+const indexEntrySize = 8
+
+// freezerTable is an append-only table of one kind of the frozen items. It consists
+// of a data file of the concatenated items, and an index file of the end offsets of
+// the items in the data file, so that the item n is data[index[n-1]:index[n]].
+//
+// The data file is always written before the index file, so the table can be
+// repaired to the last complete item after a crash by truncating both files.
+type freezerTable struct {
+	items uint64 // number of the items in the table, accessed atomically
+
+	name  string
+	index *os.File
+	data  *os.File
+	size  uint64 // size of the data file, which is the end offset of the last item
+	lock  sync.RWMutex
+}
+
+// newFreezerTable opens the freezer table of name in dir, creating the files if
+// not exist, and repairs the table to the last complete item.
+func newFreezerTable(dir, name string) (*freezerTable, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	index, err := os.OpenFile(filepath.Join(dir, name+".idx"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.OpenFile(filepath.Join(dir, name+".dat"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		index.Close()
+		return nil, err
+	}
+	t := &freezerTable{
+		name:  name,
+		index: index,
+		data:  data,
+	}
+	if err := t.repair(); err != nil {
+		t.Close()
+		return nil, errors.Wrapf(err, "repair freezer table %v", name)
+	}
+	return t, nil
+}
+
+// repair drops the incomplete items at the end of the table
+func (t *freezerTable) repair() error {
+	indexStat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+	dataStat, err := t.data.Stat()
+	if err != nil {
+		return err
+	}
+	items := uint64(indexStat.Size()) / indexEntrySize
+	dataSize := uint64(dataStat.Size())
+	for ; items > 0; items-- {
+		end, err := t.readOffset(items - 1)
+		if err != nil {
+			return err
+		}
+		if end <= dataSize {
+			dataSize = end
+			break
+		}
+	}
+	if items == 0 {
+		dataSize = 0
+	}
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+	if err := t.data.Truncate(int64(dataSize)); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&t.items, items)
+	t.size = dataSize
+	return nil
+}
+
+// readOffset reads the end offset of the item n from the index file
+func (t *freezerTable) readOffset(n uint64) (uint64, error) {
+	var b [indexEntrySize]byte
+	if _, err := t.index.ReadAt(b[:], int64(n*indexEntrySize)); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// has returns whether the item n is in the table
+func (t *freezerTable) has(n uint64) bool {
+	return atomic.LoadUint64(&t.items) > n
+}
+
+// retrieve returns the item n
+func (t *freezerTable) retrieve(n uint64) ([]byte, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.index == nil {
+		return nil, errClosed
+	}
+	if n >= atomic.LoadUint64(&t.items) {
+		return nil, errOutOfBounds
+	}
+	var start uint64
+	if n > 0 {
+		var b [2 * indexEntrySize]byte
+		if _, err := t.index.ReadAt(b[:], int64((n-1)*indexEntrySize)); err != nil {
+			return nil, err
+		}
+		start = binary.BigEndian.Uint64(b[:indexEntrySize])
+	}
+	end, err := t.readOffset(n)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		return nil, errors.Errorf("corrupted index of item %v in freezer table %v", n, t.name)
+	}
+	blob := make([]byte, end-start)
+	if _, err := t.data.ReadAt(blob, int64(start)); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// append writes blob as the item n, which must be the next item of the table
+func (t *freezerTable) append(n uint64, blob []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.index == nil {
+		return errClosed
+	}
+	items := atomic.LoadUint64(&t.items)
+	if n != items {
+		return errOutOrderInsertion
+	}
+	if _, err := t.data.WriteAt(blob, int64(t.size)); err != nil {
+		return err
+	}
+	end := t.size + uint64(len(blob))
+	var b [indexEntrySize]byte
+	binary.BigEndian.PutUint64(b[:], end)
+	if _, err := t.index.WriteAt(b[:], int64(items*indexEntrySize)); err != nil {
+		return err
+	}
+	t.size = end
+	atomic.StoreUint64(&t.items, items+1)
+	return nil
+}
+
+// truncate discards all the items but the first n items
+func (t *freezerTable) truncate(n uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.index == nil {
+		return errClosed
+	}
+	if n >= atomic.LoadUint64(&t.items) {
+		return nil
+	}
+	var end uint64
+	if n > 0 {
+		var err error
+		if end, err = t.readOffset(n - 1); err != nil {
+			return err
+		}
+	}
+	if err := t.index.Truncate(int64(n * indexEntrySize)); err != nil {
+		return err
+	}
+	if err := t.data.Truncate(int64(end)); err != nil {
+		return err
+	}
+	atomic.StoreUint64(&t.items, n)
+	t.size = end
+	return nil
+}
+
+// sizeOnDisk returns the total size of the data file and the index file
+func (t *freezerTable) sizeOnDisk() (uint64, error) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.index == nil {
+		return 0, errClosed
+	}
+	return t.size + atomic.LoadUint64(&t.items)*indexEntrySize, nil
+}
+
+// Sync flushes the data file and the index file to disk. The data file is synced
+// first, so that the index never points to the data not on disk.
+func (t *freezerTable) Sync() error {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if t.index == nil {
+		return errClosed
+	}
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+	return t.index.Sync()
+}
+
+// Close closes the files of the table
+func (t *freezerTable) Close() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.index == nil {
+		return nil
+	}
+	var errs []error
+	if err := t.index.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := t.data.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	t.index, t.data = nil, nil
+	if errs != nil {
+		return errors.Errorf("%v", errs)
+	}
+	return nil
+}
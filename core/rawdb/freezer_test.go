@@ -0,0 +1,208 @@
+package rawdb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func TestFreezerTable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer-table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	table, err := newFreezerTable(dir, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := [][]byte{[]byte("item0"), {}, []byte("item2"), []byte("item3")}
+	for i, item := range items {
+		if err := table.append(uint64(i), item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := table.append(10, []byte("item10")); err != errOutOrderInsertion {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := table.truncate(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash in the middle of appending an item
+	data, err := os.OpenFile(dir+"/test.dat", os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := data.Write([]byte("incomplete")); err != nil {
+		t.Fatal(err)
+	}
+	data.Close()
+
+	if table, err = newFreezerTable(dir, "test"); err != nil {
+		t.Fatal(err)
+	}
+	defer table.Close()
+	for i, item := range items[:3] {
+		got, err := table.retrieve(uint64(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, item) {
+			t.Errorf("unexpected item %v: %x / %x", i, got, item)
+		}
+	}
+	if _, err := table.retrieve(3); err != errOutOfBounds {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := table.append(3, items[3]); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := table.retrieve(3); !bytes.Equal(got, items[3]) {
+		t.Errorf("unexpected item 3: %x", got)
+	}
+}
+
+func TestFreezer_freezeBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	const depth = 2
+	kvdb := memorydb.New()
+	blocks := writeTestChain(t, kvdb, 5)
+	f, err := newFreezer(dir, depth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := &freezerdb{KeyValueStore: kvdb, freezer: f}
+	defer db.Close()
+
+	if frozen, err := f.freezeBatch(kvdb); err != nil || frozen != 3 {
+		t.Fatalf("unexpected frozen blocks %v, %v", frozen, err)
+	}
+	if frozen, err := f.freezeBatch(kvdb); err != nil || frozen != 0 {
+		t.Fatalf("unexpected frozen blocks %v, %v", frozen, err)
+	}
+	if frozen, _ := db.Ancients(); frozen != 3 {
+		t.Errorf("unexpected ancients %v", frozen)
+	}
+	for _, b := range blocks {
+		num, hash := b.NumberU64(), b.Hash()
+		// The frozen blocks are deleted from the key-value store except the genesis
+		if ok, _ := kvdb.Has(headerKey(num, hash)); ok != (num == 0 || num >= 3) {
+			t.Errorf("block %v: unexpected header in key-value store", num)
+		}
+		if got := ReadCanonicalHash(db, num); got != hash {
+			t.Errorf("block %v: unexpected canonical hash %v", num, got.Hex())
+		}
+		if !HasHeader(db, hash, num) || !HasBody(db, hash, num) {
+			t.Errorf("block %v: block not found", num)
+		}
+		if got := ReadBlock(db, hash, num); got == nil || got.Hash() != hash {
+			t.Errorf("block %v: unexpected block", num)
+		}
+		if got := ReadReceipts(db, hash, num); len(got) != 1 || got[0].CumulativeGasUsed != num {
+			t.Errorf("block %v: unexpected receipts %v", num, got)
+		}
+		if got := ReadTd(db, hash, num); got == nil || got.Uint64() != num {
+			t.Errorf("block %v: unexpected td %v", num, got)
+		}
+		if sig, err := ReadBlockCommitSig(db, num); err != nil || !bytes.Equal(sig, testCommitSig(num)) {
+			t.Errorf("block %v: unexpected commit sig %x, %v", num, sig, err)
+		}
+		if !HasBlockCommitSig(db, num) {
+			t.Errorf("block %v: commit sig not found", num)
+		}
+		// Frozen blocks are not found with a wrong hash
+		if HasHeader(db, common.Hash{}, num) || ReadBodyRLP(db, common.Hash{}, num) != nil {
+			t.Errorf("block %v: unexpected block with wrong hash", num)
+		}
+	}
+}
+
+func TestNewDatabaseWithFreezer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kvdb := memorydb.New()
+	writeTestChain(t, kvdb, 5)
+	f, err := newFreezer(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.freezeBatch(kvdb); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// The freezer of the chain
+	db, err := NewDatabaseWithFreezer(kvdb, dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.(*freezerdb).freezer.Close()
+
+	// The blocks are already moved into another freezer
+	if _, err := NewDatabaseWithFreezer(kvdb, dir+"-other", 2); err == nil {
+		t.Error("expect error for wrong freezer")
+	}
+	defer os.RemoveAll(dir + "-other")
+
+	// The freezer of another chain
+	if _, err := NewDatabaseWithFreezer(memorydb.New(), dir, 2); err == nil {
+		t.Error("expect error for freezer of another chain")
+	}
+}
+
+// writeTestChain writes the canonical chain of n blocks with the receipts, td
+// and commit signatures, and returns the blocks.
+func writeTestChain(t *testing.T, db DatabaseWriter, n int) []*types.Block {
+	var blocks []*types.Block
+	for i := 0; i < n; i++ {
+		header := blockfactory.NewTestHeader().With().Number(big.NewInt(int64(i))).Header()
+		b := types.NewBlockWithHeader(header)
+		hash, num := b.Hash(), b.NumberU64()
+		if err := WriteBlock(db, b); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteCanonicalHash(db, hash, num); err != nil {
+			t.Fatal(err)
+		}
+		receipts := types.Receipts{&types.Receipt{CumulativeGasUsed: num, Logs: []*types.Log{}}}
+		if err := WriteReceipts(db, hash, num, receipts); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteTd(db, hash, num, new(big.Int).SetUint64(num)); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteBlockCommitSig(db, num, testCommitSig(num)); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteHeadBlockHash(db, hash); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+func testCommitSig(num uint64) []byte {
+	return bytes.Repeat([]byte{byte(num + 1)}, 100)
+}
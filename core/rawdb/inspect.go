@@ -0,0 +1,178 @@
+package rawdb
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+const (
+	// UnaccountedCategory is the category of the entries not matched by any category
+	UnaccountedCategory = "Unaccounted"
+
+	// inspectLogInterval is the interval of the progress logs of the inspection
+	inspectLogInterval = 8 * time.Second
+)
+
+// DatabaseStat is the number of the entries of a category in the database, and the
+// total size in bytes of their keys and values.
+type DatabaseStat struct {
+	Category string `json:"category"`
+	Count    uint64 `json:"count"`
+	Size     uint64 `json:"size"`
+}
+
+// KeyCategory is a category of the database entries, matched by the key and value
+type KeyCategory struct {
+	Name  string
+	Match func(key, value []byte) bool
+}
+
+// PrefixCategory returns the category of the keys with prefix. The key length must
+// also be keyLen if it is not 0.
+func PrefixCategory(name string, prefix []byte, keyLen int) KeyCategory {
+	return KeyCategory{
+		Name: name,
+		Match: func(key, _ []byte) bool {
+			return (keyLen == 0 || len(key) == keyLen) && bytes.HasPrefix(key, prefix)
+		},
+	}
+}
+
+// keysCategory returns the category of the exact keys
+func keysCategory(name string, keys ...[]byte) KeyCategory {
+	return KeyCategory{
+		Name: name,
+		Match: func(key, _ []byte) bool {
+			for _, k := range keys {
+				if bytes.Equal(key, k) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// isTrieNode returns whether the value of the hash key is a trie node, which is
+// always a single RLP list. The contract code has the hash key as well.
+func isTrieNode(key, value []byte) bool {
+	if len(key) != common.HashLength {
+		return false
+	}
+	kind, _, rest, err := rlp.Split(value)
+	return err == nil && kind == rlp.List && len(rest) == 0
+}
+
+// chainDBCategories are the categories of the entries of the chain database. The
+// hash keys of the state are matched first, since no other key is of the same
+// length but they might start with any of the prefixes.
+var chainDBCategories = []KeyCategory{
+	{Name: "State trie nodes", Match: isTrieNode},
+	PrefixCategory("Contract code", nil, common.HashLength),
+	PrefixCategory("Headers", headerPrefix, len(headerPrefix)+8+common.HashLength),
+	PrefixCategory("Total difficulties", headerPrefix, len(headerPrefix)+8+common.HashLength+len(headerTDSuffix)),
+	PrefixCategory("Canonical hashes", headerPrefix, len(headerPrefix)+8+len(headerHashSuffix)),
+	PrefixCategory("Header numbers", headerNumberPrefix, len(headerNumberPrefix)+common.HashLength),
+	PrefixCategory("Bodies", blockBodyPrefix, len(blockBodyPrefix)+8+common.HashLength),
+	PrefixCategory("Receipts", blockReceiptsPrefix, len(blockReceiptsPrefix)+8+common.HashLength),
+	PrefixCategory("Tx lookups", txLookupPrefix, len(txLookupPrefix)+common.HashLength),
+	PrefixCategory("Cx lookups", cxLookupPrefix, len(cxLookupPrefix)+common.HashLength),
+	PrefixCategory("Cx receipts spent", cxReceiptSpentPrefix, len(cxReceiptSpentPrefix)+4+8),
+	PrefixCategory("Cx receipts", cxReceiptPrefix, len(cxReceiptPrefix)+4+8+common.HashLength),
+	PrefixCategory("Bloom bits", bloomBitsPrefix, len(bloomBitsPrefix)+2+8+common.HashLength),
+	PrefixCategory("Bloom bits index", BloomBitsIndexPrefix, 0),
+	PrefixCategory("Commit signatures", blockCommitSigPrefix, len(blockCommitSigPrefix)+8),
+	PrefixCategory("Preimages", preimagePrefix, len(preimagePrefix)+common.HashLength),
+	PrefixCategory("Shard states", shardStatePrefix, 0),
+	PrefixCategory("Crosslinks", crosslinkPrefix, 0),
+	PrefixCategory("Delegator validator lists", delegatorValidatorListPrefix, len(delegatorValidatorListPrefix)+common.AddressLength),
+	PrefixCategory("Validator snapshots", validatorSnapshotPrefix, 0),
+	PrefixCategory("Validator stats", validatorStatsPrefix, len(validatorStatsPrefix)+common.AddressLength),
+	PrefixCategory("Epoch block numbers", epochBlockNumberPrefix, 0),
+	PrefixCategory("Epoch VRF block numbers", epochVrfBlockNumbersPrefix, 0),
+	PrefixCategory("Epoch VDF block numbers", epochVdfBlockNumberPrefix, 0),
+	PrefixCategory("Block rewards", currentRewardGivenOutPrefix, len(currentRewardGivenOutPrefix)+8),
+	PrefixCategory("Chain configs", configPrefix, len(configPrefix)+common.HashLength),
+	PrefixCategory("Block traces", blockTracesPrefix, len(blockTracesPrefix)+8),
+	PrefixCategory("Trace from index", traceFromPrefix, len(traceFromPrefix)+common.AddressLength+8+4),
+	PrefixCategory("Trace to index", traceToPrefix, len(traceToPrefix)+common.AddressLength+8+4),
+	keysCategory("Metadata", databaseVerisionKey, headHeaderKey, headBlockKey, headFastBlockKey,
+		lastCommitsKey, pendingCrosslinkKey, pendingSlashingKey, validatorListKey,
+		traceIndexTailKey, traceIndexHeadKey),
+}
+
+// InspectDatabase iterates the key-value store of the chain database, and returns
+// the storage usage by the categories of the entries.
+func InspectDatabase(db ethdb.Iteratee) ([]DatabaseStat, error) {
+	it := db.NewIterator()
+	defer it.Release()
+	return InspectIterator(it, chainDBCategories)
+}
+
+// InspectFreezer returns the storage usage of the tables of the freezer in dir.
+// The freezing is not started, so the chain database is not modified.
+func InspectFreezer(dir string) ([]DatabaseStat, error) {
+	f, err := newFreezer(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	frozen, _ := f.Ancients()
+	var stats []DatabaseStat
+	for _, table := range freezerTables {
+		size, err := f.AncientSize(table)
+		if err != nil {
+			return nil, err
+		}
+		stats = append(stats, DatabaseStat{
+			Category: "Ancient " + table,
+			Count:    frozen,
+			Size:     size,
+		})
+	}
+	return stats, nil
+}
+
+// InspectIterator counts the entries of the iterator by the categories, and returns
+// the stats of the categories in order followed by UnaccountedCategory. Each entry
+// is counted in the first category matched.
+func InspectIterator(it ethdb.Iterator, categories []KeyCategory) ([]DatabaseStat, error) {
+	stats := make([]DatabaseStat, len(categories)+1)
+	for i, c := range categories {
+		stats[i].Category = c.Name
+	}
+	stats[len(categories)].Category = UnaccountedCategory
+
+	var (
+		count   uint64
+		lastLog = time.Now()
+	)
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		i := 0
+		for ; i < len(categories); i++ {
+			if categories[i].Match(key, value) {
+				break
+			}
+		}
+		stats[i].Count++
+		stats[i].Size += uint64(len(key) + len(value))
+
+		count++
+		if time.Since(lastLog) > inspectLogInterval {
+			utils.Logger().Info().Uint64("count", count).Str("key", common.Bytes2Hex(key)).
+				Msg("Inspecting database")
+			lastLog = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
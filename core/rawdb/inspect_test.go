@@ -0,0 +1,83 @@
+package rawdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestInspectDatabase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "freezer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kvdb := memorydb.New()
+	writeTestChain(t, kvdb, 5)
+	f, err := newFreezer(dir, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.freezeBatch(kvdb); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	node, _ := rlp.EncodeToBytes([][]byte{[]byte("key"), []byte("value")})
+	code := []byte{0x60, 0x80, 0x60, 0x40}
+	entries := [][2][]byte{
+		{crypto.Keccak256(node), node},
+		{crypto.Keccak256(code), code},
+		{[]byte("unknown"), []byte("value")},
+	}
+	for _, e := range entries {
+		if err := kvdb.Put(e[0], e[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats, err := InspectDatabase(kvdb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ancientStats, err := InspectFreezer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats = append(stats, ancientStats...)
+	counts := make(map[string]uint64)
+	for _, s := range stats {
+		counts[s.Category] = s.Count
+		if s.Count == 0 && s.Size != 0 {
+			t.Errorf("%v: unexpected size %v of no entries", s.Category, s.Size)
+		}
+	}
+	// The genesis and the recent 2 blocks are in the key-value store
+	expected := map[string]uint64{
+		"State trie nodes":   1,
+		"Contract code":      1,
+		"Headers":            3,
+		"Total difficulties": 3,
+		"Canonical hashes":   3,
+		"Header numbers":     5,
+		"Bodies":             3,
+		"Receipts":           3,
+		"Commit signatures":  3,
+		"Metadata":           1,
+		"Ancient headers":    3,
+		"Ancient sigs":       3,
+		UnaccountedCategory:  1,
+	}
+	for category, count := range expected {
+		if counts[category] != count {
+			t.Errorf("%v: unexpected count %v / %v", category, counts[category], count)
+		}
+	}
+	if len(stats) != len(chainDBCategories)+1+len(freezerTables) {
+		t.Errorf("unexpected number of categories %v", len(stats))
+	}
+}
@@ -74,6 +74,17 @@ var (
 	currentRewardGivenOutPrefix = []byte("blk-rwd-")
 )
 
+var (
+	// traceIndexTailKey and traceIndexHeadKey track the first and the last block
+	// of the trace index
+	traceIndexTailKey = []byte("TraceIndexTail")
+	traceIndexHeadKey = []byte("TraceIndexHead")
+	blockTracesPrefix = []byte("tb") // blockTracesPrefix + num (uint64 big endian) -> block call traces
+	traceHashPrefix   = []byte("th") // traceHashPrefix + num (uint64 big endian) -> hash of the indexed block
+	traceFromPrefix   = []byte("tf") // traceFromPrefix + address + num (uint64 big endian) + index (uint32 big endian) -> nil
+	traceToPrefix     = []byte("tt") // traceToPrefix + address + num (uint64 big endian) + index (uint32 big endian) -> nil
+)
+
 // TxLookupEntry is a positional metadata to help looking up the data content of
 // a transaction or receipt given only its hash.
 type TxLookupEntry struct {
@@ -124,6 +135,22 @@ func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
 }
 
+// blockTracesKey = blockTracesPrefix + num (uint64 big endian)
+func blockTracesKey(number uint64) []byte {
+	return append(blockTracesPrefix, encodeBlockNumber(number)...)
+}
+
+// traceHashKey = traceHashPrefix + num (uint64 big endian)
+func traceHashKey(number uint64) []byte {
+	return append(traceHashPrefix, encodeBlockNumber(number)...)
+}
+
+// traceAddressKey = prefix + address + num (uint64 big endian) + index (uint32 big endian)
+func traceAddressKey(prefix []byte, addr common.Address, number uint64, index uint32) []byte {
+	key := append(append(prefix, addr.Bytes()...), encodeBlockNumber(number)...)
+	return append(key, byte(index>>24), byte(index>>16), byte(index>>8), byte(index))
+}
+
 // cxLookupKey = cxLookupPrefix + hash
 func cxLookupKey(hash common.Hash) []byte {
 	return append(cxLookupPrefix, hash.Bytes()...)
@@ -0,0 +1,263 @@
+package pruner
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+	bloomfilter "github.com/steakknife/bloomfilter"
+)
+
+const (
+	// bloomFilterSuffix is the suffix of the state bloom file next to the chain db.
+	// The file exists from the bloom filter is committed until the pruning is done,
+	// so that an interrupted pruning can be resumed with the same bloom filter.
+	bloomFilterSuffix = ".statebloom.bf.gz"
+
+	// bloomFilterHashes is the number of hash functions of the bloom filter
+	bloomFilterHashes = 4
+
+	// progressLogInterval is the interval to log the progress
+	progressLogInterval = 8 * time.Second
+)
+
+var (
+	emptyRoot     = common.HexToHash("56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421")
+	emptyCodeHash = crypto.Keccak256Hash(nil)
+)
+
+// BloomFilterPath returns the path of the state bloom file of the chain db at dbDir
+func BloomFilterPath(dbDir string) string {
+	return dbDir + bloomFilterSuffix
+}
+
+// IsInterrupted returns whether the pruning of the chain db at dbDir is started
+// but not finished. The chain db shall not be used until the pruning is resumed
+// and finished, since the state written after the bloom filter is committed is
+// not protected from deletion.
+func IsInterrupted(dbDir string) bool {
+	_, err := os.Stat(BloomFilterPath(dbDir))
+	return err == nil
+}
+
+// Pruner deletes the trie nodes and the contract code in the chain db which are
+// not reachable from the state roots to keep.
+//
+// The reachable trie nodes and code are recorded in a bloom filter, so a small
+// fraction of the stale entries might be kept due to false positives. Only the
+// entries keyed by the hash of the value are deleted, which are trie nodes and
+// contract code, so the other data of the chain db is never touched.
+type Pruner struct {
+	db        ethdb.Database
+	dbDir     string
+	roots     []common.Hash
+	bloomSize uint64 // size of the bloom filter in megabytes
+}
+
+// NewPruner creates a pruner of the chain db at dbDir keeping the state at roots.
+func NewPruner(db ethdb.Database, dbDir string, roots []common.Hash, bloomSize uint64) (*Pruner, error) {
+	if len(roots) == 0 {
+		return nil, errors.New("no state root to keep")
+	}
+	if bloomSize == 0 {
+		return nil, errors.New("zero bloom filter size")
+	}
+	return &Pruner{
+		db:        db,
+		dbDir:     dbDir,
+		roots:     roots,
+		bloomSize: bloomSize,
+	}, nil
+}
+
+// Prune builds the bloom filter of the state to keep, and deletes the other trie
+// nodes and code. If the previous pruning is interrupted, the committed bloom
+// filter is reused and the deletion is resumed.
+func (p *Pruner) Prune() error {
+	var (
+		bloom *bloomfilter.Filter
+		err   error
+	)
+	if IsInterrupted(p.dbDir) {
+		utils.Logger().Info().Str("path", BloomFilterPath(p.dbDir)).
+			Msg("[Pruner] resuming the interrupted pruning")
+		if bloom, _, err = bloomfilter.ReadFile(BloomFilterPath(p.dbDir)); err != nil {
+			return errors.Wrap(err, "read state bloom")
+		}
+	} else {
+		for _, root := range p.roots {
+			if !p.hasNode(root) {
+				return fmt.Errorf("state %v not found in db", root.Hex())
+			}
+		}
+		if bloom, err = p.buildBloom(); err != nil {
+			return err
+		}
+		if err := commitBloom(bloom, BloomFilterPath(p.dbDir)); err != nil {
+			return err
+		}
+	}
+
+	if err := p.deleteStale(bloom); err != nil {
+		return err
+	}
+	utils.Logger().Info().Msg("[Pruner] compacting the database")
+	if err := p.db.Compact(nil, nil); err != nil {
+		return errors.Wrap(err, "compact db")
+	}
+	return os.Remove(BloomFilterPath(p.dbDir))
+}
+
+func (p *Pruner) hasNode(hash common.Hash) bool {
+	ok, _ := p.db.Has(hash[:])
+	return ok
+}
+
+// buildBloom iterates the account tries and the storage tries of the state at
+// roots, and adds the hashes of the trie nodes and the code to the bloom filter.
+func (p *Pruner) buildBloom() (*bloomfilter.Filter, error) {
+	bloom, err := bloomfilter.New(p.bloomSize*1024*1024*8, bloomFilterHashes)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		sdb     = state.NewDatabase(p.db)
+		nodes   uint64
+		lastLog = time.Now()
+	)
+	add := func(hash common.Hash) {
+		bloom.Add(stateBloomHasher(hash[:]))
+		nodes++
+		if time.Since(lastLog) > progressLogInterval {
+			utils.Logger().Info().Uint64("nodes", nodes).Msg("[Pruner] building state bloom")
+			lastLog = time.Now()
+		}
+	}
+	iterate := func(it trie.NodeIterator, onLeaf func(blob []byte) error) error {
+		for it.Next(true) {
+			if hash := it.Hash(); hash != (common.Hash{}) {
+				add(hash)
+			}
+			if it.Leaf() && onLeaf != nil {
+				if err := onLeaf(it.LeafBlob()); err != nil {
+					return err
+				}
+			}
+		}
+		return it.Error()
+	}
+
+	for _, root := range p.roots {
+		tr, err := sdb.OpenTrie(root)
+		if err != nil {
+			return nil, err
+		}
+		err = iterate(tr.NodeIterator(nil), func(blob []byte) error {
+			var acc state.Account
+			if err := rlp.DecodeBytes(blob, &acc); err != nil {
+				return err
+			}
+			if codeHash := common.BytesToHash(acc.CodeHash); codeHash != emptyCodeHash {
+				add(codeHash)
+			}
+			if acc.Root == emptyRoot {
+				return nil
+			}
+			storageTr, err := sdb.OpenStorageTrie(common.Hash{}, acc.Root)
+			if err != nil {
+				return err
+			}
+			return iterate(storageTr.NodeIterator(nil), nil)
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "iterate state %v", root.Hex())
+		}
+	}
+	utils.Logger().Info().Uint64("nodes", nodes).Msg("[Pruner] state bloom built")
+	return bloom, nil
+}
+
+// deleteStale deletes the trie nodes and code not in the bloom filter
+func (p *Pruner) deleteStale(bloom *bloomfilter.Filter) error {
+	var (
+		it      = p.db.NewIterator()
+		batch   = p.db.NewBatch()
+		checked uint64
+		deleted uint64
+		size    common.StorageSize
+		lastLog = time.Now()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key, val := it.Key(), it.Value()
+		if len(key) != common.HashLength {
+			continue
+		}
+		checked++
+		if bloom.Contains(stateBloomHasher(key)) || !bytes.Equal(crypto.Keccak256(val), key) {
+			continue
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+		deleted++
+		size += common.StorageSize(len(key) + len(val))
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		if time.Since(lastLog) > progressLogInterval {
+			// The hash keys are evenly distributed, so the first bytes of the key
+			// tell the progress.
+			progress := float64(binary.BigEndian.Uint64(key[:8])) / math.MaxUint64 * 100
+			utils.Logger().Info().Uint64("checked", checked).Uint64("deleted", deleted).
+				Str("size", size.String()).Str("progress", fmt.Sprintf("%.2f%%", progress)).
+				Msg("[Pruner] deleting stale state")
+			lastLog = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	utils.Logger().Info().Uint64("checked", checked).Uint64("deleted", deleted).
+		Str("size", size.String()).Msg("[Pruner] stale state deleted")
+	return nil
+}
+
+// commitBloom writes the bloom filter to path atomically
+func commitBloom(bloom *bloomfilter.Filter, path string) error {
+	tmp := path + ".tmp"
+	if _, err := bloom.WriteFile(tmp); err != nil {
+		return errors.Wrap(err, "write state bloom")
+	}
+	return os.Rename(tmp, path)
+}
+
+// stateBloomHasher is a wrapper around a byte blob to satisfy the interface of
+// the bloom filter. The keys are hashes, so the first bytes are used directly.
+type stateBloomHasher []byte
+
+func (f stateBloomHasher) Write(p []byte) (n int, err error) { panic("not implemented") }
+func (f stateBloomHasher) Sum(b []byte) []byte               { panic("not implemented") }
+func (f stateBloomHasher) Reset()                            { panic("not implemented") }
+func (f stateBloomHasher) BlockSize() int                    { panic("not implemented") }
+func (f stateBloomHasher) Size() int                         { return 8 }
+func (f stateBloomHasher) Sum64() uint64                     { return binary.BigEndian.Uint64(f) }
@@ -0,0 +1,159 @@
+package pruner
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/core/state"
+)
+
+var (
+	testAddr1 = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testAddr2 = common.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+func TestPruner_Prune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state-pruner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dbDir := filepath.Join(dir, "harmony_db_0")
+
+	db := rawdb.NewMemoryDatabase()
+	otherKey, otherVal := []byte("LastBlock"), []byte("value")
+	if err := db.Put(otherKey, otherVal); err != nil {
+		t.Fatal(err)
+	}
+	staleRoot := commitTestState(t, db, common.Hash{}, 1)
+	root := commitTestState(t, db, staleRoot, 2)
+	staleCnt := countHashKeys(t, db)
+
+	p, err := NewPruner(db, dbDir, []common.Hash{root}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	if IsInterrupted(dbDir) {
+		t.Fatal("bloom filter not removed")
+	}
+
+	if cnt := countHashKeys(t, db); cnt >= staleCnt {
+		t.Errorf("stale state not deleted: %v / %v", cnt, staleCnt)
+	}
+	if ok, _ := db.Has(staleRoot[:]); ok {
+		t.Errorf("stale root not deleted")
+	}
+	if val, err := db.Get(otherKey); err != nil || string(val) != string(otherVal) {
+		t.Errorf("unexpected other value %v, %v", val, err)
+	}
+	// The kept state is complete
+	sdb, err := state.New(root, state.NewDatabase(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := sdb.GetBalance(testAddr1); balance.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("unexpected balance %v", balance)
+	}
+	if code := sdb.GetCode(testAddr2); string(code) != "code2" {
+		t.Errorf("unexpected code %v", code)
+	}
+	if val := sdb.GetState(testAddr2, common.Hash{0x1}); val != (common.Hash{0x2}) {
+		t.Errorf("unexpected storage %v", val.Hex())
+	}
+}
+
+func TestPruner_resume(t *testing.T) {
+	dir, err := ioutil.TempDir("", "state-pruner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dbDir := filepath.Join(dir, "harmony_db_0")
+
+	db := rawdb.NewMemoryDatabase()
+	staleRoot := commitTestState(t, db, common.Hash{}, 1)
+	root := commitTestState(t, db, staleRoot, 2)
+
+	// Interrupted after the bloom filter is committed
+	p, err := NewPruner(db, dbDir, []common.Hash{root}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bloom, err := p.buildBloom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := commitBloom(bloom, BloomFilterPath(dbDir)); err != nil {
+		t.Fatal(err)
+	}
+	if !IsInterrupted(dbDir) {
+		t.Fatal("pruning not interrupted")
+	}
+
+	// The roots are ignored on resumption
+	p, err = NewPruner(db, dbDir, []common.Hash{staleRoot}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := db.Has(staleRoot[:]); ok {
+		t.Errorf("stale root not deleted")
+	}
+	if ok, _ := db.Has(root[:]); !ok {
+		t.Errorf("root deleted")
+	}
+}
+
+func TestNewPruner(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	if _, err := NewPruner(db, "", nil, 1); err == nil {
+		t.Error("expect error for no root")
+	}
+	if _, err := NewPruner(db, "", []common.Hash{{}}, 0); err == nil {
+		t.Error("expect error for zero bloom size")
+	}
+}
+
+// commitTestState writes the state with the values of i on top of the parent
+// state to db, and returns the state root.
+func commitTestState(t *testing.T, db ethdb.Database, parent common.Hash, i int64) common.Hash {
+	sdb, err := state.New(parent, state.NewDatabase(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sdb.SetBalance(testAddr1, big.NewInt(i))
+	sdb.SetCode(testAddr2, []byte("code"+big.NewInt(i).String()))
+	sdb.SetState(testAddr2, common.Hash{0x1}, common.Hash{byte(i)})
+	root, err := sdb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.Database().TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func countHashKeys(t *testing.T, db ethdb.Database) int {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var cnt int
+	for it.Next() {
+		if len(it.Key()) == common.HashLength {
+			cnt++
+		}
+	}
+	return cnt
+}
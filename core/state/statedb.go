@@ -97,6 +97,9 @@ type DB struct {
 
 	preimages map[common.Hash][]byte
 
+	// Per-transaction access list
+	accessList *accessList
+
 	// Journal of state modifications. This is the backbone of
 	// Snapshot and RevertToSnapshot.
 	journal        *journal
@@ -130,6 +133,7 @@ func New(root common.Hash, db Database) (*DB, error) {
 		logs:                make(map[common.Hash][]*types.Log),
 		preimages:           make(map[common.Hash][]byte),
 		journal:             newJournal(),
+		accessList:          newAccessList(),
 	}, nil
 }
 
@@ -162,6 +166,7 @@ func (db *DB) Reset(root common.Hash) error {
 	db.logs = make(map[common.Hash][]*types.Log)
 	db.logSize = 0
 	db.preimages = make(map[common.Hash][]byte)
+	db.accessList = newAccessList()
 	db.clearJournalAndRefund()
 	return nil
 }
@@ -418,6 +423,15 @@ func (db *DB) SetState(addr common.Address, key, value common.Hash) {
 	}
 }
 
+// SetStorage replaces the entire storage for the specified account with given
+// storage. This function should only be used for debugging.
+func (db *DB) SetStorage(addr common.Address, storage map[common.Hash]common.Hash) {
+	stateObject := db.GetOrNewStateObject(addr)
+	if stateObject != nil {
+		stateObject.SetStorage(storage)
+	}
+}
+
 // Suicide marks the given account as suicided.
 // This clears the account balance.
 //
@@ -649,6 +663,9 @@ func (db *DB) Copy() *DB {
 	for hash, preimage := range db.preimages {
 		state.preimages[hash] = preimage
 	}
+	// The access list is only valid within a transaction, but it is copied in
+	// case the copy is taken in the middle of a transaction.
+	state.accessList = db.accessList.Copy()
 	return state
 }
 
@@ -745,6 +762,66 @@ func (db *DB) Prepare(thash, bhash common.Hash, ti int) {
 	db.thash = thash
 	db.bhash = bhash
 	db.txIndex = ti
+	db.accessList = newAccessList()
+}
+
+// PrepareAccessList handles the preparatory steps for executing a state
+// transition with regards to EIP-2929 and EIP-2930. It adds the sender, the
+// destination (if any), the precompiles and the entries of the optional
+// transaction access list to the access list.
+//
+// This method should only be called if EIP-2930 is applicable to the
+// current epoch.
+func (db *DB) PrepareAccessList(sender common.Address, dst *common.Address, precompiles []common.Address, list types.AccessList) {
+	db.AddAddressToAccessList(sender)
+	if dst != nil {
+		db.AddAddressToAccessList(*dst)
+		// If it's a create-tx, the destination will be added inside evm.create
+	}
+	for _, addr := range precompiles {
+		db.AddAddressToAccessList(addr)
+	}
+	for _, el := range list {
+		db.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			db.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
+// AddAddressToAccessList adds the given address to the access list
+func (db *DB) AddAddressToAccessList(addr common.Address) {
+	if db.accessList.AddAddress(addr) {
+		db.journal.append(accessListAddAccountChange{&addr})
+	}
+}
+
+// AddSlotToAccessList adds the given (address, slot)-tuple to the access list
+func (db *DB) AddSlotToAccessList(addr common.Address, slot common.Hash) {
+	addrMod, slotMod := db.accessList.AddSlot(addr, slot)
+	if addrMod {
+		// In practice, this should not happen, since there is no way to enter the
+		// scope of 'address' without having the 'address' become already added
+		// to the access list (via call-variant, create, etc).
+		// Better safe than sorry, though
+		db.journal.append(accessListAddAccountChange{&addr})
+	}
+	if slotMod {
+		db.journal.append(accessListAddSlotChange{
+			address: &addr,
+			slot:    &slot,
+		})
+	}
+}
+
+// AddressInAccessList returns true if the given address is in the access list.
+func (db *DB) AddressInAccessList(addr common.Address) bool {
+	return db.accessList.ContainsAddress(addr)
+}
+
+// SlotInAccessList returns true if the given (address, slot)-tuple is in the access list.
+func (db *DB) SlotInAccessList(addr common.Address, slot common.Hash) (addressPresent bool, slotPresent bool) {
+	return db.accessList.Contains(addr, slot)
 }
 
 func (db *DB) clearJournalAndRefund() {
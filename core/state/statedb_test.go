@@ -916,6 +916,80 @@ func TestDeleteCreateRevert(t *testing.T) {
 	}
 }
 
+// TestSetStorage tests that the overridden storage replaces the original one.
+func TestSetStorage(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+
+	addr := toAddr([]byte("so"))
+	key1, key2 := common.HexToHash("0x01"), common.HexToHash("0x02")
+	state.SetState(addr, key1, common.HexToHash("0x11"))
+	state.SetState(addr, key2, common.HexToHash("0x22"))
+	root, _ := state.Commit(false)
+	state.Reset(root)
+
+	state.SetStorage(addr, map[common.Hash]common.Hash{key2: common.HexToHash("0x33")})
+	if value := state.GetState(addr, key1); value != (common.Hash{}) {
+		t.Errorf("original storage not replaced: %x", value)
+	}
+	if value := state.GetState(addr, key2); value != common.HexToHash("0x33") {
+		t.Errorf("unexpected overridden storage: %x", value)
+	}
+	state.SetState(addr, key1, common.HexToHash("0x44"))
+	state.Finalise(true)
+	if value := state.GetState(addr, key1); value != common.HexToHash("0x44") {
+		t.Errorf("unexpected storage after update: %x", value)
+	}
+}
+
+func TestStateDBAccessList(t *testing.T) {
+	state, _ := New(common.Hash{}, NewDatabase(rawdb.NewMemoryDatabase()))
+
+	var (
+		sender = common.Address{1}
+		dst    = common.Address{2}
+		addr   = common.Address{3}
+		slot   = common.Hash{1}
+	)
+	state.PrepareAccessList(sender, &dst, nil, types.AccessList{
+		{Address: addr, StorageKeys: []common.Hash{slot}},
+	})
+	for _, a := range []common.Address{sender, dst, addr} {
+		if !state.AddressInAccessList(a) {
+			t.Fatalf("address %x not in access list", a)
+		}
+	}
+	if _, ok := state.SlotInAccessList(addr, slot); !ok {
+		t.Fatalf("slot %x not in access list", slot)
+	}
+
+	// The additions after the snapshot are reverted
+	id := state.Snapshot()
+	newAddr, newSlot := common.Address{4}, common.Hash{2}
+	state.AddSlotToAccessList(newAddr, newSlot)
+	state.AddSlotToAccessList(addr, newSlot)
+	if !state.AddressInAccessList(newAddr) {
+		t.Fatalf("address %x not in access list", newAddr)
+	}
+	state.RevertToSnapshot(id)
+
+	if state.AddressInAccessList(newAddr) {
+		t.Errorf("reverted address %x in access list", newAddr)
+	}
+	if addrOk, slotOk := state.SlotInAccessList(addr, newSlot); !addrOk || slotOk {
+		t.Errorf("unexpected access list of %x: %v, %v", addr, addrOk, slotOk)
+	}
+	if _, ok := state.SlotInAccessList(addr, slot); !ok {
+		t.Errorf("slot %x not in access list after revert", slot)
+	}
+
+	// The copy is independent of the original
+	cpy := state.Copy()
+	cpy.AddAddressToAccessList(newAddr)
+	if state.AddressInAccessList(newAddr) {
+		t.Errorf("address %x added to copy is in original access list", newAddr)
+	}
+}
+
 func makeValidValidatorWrapper(addr common.Address) stk.ValidatorWrapper {
 	cr := stk.CommissionRates{
 		Rate:          numeric.ZeroDec(),
@@ -241,11 +241,11 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 		if !config.IsEthCompatible(header.Epoch()) {
 			return nil, nil, 0, errors.New("ethereum compatible transactions not supported at current epoch")
 		}
-		signer = types.NewEIP155Signer(config.EthCompatibleChainID)
+		signer = types.MakeEthSigner(config, header.Epoch())
 	} else {
 		signer = types.MakeSigner(config, header.Epoch())
 	}
-	msg, err := tx.AsMessage(signer)
+	msg, err := tx.AsMessage(signer, header.BaseFee())
 
 	// skip signer err for additiononly tx
 	if err != nil {
@@ -276,6 +276,7 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	// Create a new receipt for the transaction, storing the intermediate root and gas used by the tx
 	// based on the eip phase, we're passing whether the root touch-delete accounts.
 	receipt := types.NewReceipt(root, failedExe, *usedGas)
+	receipt.Type = tx.Type()
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
 	// if the transaction created a contract, store the creation address in the receipt.
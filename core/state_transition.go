@@ -88,6 +88,8 @@ type Message interface {
 	To() *common.Address
 
 	GasPrice() *big.Int
+	GasFeeCap() *big.Int
+	GasTipCap() *big.Int
 	Gas() uint64
 	Value() *big.Int
 
@@ -96,6 +98,7 @@ type Message interface {
 	Data() []byte
 	Type() types.TransactionType
 	BlockNum() *big.Int
+	AccessList() types.AccessList
 }
 
 // ExecutionResult is the return value from a transaction committed to the DB
@@ -132,8 +135,9 @@ func (result *ExecutionResult) Revert() []byte {
 	return common.CopyBytes(result.ReturnData)
 }
 
-// IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidatorCreation bool) (uint64, error) {
+// IntrinsicGas computes the 'intrinsic gas' for a message with the given data
+// and the EIP-2930 access list.
+func IntrinsicGas(data []byte, accessList types.AccessList, contractCreation, homestead, istanbul, isValidatorCreation bool) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if contractCreation && homestead {
@@ -168,6 +172,10 @@ func IntrinsicGas(data []byte, contractCreation, homestead, istanbul, isValidato
 		}
 		gas += z * params.TxDataZeroGas
 	}
+	if accessList != nil {
+		gas += uint64(len(accessList)) * params.TxAccessListAddressGas
+		gas += uint64(accessList.StorageKeys()) * params.TxAccessListStorageKeyGas
+	}
 	return gas, nil
 }
 
@@ -220,10 +228,16 @@ func (st *StateTransition) useGas(amount uint64) error {
 
 func (st *StateTransition) buyGas() error {
 	mgval := new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.gasPrice)
-	if have := st.state.GetBalance(st.msg.From()); have.Cmp(mgval) < 0 {
+	// The balance must cover the fee cap, even though only the effective gas
+	// price is charged
+	balanceCheck := mgval
+	if feeCap := st.msg.GasFeeCap(); feeCap != nil && st.evm.BaseFee != nil {
+		balanceCheck = new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), feeCap)
+	}
+	if have := st.state.GetBalance(st.msg.From()); have.Cmp(balanceCheck) < 0 {
 		return errors.Wrapf(
 			errInsufficientBalanceForGas,
-			"had: %s but need: %s", have.String(), mgval.String(),
+			"had: %s but need: %s", have.String(), balanceCheck.String(),
 		)
 	}
 	if err := st.gp.SubGas(st.msg.Gas()); err != nil {
@@ -247,6 +261,22 @@ func (st *StateTransition) preCheck() error {
 			return ErrNonceTooLow
 		}
 	}
+	// Make sure the fee caps are valid against the base fee of EIP-1559
+	if baseFee := st.evm.BaseFee; baseFee != nil {
+		feeCap, tipCap := st.msg.GasFeeCap(), st.msg.GasTipCap()
+		// The calls without any fee are allowed to skip the check
+		skipCheck := st.evm.Config().NoBaseFee && feeCap.Sign() == 0 && tipCap.Sign() == 0
+		if !skipCheck {
+			if feeCap.Cmp(tipCap) < 0 {
+				return errors.Wrapf(ErrTipAboveFeeCap, "address %v, maxPriorityFeePerGas: %s, maxFeePerGas: %s",
+					st.msg.From().Hex(), tipCap, feeCap)
+			}
+			if feeCap.Cmp(baseFee) < 0 {
+				return errors.Wrapf(ErrFeeCapTooLow, "address %v, maxFeePerGas: %s, baseFee: %s",
+					st.msg.From().Hex(), feeCap, baseFee)
+			}
+		}
+	}
 	return st.buyGas()
 }
 
@@ -264,7 +294,7 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	contractCreation := msg.To() == nil
 
 	// Pay intrinsic gas
-	gas, err := IntrinsicGas(st.data, contractCreation, homestead, istanbul, false)
+	gas, err := IntrinsicGas(st.data, msg.AccessList(), contractCreation, homestead, istanbul, false)
 	if err != nil {
 		return ExecutionResult{}, err
 	}
@@ -273,6 +303,9 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	}
 
 	evm := st.evm
+	if rules := evm.ChainConfig().Rules(evm.EpochNumber); rules.IsEIP2930 {
+		evm.StateDB.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
+	}
 
 	var ret []byte
 	// All VM errors are valid except for insufficient balance, therefore returned separately
@@ -297,9 +330,19 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	}
 	st.refundGas()
 
-	// Burn Txn Fees after staking epoch
+	// The base fee of EIP-1559 is burnt in all cases. Before the staking epoch the
+	// rest of the fee, which is the tip, is paid to the coinbase. After the staking
+	// epoch the whole txn fee is burnt, which already burns the base fee along with
+	// the tip, so nothing is paid to the coinbase.
 	if !st.evm.ChainConfig().IsStaking(st.evm.EpochNumber) {
-		txFee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), st.gasPrice)
+		price := st.gasPrice
+		if baseFee := st.evm.BaseFee; baseFee != nil {
+			price = new(big.Int).Sub(st.gasPrice, baseFee)
+			if price.Sign() < 0 {
+				price.SetUint64(0)
+			}
+		}
+		txFee := new(big.Int).Mul(new(big.Int).SetUint64(st.gasUsed()), price)
 		st.state.AddBalance(st.evm.Coinbase, txFee)
 	}
 
@@ -346,7 +389,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 	istanbul := st.evm.ChainConfig().IsIstanbul(st.evm.EpochNumber)
 
 	// Pay intrinsic gas
-	gas, err := IntrinsicGas(st.data, false, homestead, istanbul, msg.Type() == types.StakeCreateVal)
+	gas, err := IntrinsicGas(st.data, nil, false, homestead, istanbul, msg.Type() == types.StakeCreateVal)
 
 	if err != nil {
 		return 0, err
@@ -453,23 +496,47 @@ func (st *StateTransition) verifyAndApplyEditValidatorTx(
 }
 
 func (st *StateTransition) verifyAndApplyDelegateTx(delegate *staking.Delegate) error {
-	delegations, err := st.bc.ReadDelegationsByDelegator(delegate.DelegatorAddress)
+	return applyDelegate(
+		st.state, st.bc, st.evm.ChainConfig(), st.evm.EpochNumber, st.evm.BlockNumber, delegate,
+	)
+}
+
+func (st *StateTransition) verifyAndApplyUndelegateTx(
+	undelegate *staking.Undelegate,
+) error {
+	return applyUndelegate(st.state, st.evm.EpochNumber, undelegate)
+}
+
+func (st *StateTransition) verifyAndApplyCollectRewards(collectRewards *staking.CollectRewards) (*big.Int, error) {
+	return applyCollectRewards(st.state, st.bc, st.evm.BlockNumber, collectRewards)
+}
+
+// applyDelegate verifies the delegation and applies it to the state. It is shared
+// by the staking transactions and the staking precompile.
+func applyDelegate(
+	db vm.StateDB, bc ChainContext, config *params.ChainConfig,
+	epoch, blockNum *big.Int, delegate *staking.Delegate,
+) error {
+	if bc == nil {
+		return errors.New("[Delegate] No chain context provided")
+	}
+	delegations, err := bc.ReadDelegationsByDelegator(delegate.DelegatorAddress)
 	if err != nil {
 		return err
 	}
 	updatedValidatorWrappers, balanceToBeDeducted, fromLockedTokens, err := VerifyAndDelegateFromMsg(
-		st.state, st.evm.EpochNumber, delegate, delegations, st.evm.ChainConfig())
+		db, epoch, delegate, delegations, config)
 	if err != nil {
 		return err
 	}
 
 	for _, wrapper := range updatedValidatorWrappers {
-		if err := st.state.UpdateValidatorWrapper(wrapper.Address, wrapper); err != nil {
+		if err := db.UpdateValidatorWrapper(wrapper.Address, wrapper); err != nil {
 			return err
 		}
 	}
 
-	st.state.SubBalance(delegate.DelegatorAddress, balanceToBeDeducted)
+	db.SubBalance(delegate.DelegatorAddress, balanceToBeDeducted)
 
 	if len(fromLockedTokens) > 0 {
 		sortedKeys := []common.Address{}
@@ -492,54 +559,59 @@ func (st *StateTransition) verifyAndApplyDelegateTx(delegate *staking.Delegate)
 			// The data field format is:
 			// [first 20 bytes]: Validator address from which the locked token is used for redelegation.
 			// [rest of the bytes]: the bigInt serialized bytes for the token amount.
-			st.state.AddLog(&types.Log{
+			db.AddLog(&types.Log{
 				Address:     delegate.DelegatorAddress,
 				Topics:      []common.Hash{staking2.DelegateTopic},
 				Data:        encodedRedelegationData,
-				BlockNumber: st.evm.BlockNumber.Uint64(),
+				BlockNumber: blockNum.Uint64(),
 			})
 		}
 	}
 	return nil
 }
 
-func (st *StateTransition) verifyAndApplyUndelegateTx(
-	undelegate *staking.Undelegate,
-) error {
-	wrapper, err := VerifyAndUndelegateFromMsg(st.state, st.evm.EpochNumber, undelegate)
+// applyUndelegate verifies the undelegation and applies it to the state. It is
+// shared by the staking transactions and the staking precompile.
+func applyUndelegate(db vm.StateDB, epoch *big.Int, undelegate *staking.Undelegate) error {
+	wrapper, err := VerifyAndUndelegateFromMsg(db, epoch, undelegate)
 	if err != nil {
 		return err
 	}
-	return st.state.UpdateValidatorWrapper(wrapper.Address, wrapper)
+	return db.UpdateValidatorWrapper(wrapper.Address, wrapper)
 }
 
-func (st *StateTransition) verifyAndApplyCollectRewards(collectRewards *staking.CollectRewards) (*big.Int, error) {
-	if st.bc == nil {
+// applyCollectRewards verifies the reward collection and applies it to the
+// state, and returns the collected rewards. It is shared by the staking
+// transactions and the staking precompile.
+func applyCollectRewards(
+	db vm.StateDB, bc ChainContext, blockNum *big.Int, collectRewards *staking.CollectRewards,
+) (*big.Int, error) {
+	if bc == nil {
 		return stakingReward.None, errors.New("[CollectRewards] No chain context provided")
 	}
-	delegations, err := st.bc.ReadDelegationsByDelegator(collectRewards.DelegatorAddress)
+	delegations, err := bc.ReadDelegationsByDelegator(collectRewards.DelegatorAddress)
 	if err != nil {
 		return stakingReward.None, err
 	}
 	updatedValidatorWrappers, totalRewards, err := VerifyAndCollectRewardsFromDelegation(
-		st.state, delegations,
+		db, delegations,
 	)
 	if err != nil {
 		return stakingReward.None, err
 	}
 	for _, wrapper := range updatedValidatorWrappers {
-		if err := st.state.UpdateValidatorWrapper(wrapper.Address, wrapper); err != nil {
+		if err := db.UpdateValidatorWrapper(wrapper.Address, wrapper); err != nil {
 			return stakingReward.None, err
 		}
 	}
-	st.state.AddBalance(collectRewards.DelegatorAddress, totalRewards)
+	db.AddBalance(collectRewards.DelegatorAddress, totalRewards)
 
 	// Add log if everything is good
-	st.state.AddLog(&types.Log{
+	db.AddLog(&types.Log{
 		Address:     collectRewards.DelegatorAddress,
 		Topics:      []common.Hash{staking2.CollectRewardsTopic},
 		Data:        totalRewards.Bytes(),
-		BlockNumber: st.evm.BlockNumber.Uint64(),
+		BlockNumber: blockNum.Uint64(),
 	})
 
 	return totalRewards, nil
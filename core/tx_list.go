@@ -295,6 +295,13 @@ func (l *txList) Add(tx types.PoolTransaction, priceBump uint64) (bool, types.Po
 		if old.GasPrice().Cmp(tx.GasPrice()) >= 0 || threshold.Cmp(tx.GasPrice()) > 0 {
 			return false, nil
 		}
+		// The tip cap must be bumped as well, which is the gas price for the
+		// transactions other than the dynamic fee transactions
+		oldTip, newTip := poolGasTipCap(old), poolGasTipCap(tx)
+		tipThreshold := new(big.Int).Div(new(big.Int).Mul(oldTip, big.NewInt(100+int64(priceBump))), big.NewInt(100))
+		if oldTip.Cmp(newTip) >= 0 || tipThreshold.Cmp(newTip) > 0 {
+			return false, nil
+		}
 	}
 	// Otherwise overwrite the old transaction with the current one
 	cost, err := tx.Cost()
@@ -445,36 +452,63 @@ func (l *txList) Flatten() types.PoolTransactions {
 }
 
 // priceHeap is a heap.Interface implementation over transactions for retrieving
-// price-sorted transactions to discard when the pool fills up.
-type priceHeap []types.PoolTransaction
+// price-sorted transactions to discard when the pool fills up. The transactions
+// are sorted by the effective tip given the base fee, which is the gas price if
+// there is no base fee.
+type priceHeap struct {
+	baseFee *big.Int
+	list    []types.PoolTransaction
+}
 
-func (h priceHeap) Len() int      { return len(h) }
-func (h priceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *priceHeap) Len() int      { return len(h.list) }
+func (h *priceHeap) Swap(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] }
 
-func (h priceHeap) Less(i, j int) bool {
-	// Sort primarily by price, returning the cheaper one
-	switch h[i].GasPrice().Cmp(h[j].GasPrice()) {
+func (h *priceHeap) Less(i, j int) bool {
+	switch h.cmp(h.list[i], h.list[j]) {
 	case -1:
 		return true
 	case 1:
 		return false
 	}
 	// If the prices match, stabilize via nonces (high nonce is worse)
-	return h[i].Nonce() > h[j].Nonce()
+	return h.list[i].Nonce() > h.list[j].Nonce()
+}
+
+// cmp compares the effective tips of the transactions, and then the fee caps
+func (h *priceHeap) cmp(a, b types.PoolTransaction) int {
+	if c := poolTxTip(a, h.baseFee).Cmp(poolTxTip(b, h.baseFee)); c != 0 {
+		return c
+	}
+	return a.GasPrice().Cmp(b.GasPrice())
 }
 
 func (h *priceHeap) Push(x interface{}) {
-	*h = append(*h, x.(types.PoolTransaction))
+	h.list = append(h.list, x.(types.PoolTransaction))
 }
 
 func (h *priceHeap) Pop() interface{} {
-	old := *h
+	old := h.list
 	n := len(old)
 	x := old[n-1]
-	*h = old[0 : n-1]
+	h.list = old[0 : n-1]
 	return x
 }
 
+// poolTxTip returns the effective tip of the transaction given the base fee,
+// which is negative if the fee cap is below the base fee. A nil base fee means
+// the tip is the tip cap.
+func poolTxTip(tx types.PoolTransaction, baseFee *big.Int) *big.Int {
+	tipCap := poolGasTipCap(tx)
+	if baseFee == nil {
+		return tipCap
+	}
+	tip := new(big.Int).Sub(tx.GasPrice(), baseFee)
+	if tip.Cmp(tipCap) > 0 {
+		return tipCap
+	}
+	return tip
+}
+
 // txPricedList is a price-sorted heap to allow operating on transactions pool
 // contents in a price-incrementing way.
 type txPricedList struct {
@@ -502,15 +536,29 @@ func (l *txPricedList) Put(tx types.PoolTransaction) {
 func (l *txPricedList) Removed() {
 	// Bump the stale counter, but exit if still too low (< 25%)
 	l.stales++
-	if l.stales <= len(*l.items)/4 {
+	if l.stales <= l.items.Len()/4 {
 		return
 	}
 	// Seems we've reached a critical number of stale transactions, reheap
-	reheap := make(priceHeap, 0, l.all.Count())
+	l.reheap()
+}
+
+// SetBaseFee updates the base fee the transactions are sorted with, and
+// rebuilds the heap since the order might change.
+func (l *txPricedList) SetBaseFee(baseFee *big.Int) {
+	l.items.baseFee = baseFee
+	l.reheap()
+}
 
-	l.stales, l.items = 0, &reheap
+// reheap rebuilds the heap from all the transactions in the pool
+func (l *txPricedList) reheap() {
+	reheap := &priceHeap{
+		baseFee: l.items.baseFee,
+		list:    make([]types.PoolTransaction, 0, l.all.Count()),
+	}
+	l.stales, l.items = 0, reheap
 	l.all.Range(func(hash common.Hash, tx types.PoolTransaction) bool {
-		*l.items = append(*l.items, tx)
+		l.items.list = append(l.items.list, tx)
 		return true
 	})
 	heap.Init(l.items)
@@ -518,17 +566,23 @@ func (l *txPricedList) Removed() {
 
 // Cap finds all the transactions below the given price threshold, drops them
 // from the priced list and returns them for further removal from the entire pool.
+// The dynamic fee transactions are kept since their tip is not priced against
+// the gas price threshold.
 func (l *txPricedList) Cap(threshold *big.Int, local *accountSet) types.PoolTransactions {
 	drop := make(types.PoolTransactions, 0, 128) // Remote underpriced transactions to drop
 	save := make(types.PoolTransactions, 0, 64)  // Local underpriced transactions to keep
 
-	for len(*l.items) > 0 {
+	for l.items.Len() > 0 {
 		// Discard stale transactions if found during cleanup
 		tx := heap.Pop(l.items).(types.PoolTransaction)
 		if l.all.Get(tx.Hash()) == nil {
 			l.stales--
 			continue
 		}
+		if isDynamicFeeTx(tx) {
+			save = append(save, tx)
+			continue
+		}
 		// Stop the discards if we've reached the threshold
 		if tx.GasPrice().Cmp(threshold) >= 0 {
 			save = append(save, tx)
@@ -555,8 +609,8 @@ func (l *txPricedList) Underpriced(tx types.PoolTransaction, local *accountSet)
 		return false
 	}
 	// Discard stale price points if found at the heap start
-	for len(*l.items) > 0 {
-		head := types.PoolTransactions(*l.items)[0]
+	for l.items.Len() > 0 {
+		head := l.items.list[0]
 		if l.all.Get(head.Hash()) == nil {
 			l.stales--
 			heap.Pop(l.items)
@@ -565,12 +619,12 @@ func (l *txPricedList) Underpriced(tx types.PoolTransaction, local *accountSet)
 		break
 	}
 	// Check if the transaction is underpriced or not
-	if len(*l.items) == 0 {
+	if l.items.Len() == 0 {
 		utils.Logger().Error().Msg("Pricing query for empty pool") // This cannot happen, print to catch programming errors
 		return false
 	}
-	cheapest := types.PoolTransactions(*l.items)[0]
-	return cheapest.GasPrice().Cmp(tx.GasPrice()) >= 0
+	cheapest := l.items.list[0]
+	return l.items.cmp(cheapest, tx) >= 0
 }
 
 // Discard finds a number of most underpriced transactions, removes them from the
@@ -579,7 +633,7 @@ func (l *txPricedList) Discard(count int, local *accountSet) types.PoolTransacti
 	drop := make(types.PoolTransactions, 0, count) // Remote underpriced transactions to drop
 	save := make(types.PoolTransactions, 0, 64)    // Local underpriced transactions to keep
 
-	for len(*l.items) > 0 && count > 0 {
+	for l.items.Len() > 0 && count > 0 {
 		// Discard stale transactions if found during cleanup
 		tx := heap.Pop(l.items).(types.PoolTransaction)
 		if l.all.Get(tx.Hash()) == nil {
@@ -32,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/consensus/misc"
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
 	hmyCommon "github.com/harmony-one/harmony/internal/common"
@@ -72,6 +73,10 @@ var (
 	// than required to start the invocation.
 	ErrIntrinsicGas = errors.New("intrinsic gas too low")
 
+	// ErrTxTypeNotSupported is returned if a transaction is not supported in the
+	// current network configuration.
+	ErrTxTypeNotSupported = types.ErrTxTypeNotSupported
+
 	// ErrGasLimit is returned if a transaction's requested gas limit exceeds the
 	// maximum allowance of the current block.
 	ErrGasLimit = errors.New("exceeds block gas limit")
@@ -152,6 +157,7 @@ type TxPoolConfig struct {
 	Rejournal time.Duration    // Time interval to regenerate the local transaction journal
 
 	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
+	TipLimit   uint64 // Minimum gas tip to enforce for acceptance of dynamic fee transactions into the pool
 	PriceBump  uint64 // Minimum price bump to replace an already existing transaction (nonce)
 
 	AccountSlots uint64 // Number of executable transaction slots guaranteed per account
@@ -171,6 +177,7 @@ var DefaultTxPoolConfig = TxPoolConfig{
 	Rejournal: time.Hour,
 
 	PriceLimit: 1e9, // 1 Gwei/Nano
+	TipLimit:   1,   // 1 wei, the base fee is never below the 1 Gwei/Nano floor
 	PriceBump:  10,
 
 	AccountSlots: 16,
@@ -228,6 +235,7 @@ type TxPool struct {
 	chainconfig  *params.ChainConfig
 	chain        blockChain
 	gasPrice     *big.Int
+	gasTip       *big.Int
 	txFeed       event.Feed
 	scope        event.SubscriptionScope
 	chainHeadCh  chan ChainHeadEvent
@@ -253,6 +261,8 @@ type TxPool struct {
 
 	homestead bool
 	istanbul  bool
+	eip2930   bool
+	eip1559   bool
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -274,6 +284,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig,
 		all:         newTxLookup(),
 		chainHeadCh: make(chan ChainHeadEvent, chainHeadChanSize),
 		gasPrice:    new(big.Int).SetUint64(config.PriceLimit),
+		gasTip:      new(big.Int).SetUint64(config.TipLimit),
 		txErrorSink: txErrorSink,
 	}
 	pool.locals = newAccountSet(chainconfig.ChainID)
@@ -339,6 +350,12 @@ func (pool *TxPool) loop() {
 				if pool.chainconfig.IsIstanbul(ev.Block.Epoch()) {
 					pool.istanbul = true
 				}
+				if pool.chainconfig.IsEIP2930(ev.Block.Epoch()) {
+					pool.eip2930 = true
+				}
+				if pool.chainconfig.IsEIP1559(ev.Block.Epoch()) {
+					pool.eip1559 = true
+				}
 				pool.reset(head.Header(), ev.Block.Header())
 				head = ev.Block
 				pool.mu.Unlock()
@@ -502,6 +519,11 @@ func (pool *TxPool) reset(oldHead, newHead *block.Header) {
 	pool.pendingState = state.ManageState(statedb)
 	pool.currentMaxGas = newHead.GasLimit()
 
+	// Sort the transactions by the effective tip of the next block
+	if newHead.BaseFee() != nil {
+		pool.priced.SetBaseFee(misc.CalcBaseFee(pool.chainconfig, newHead))
+	}
+
 	// Inject any transactions discarded due to reorgs
 	utils.Logger().Debug().Int("count", len(reinject)).Msg("Reinjecting stale transactions")
 	//senderCacher.recover(pool.signer, reinject)
@@ -620,6 +642,23 @@ func (pool *TxPool) Content() (map[common.Address]types.PoolTransactions, map[co
 	return pending, queued
 }
 
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of this address, grouped by nonce.
+func (pool *TxPool) ContentFrom(addr common.Address) (types.PoolTransactions, types.PoolTransactions) {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	var pending types.PoolTransactions
+	if list, ok := pool.pending[addr]; ok {
+		pending = list.Flatten()
+	}
+	var queued types.PoolTransactions
+	if list, ok := pool.queue[addr]; ok {
+		queued = list.Flatten()
+	}
+	return pending, queued
+}
+
 // Pending retrieves all currently executable transactions, grouped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -672,6 +711,23 @@ func (pool *TxPool) local() map[common.Address]types.PoolTransactions {
 	return txs
 }
 
+// poolGasTipCap returns the price of the transaction compared against the minimal
+// accepted gas price of the pool, which is the tip cap for the dynamic fee
+// transactions and the gas price for the others.
+func poolGasTipCap(tx types.PoolTransaction) *big.Int {
+	if plainTx, ok := tx.(*types.Transaction); ok {
+		return plainTx.GasTipCap()
+	}
+	return tx.GasPrice()
+}
+
+// isDynamicFeeTx returns whether the transaction is a dynamic fee transaction,
+// whose price is made of the base fee enforced by the consensus and the tip.
+func isDynamicFeeTx(tx types.PoolTransaction) bool {
+	plainTx, ok := tx.(*types.Transaction)
+	return ok && plainTx.Type() == types.DynamicFeeTxType
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
@@ -682,6 +738,23 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	if tx.Size() >= types.MaxPoolTransactionDataSize {
 		return errors.WithMessagef(ErrOversizedData, "transaction size is %s", tx.Size().String())
 	}
+	// Accept only the legacy transactions until EIP-2930 is activated, and the
+	// dynamic fee transactions until EIP-1559 is activated
+	var accessList types.AccessList
+	if plainTx, ok := tx.(*types.Transaction); ok {
+		if plainTx.Type() != types.LegacyTxType && !pool.eip2930 {
+			return errors.WithMessagef(ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+		}
+		if plainTx.Type() == types.DynamicFeeTxType && !pool.eip1559 {
+			return errors.WithMessagef(ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+		}
+		// The tip can't be above the fee cap
+		if plainTx.GasTipCap().Cmp(plainTx.GasFeeCap()) > 0 {
+			return errors.WithMessagef(ErrTipAboveFeeCap, "transaction tip is %s, fee cap is %s",
+				plainTx.GasTipCap().String(), plainTx.GasFeeCap().String())
+		}
+		accessList = plainTx.AccessList()
+	}
 	// Transactions can't be negative. This may never happen using RLP decoded
 	// transactions but may occur if you create a transaction using the RPC.
 	if tx.Value().Sign() < 0 {
@@ -717,7 +790,13 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	}
 	// Drop non-local transactions under our own minimal accepted gas price
 	local = local || pool.locals.contains(from) // account may be local even if the transaction arrived from the network
-	if !local && pool.gasPrice.Cmp(tx.GasPrice()) > 0 {
+	// The dynamic fee transactions pay at least the base fee, which is floored by
+	// the consensus, so only their tip is checked against the tip floor.
+	if !local && isDynamicFeeTx(tx) && pool.gasTip.Cmp(poolGasTipCap(tx)) > 0 {
+		return errors.WithMessagef(ErrUnderpriced, "transaction gas tip is %s wei; minimum gas tip is %s wei",
+			poolGasTipCap(tx).String(), pool.gasTip.String())
+	}
+	if !local && !isDynamicFeeTx(tx) && pool.gasPrice.Cmp(tx.GasPrice()) > 0 {
 		gasPrice := new(big.Float).SetInt64(tx.GasPrice().Int64())
 		gasPrice = gasPrice.Mul(gasPrice, new(big.Float).SetFloat64(1e-9)) // Gas-price is in Nano
 
@@ -747,9 +826,9 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	}
 	intrGas := uint64(0)
 	if isStakingTx {
-		intrGas, err = IntrinsicGas(tx.Data(), false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator)
+		intrGas, err = IntrinsicGas(tx.Data(), nil, false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator)
 	} else {
-		intrGas, err = IntrinsicGas(tx.Data(), tx.To() == nil, pool.homestead, pool.istanbul, false)
+		intrGas, err = IntrinsicGas(tx.Data(), accessList, tx.To() == nil, pool.homestead, pool.istanbul, false)
 	}
 	if err != nil {
 		return err
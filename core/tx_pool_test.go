@@ -552,6 +552,68 @@ func TestTransactionQueue(t *testing.T) {
 	}
 }
 
+func TestTransactionContentFrom(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+
+	tx1 := transaction(0, 0, 100, key)
+	tx2 := transaction(0, 1, 100, key)
+	tx3 := transaction(0, 10, 100, key)
+	from, _ := deriveSender(tx1)
+	pool.currentState.AddBalance(from, big.NewInt(1000000000000))
+	pool.lockedReset(nil, nil)
+
+	pool.enqueueTx(tx3)
+	pool.enqueueTx(tx2)
+	pool.enqueueTx(tx1)
+	pool.promoteExecutables([]common.Address{from})
+
+	pending, queued := pool.ContentFrom(from)
+	if len(pending) != 2 || pending[0].Nonce() != 0 || pending[1].Nonce() != 1 {
+		t.Errorf("unexpected pending transactions: %v", pending)
+	}
+	if len(queued) != 1 || queued[0].Nonce() != 10 {
+		t.Errorf("unexpected queued transactions: %v", queued)
+	}
+	if pending, queued := pool.ContentFrom(common.Address{}); len(pending) != 0 || len(queued) != 0 {
+		t.Errorf("unexpected content of unknown address: %v, %v", pending, queued)
+	}
+}
+
+func TestTransactionTipFloor(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+	pool.mu.Lock()
+	pool.eip2930, pool.eip1559 = true, true
+	pool.mu.Unlock()
+
+	signer := types.NewLondonSigner(params.TestChainConfig.ChainID)
+	dynamicFeeTx := func(nonce uint64, tip *big.Int) types.PoolTransaction {
+		tx, _ := types.SignTx(types.NewDynamicFeeTransaction(params.TestChainConfig.ChainID, nonce,
+			&common.Address{}, 0, 0, big.NewInt(100), 100000, tip, big.NewInt(2000000000), nil, nil),
+			signer, key)
+		return tx
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	pool.currentState.AddBalance(from, big.NewInt(1e18))
+
+	// The tip of the dynamic fee transactions is checked against the tip floor,
+	// not the gas price floor of the legacy transactions
+	if err := pool.AddRemote(dynamicFeeTx(0, big.NewInt(1))); err != nil {
+		t.Error("expected nil, got", err)
+	}
+	if err := pool.AddRemote(dynamicFeeTx(1, big.NewInt(0))); err != ErrUnderpriced {
+		t.Error("expected", ErrUnderpriced, "got", err)
+	}
+	if err := pool.AddRemote(pricedTransaction(0, 1, 100000, big.NewInt(1), key)); err != ErrUnderpriced {
+		t.Error("expected", ErrUnderpriced, "got", err)
+	}
+}
+
 func TestTransactionNegativeValue(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,342 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Transaction types of EIP-2718.
+const (
+	LegacyTxType = iota
+	AccessListTxType
+	DynamicFeeTxType
+)
+
+var (
+	// ErrTxTypeNotSupported is returned for the transaction types not supported
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+
+	errEmptyTypedTx = errors.New("empty typed transaction bytes")
+)
+
+// AccessList is an EIP-2930 access list.
+type AccessList []AccessTuple
+
+// AccessTuple is the element type of an access list.
+type AccessTuple struct {
+	Address     common.Address `json:"address"        gencodec:"required"`
+	StorageKeys []common.Hash  `json:"storageKeys"    gencodec:"required"`
+}
+
+// StorageKeys returns the total number of storage keys in the access list.
+func (al AccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+	return sum
+}
+
+// copyAccessList returns a deep copy of the access list
+func copyAccessList(al AccessList) AccessList {
+	if al == nil {
+		return nil
+	}
+	cpy := make(AccessList, len(al))
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]common.Hash{}, tuple.StorageKeys...),
+		}
+	}
+	return cpy
+}
+
+func copyBigInt(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).Set(v)
+}
+
+// accessListTxdata is the payload of the harmony access list transaction, which
+// carries the shard IDs on top of the EIP-2930 fields.
+type accessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethAccessListTxdata is the payload of the EIP-2930 access list transaction.
+type ethAccessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+func (d *txdata) toAccessListTxdata() *accessListTxdata {
+	return &accessListTxdata{
+		ChainID:      d.ChainID,
+		AccountNonce: d.AccountNonce,
+		Price:        d.Price,
+		GasLimit:     d.GasLimit,
+		ShardID:      d.ShardID,
+		ToShardID:    d.ToShardID,
+		Recipient:    d.Recipient,
+		Amount:       d.Amount,
+		Payload:      d.Payload,
+		AccessList:   d.AccessList,
+		V:            d.V,
+		R:            d.R,
+		S:            d.S,
+	}
+}
+
+func (d *txdata) fromAccessListTxdata(inner *accessListTxdata) {
+	*d = txdata{
+		AccountNonce: inner.AccountNonce,
+		Price:        inner.Price,
+		GasLimit:     inner.GasLimit,
+		ShardID:      inner.ShardID,
+		ToShardID:    inner.ToShardID,
+		Recipient:    inner.Recipient,
+		Amount:       inner.Amount,
+		Payload:      inner.Payload,
+		V:            inner.V,
+		R:            inner.R,
+		S:            inner.S,
+		Type:         AccessListTxType,
+		ChainID:      inner.ChainID,
+		AccessList:   inner.AccessList,
+	}
+}
+
+func (d *ethTxdata) toAccessListTxdata() *ethAccessListTxdata {
+	return &ethAccessListTxdata{
+		ChainID:      d.ChainID,
+		AccountNonce: d.AccountNonce,
+		Price:        d.Price,
+		GasLimit:     d.GasLimit,
+		Recipient:    d.Recipient,
+		Amount:       d.Amount,
+		Payload:      d.Payload,
+		AccessList:   d.AccessList,
+		V:            d.V,
+		R:            d.R,
+		S:            d.S,
+	}
+}
+
+func (d *ethTxdata) fromAccessListTxdata(inner *ethAccessListTxdata) {
+	*d = ethTxdata{
+		AccountNonce: inner.AccountNonce,
+		Price:        inner.Price,
+		GasLimit:     inner.GasLimit,
+		Recipient:    inner.Recipient,
+		Amount:       inner.Amount,
+		Payload:      inner.Payload,
+		V:            inner.V,
+		R:            inner.R,
+		S:            inner.S,
+		Type:         AccessListTxType,
+		ChainID:      inner.ChainID,
+		AccessList:   inner.AccessList,
+	}
+}
+
+// encodeTyped returns the EIP-2718 envelope of the typed transaction payload,
+// which is the transaction type followed by the RLP encoding of the payload.
+func encodeTyped(txType byte, payload interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(txType)
+	if err := rlp.Encode(&buf, payload); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// isTypedEnvelope returns whether the binary encoding of a transaction is an
+// EIP-2718 envelope rather than a legacy RLP list.
+func isTypedEnvelope(b []byte) bool {
+	return len(b) > 0 && b[0] <= 0x7f
+}
+
+// MarshalBinary returns the canonical encoding of the transaction. For legacy
+// transactions, it returns the RLP encoding. For typed transactions, it returns
+// the type and the payload.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	return tx.encodeTyped()
+}
+
+// UnmarshalBinary decodes the canonical encoding of transactions. It supports
+// the legacy RLP transactions and the typed transactions.
+func (tx *Transaction) UnmarshalBinary(b []byte) error {
+	if !isTypedEnvelope(b) {
+		var data txdata
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+		*tx = Transaction{data: data}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	}
+	var decoded Transaction
+	if err := decoded.decodeTyped(b); err != nil {
+		return err
+	}
+	*tx = decoded
+	return nil
+}
+
+func (tx *Transaction) encodeTyped() ([]byte, error) {
+	switch tx.data.Type {
+	case AccessListTxType:
+		return encodeTyped(tx.data.Type, tx.data.toAccessListTxdata())
+	case DynamicFeeTxType:
+		return encodeTyped(tx.data.Type, tx.data.toDynamicFeeTxdata())
+	default:
+		return nil, ErrTxTypeNotSupported
+	}
+}
+
+func (tx *Transaction) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner accessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		tx.data.fromAccessListTxdata(&inner)
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	case DynamicFeeTxType:
+		var inner dynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		tx.data.fromDynamicFeeTxdata(&inner)
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+// MarshalBinary returns the canonical encoding of the transaction. For legacy
+// transactions, it returns the RLP encoding. For typed transactions, it returns
+// the type and the payload.
+func (tx *EthTransaction) MarshalBinary() ([]byte, error) {
+	if tx.data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	return tx.encodeTyped()
+}
+
+// UnmarshalBinary decodes the canonical encoding of transactions. It supports
+// the legacy RLP transactions and the typed transactions.
+func (tx *EthTransaction) UnmarshalBinary(b []byte) error {
+	if !isTypedEnvelope(b) {
+		var data ethTxdata
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+		*tx = EthTransaction{data: data}
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	}
+	var decoded EthTransaction
+	if err := decoded.decodeTyped(b); err != nil {
+		return err
+	}
+	*tx = decoded
+	return nil
+}
+
+func (tx *EthTransaction) encodeTyped() ([]byte, error) {
+	switch tx.data.Type {
+	case AccessListTxType:
+		return encodeTyped(tx.data.Type, tx.data.toAccessListTxdata())
+	case DynamicFeeTxType:
+		return encodeTyped(tx.data.Type, tx.data.toDynamicFeeTxdata())
+	default:
+		return nil, ErrTxTypeNotSupported
+	}
+}
+
+func (tx *EthTransaction) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner ethAccessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		tx.data.fromAccessListTxdata(&inner)
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	case DynamicFeeTxType:
+		var inner ethDynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		tx.data.fromDynamicFeeTxdata(&inner)
+		tx.size.Store(common.StorageSize(len(b)))
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+// NewAccessListTransaction returns a new EIP-2930 access list transaction of
+// the harmony format, which is signed with the EIP2930Signer.
+func NewAccessListTransaction(chainID *big.Int, nonce uint64, to *common.Address, shardID, toShardID uint32, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, accessList AccessList) *Transaction {
+	tx := newCrossShardTransaction(nonce, to, shardID, toShardID, amount, gasLimit, gasPrice, data)
+	tx.data.Type = AccessListTxType
+	tx.data.ChainID = copyBigInt(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	return tx
+}
+
+// NewEthAccessListTransaction returns a new EIP-2930 access list transaction of
+// the ethereum format, which is signed with the EIP2930Signer.
+func NewEthAccessListTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := newEthTransaction(nonce, to, amount, gasLimit, gasPrice, data)
+	tx.data.Type = AccessListTxType
+	tx.data.ChainID = copyBigInt(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	return tx
+}
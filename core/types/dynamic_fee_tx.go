@@ -0,0 +1,192 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrGasFeeCapTooLow is returned if the fee cap of a transaction is below the
+// base fee of the block
+var ErrGasFeeCapTooLow = errors.New("fee cap less than base fee")
+
+// dynamicFeeTxdata is the payload of the harmony dynamic fee transaction, which
+// carries the shard IDs on top of the EIP-1559 fields.
+type dynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int
+	GasFeeCap    *big.Int
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+// ethDynamicFeeTxdata is the payload of the EIP-1559 dynamic fee transaction.
+type ethDynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int
+	GasFeeCap    *big.Int
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"` // nil means contract creation
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+
+	// Signature values
+	V *big.Int
+	R *big.Int
+	S *big.Int
+}
+
+func (d *txdata) toDynamicFeeTxdata() *dynamicFeeTxdata {
+	return &dynamicFeeTxdata{
+		ChainID:      d.ChainID,
+		AccountNonce: d.AccountNonce,
+		GasTipCap:    d.GasTipCap,
+		GasFeeCap:    d.Price,
+		GasLimit:     d.GasLimit,
+		ShardID:      d.ShardID,
+		ToShardID:    d.ToShardID,
+		Recipient:    d.Recipient,
+		Amount:       d.Amount,
+		Payload:      d.Payload,
+		AccessList:   d.AccessList,
+		V:            d.V,
+		R:            d.R,
+		S:            d.S,
+	}
+}
+
+func (d *txdata) fromDynamicFeeTxdata(inner *dynamicFeeTxdata) {
+	*d = txdata{
+		AccountNonce: inner.AccountNonce,
+		Price:        inner.GasFeeCap,
+		GasLimit:     inner.GasLimit,
+		ShardID:      inner.ShardID,
+		ToShardID:    inner.ToShardID,
+		Recipient:    inner.Recipient,
+		Amount:       inner.Amount,
+		Payload:      inner.Payload,
+		V:            inner.V,
+		R:            inner.R,
+		S:            inner.S,
+		Type:         DynamicFeeTxType,
+		ChainID:      inner.ChainID,
+		AccessList:   inner.AccessList,
+		GasTipCap:    inner.GasTipCap,
+	}
+}
+
+func (d *ethTxdata) toDynamicFeeTxdata() *ethDynamicFeeTxdata {
+	return &ethDynamicFeeTxdata{
+		ChainID:      d.ChainID,
+		AccountNonce: d.AccountNonce,
+		GasTipCap:    d.GasTipCap,
+		GasFeeCap:    d.Price,
+		GasLimit:     d.GasLimit,
+		Recipient:    d.Recipient,
+		Amount:       d.Amount,
+		Payload:      d.Payload,
+		AccessList:   d.AccessList,
+		V:            d.V,
+		R:            d.R,
+		S:            d.S,
+	}
+}
+
+func (d *ethTxdata) fromDynamicFeeTxdata(inner *ethDynamicFeeTxdata) {
+	*d = ethTxdata{
+		AccountNonce: inner.AccountNonce,
+		Price:        inner.GasFeeCap,
+		GasLimit:     inner.GasLimit,
+		Recipient:    inner.Recipient,
+		Amount:       inner.Amount,
+		Payload:      inner.Payload,
+		V:            inner.V,
+		R:            inner.R,
+		S:            inner.S,
+		Type:         DynamicFeeTxType,
+		ChainID:      inner.ChainID,
+		AccessList:   inner.AccessList,
+		GasTipCap:    inner.GasTipCap,
+	}
+}
+
+// gasTipCap returns the priority fee cap of the transaction data, which is the
+// gas price for the transactions other than the dynamic fee transactions.
+func gasTipCap(txType byte, price, tipCap *big.Int) *big.Int {
+	if txType == DynamicFeeTxType {
+		return tipCap
+	}
+	return price
+}
+
+// effectiveGasTip returns the priority fee per gas paid to the block proposer
+// given the base fee, which is min(tipCap, feeCap - baseFee). It returns
+// ErrGasFeeCapTooLow if the fee cap is below the base fee.
+func effectiveGasTip(feeCap, tipCap, baseFee *big.Int) (*big.Int, error) {
+	if baseFee == nil {
+		return new(big.Int).Set(tipCap), nil
+	}
+	tip := new(big.Int).Sub(feeCap, baseFee)
+	if tip.Sign() < 0 {
+		return tip, ErrGasFeeCapTooLow
+	}
+	if tip.Cmp(tipCap) > 0 {
+		tip.Set(tipCap)
+	}
+	return tip, nil
+}
+
+// effectiveGasPrice returns the gas price actually paid per gas given the base
+// fee, which is min(tipCap + baseFee, feeCap).
+func effectiveGasPrice(feeCap, tipCap, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(feeCap)
+	}
+	price := new(big.Int).Add(tipCap, baseFee)
+	if price.Cmp(feeCap) > 0 {
+		price.Set(feeCap)
+	}
+	return price
+}
+
+// NewDynamicFeeTransaction returns a new EIP-1559 dynamic fee transaction of
+// the harmony format, which is signed with the LondonSigner.
+func NewDynamicFeeTransaction(chainID *big.Int, nonce uint64, to *common.Address, shardID, toShardID uint32, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte, accessList AccessList) *Transaction {
+	tx := newCrossShardTransaction(nonce, to, shardID, toShardID, amount, gasLimit, gasFeeCap, data)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.ChainID = copyBigInt(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	tx.data.GasTipCap = new(big.Int)
+	if gasTipCap != nil {
+		tx.data.GasTipCap.Set(gasTipCap)
+	}
+	return tx
+}
+
+// NewEthDynamicFeeTransaction returns a new EIP-1559 dynamic fee transaction of
+// the ethereum format, which is signed with the LondonSigner.
+func NewEthDynamicFeeTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := newEthTransaction(nonce, to, amount, gasLimit, gasFeeCap, data)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.ChainID = copyBigInt(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	tx.data.GasTipCap = new(big.Int)
+	if gasTipCap != nil {
+		tx.data.GasTipCap.Set(gasTipCap)
+	}
+	return tx
+}
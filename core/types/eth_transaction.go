@@ -17,6 +17,7 @@
 package types
 
 import (
+	"errors"
 	"io"
 	"math/big"
 	"sync/atomic"
@@ -58,6 +59,15 @@ type ethTxdata struct {
 	R *big.Int `json:"r" gencodec:"required"`
 	S *big.Int `json:"s" gencodec:"required"`
 
+	// Fields of the typed transactions, which are not in the legacy encoding
+	Type       byte       `json:"type,omitempty"       rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"    rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty" rlp:"-"`
+
+	// GasTipCap is the priority fee of the dynamic fee transactions, whose fee
+	// cap is kept in Price.
+	GasTipCap *big.Int `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
 }
@@ -72,6 +82,10 @@ func (d *ethTxdata) CopyFrom(d2 *ethTxdata) {
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
+	d.Type = d2.Type
+	d.ChainID = copyBigInt(d2.ChainID)
+	d.AccessList = copyAccessList(d2.AccessList)
+	d.GasTipCap = copyBigInt(d2.GasTipCap)
 	d.Hash = copyHash(d2.Hash)
 }
 
@@ -84,6 +98,9 @@ type ethTxdataMarshaling struct {
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
+	GasTipCap    *hexutil.Big
 }
 
 // NewEthTransaction returns new ethereum-compatible transaction, which works as a intra-shard transaction
@@ -168,11 +185,29 @@ func (tx *EthTransaction) shardID() uint32 {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *EthTransaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
+// Type returns the EIP-2718 type of the transaction
+func (tx *EthTransaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// AccessList returns the EIP-2930 access list of the transaction, which is nil
+// for the legacy transactions.
+func (tx *EthTransaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
 // Protected returns whether the transaction is protected from replay protection.
+// The typed transactions are always protected.
 func (tx *EthTransaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -198,6 +233,10 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
+	d2.Type = d.Type
+	d2.ChainID = copyBigInt(d.ChainID)
+	d2.AccessList = copyAccessList(d.AccessList)
+	d2.GasTipCap = copyBigInt(d.GasTipCap)
 
 	d2.ShardID = tx.ShardID()
 	d2.ToShardID = tx.ToShardID()
@@ -208,15 +247,33 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	return &tx2
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder. The typed transactions are encoded as the
+// RLP string of the EIP-2718 envelope.
 func (tx *EthTransaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.encodeTyped()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *EthTransaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind != rlp.List {
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		return tx.decodeTyped(b)
+	}
+	err = s.Decode(&tx.data)
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 	}
@@ -239,10 +296,18 @@ func (tx *EthTransaction) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
+	if dec.Type != LegacyTxType && dec.ChainID == nil {
+		return errors.New("missing required field 'chainId' for typed transaction")
+	}
+	if dec.Type == DynamicFeeTxType && dec.GasTipCap == nil {
+		return errors.New("missing required field 'maxPriorityFeePerGas' for dynamic fee transaction")
+	}
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -262,11 +327,38 @@ func (tx *EthTransaction) Gas() uint64 {
 	return tx.data.GasLimit
 }
 
-// GasPrice returns gas price of Transaction.
+// GasPrice returns gas price of Transaction, which is the fee cap for the
+// dynamic fee transactions.
 func (tx *EthTransaction) GasPrice() *big.Int {
 	return new(big.Int).Set(tx.data.Price)
 }
 
+// GasFeeCap is the maximum fee per gas the sender is willing to pay, which is
+// the gas price for the transactions other than the dynamic fee transactions.
+func (tx *EthTransaction) GasFeeCap() *big.Int {
+	return new(big.Int).Set(tx.data.Price)
+}
+
+// GasTipCap is the maximum priority fee per gas paid to the block proposer,
+// which is the gas price for the transactions other than the dynamic fee
+// transactions.
+func (tx *EthTransaction) GasTipCap() *big.Int {
+	return new(big.Int).Set(gasTipCap(tx.data.Type, tx.data.Price, tx.data.GasTipCap))
+}
+
+// EffectiveGasTip returns the priority fee per gas paid to the block proposer
+// given the base fee. It returns ErrGasFeeCapTooLow if the fee cap is below the
+// base fee. A nil base fee means the tip is the tip cap.
+func (tx *EthTransaction) EffectiveGasTip(baseFee *big.Int) (*big.Int, error) {
+	return effectiveGasTip(tx.data.Price, gasTipCap(tx.data.Type, tx.data.Price, tx.data.GasTipCap), baseFee)
+}
+
+// EffectiveGasPrice returns the gas price actually paid per gas given the base
+// fee. A nil base fee means the price is the fee cap.
+func (tx *EthTransaction) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	return effectiveGasPrice(tx.data.Price, gasTipCap(tx.data.Type, tx.data.Price, tx.data.GasTipCap), baseFee)
+}
+
 // Nonce returns account nonce from Transaction.
 func (tx *EthTransaction) Nonce() uint64 {
 	return tx.data.AccountNonce
@@ -287,13 +379,19 @@ func (tx *EthTransaction) To() *common.Address {
 	return &to
 }
 
-// Hash hashes the RLP encoding of tx.
+// Hash hashes the RLP encoding of tx, or the EIP-2718 envelope of the typed tx.
 // It uniquely identifies the transaction.
 func (tx *EthTransaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.encodeTyped()
+		v = hash.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -304,6 +402,11 @@ func (tx *EthTransaction) Size() common.StorageSize {
 	if size := tx.size.Load(); size != nil {
 		return size.(common.StorageSize)
 	}
+	if tx.data.Type != LegacyTxType {
+		enc, _ := tx.encodeTyped()
+		tx.size.Store(common.StorageSize(len(enc)))
+		return common.StorageSize(len(enc))
+	}
 	c := writeCounter(0)
 	rlp.Encode(&c, &tx.data)
 	tx.size.Store(common.StorageSize(c))
@@ -323,6 +426,8 @@ func (tx *EthTransaction) SenderAddress() (common.Address, error) {
 	var signer Signer
 	if !tx.Protected() {
 		signer = HomesteadSigner{}
+	} else if tx.Type() != LegacyTxType {
+		signer = NewLondonSigner(tx.ChainID())
 	} else {
 		signer = NewEIP155Signer(tx.ChainID())
 	}
@@ -340,18 +445,22 @@ func (tx *EthTransaction) IsEthCompatible() bool {
 
 // AsMessage returns the transaction as a core.Message.
 //
-// AsMessage requires a signer to derive the sender.
+// AsMessage requires a signer to derive the sender, and the base fee of the
+// block to derive the effective gas price, which is nil before EIP-1559.
 //
 // XXX Rename message to something less arbitrary?
-func (tx *EthTransaction) AsMessage(s Signer) (Message, error) {
+func (tx *EthTransaction) AsMessage(s Signer, baseFee *big.Int) (Message, error) {
 	msg := Message{
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
-		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasPrice:   tx.EffectiveGasPrice(baseFee),
+		gasFeeCap:  new(big.Int).Set(tx.data.Price),
+		gasTipCap:  new(big.Int).Set(gasTipCap(tx.data.Type, tx.data.Price, tx.data.GasTipCap)),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
 		checkNonce: true,
+		accessList: tx.data.AccessList,
 	}
 
 	var err error
@@ -388,6 +497,6 @@ func (s EthTransactions) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 // GetRlp implements Rlpable and returns the i'th element of s in rlp.
 func (s EthTransactions) GetRlp(i int) []byte {
-	enc, _ := rlp.EncodeToBytes(s[i])
+	enc, _ := s[i].MarshalBinary()
 	return enc
 }
@@ -1,12 +1,14 @@
 package types
 
 import (
+	"bytes"
 	"reflect"
 	"testing"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/staking"
 )
 
@@ -112,3 +114,54 @@ func TestFindLogsWithTopic(t *testing.T) {
 		}
 	}
 }
+
+func TestReceiptTypedRLP(t *testing.T) {
+	var receipts Receipts
+	for i, txType := range []byte{LegacyTxType, AccessListTxType, DynamicFeeTxType} {
+		receipts = append(receipts, &Receipt{
+			Type:              txType,
+			Status:            ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(i+1) * 21000,
+			Logs: []*Log{{
+				Address: ethcommon.BytesToAddress([]byte{byte(i)}),
+				Topics:  []ethcommon.Hash{staking.CollectRewardsTopic},
+				Data:    []byte{byte(i)},
+			}},
+		})
+	}
+	enc, err := rlp.EncodeToBytes(receipts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Receipts
+	if err := rlp.DecodeBytes(enc, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(receipts) {
+		t.Fatalf("unexpected receipts %v", decoded)
+	}
+	for i, receipt := range decoded {
+		exp := receipts[i]
+		if receipt.Type != exp.Type || receipt.Status != exp.Status ||
+			receipt.CumulativeGasUsed != exp.CumulativeGasUsed || len(receipt.Logs) != 1 ||
+			receipt.Logs[0].Address != exp.Logs[0].Address || !bytes.Equal(receipt.Logs[0].Data, exp.Logs[0].Data) {
+			t.Errorf("receipt %d: unexpected receipt %+v", i, receipt)
+		}
+	}
+	reenc, err := rlp.EncodeToBytes(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reenc, enc) {
+		t.Errorf("unexpected encoding %x / %x", reenc, enc)
+	}
+
+	// Receipts of unknown transaction types are rejected
+	unknown, err := rlp.EncodeToBytes(&Receipt{Type: DynamicFeeTxType + 1, Status: ReceiptStatusSuccessful})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rlp.DecodeBytes(unknown, new(Receipt)); err != ErrTxTypeNotSupported {
+		t.Errorf("unexpected error %v", err)
+	}
+}
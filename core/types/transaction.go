@@ -72,8 +72,16 @@ type InternalTransaction interface {
 	R() *big.Int
 	S() *big.Int
 
+	// EIP-2718 transaction type and EIP-2930 access list
+	Type() uint8
+	AccessList() AccessList
+
+	// EIP-1559 fee caps
+	GasFeeCap() *big.Int
+	GasTipCap() *big.Int
+
 	IsEthCompatible() bool
-	AsMessage(s Signer) (Message, error)
+	AsMessage(s Signer, baseFee *big.Int) (Message, error)
 }
 
 // CoreTransaction defines the core funcs of any transactions
@@ -139,6 +147,15 @@ type txdata struct {
 	R *big.Int `json:"r" gencodec:"required"`
 	S *big.Int `json:"s" gencodec:"required"`
 
+	// Fields of the typed transactions, which are not in the legacy encoding
+	Type       byte       `json:"type,omitempty"       rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"    rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty" rlp:"-"`
+
+	// GasTipCap is the priority fee of the dynamic fee transactions, whose fee
+	// cap is kept in Price.
+	GasTipCap *big.Int `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
 }
@@ -171,6 +188,10 @@ func (d *txdata) CopyFrom(d2 *txdata) {
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
+	d.Type = d2.Type
+	d.ChainID = copyBigInt(d2.ChainID)
+	d.AccessList = copyAccessList(d2.AccessList)
+	d.GasTipCap = copyBigInt(d2.GasTipCap)
 	d.Hash = copyHash(d2.Hash)
 }
 
@@ -183,6 +204,9 @@ type txdataMarshaling struct {
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
+	GasTipCap    *hexutil.Big
 }
 
 // NewTransaction returns new transaction, this method is to create same shard transaction
@@ -284,11 +308,38 @@ func (tx *Transaction) GasLimit() uint64 {
 	return tx.data.GasLimit
 }
 
-// GasPrice is the gas price of the transaction
+// GasPrice is the gas price of the transaction, which is the fee cap for the
+// dynamic fee transactions.
 func (tx *Transaction) GasPrice() *big.Int {
 	return tx.data.Price
 }
 
+// GasFeeCap is the maximum fee per gas the sender is willing to pay, which is
+// the gas price for the transactions other than the dynamic fee transactions.
+func (tx *Transaction) GasFeeCap() *big.Int {
+	return tx.data.Price
+}
+
+// GasTipCap is the maximum priority fee per gas paid to the block proposer,
+// which is the gas price for the transactions other than the dynamic fee
+// transactions.
+func (tx *Transaction) GasTipCap() *big.Int {
+	return gasTipCap(tx.data.Type, tx.data.Price, tx.data.GasTipCap)
+}
+
+// EffectiveGasTip returns the priority fee per gas paid to the block proposer
+// given the base fee. It returns ErrGasFeeCapTooLow if the fee cap is below the
+// base fee. A nil base fee means the tip is the tip cap.
+func (tx *Transaction) EffectiveGasTip(baseFee *big.Int) (*big.Int, error) {
+	return effectiveGasTip(tx.GasFeeCap(), tx.GasTipCap(), baseFee)
+}
+
+// EffectiveGasPrice returns the gas price actually paid per gas given the base
+// fee. A nil base fee means the price is the fee cap.
+func (tx *Transaction) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	return effectiveGasPrice(tx.GasFeeCap(), tx.GasTipCap(), baseFee)
+}
+
 // Data returns data payload of Transaction.
 func (tx *Transaction) Data() []byte {
 	return common.CopyBytes(tx.data.Payload)
@@ -296,9 +347,23 @@ func (tx *Transaction) Data() []byte {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *Transaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
+// Type returns the EIP-2718 type of the transaction
+func (tx *Transaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// AccessList returns the EIP-2930 access list of the transaction, which is nil
+// for the legacy transactions.
+func (tx *Transaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
 // ShardID returns which shard id this transaction was signed for (if at all)
 func (tx *Transaction) ShardID() uint32 {
 	return tx.data.ShardID
@@ -310,7 +375,11 @@ func (tx *Transaction) ToShardID() uint32 {
 }
 
 // Protected returns whether the transaction is protected from replay protection.
+// The typed transactions are always protected.
 func (tx *Transaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -323,15 +392,33 @@ func isProtectedV(V *big.Int) bool {
 	return true
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder. The typed transactions are encoded as the
+// RLP string of the EIP-2718 envelope.
 func (tx *Transaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	enc, err := tx.encodeTyped()
+	if err != nil {
+		return err
+	}
+	return rlp.Encode(w, enc)
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind != rlp.List {
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		return tx.decodeTyped(b)
+	}
+	err = s.Decode(&tx.data)
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 	}
@@ -354,10 +441,18 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
+	if dec.Type != LegacyTxType && dec.ChainID == nil {
+		return errors.New("missing required field 'chainId' for typed transaction")
+	}
+	if dec.Type == DynamicFeeTxType && dec.GasTipCap == nil {
+		return errors.New("missing required field 'maxPriorityFeePerGas' for dynamic fee transaction")
+	}
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -392,13 +487,19 @@ func (tx *Transaction) To() *common.Address {
 	return &to
 }
 
-// Hash hashes the RLP encoding of tx.
+// Hash hashes the RLP encoding of tx, or the EIP-2718 envelope of the typed tx.
 // It uniquely identifies the transaction.
 func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.encodeTyped()
+		v = hash.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -418,6 +519,11 @@ func (tx *Transaction) Size() common.StorageSize {
 	if size := tx.size.Load(); size != nil {
 		return size.(common.StorageSize)
 	}
+	if tx.data.Type != LegacyTxType {
+		enc, _ := tx.encodeTyped()
+		tx.size.Store(common.StorageSize(len(enc)))
+		return common.StorageSize(len(enc))
+	}
 	c := writeCounter(0)
 	rlp.Encode(&c, &tx.data)
 	tx.size.Store(common.StorageSize(c))
@@ -444,6 +550,10 @@ func (tx *Transaction) ConvertToEth() *EthTransaction {
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
+	d2.Type = d.Type
+	d2.ChainID = copyBigInt(d.ChainID)
+	d2.AccessList = copyAccessList(d.AccessList)
+	d2.GasTipCap = copyBigInt(d.GasTipCap)
 
 	copy := tx2.Hash()
 	d2.Hash = &copy
@@ -453,18 +563,22 @@ func (tx *Transaction) ConvertToEth() *EthTransaction {
 
 // AsMessage returns the transaction as a core.Message.
 //
-// AsMessage requires a signer to derive the sender.
+// AsMessage requires a signer to derive the sender, and the base fee of the
+// block to derive the effective gas price, which is nil before EIP-1559.
 //
 // XXX Rename message to something less arbitrary?
-func (tx *Transaction) AsMessage(s Signer) (Message, error) {
+func (tx *Transaction) AsMessage(s Signer, baseFee *big.Int) (Message, error) {
 	msg := Message{
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
-		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasPrice:   tx.EffectiveGasPrice(baseFee),
+		gasFeeCap:  new(big.Int).Set(tx.data.Price),
+		gasTipCap:  new(big.Int).Set(gasTipCap(tx.data.Type, tx.data.Price, tx.data.GasTipCap)),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
 		checkNonce: true,
+		accessList: tx.data.AccessList,
 	}
 
 	var err error
@@ -509,6 +623,8 @@ func (tx *Transaction) SenderAddress() (common.Address, error) {
 	var signer Signer
 	if !tx.Protected() {
 		signer = HomesteadSigner{}
+	} else if tx.Type() != LegacyTxType {
+		signer = NewLondonSigner(tx.ChainID())
 	} else {
 		signer = NewEIP155Signer(tx.ChainID())
 	}
@@ -528,17 +644,36 @@ func (s TxByNonce) Len() int           { return len(s) }
 func (s TxByNonce) Less(i, j int) bool { return s[i].Nonce() < s[j].Nonce() }
 func (s TxByNonce) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
+// TxWithMinerFee wraps a transaction with its effective priority fee per gas,
+// which is paid to the block proposer.
+type TxWithMinerFee struct {
+	tx       *Transaction
+	minerFee *big.Int
+}
+
+// NewTxWithMinerFee creates a wrapped transaction, calculating the effective
+// priority fee if the base fee is provided. It returns error if the fee cap of
+// the transaction is below the base fee.
+func NewTxWithMinerFee(tx *Transaction, baseFee *big.Int) (*TxWithMinerFee, error) {
+	minerFee, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		return nil, err
+	}
+	return &TxWithMinerFee{tx: tx, minerFee: minerFee}, nil
+}
+
 // TxByPrice implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
-type TxByPrice Transactions
+// The transactions are ordered by the effective priority fee.
+type TxByPrice []*TxWithMinerFee
 
 func (s TxByPrice) Len() int           { return len(s) }
-func (s TxByPrice) Less(i, j int) bool { return s[i].GasPrice().Cmp(s[j].GasPrice()) > 0 }
+func (s TxByPrice) Less(i, j int) bool { return s[i].minerFee.Cmp(s[j].minerFee) > 0 }
 func (s TxByPrice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
 // Push pushes a transaction.
 func (s *TxByPrice) Push(x interface{}) {
-	*s = append(*s, x.(*Transaction))
+	*s = append(*s, x.(*TxWithMinerFee))
 }
 
 // Pop pops a transaction.
@@ -558,21 +693,29 @@ type TransactionsByPriceAndNonce struct {
 	heads     TxByPrice                       // Next transaction for each unique account (price heap)
 	signer    Signer                          // Signer for the set of transactions
 	ethSigner Signer                          // Signer for the set of transactions
+	baseFee   *big.Int                        // Current base fee, nil before EIP-1559
 }
 
 // NewTransactionsByPriceAndNonce creates a transaction set that can retrieve
-// price sorted transactions in a nonce-honouring way.
+// price sorted transactions in a nonce-honouring way. The transactions are sorted
+// by the effective priority fee given the base fee, and the accounts whose next
+// transaction cannot pay the base fee are skipped.
 //
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
-func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[common.Address]Transactions) *TransactionsByPriceAndNonce {
+func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[common.Address]Transactions, baseFee *big.Int) *TransactionsByPriceAndNonce {
 	// Initialize a price based heap with the head transactions
 	heads := make(TxByPrice, 0, len(txs))
 	for from, accTxs := range txs {
 		if accTxs.Len() == 0 {
 			continue
 		}
-		heads = append(heads, accTxs[0])
+		wrapped, err := NewTxWithMinerFee(accTxs[0], baseFee)
+		if err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads = append(heads, wrapped)
 		// Ensure the sender address is from the signer
 		signer := hmySigner
 		if accTxs[0].IsEthCompatible() {
@@ -592,6 +735,7 @@ func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[
 		heads:     heads,
 		signer:    hmySigner,
 		ethSigner: ethSigner,
+		baseFee:   baseFee,
 	}
 }
 
@@ -600,7 +744,7 @@ func (t *TransactionsByPriceAndNonce) Peek() *Transaction {
 	if len(t.heads) == 0 {
 		return nil
 	}
-	return t.heads[0]
+	return t.heads[0].tx
 }
 
 // Shift replaces the current best head with the next one from the same account.
@@ -609,16 +753,18 @@ func (t *TransactionsByPriceAndNonce) Shift() {
 		return
 	}
 	signer := t.signer
-	if t.heads[0].IsEthCompatible() {
+	if t.heads[0].tx.IsEthCompatible() {
 		signer = t.ethSigner
 	}
-	acc, _ := Sender(signer, t.heads[0])
+	acc, _ := Sender(signer, t.heads[0].tx)
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		t.heads[0], t.txs[acc] = txs[0], txs[1:]
-		heap.Fix(&t.heads, 0)
-	} else {
-		heap.Pop(&t.heads)
+		if wrapped, err := NewTxWithMinerFee(txs[0], t.baseFee); err == nil {
+			t.heads[0], t.txs[acc] = wrapped, txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
 	}
+	heap.Pop(&t.heads)
 }
 
 // Pop removes the best transaction, *not* replacing it with the next one from
@@ -637,14 +783,25 @@ type Message struct {
 	amount     *big.Int
 	gasLimit   uint64
 	gasPrice   *big.Int
+	gasFeeCap  *big.Int
+	gasTipCap  *big.Int
 	data       []byte
 	checkNonce bool
 	blockNum   *big.Int
 	txType     TransactionType
+	accessList AccessList
 }
 
-// NewMessage returns new message.
-func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, checkNonce bool) Message {
+// NewMessage returns new message. The gas price is the effective gas price paid
+// per gas, which is capped by the fee caps of EIP-1559. Nil fee caps mean the
+// gas price.
+func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, gasPrice, gasFeeCap, gasTipCap *big.Int, data []byte, checkNonce bool) Message {
+	if gasFeeCap == nil {
+		gasFeeCap = gasPrice
+	}
+	if gasTipCap == nil {
+		gasTipCap = gasFeeCap
+	}
 	return Message{
 		from:       from,
 		to:         to,
@@ -652,6 +809,8 @@ func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *b
 		amount:     amount,
 		gasLimit:   gasLimit,
 		gasPrice:   gasPrice,
+		gasFeeCap:  gasFeeCap,
+		gasTipCap:  gasTipCap,
 		data:       data,
 		checkNonce: checkNonce,
 	}
@@ -665,6 +824,8 @@ func NewStakingMessage(from common.Address, nonce uint64, gasLimit uint64, gasPr
 		nonce:      nonce,
 		gasLimit:   gasLimit,
 		gasPrice:   new(big.Int).Set(gasPrice),
+		gasFeeCap:  new(big.Int).Set(gasPrice),
+		gasTipCap:  new(big.Int).Set(gasPrice),
 		data:       data,
 		checkNonce: true,
 		blockNum:   blockNum,
@@ -686,6 +847,16 @@ func (m Message) GasPrice() *big.Int {
 	return m.gasPrice
 }
 
+// GasFeeCap returns the EIP-1559 fee cap from Message.
+func (m Message) GasFeeCap() *big.Int {
+	return m.gasFeeCap
+}
+
+// GasTipCap returns the EIP-1559 priority fee cap from Message.
+func (m Message) GasTipCap() *big.Int {
+	return m.gasTipCap
+}
+
 // Value returns the value amount from Message.
 func (m Message) Value() *big.Int {
 	return m.amount
@@ -726,6 +897,16 @@ func (m Message) BlockNum() *big.Int {
 	return m.blockNum
 }
 
+// AccessList returns the EIP-2930 access list of the Message.
+func (m Message) AccessList() AccessList {
+	return m.accessList
+}
+
+// SetAccessList set the EIP-2930 access list of the Message.
+func (m *Message) SetAccessList(accessList AccessList) {
+	m.accessList = accessList
+}
+
 // RecentTxsStats is a recent transactions stats map tracking stats like BlockTxsCounts.
 type RecentTxsStats map[uint64]BlockTxsCounts
 
@@ -764,7 +945,7 @@ func (s Transactions) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
 
 // GetRlp implements Rlpable and returns the i'th element of s in rlp.
 func (s Transactions) GetRlp(i int) []byte {
-	enc, _ := rlp.EncodeToBytes(s[i])
+	enc, _ := s[i].MarshalBinary()
 	return enc
 }
 
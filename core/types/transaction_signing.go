@@ -46,6 +46,10 @@ type sigCache struct {
 func MakeSigner(config *params.ChainConfig, epochNumber *big.Int) Signer {
 	var signer Signer
 	switch {
+	case config.IsEIP1559(epochNumber):
+		signer = NewLondonSigner(config.ChainID)
+	case config.IsEIP2930(epochNumber):
+		signer = NewEIP2930Signer(config.ChainID)
 	case config.IsEIP155(epochNumber):
 		signer = NewEIP155Signer(config.ChainID)
 	default:
@@ -54,6 +58,24 @@ func MakeSigner(config *params.ChainConfig, epochNumber *big.Int) Signer {
 	return signer
 }
 
+// MakeEthSigner returns the Signer of the ethereum compatible transactions based
+// on the given chain config and epoch number.
+func MakeEthSigner(config *params.ChainConfig, epochNumber *big.Int) Signer {
+	if config.IsEIP1559(epochNumber) {
+		return NewLondonSigner(config.EthCompatibleChainID)
+	}
+	if config.IsEIP2930(epochNumber) {
+		return NewEIP2930Signer(config.EthCompatibleChainID)
+	}
+	return NewEIP155Signer(config.EthCompatibleChainID)
+}
+
+// LatestSignerForChainID returns the most permissive Signer available for the
+// given chain ID, which accepts all the transaction types regardless of the epoch.
+func LatestSignerForChainID(chainID *big.Int) Signer {
+	return NewLondonSigner(chainID)
+}
+
 // SignTx signs the transaction using the given signer and private key
 func SignTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey) (*Transaction, error) {
 	h := s.Hash(tx)
@@ -114,6 +136,182 @@ type Signer interface {
 	Equal(Signer) bool
 }
 
+// EIP2930Signer implements Signer using the EIP-2930 rules, which accepts the
+// access list transactions on top of the legacy transactions of EIP155Signer.
+type EIP2930Signer struct{ EIP155Signer }
+
+// NewEIP2930Signer creates a EIP2930Signer given chainID.
+func NewEIP2930Signer(chainID *big.Int) EIP2930Signer {
+	return EIP2930Signer{NewEIP155Signer(chainID)}
+}
+
+// Equal checks if the given EIP2930Signer is equal to another Signer.
+func (s EIP2930Signer) Equal(s2 Signer) bool {
+	x, ok := s2.(EIP2930Signer)
+	return ok && x.chainID.Cmp(s.chainID) == 0
+}
+
+// Sender returns the sender address of the given signer.
+func (s EIP2930Signer) Sender(tx InternalTransaction) (common.Address, error) {
+	switch tx.Type() {
+	case LegacyTxType:
+		return s.EIP155Signer.Sender(tx)
+	case AccessListTxType:
+		// The access list transactions are defined to use 0 and 1 as their
+		// recovery id, add 27 to become equivalent to the unprotected Homestead
+		// signatures.
+		if tx.ChainID().Cmp(s.chainID) != 0 {
+			return common.Address{}, ErrInvalidChainID
+		}
+		V := new(big.Int).Add(tx.V(), big.NewInt(27))
+		return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
+	default:
+		return common.Address{}, ErrTxTypeNotSupported
+	}
+}
+
+// SignatureValues returns signature values. This signature
+// needs to be in the [R || S || V] format where V is 0 or 1.
+func (s EIP2930Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S, V *big.Int, err error) {
+	switch tx.Type() {
+	case LegacyTxType:
+		return s.EIP155Signer.SignatureValues(tx, sig)
+	case AccessListTxType:
+		// Check that chain ID of tx matches the signer. We also accept ID zero
+		// here, because it indicates that the chain ID was not specified in the
+		// tx.
+		if tx.ChainID().Sign() != 0 && tx.ChainID().Cmp(s.chainID) != 0 {
+			return nil, nil, nil, ErrInvalidChainID
+		}
+		R, S, _, err = HomesteadSigner{}.SignatureValues(tx, sig)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		V = big.NewInt(int64(sig[64]))
+		return R, S, V, nil
+	default:
+		return nil, nil, nil, ErrTxTypeNotSupported
+	}
+}
+
+// Hash returns the hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s EIP2930Signer) Hash(tx InternalTransaction) common.Hash {
+	if tx.Type() != AccessListTxType {
+		return s.EIP155Signer.Hash(tx)
+	}
+	if params.IsEthCompatible(s.chainID) {
+		return prefixedRLPHash(tx.Type(), []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+	}
+	return prefixedRLPHash(tx.Type(), []interface{}{
+		s.chainID,
+		tx.Nonce(),
+		tx.GasPrice(),
+		tx.GasLimit(),
+		tx.ShardID(),
+		tx.ToShardID(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		tx.AccessList(),
+	})
+}
+
+// LondonSigner implements Signer using the EIP-1559 rules, which accepts the
+// dynamic fee transactions on top of the transactions of EIP2930Signer.
+type LondonSigner struct{ EIP2930Signer }
+
+// NewLondonSigner creates a LondonSigner given chainID.
+func NewLondonSigner(chainID *big.Int) LondonSigner {
+	return LondonSigner{NewEIP2930Signer(chainID)}
+}
+
+// Equal checks if the given LondonSigner is equal to another Signer.
+func (s LondonSigner) Equal(s2 Signer) bool {
+	x, ok := s2.(LondonSigner)
+	return ok && x.chainID.Cmp(s.chainID) == 0
+}
+
+// Sender returns the sender address of the given signer.
+func (s LondonSigner) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != DynamicFeeTxType {
+		return s.EIP2930Signer.Sender(tx)
+	}
+	if tx.ChainID().Cmp(s.chainID) != 0 {
+		return common.Address{}, ErrInvalidChainID
+	}
+	// The dynamic fee transactions use 0 and 1 as their recovery id as well
+	V := new(big.Int).Add(tx.V(), big.NewInt(27))
+	return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
+}
+
+// SignatureValues returns signature values. This signature
+// needs to be in the [R || S || V] format where V is 0 or 1.
+func (s LondonSigner) SignatureValues(tx InternalTransaction, sig []byte) (R, S, V *big.Int, err error) {
+	if tx.Type() != DynamicFeeTxType {
+		return s.EIP2930Signer.SignatureValues(tx, sig)
+	}
+	if tx.ChainID().Sign() != 0 && tx.ChainID().Cmp(s.chainID) != 0 {
+		return nil, nil, nil, ErrInvalidChainID
+	}
+	R, S, _, err = HomesteadSigner{}.SignatureValues(tx, sig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	V = big.NewInt(int64(sig[64]))
+	return R, S, V, nil
+}
+
+// Hash returns the hash to be signed by the sender.
+// It does not uniquely identify the transaction.
+func (s LondonSigner) Hash(tx InternalTransaction) common.Hash {
+	if tx.Type() != DynamicFeeTxType {
+		return s.EIP2930Signer.Hash(tx)
+	}
+	if params.IsEthCompatible(s.chainID) {
+		return prefixedRLPHash(tx.Type(), []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			tx.GasTipCap(),
+			tx.GasFeeCap(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+	}
+	return prefixedRLPHash(tx.Type(), []interface{}{
+		s.chainID,
+		tx.Nonce(),
+		tx.GasTipCap(),
+		tx.GasFeeCap(),
+		tx.GasLimit(),
+		tx.ShardID(),
+		tx.ToShardID(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		tx.AccessList(),
+	})
+}
+
+// prefixedRLPHash writes the prefix into the hasher before rlp-encoding x.
+// It's used for typed transactions.
+func prefixedRLPHash(prefix byte, x interface{}) common.Hash {
+	enc, _ := encodeTyped(prefix, x)
+	return hash.Keccak256Hash(enc)
+}
+
 // EIP155Signer implements Signer using the EIP155 rules.
 type EIP155Signer struct {
 	chainID, chainIDMul *big.Int
@@ -140,6 +338,9 @@ var big8 = big.NewInt(8)
 
 // Sender returns the sender address of the given signer.
 func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != LegacyTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	if !tx.Protected() {
 		return HomesteadSigner{}.Sender(tx)
 	}
@@ -156,6 +357,9 @@ func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
 // SignatureValues returns signature values. This signature
 // needs to be in the [R || S || V] format where V is 0 or 1.
 func (s EIP155Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S, V *big.Int, err error) {
+	if tx.Type() != LegacyTxType {
+		return nil, nil, nil, ErrTxTypeNotSupported
+	}
 	R, S, V, err = HomesteadSigner{}.SignatureValues(tx, sig)
 	if err != nil {
 		return nil, nil, nil, err
@@ -213,6 +417,9 @@ func (hs HomesteadSigner) SignatureValues(tx InternalTransaction, sig []byte) (r
 
 // Sender returns the address of the sender.
 func (hs HomesteadSigner) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != LegacyTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	return recoverPlain(hs.Hash(tx), tx.R(), tx.S(), tx.V(), true)
 }
 
@@ -254,6 +461,9 @@ func (fs FrontierSigner) Hash(tx InternalTransaction) common.Hash {
 
 // Sender returns the sender address of the given transaction.
 func (fs FrontierSigner) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != LegacyTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	return recoverPlain(fs.Hash(tx), tx.R(), tx.S(), tx.V(), false)
 }
 
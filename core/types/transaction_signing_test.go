@@ -17,11 +17,14 @@
 package types
 
 import (
+	"encoding/json"
 	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/internal/params"
 )
 
 func TestEIP155Signing(t *testing.T) {
@@ -96,3 +99,177 @@ func TestChainID(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+var testAccessList = AccessList{
+	{Address: common.Address{1}, StorageKeys: []common.Hash{{1}, {2}}},
+	{Address: common.Address{2}},
+}
+
+func TestEIP2930Signing(t *testing.T) {
+	key, addr := defaultTestKey()
+	to := common.Address{3}
+
+	signer := NewEIP2930Signer(big.NewInt(2))
+	tx, err := SignTx(NewAccessListTransaction(big.NewInt(2), 1, &to, 0, 1, big.NewInt(10), 50000, big.NewInt(1), []byte("abcdef"), testAccessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type() != AccessListTxType || !tx.Protected() || tx.ChainID().Cmp(big.NewInt(2)) != 0 {
+		t.Fatalf("unexpected tx type %v, chain id %v", tx.Type(), tx.ChainID())
+	}
+	if from, err := Sender(signer, tx); err != nil || from != addr {
+		t.Fatalf("unexpected sender %x, %v", from, err)
+	}
+	if _, err := Sender(NewEIP2930Signer(big.NewInt(3)), tx); err != ErrInvalidChainID {
+		t.Errorf("expected error %v, got %v", ErrInvalidChainID, err)
+	}
+	if _, err := Sender(NewEIP155Signer(big.NewInt(2)), tx); err != ErrTxTypeNotSupported {
+		t.Errorf("expected error %v, got %v", ErrTxTypeNotSupported, err)
+	}
+
+	// The legacy transactions are still accepted by the EIP2930Signer
+	legacy, err := SignTx(NewTransaction(0, to, 0, new(big.Int), 21000, new(big.Int), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from, err := Sender(NewEIP155Signer(big.NewInt(2)), legacy); err != nil || from != addr {
+		t.Fatalf("unexpected sender of legacy tx %x, %v", from, err)
+	}
+}
+
+func TestEIP2930Encoding(t *testing.T) {
+	key, addr := defaultTestKey()
+	to := common.Address{3}
+
+	signer := NewEIP2930Signer(big.NewInt(2))
+	tx, err := SignTx(NewAccessListTransaction(big.NewInt(2), 1, &to, 0, 1, big.NewInt(10), 50000, big.NewInt(1), []byte("abcdef"), testAccessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc[0] != AccessListTxType {
+		t.Fatalf("unexpected envelope type %v", enc[0])
+	}
+	if tx.Hash() != crypto.Keccak256Hash(enc) {
+		t.Errorf("unexpected tx hash %x", tx.Hash())
+	}
+
+	var binTx Transaction
+	if err := binTx.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	rlpEnc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rlpTx Transaction
+	if err := rlp.DecodeBytes(rlpEnc, &rlpTx); err != nil {
+		t.Fatal(err)
+	}
+	jsonEnc, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jsonTx Transaction
+	if err := json.Unmarshal(jsonEnc, &jsonTx); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, decoded := range []*Transaction{&binTx, &rlpTx, &jsonTx} {
+		if decoded.Hash() != tx.Hash() {
+			t.Errorf("Test %v: unexpected hash %x / %x", i, decoded.Hash(), tx.Hash())
+		}
+		if decoded.ShardID() != 0 || decoded.ToShardID() != 1 || decoded.AccessList().StorageKeys() != 2 {
+			t.Errorf("Test %v: unexpected decoded tx %v", i, decoded)
+		}
+		if from, err := Sender(signer, decoded); err != nil || from != addr {
+			t.Errorf("Test %v: unexpected sender %x, %v", i, from, err)
+		}
+	}
+}
+
+func TestEIP2930EthEncoding(t *testing.T) {
+	key, addr := defaultTestKey()
+	to := common.Address{3}
+	chainID := params.EthMainnetShard0ChainID
+
+	signer := NewEIP2930Signer(chainID)
+	tx, err := SignEthTx(NewEthAccessListTransaction(chainID, 1, &to, big.NewInt(10), 50000, big.NewInt(1), nil, testAccessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash() != crypto.Keccak256Hash(enc) {
+		t.Errorf("unexpected tx hash %x", tx.Hash())
+	}
+	var decoded EthTransaction
+	if err := decoded.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != tx.Hash() || decoded.Type() != AccessListTxType {
+		t.Errorf("unexpected decoded tx %v", decoded)
+	}
+	if from, err := decoded.SenderAddress(); err != nil || from != addr {
+		t.Errorf("unexpected sender %x, %v", from, err)
+	}
+	if from, err := decoded.ConvertToHmy().SenderAddress(); err != nil || from != addr {
+		t.Errorf("unexpected sender of converted tx %x, %v", from, err)
+	}
+}
+
+func TestLondonSigningAndEncoding(t *testing.T) {
+	key, addr := defaultTestKey()
+	to := common.Address{3}
+
+	signer := NewLondonSigner(big.NewInt(2))
+	tx, err := SignTx(NewDynamicFeeTransaction(big.NewInt(2), 1, &to, 0, 1, big.NewInt(10), 50000, big.NewInt(2), big.NewInt(5), []byte("abcdef"), testAccessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type() != DynamicFeeTxType || tx.GasTipCap().Int64() != 2 || tx.GasFeeCap().Int64() != 5 {
+		t.Fatalf("unexpected tx type %v, tip cap %v, fee cap %v", tx.Type(), tx.GasTipCap(), tx.GasFeeCap())
+	}
+	if from, err := Sender(signer, tx); err != nil || from != addr {
+		t.Fatalf("unexpected sender %x, %v", from, err)
+	}
+	if _, err := Sender(NewEIP2930Signer(big.NewInt(2)), tx); err != ErrTxTypeNotSupported {
+		t.Errorf("expected error %v, got %v", ErrTxTypeNotSupported, err)
+	}
+
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc[0] != DynamicFeeTxType || tx.Hash() != crypto.Keccak256Hash(enc) {
+		t.Fatalf("unexpected envelope type %v, hash %x", enc[0], tx.Hash())
+	}
+	var binTx Transaction
+	if err := binTx.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	jsonEnc, err := json.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jsonTx Transaction
+	if err := json.Unmarshal(jsonEnc, &jsonTx); err != nil {
+		t.Fatal(err)
+	}
+	for i, decoded := range []*Transaction{&binTx, &jsonTx} {
+		if decoded.Hash() != tx.Hash() {
+			t.Errorf("Test %v: unexpected hash %x / %x", i, decoded.Hash(), tx.Hash())
+		}
+		if decoded.GasTipCap().Cmp(tx.GasTipCap()) != 0 || decoded.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 {
+			t.Errorf("Test %v: unexpected fee caps %v / %v", i, decoded.GasTipCap(), decoded.GasFeeCap())
+		}
+		if from, err := Sender(signer, decoded); err != nil || from != addr {
+			t.Errorf("Test %v: unexpected sender %x, %v", i, from, err)
+		}
+	}
+}
@@ -53,7 +53,7 @@ func TestTransactionPriceNonceSort(t *testing.T) {
 		}
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := NewTransactionsByPriceAndNonce(signer, signer, groups)
+	txset := NewTransactionsByPriceAndNonce(signer, signer, groups, nil)
 
 	txs := InternalTransactions{}
 	for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
@@ -133,3 +133,69 @@ func TestTransactionJSON(t *testing.T) {
 		}
 	}
 }
+
+func TestTransactionEffectiveGasTip(t *testing.T) {
+	to := common.Address{1}
+	dynamic := NewDynamicFeeTransaction(common.Big1, 0, &to, 0, 0, common.Big0, 21000, big.NewInt(2), big.NewInt(10), nil, nil)
+	legacy := NewTransaction(0, to, 0, common.Big0, 21000, big.NewInt(10), nil)
+
+	tests := []struct {
+		tx       *Transaction
+		baseFee  *big.Int
+		tip      int64
+		price    int64
+		tooLowFC bool
+	}{
+		{dynamic, nil, 2, 10, false},
+		{dynamic, big.NewInt(5), 2, 7, false},
+		{dynamic, big.NewInt(9), 1, 10, false},
+		{dynamic, big.NewInt(11), 0, 10, true},
+		{legacy, nil, 10, 10, false},
+		{legacy, big.NewInt(4), 6, 10, false},
+	}
+	for i, test := range tests {
+		tip, err := test.tx.EffectiveGasTip(test.baseFee)
+		if (err == ErrGasFeeCapTooLow) != test.tooLowFC {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+		if err == nil && tip.Int64() != test.tip {
+			t.Errorf("test %d: unexpected tip %v / %v", i, tip, test.tip)
+		}
+		if price := test.tx.EffectiveGasPrice(test.baseFee); price.Int64() != test.price {
+			t.Errorf("test %d: unexpected price %v / %v", i, price, test.price)
+		}
+	}
+}
+
+// Tests that the transactions are sorted by the effective tip given the base
+// fee, and the transactions whose fee cap is below the base fee are skipped.
+func TestTransactionBaseFeeSort(t *testing.T) {
+	signer := NewLondonSigner(common.Big1)
+	var (
+		baseFee = big.NewInt(10)
+		to      = common.Address{}
+		groups  = map[common.Address]Transactions{}
+		keys    = make([]*ecdsa.PrivateKey, 4)
+	)
+	// tip caps and fee caps, whose effective tips are 8, 3, 1 and too low
+	caps := [][2]int64{{8, 20}, {5, 13}, {3, 11}, {5, 9}}
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		tx, _ := SignTx(NewDynamicFeeTransaction(common.Big1, 0, &to, 0, 0, common.Big0, 21000, big.NewInt(caps[i][0]), big.NewInt(caps[i][1]), nil, nil), signer, keys[i])
+		groups[crypto.PubkeyToAddress(keys[i].PublicKey)] = Transactions{tx}
+	}
+	txset := NewTransactionsByPriceAndNonce(signer, signer, groups, baseFee)
+
+	var tips []int64
+	for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			t.Fatalf("unexpected tx with fee cap %v", tx.GasFeeCap())
+		}
+		tips = append(tips, tip.Int64())
+		txset.Shift()
+	}
+	if len(tips) != 3 || tips[0] != 8 || tips[1] != 3 || tips[2] != 1 {
+		t.Errorf("unexpected tips %v", tips)
+	}
+}
@@ -0,0 +1,63 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+func TestAccessListTracer(t *testing.T) {
+	var (
+		from     = common.HexToAddress("0x01")
+		to       = common.HexToAddress("0x02")
+		other    = common.HexToAddress("0x03")
+		listed   = common.HexToAddress("0x04")
+		precomp  = common.BytesToAddress([]byte{1})
+		env      = NewEVM(Context{}, &dummyStatedb{}, params.TestChainConfig, Config{})
+		mem      = NewMemory()
+		contract = NewContract(&dummyContractRef{}, &dummyContractRef{}, new(big.Int), 0)
+		slot     = common.HexToHash("0x2a")
+	)
+	acl := types.AccessList{{Address: listed, StorageKeys: []common.Hash{slot}}}
+	tracer := NewAccessListTracer(acl, from, to, []common.Address{precomp})
+	initial := NewAccessListTracer(acl, from, to, []common.Address{precomp})
+
+	capture := func(op OpCode, items ...*big.Int) {
+		stack := newstack()
+		for i := len(items) - 1; i >= 0; i-- {
+			stack.push(items[i])
+		}
+		tracer.CaptureState(env, 0, op, 0, 0, mem, stack, contract, 0, nil)
+	}
+	// Touching the sender, the recipient and the precompiles changes nothing
+	capture(BALANCE, new(big.Int).SetBytes(from.Bytes()))
+	capture(EXTCODESIZE, new(big.Int).SetBytes(to.Bytes()))
+	capture(CALL, new(big.Int), new(big.Int).SetBytes(precomp.Bytes()), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int))
+	if !tracer.Equal(initial) {
+		t.Fatalf("unexpected access list %v", tracer.AccessList())
+	}
+	capture(SLOAD, new(big.Int).SetBytes(slot.Bytes()))
+	capture(STATICCALL, new(big.Int), new(big.Int).SetBytes(other.Bytes()), new(big.Int), new(big.Int), new(big.Int), new(big.Int))
+	if tracer.Equal(initial) {
+		t.Fatal("access list not expanded")
+	}
+
+	list := make(map[common.Address][]common.Hash)
+	for _, tuple := range tracer.AccessList() {
+		list[tuple.Address] = tuple.StorageKeys
+	}
+	if len(list) != 3 {
+		t.Fatalf("unexpected access list %v", list)
+	}
+	for _, addr := range []common.Address{listed, contract.Address()} {
+		if keys := list[addr]; len(keys) != 1 || keys[0] != slot {
+			t.Errorf("unexpected storage keys of %x: %v", addr, keys)
+		}
+	}
+	if keys, ok := list[other]; !ok || len(keys) != 0 {
+		t.Errorf("unexpected storage keys of %x: %v", other, keys)
+	}
+}
@@ -0,0 +1,75 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChainInfoPrecompileABI is the ABI of the chain info precompile
+const ChainInfoPrecompileABI = `[
+	{"type":"function","name":"vrf","stateMutability":"view","inputs":[{"name":"blockNumber","type":"uint256"}],"outputs":[{"name":"","type":"bytes32"}]},
+	{"type":"function","name":"epoch","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"shardId","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint32"}]}
+]`
+
+var (
+	// ChainInfoPrecompileAddress is the address of the chain info precompile
+	ChainInfoPrecompileAddress = common.BytesToAddress([]byte{251})
+
+	chainInfoABI = mustParseABI(ChainInfoPrecompileABI)
+)
+
+var (
+	errChainInfoInput       = errors.New("invalid chain info precompile input")
+	errChainInfoVRFNotFound = errors.New("vrf is only available for the current block and the 256 blocks before it")
+)
+
+// chainInfo is a read only precompile which returns the VRF of the current block
+// or the 256 blocks before it, the current epoch and the shard ID. All of them
+// are read from the block headers, so that the results are the same when the
+// blocks are re-executed during sync.
+type chainInfo struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *chainInfo) RequiredGas(input []byte) uint64 {
+	return GasExtStep
+}
+
+// Run is not supported, as the chain info precompile needs the EVM to run.
+func (c *chainInfo) Run(input []byte) ([]byte, error) {
+	return nil, errChainInfoInput
+}
+
+// RunStateful returns the chain info requested by the input.
+func (c *chainInfo) RunStateful(
+	evm *EVM, contract *Contract, input []byte, readOnly bool,
+) ([]byte, error) {
+	if len(input) < 4 {
+		return nil, errChainInfoInput
+	}
+	method, err := chainInfoABI.MethodById(input[:4])
+	if err != nil {
+		return nil, errChainInfoInput
+	}
+	args, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return nil, errChainInfoInput
+	}
+
+	switch method.Name {
+	case "vrf":
+		vrf, ok := evm.recentVRF(args[0].(*big.Int))
+		if !ok {
+			return nil, errChainInfoVRFNotFound
+		}
+		return method.Outputs.Pack(vrf)
+	case "epoch":
+		return method.Outputs.Pack(new(big.Int).Set(evm.EpochNumber))
+	case "shardId":
+		return method.Outputs.Pack(evm.ShardID)
+	default:
+		return nil, errChainInfoInput
+	}
+}
@@ -0,0 +1,106 @@
+package vm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var chainInfoTestVRF = common.HexToHash("0x8a5bbd1c8c5b1f1ba7b1bfe1b41c4e4b2d0c9b6dbb40ec9e1b5d22a0b3e2f1a9")
+
+// newChainInfoTestEVM returns the EVM of block 300 of epoch 7 on shard 3, where
+// the VRF of the previous blocks is the hash of the block number.
+func newChainInfoTestEVM() *EVM {
+	ctx := Context{
+		CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *big.Int, types.TransactionType) {},
+		IsValidator: func(StateDB, common.Address) bool { return false },
+		GetVRF: func(n uint64) common.Hash {
+			return crypto.Keccak256Hash(new(big.Int).SetUint64(n).Bytes())
+		},
+		BlockNumber: big.NewInt(300),
+		EpochNumber: big.NewInt(7),
+		VRF:         chainInfoTestVRF,
+		ShardID:     3,
+	}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	return NewEVM(ctx, statedb, params.TestChainConfig, Config{})
+}
+
+func packChainInfoInput(t *testing.T, method string, args ...interface{}) []byte {
+	input, err := chainInfoABI.Pack(method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input
+}
+
+func TestChainInfoPrecompile(t *testing.T) {
+	blockVRF := func(n uint64) []byte {
+		return crypto.Keccak256(new(big.Int).SetUint64(n).Bytes())
+	}
+	tests := []struct {
+		name     string
+		input    []byte
+		expected []byte
+		err      error
+	}{
+		{"vrf of current block", packChainInfoInput(t, "vrf", big.NewInt(300)), chainInfoTestVRF.Bytes(), nil},
+		{"vrf of parent block", packChainInfoInput(t, "vrf", big.NewInt(299)), blockVRF(299), nil},
+		{"vrf of oldest block", packChainInfoInput(t, "vrf", big.NewInt(44)), blockVRF(44), nil},
+		{"vrf of too old block", packChainInfoInput(t, "vrf", big.NewInt(43)), nil, errChainInfoVRFNotFound},
+		{"vrf of future block", packChainInfoInput(t, "vrf", big.NewInt(301)), nil, errChainInfoVRFNotFound},
+		{"epoch", packChainInfoInput(t, "epoch"), common.LeftPadBytes([]byte{7}, 32), nil},
+		{"shard id", packChainInfoInput(t, "shardId"), common.LeftPadBytes([]byte{3}, 32), nil},
+		{"unknown method", []byte{1, 2, 3, 4}, nil, errChainInfoInput},
+		{"short input", []byte{1}, nil, errChainInfoInput},
+	}
+	for _, test := range tests {
+		// The results must be the same when the block is re-executed
+		for i := 0; i < 2; i++ {
+			evm := newChainInfoTestEVM()
+			ret, gas, err := evm.StaticCall(AccountRef(common.Address{1}), ChainInfoPrecompileAddress, test.input, 10000)
+			if err != test.err {
+				t.Errorf("%s: unexpected error %v, expected %v", test.name, err, test.err)
+				continue
+			}
+			if err == nil && 10000-gas != GasExtStep {
+				t.Errorf("%s: unexpected gas used %v", test.name, 10000-gas)
+			}
+			if !bytes.Equal(ret, test.expected) {
+				t.Errorf("%s: returned %x, expected %x", test.name, ret, test.expected)
+			}
+		}
+	}
+}
+
+func TestVRFPrecompile(t *testing.T) {
+	vrfAddr := common.BytesToAddress([]byte{255})
+	tests := []struct {
+		number   int64
+		expected []byte
+	}{
+		{300, chainInfoTestVRF.Bytes()},
+		{299, crypto.Keccak256(big.NewInt(299).Bytes())},
+		// out of range block numbers default to the VRF of the current block
+		{43, chainInfoTestVRF.Bytes()},
+		{301, chainInfoTestVRF.Bytes()},
+	}
+	for _, test := range tests {
+		evm := newChainInfoTestEVM()
+		ret, _, err := evm.StaticCall(AccountRef(common.Address{1}), vrfAddr, common.LeftPadBytes(big.NewInt(test.number).Bytes(), 32), 10000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(ret, test.expected) {
+			t.Errorf("block %v: returned %x, expected %x", test.number, ret, test.expected)
+		}
+	}
+}
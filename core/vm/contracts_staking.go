@@ -0,0 +1,176 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// StakingPrecompileABI is the ABI of the staking precompile. The delegator of the
+// directives is always the caller of the precompile, i.e. msg.sender.
+const StakingPrecompileABI = `[
+	{"type":"function","name":"delegate","inputs":[{"name":"validatorAddress","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"undelegate","inputs":[{"name":"validatorAddress","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"collectRewards","inputs":[],"outputs":[{"name":"amount","type":"uint256"}]},
+	{"type":"event","name":"Delegated","inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"validator","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Undelegated","inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"validator","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false}]},
+	{"type":"event","name":"RewardsCollected","inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false}]}
+]`
+
+var (
+	// StakingPrecompileAddress is the address of the staking precompile
+	StakingPrecompileAddress = common.BytesToAddress([]byte{252})
+
+	stakingABI = mustParseABI(StakingPrecompileABI)
+
+	// StakingDelegatedTopic, StakingUndelegatedTopic and StakingRewardsCollectedTopic
+	// are the topics of the events logged by the staking precompile
+	StakingDelegatedTopic        = stakingABI.Events["Delegated"].ID()
+	StakingUndelegatedTopic      = stakingABI.Events["Undelegated"].ID()
+	StakingRewardsCollectedTopic = stakingABI.Events["RewardsCollected"].ID()
+)
+
+var (
+	errStakingPrecompileNotSupported = errors.New("staking precompile is not supported")
+	errStakingPrecompileNotCalled    = errors.New("staking precompile must be invoked with CALL")
+	errStakingPrecompileValue        = errors.New("staking precompile does not accept value")
+	errStakingPrecompileInput        = errors.New("invalid staking precompile input")
+)
+
+func mustParseABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// stakingPrecompile lets smart contracts issue the Delegate, Undelegate and
+// CollectRewards staking directives, with the caller as the delegator.
+type stakingPrecompile struct{}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *stakingPrecompile) RequiredGas(input []byte) uint64 {
+	return params.StakingPrecompileGas
+}
+
+// Run is not supported, as the staking precompile needs the EVM to run.
+func (c *stakingPrecompile) Run(input []byte) ([]byte, error) {
+	return nil, errStakingPrecompileNotSupported
+}
+
+// RunStateful decodes the staking directive in the input and applies it with the
+// staking functions of the EVM context.
+func (c *stakingPrecompile) RunStateful(
+	evm *EVM, contract *Contract, input []byte, readOnly bool,
+) ([]byte, error) {
+	if readOnly {
+		return nil, errWriteProtection
+	}
+	// DELEGATECALL and CALLCODE would run the directive on behalf of the caller
+	// of the calling contract, so they are rejected.
+	if contract.Address() != StakingPrecompileAddress {
+		return nil, errStakingPrecompileNotCalled
+	}
+	if contract.Value().Sign() != 0 {
+		return nil, errStakingPrecompileValue
+	}
+	if len(input) < 4 {
+		return nil, errStakingPrecompileInput
+	}
+	method, err := stakingABI.MethodById(input[:4])
+	if err != nil {
+		return nil, errStakingPrecompileInput
+	}
+	args, err := method.Inputs.UnpackValues(input[4:])
+	if err != nil {
+		return nil, errStakingPrecompileInput
+	}
+	delegator := contract.Caller()
+
+	switch method.Name {
+	case "delegate":
+		if evm.Context.Delegate == nil {
+			return nil, errStakingPrecompileNotSupported
+		}
+		msg := &staking.Delegate{
+			DelegatorAddress: delegator,
+			ValidatorAddress: args[0].(common.Address),
+			Amount:           args[1].(*big.Int),
+		}
+		if err := evm.Context.Delegate(evm.StateDB, msg); err != nil {
+			return nil, err
+		}
+		evm.addStakingLog(StakingDelegatedTopic, msg.Amount, delegator, msg.ValidatorAddress)
+		return nil, nil
+	case "undelegate":
+		if evm.Context.Undelegate == nil {
+			return nil, errStakingPrecompileNotSupported
+		}
+		msg := &staking.Undelegate{
+			DelegatorAddress: delegator,
+			ValidatorAddress: args[0].(common.Address),
+			Amount:           args[1].(*big.Int),
+		}
+		if err := evm.Context.Undelegate(evm.StateDB, msg); err != nil {
+			return nil, err
+		}
+		evm.addStakingLog(StakingUndelegatedTopic, msg.Amount, delegator, msg.ValidatorAddress)
+		return nil, nil
+	case "collectRewards":
+		if evm.Context.CollectRewards == nil {
+			return nil, errStakingPrecompileNotSupported
+		}
+		rewards, err := evm.Context.CollectRewards(evm.StateDB, &staking.CollectRewards{
+			DelegatorAddress: delegator,
+		})
+		if err != nil {
+			return nil, err
+		}
+		evm.addStakingLog(StakingRewardsCollectedTopic, rewards, delegator)
+		return method.Outputs.Pack(rewards)
+	default:
+		return nil, errStakingPrecompileInput
+	}
+}
+
+// addStakingLog adds the event log of a staking directive of the staking
+// precompile, where the addresses are the indexed topics and the amount is the
+// data, as the events of StakingPrecompileABI.
+func (evm *EVM) addStakingLog(topic common.Hash, amount *big.Int, addrs ...common.Address) {
+	topics := []common.Hash{topic}
+	for _, addr := range addrs {
+		topics = append(topics, addr.Hash())
+	}
+	evm.StateDB.AddLog(&types.Log{
+		Address:     StakingPrecompileAddress,
+		Topics:      topics,
+		Data:        math.PaddedBigBytes(amount, 32),
+		BlockNumber: evm.BlockNumber.Uint64(),
+	})
+}
+
+// ParseStakingDelegations returns the delegations made through the staking
+// precompile from the logs, in order.
+func ParseStakingDelegations(logs []*types.Log) []*staking.Delegate {
+	var delegations []*staking.Delegate
+	for _, log := range logs {
+		if log.Address != StakingPrecompileAddress || len(log.Topics) != 3 ||
+			log.Topics[0] != StakingDelegatedTopic {
+			continue
+		}
+		delegations = append(delegations, &staking.Delegate{
+			DelegatorAddress: common.BytesToAddress(log.Topics[1].Bytes()),
+			ValidatorAddress: common.BytesToAddress(log.Topics[2].Bytes()),
+			Amount:           new(big.Int).SetBytes(log.Data),
+		})
+	}
+	return delegations
+}
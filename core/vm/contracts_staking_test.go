@@ -0,0 +1,215 @@
+package vm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+var (
+	stakingTestDelegator = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	stakingTestValidator = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	stakingTestContract  = common.HexToAddress("0x3000000000000000000000000000000000000003")
+
+	errStakingTest = errors.New("staking test error")
+)
+
+// stakingTestHooks records the staking directives applied by the staking precompile
+type stakingTestHooks struct {
+	delegates      []*staking.Delegate
+	undelegates    []*staking.Undelegate
+	collectRewards []*staking.CollectRewards
+	rewards        *big.Int
+	err            error
+}
+
+func newStakingTestEVM(hooks *stakingTestHooks) (*EVM, *state.DB) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	ctx := Context{
+		CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+		Transfer:    func(StateDB, common.Address, common.Address, *big.Int, types.TransactionType) {},
+		IsValidator: func(StateDB, common.Address) bool { return false },
+		BlockNumber: big.NewInt(10),
+		EpochNumber: big.NewInt(1),
+	}
+	if hooks != nil {
+		ctx.Delegate = func(db StateDB, msg *staking.Delegate) error {
+			hooks.delegates = append(hooks.delegates, msg)
+			return hooks.err
+		}
+		ctx.Undelegate = func(db StateDB, msg *staking.Undelegate) error {
+			hooks.undelegates = append(hooks.undelegates, msg)
+			return hooks.err
+		}
+		ctx.CollectRewards = func(db StateDB, msg *staking.CollectRewards) (*big.Int, error) {
+			hooks.collectRewards = append(hooks.collectRewards, msg)
+			return hooks.rewards, hooks.err
+		}
+	}
+	return NewEVM(ctx, statedb, params.TestChainConfig, Config{}), statedb
+}
+
+func packStakingInput(t *testing.T, method string, args ...interface{}) []byte {
+	input, err := stakingABI.Pack(method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input
+}
+
+func TestStakingPrecompile(t *testing.T) {
+	hooks := &stakingTestHooks{rewards: big.NewInt(42)}
+	evm, statedb := newStakingTestEVM(hooks)
+	amount := big.NewInt(1000)
+
+	calls := []struct {
+		input    []byte
+		expected []byte
+	}{
+		{packStakingInput(t, "delegate", stakingTestValidator, amount), nil},
+		{packStakingInput(t, "undelegate", stakingTestValidator, amount), nil},
+		{packStakingInput(t, "collectRewards"), common.LeftPadBytes([]byte{42}, 32)},
+	}
+	for i, call := range calls {
+		ret, gas, err := evm.Call(AccountRef(stakingTestDelegator), StakingPrecompileAddress, call.input, 100000, new(big.Int))
+		if err != nil {
+			t.Fatalf("call %d: unexpected error %v", i, err)
+		}
+		if used := 100000 - gas; used != params.StakingPrecompileGas {
+			t.Errorf("call %d: gas used %v, expected %v", i, used, params.StakingPrecompileGas)
+		}
+		if common.Bytes2Hex(ret) != common.Bytes2Hex(call.expected) {
+			t.Errorf("call %d: returned %x, expected %x", i, ret, call.expected)
+		}
+	}
+
+	if len(hooks.delegates) != 1 || hooks.delegates[0].DelegatorAddress != stakingTestDelegator ||
+		hooks.delegates[0].ValidatorAddress != stakingTestValidator || hooks.delegates[0].Amount.Cmp(amount) != 0 {
+		t.Errorf("unexpected delegations %+v", hooks.delegates)
+	}
+	if len(hooks.undelegates) != 1 || hooks.undelegates[0].DelegatorAddress != stakingTestDelegator ||
+		hooks.undelegates[0].ValidatorAddress != stakingTestValidator || hooks.undelegates[0].Amount.Cmp(amount) != 0 {
+		t.Errorf("unexpected undelegations %+v", hooks.undelegates)
+	}
+	if len(hooks.collectRewards) != 1 || hooks.collectRewards[0].DelegatorAddress != stakingTestDelegator {
+		t.Errorf("unexpected reward collections %+v", hooks.collectRewards)
+	}
+
+	logs := statedb.Logs()
+	topics := []common.Hash{StakingDelegatedTopic, StakingUndelegatedTopic, StakingRewardsCollectedTopic}
+	if len(logs) != len(topics) {
+		t.Fatalf("unexpected number of logs %v", len(logs))
+	}
+	for i, log := range logs {
+		if log.Address != StakingPrecompileAddress || log.Topics[0] != topics[i] ||
+			log.Topics[1] != stakingTestDelegator.Hash() || log.BlockNumber != 10 {
+			t.Errorf("log %d: unexpected log %+v", i, log)
+		}
+	}
+	delegations := ParseStakingDelegations(logs)
+	if len(delegations) != 1 || delegations[0].DelegatorAddress != stakingTestDelegator ||
+		delegations[0].ValidatorAddress != stakingTestValidator || delegations[0].Amount.Cmp(amount) != 0 {
+		t.Errorf("unexpected parsed delegations %+v", delegations)
+	}
+}
+
+func TestStakingPrecompileFailure(t *testing.T) {
+	delegateInput := packStakingInput(t, "delegate", stakingTestValidator, big.NewInt(1000))
+
+	tests := []struct {
+		name  string
+		hooks *stakingTestHooks
+		call  func(evm *EVM) error
+		err   error
+	}{
+		{
+			name:  "unsupported",
+			hooks: nil,
+			call: func(evm *EVM) error {
+				_, _, err := evm.Call(AccountRef(stakingTestDelegator), StakingPrecompileAddress, delegateInput, 100000, new(big.Int))
+				return err
+			},
+			err: errStakingPrecompileNotSupported,
+		},
+		{
+			name:  "directive failure",
+			hooks: &stakingTestHooks{err: errStakingTest},
+			call: func(evm *EVM) error {
+				_, _, err := evm.Call(AccountRef(stakingTestDelegator), StakingPrecompileAddress, delegateInput, 100000, new(big.Int))
+				return err
+			},
+			err: errStakingTest,
+		},
+		{
+			name:  "static call",
+			hooks: &stakingTestHooks{},
+			call: func(evm *EVM) error {
+				_, _, err := evm.StaticCall(AccountRef(stakingTestDelegator), StakingPrecompileAddress, delegateInput, 100000)
+				return err
+			},
+			err: errWriteProtection,
+		},
+		{
+			name:  "delegate call",
+			hooks: &stakingTestHooks{},
+			call: func(evm *EVM) error {
+				caller := NewContract(AccountRef(stakingTestDelegator), AccountRef(stakingTestContract), new(big.Int), 100000)
+				_, _, err := evm.DelegateCall(caller, StakingPrecompileAddress, delegateInput, 100000)
+				return err
+			},
+			err: errStakingPrecompileNotCalled,
+		},
+		{
+			name:  "value",
+			hooks: &stakingTestHooks{},
+			call: func(evm *EVM) error {
+				_, _, err := evm.Call(AccountRef(stakingTestDelegator), StakingPrecompileAddress, delegateInput, 100000, big.NewInt(1))
+				return err
+			},
+			err: errStakingPrecompileValue,
+		},
+		{
+			name:  "unknown method",
+			hooks: &stakingTestHooks{},
+			call: func(evm *EVM) error {
+				_, _, err := evm.Call(AccountRef(stakingTestDelegator), StakingPrecompileAddress, []byte{1, 2, 3, 4}, 100000, new(big.Int))
+				return err
+			},
+			err: errStakingPrecompileInput,
+		},
+		{
+			name:  "short arguments",
+			hooks: &stakingTestHooks{},
+			call: func(evm *EVM) error {
+				_, _, err := evm.Call(AccountRef(stakingTestDelegator), StakingPrecompileAddress, delegateInput[:36], 100000, new(big.Int))
+				return err
+			},
+			err: errStakingPrecompileInput,
+		},
+		{
+			name:  "out of gas",
+			hooks: &stakingTestHooks{},
+			call: func(evm *EVM) error {
+				_, _, err := evm.Call(AccountRef(stakingTestDelegator), StakingPrecompileAddress, delegateInput, params.StakingPrecompileGas-1, new(big.Int))
+				return err
+			},
+			err: ErrOutOfGas,
+		},
+	}
+	for _, test := range tests {
+		evm, statedb := newStakingTestEVM(test.hooks)
+		if err := test.call(evm); err != test.err {
+			t.Errorf("%s: unexpected error %v, expected %v", test.name, err, test.err)
+		}
+		if logs := statedb.Logs(); len(logs) != 0 {
+			t.Errorf("%s: unexpected logs %v", test.name, logs)
+		}
+	}
+}
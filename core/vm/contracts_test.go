@@ -21,11 +21,17 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/internal/params"
 )
 
+// allPrecompiles contains the pre-compiled contracts of the latest release and
+// the BLS12-381 curve operations.
+var allPrecompiles = activePrecompiledContracts(params.Rules{IsSHA3: true, IsBLS12381: true})
+
 // precompiledTest defines the input/output pairs for precompiled contract tests.
 type precompiledTest struct {
 	input, expected string
@@ -399,7 +405,7 @@ var blake2FTests = []precompiledTest{
 }
 
 func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := allPrecompiles[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -418,7 +424,7 @@ func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := allPrecompiles[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), p.RequiredGas(in)-1)
@@ -436,7 +442,7 @@ func testPrecompiledOOG(addr string, test precompiledTest, t *testing.T) {
 }
 
 func testPrecompiledFailure(addr string, test precompiledFailureTest, t *testing.T) {
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := allPrecompiles[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("31337")),
 		nil, new(big.Int), p.RequiredGas(in))
@@ -458,7 +464,7 @@ func benchmarkPrecompiled(addr string, test precompiledTest, bench *testing.B) {
 	if test.noBenchmark {
 		return
 	}
-	p := PrecompiledContractsSHA3FIPS[common.HexToAddress(addr)]
+	p := allPrecompiles[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	reqGas := p.RequiredGas(in)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
@@ -717,3 +723,295 @@ func TestPrecompiledEcrecoverPublicKey(t *testing.T) {
 	}
 
 }
+
+// BLS12-381 test vectors, which are the encodings of the curve points of EIP-2537
+var (
+	// generator of G1
+	blsG1 = "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb" +
+		"0000000000000000000000000000000008b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1"
+	// 2 * generator of G1
+	blsG1x2 = "000000000000000000000000000000000572cbea904d67468808c8eb50a9450c9721db309128012543902d0ac358a62ae28f75bb8f1c7c42c39a8c5529bf0f4e" +
+		"00000000000000000000000000000000166a9d8cabc673a322fda673779d8e3822ba3ecb8670e461f73bb9021d5fd76a4c56d9d4cd16bd1bba86881979749d28"
+	// 3 * generator of G1
+	blsG1x3 = "0000000000000000000000000000000009ece308f9d1f0131765212deca99697b112d61f9be9a5f1f3780a51335b3ff981747a0b2ca2179b96d2c0c9024e5224" +
+		"00000000000000000000000000000000032b80d3a6f5b09f8a84623389c5f80ca69a0cddabc3097f9d9c27310fd43be6e745256c634af45ca3473b0590ae30d1"
+	// negation of the generator of G1
+	blsG1Neg = "0000000000000000000000000000000017f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb" +
+		"00000000000000000000000000000000114d1d6855d545a8aa7d76c8cf2e21f267816aef1db507c96655b9d5caac42364e6f38ba0ecb751bad54dcd6b939c2ca"
+	// generator of G2
+	blsG2 = "00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8" +
+		"0000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+		"000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801" +
+		"000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be"
+	// 2 * generator of G2
+	blsG2x2 = "000000000000000000000000000000001638533957d540a9d2370f17cc7ed5863bc0b995b8825e0ee1ea1e1e4d00dbae81f14b0bf3611b78c952aacab827a053" +
+		"000000000000000000000000000000000a4edef9c1ed7f729f520e47730a124fd70662a904ba1074728114d1031e1572c6c886f6b57ec72a6178288c47c33577" +
+		"000000000000000000000000000000000468fb440d82b0630aeb8dca2b5256789a66da69bf91009cbfe6bd221e47aa8ae88dece9764bf3bd999d95d71e4c9899" +
+		"000000000000000000000000000000000f6d4552fa65dd2638b361543f887136a43253d9c66c411697003f7a13c308f5422e1aa0a59c8967acdefd8b6e36ccf3"
+	// 3 * generator of G2
+	blsG2x3 = "00000000000000000000000000000000122915c824a0857e2ee414a3dccb23ae691ae54329781315a0c75df1c04d6d7a50a030fc866f09d516020ef82324afae" +
+		"0000000000000000000000000000000009380275bbc8e5dcea7dc4dd7e0550ff2ac480905396eda55062650f8d251c96eb480673937cc6d9d6a44aaa56ca66dc" +
+		"000000000000000000000000000000000b21da7955969e61010c7a1abc1a6f0136961d1e3b20b1a7326ac738fef5c721479dfd948b52fdf2455e44813ecfd892" +
+		"0000000000000000000000000000000008f239ba329b3967fe48d718a36cfe5f62a7e42e0bf1c1ed714150a166bfbd6bcf6b3b58b975b9edea56d53f23a0e849"
+	// point at infinity of G1 and G2
+	blsG1Zero = strings.Repeat("00", 128)
+	blsG2Zero = strings.Repeat("00", 256)
+	// point (0, 2) is on the G1 curve, but not in the correct subgroup as it is of order 3
+	blsG1NotInSubgroup = strings.Repeat("00", 127) + "02"
+
+	blsScalar0 = strings.Repeat("00", 32)
+	blsScalar1 = strings.Repeat("00", 31) + "01"
+	blsScalar2 = strings.Repeat("00", 31) + "02"
+	blsScalar3 = strings.Repeat("00", 31) + "03"
+	// group order q + 2, which is reduced to 2
+	blsScalarQPlus2 = "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000003"
+
+	blsPairingTrue  = strings.Repeat("00", 31) + "01"
+	blsPairingFalse = strings.Repeat("00", 32)
+)
+
+var blsG1AddTests = []precompiledTest{
+	{input: blsG1 + blsG1, expected: blsG1x2, name: "bls_g1add_g1+g1"},
+	{input: blsG1x2 + blsG1, expected: blsG1x3, name: "bls_g1add_2g1+g1"},
+	{input: blsG1 + blsG1Neg, expected: blsG1Zero, name: "bls_g1add_g1-g1"},
+	{input: blsG1 + blsG1Zero, expected: blsG1, name: "bls_g1add_g1+0"},
+	{input: blsG1Zero + blsG1Zero, expected: blsG1Zero, name: "bls_g1add_0+0"},
+}
+
+var blsG1MulTests = []precompiledTest{
+	{input: blsG1 + blsScalar2, expected: blsG1x2, name: "bls_g1mul_2*g1"},
+	{input: blsG1 + blsScalar3, expected: blsG1x3, name: "bls_g1mul_3*g1"},
+	{input: blsG1 + blsScalarQPlus2, expected: blsG1x2, name: "bls_g1mul_(q+2)*g1"},
+	{input: blsG1 + blsScalar0, expected: blsG1Zero, name: "bls_g1mul_0*g1"},
+	{input: blsG1Zero + blsScalar2, expected: blsG1Zero, name: "bls_g1mul_2*0"},
+}
+
+var blsG1MultiExpTests = []precompiledTest{
+	{input: blsG1 + blsScalar2, expected: blsG1x2, name: "bls_g1multiexp_2*g1"},
+	{input: blsG1 + blsScalar1 + blsG1 + blsScalar2, expected: blsG1x3, name: "bls_g1multiexp_1*g1+2*g1"},
+	{input: blsG1x2 + blsScalar1 + blsG1Neg + blsScalar2, expected: blsG1Zero, name: "bls_g1multiexp_2g1-2*g1"},
+}
+
+var blsG2AddTests = []precompiledTest{
+	{input: blsG2 + blsG2, expected: blsG2x2, name: "bls_g2add_g2+g2"},
+	{input: blsG2x2 + blsG2, expected: blsG2x3, name: "bls_g2add_2g2+g2"},
+	{input: blsG2 + blsG2Zero, expected: blsG2, name: "bls_g2add_g2+0"},
+	{input: blsG2Zero + blsG2Zero, expected: blsG2Zero, name: "bls_g2add_0+0"},
+}
+
+var blsG2MulTests = []precompiledTest{
+	{input: blsG2 + blsScalar2, expected: blsG2x2, name: "bls_g2mul_2*g2"},
+	{input: blsG2 + blsScalar3, expected: blsG2x3, name: "bls_g2mul_3*g2"},
+	{input: blsG2 + blsScalarQPlus2, expected: blsG2x2, name: "bls_g2mul_(q+2)*g2"},
+	{input: blsG2 + blsScalar0, expected: blsG2Zero, name: "bls_g2mul_0*g2"},
+}
+
+var blsG2MultiExpTests = []precompiledTest{
+	{input: blsG2 + blsScalar2, expected: blsG2x2, name: "bls_g2multiexp_2*g2"},
+	{input: blsG2 + blsScalar1 + blsG2 + blsScalar2, expected: blsG2x3, name: "bls_g2multiexp_1*g2+2*g2"},
+}
+
+var blsPairingTests = []precompiledTest{
+	{input: blsG1 + blsG2 + blsG1Neg + blsG2, expected: blsPairingTrue, name: "bls_pairing_e(g1,g2)*e(-g1,g2)"},
+	{input: blsG1x2 + blsG2 + blsG1Neg + blsG2x2, expected: blsPairingTrue, name: "bls_pairing_e(2*g1,g2)*e(-g1,2*g2)"},
+	{input: blsG1 + blsG2, expected: blsPairingFalse, name: "bls_pairing_e(g1,g2)"},
+	{input: blsG1 + blsG2 + blsG1 + blsG2, expected: blsPairingFalse, name: "bls_pairing_e(g1,g2)*e(g1,g2)"},
+	{input: blsG1Zero + blsG2, expected: blsPairingTrue, name: "bls_pairing_e(0,g2)"},
+}
+
+var blsMapG1Tests = []precompiledTest{
+	{
+		input: strings.Repeat("00", 63) + "01",
+		expected: "000000000000000000000000000000001073311196f8ef19477219ccee3a48035ff432295aa9419eed45d186027d88b90832e14c4f0e2aa4d15f54d1c3ed0f93" +
+			"00000000000000000000000000000000034d6e3755a2073039d609db4cf3aef548283b5cc92f1021cbdb276414bcd8072b112d80a2b0a7dbf22bdaf17e006d45",
+		name: "bls_mapg1_1",
+	},
+}
+
+var blsMapG2Tests = []precompiledTest{
+	{
+		input: strings.Repeat("00", 63) + "01" + strings.Repeat("00", 63) + "02",
+		expected: "0000000000000000000000000000000003affe41434a0ba0c57a12a44659cb0a3880ab68671d59e14ada0697e1e284a24bbd1027e73fb2a5fa1b7b83a2ee3693" +
+			"000000000000000000000000000000000afb7419b48cf4b1d4205cb7a65b76bb00da7a3bdfa1b8da5bfda384aa78e27dbe4838d2660c885c80845e83ff4eea30" +
+			"0000000000000000000000000000000016472687b24e83cbb72b626b04f37e880ff22701500ab276f7a553cd95315b06f39f8f21218aabc3367aeca0152322e8" +
+			"000000000000000000000000000000000a17e8006aa32586025a73fb9f5147067aeec10241a8eca8d8e2f121cf18080cfb0618c528d92a5ad538c7ffcf46d81f",
+		name: "bls_mapg2_(1,2)",
+	},
+}
+
+var blsFailureTests = map[string][]precompiledFailureTest{
+	"0a": {
+		{input: "", expectedError: errBLS12381InvalidInputLength, name: "bls_g1add_empty_input"},
+		{input: blsG1, expectedError: errBLS12381InvalidInputLength, name: "bls_g1add_short_input"},
+		{input: "01" + blsG1[2:] + blsG1, expectedError: errBLS12381InvalidFieldElementTopBytes, name: "bls_g1add_violate_top_bytes"},
+	},
+	"0b": {
+		{input: blsG1, expectedError: errBLS12381InvalidInputLength, name: "bls_g1mul_short_input"},
+		{input: blsG1NotInSubgroup + blsScalar2, expectedError: errBLS12381G1PointSubgroup, name: "bls_g1mul_not_in_subgroup"},
+	},
+	"0c": {
+		{input: "", expectedError: errBLS12381InvalidInputLength, name: "bls_g1multiexp_empty_input"},
+		{input: blsG1 + blsScalar2 + blsG1, expectedError: errBLS12381InvalidInputLength, name: "bls_g1multiexp_short_input"},
+		{input: blsG1 + blsScalar2 + blsG1NotInSubgroup + blsScalar2, expectedError: errBLS12381G1PointSubgroup, name: "bls_g1multiexp_not_in_subgroup"},
+	},
+	"0d": {
+		{input: blsG2, expectedError: errBLS12381InvalidInputLength, name: "bls_g2add_short_input"},
+		{input: blsG2 + "01" + blsG2[2:], expectedError: errBLS12381InvalidFieldElementTopBytes, name: "bls_g2add_violate_top_bytes"},
+	},
+	"0e": {
+		{input: blsG2 + blsScalar2 + "00", expectedError: errBLS12381InvalidInputLength, name: "bls_g2mul_long_input"},
+	},
+	"0f": {
+		{input: "", expectedError: errBLS12381InvalidInputLength, name: "bls_g2multiexp_empty_input"},
+	},
+	"10": {
+		{input: "", expectedError: errBLS12381InvalidInputLength, name: "bls_pairing_empty_input"},
+		{input: blsG1 + blsG2 + blsG1, expectedError: errBLS12381InvalidInputLength, name: "bls_pairing_short_input"},
+		{input: blsG1NotInSubgroup + blsG2, expectedError: errBLS12381G1PointSubgroup, name: "bls_pairing_g1_not_in_subgroup"},
+	},
+	"11": {
+		{input: strings.Repeat("00", 63), expectedError: errBLS12381InvalidInputLength, name: "bls_mapg1_short_input"},
+		{input: "01" + strings.Repeat("00", 63), expectedError: errBLS12381InvalidFieldElementTopBytes, name: "bls_mapg1_violate_top_bytes"},
+	},
+	"12": {
+		{input: strings.Repeat("00", 64), expectedError: errBLS12381InvalidInputLength, name: "bls_mapg2_short_input"},
+		{input: strings.Repeat("00", 64) + "01" + strings.Repeat("00", 63), expectedError: errBLS12381InvalidFieldElementTopBytes, name: "bls_mapg2_violate_top_bytes"},
+	},
+}
+
+func TestPrecompiledBLS12381G1Add(t *testing.T) {
+	for _, test := range blsG1AddTests {
+		testPrecompiled("0a", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G1Mul(t *testing.T) {
+	for _, test := range blsG1MulTests {
+		testPrecompiled("0b", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G1MultiExp(t *testing.T) {
+	for _, test := range blsG1MultiExpTests {
+		testPrecompiled("0c", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G2Add(t *testing.T) {
+	for _, test := range blsG2AddTests {
+		testPrecompiled("0d", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G2Mul(t *testing.T) {
+	for _, test := range blsG2MulTests {
+		testPrecompiled("0e", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381G2MultiExp(t *testing.T) {
+	for _, test := range blsG2MultiExpTests {
+		testPrecompiled("0f", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381Pairing(t *testing.T) {
+	for _, test := range blsPairingTests {
+		testPrecompiled("10", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381MapG1(t *testing.T) {
+	for _, test := range blsMapG1Tests {
+		testPrecompiled("11", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381MapG2(t *testing.T) {
+	for _, test := range blsMapG2Tests {
+		testPrecompiled("12", test, t)
+	}
+}
+
+func TestPrecompiledBLS12381Failures(t *testing.T) {
+	for addr, tests := range blsFailureTests {
+		for _, test := range tests {
+			testPrecompiledFailure(addr, test, t)
+		}
+	}
+}
+
+// Tests the gas costs of the BLS12-381 precompiles, which are specified in EIP-2537.
+func TestPrecompiledBLS12381Gas(t *testing.T) {
+	tests := []struct {
+		addr  string
+		input string
+		gas   uint64
+	}{
+		{"0a", blsG1 + blsG1, 600},
+		{"0b", blsG1 + blsScalar2, 12000},
+		{"0c", blsG1 + blsScalar2, 14400},
+		{"0c", blsG1 + blsScalar1 + blsG1 + blsScalar2, 21312},
+		{"0c", strings.Repeat(blsG1+blsScalar2, 128), 128 * 12000 * 174 / 1000},
+		{"0c", strings.Repeat(blsG1+blsScalar2, 200), 200 * 12000 * 174 / 1000},
+		{"0d", blsG2 + blsG2, 4500},
+		{"0e", blsG2 + blsScalar2, 55000},
+		{"0f", blsG2 + blsScalar2, 66000},
+		{"10", blsG1 + blsG2, 138000},
+		{"10", blsG1 + blsG2 + blsG1Neg + blsG2, 161000},
+		{"11", blsScalar1 + blsScalar1, 5500},
+		{"12", blsScalar1 + blsScalar1 + blsScalar1 + blsScalar1, 110000},
+	}
+	for i, test := range tests {
+		p := allPrecompiles[common.HexToAddress(test.addr)]
+		if gas := p.RequiredGas(common.Hex2Bytes(test.input)); gas != test.gas {
+			t.Errorf("test %d: precompile %v gas %d, expected %d", i, test.addr, gas, test.gas)
+		}
+	}
+}
+
+func BenchmarkPrecompiledBLS12381G1MultiExp(bench *testing.B) {
+	for _, test := range blsG1MultiExpTests {
+		benchmarkPrecompiled("0c", test, bench)
+	}
+}
+
+func BenchmarkPrecompiledBLS12381Pairing(bench *testing.B) {
+	for _, test := range blsPairingTests {
+		benchmarkPrecompiled("10", test, bench)
+	}
+}
+
+func TestActivePrecompiledContracts(t *testing.T) {
+	var (
+		blsG1Add  = common.BytesToAddress([]byte{10})
+		staking   = common.BytesToAddress([]byte{252})
+		chainInfo = common.BytesToAddress([]byte{251})
+	)
+	tests := []struct {
+		rules  params.Rules
+		active []common.Address
+		absent []common.Address
+	}{
+		{params.Rules{IsSHA3: true}, nil, []common.Address{blsG1Add, staking}},
+		{params.Rules{IsSHA3: true, IsBLS12381: true}, []common.Address{blsG1Add}, []common.Address{staking}},
+		{params.Rules{IsSHA3: true, IsStakingPrecompile: true}, []common.Address{staking}, []common.Address{blsG1Add}},
+		{params.Rules{IsSHA3: true, IsBLS12381: true, IsStakingPrecompile: true}, []common.Address{blsG1Add, staking}, nil},
+		{params.Rules{IsIstanbul: true, IsStakingPrecompile: true}, []common.Address{staking}, []common.Address{blsG1Add}},
+		{params.Rules{IsSHA3: true, IsChainInfoPrecompile: true}, []common.Address{chainInfo}, []common.Address{blsG1Add, staking}},
+		{params.Rules{IsSHA3: true, IsBLS12381: true, IsStakingPrecompile: true}, nil, []common.Address{chainInfo}},
+	}
+	for i, test := range tests {
+		precompiles := activePrecompiledContracts(test.rules)
+		for _, addr := range test.active {
+			if _, ok := precompiles[addr]; !ok {
+				t.Errorf("test %d: precompile %x not active", i, addr)
+			}
+		}
+		for _, addr := range test.absent {
+			if _, ok := precompiles[addr]; ok {
+				t.Errorf("test %d: precompile %x unexpectedly active", i, addr)
+			}
+		}
+	}
+}
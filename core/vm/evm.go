@@ -26,6 +26,7 @@ import (
 	"github.com/harmony-one/harmony/internal/params"
 
 	"github.com/harmony-one/harmony/core/types"
+	staking "github.com/harmony-one/harmony/staking/types"
 )
 
 // emptyCodeHash is used by create to ensure deployment is disallowed to already
@@ -45,35 +46,25 @@ type (
 	// GetVRFFunc returns the nth block vrf in the blockchain
 	// and is used by the precompile VRF contract.
 	GetVRFFunc func(uint64) common.Hash
+	// DelegateFunc verifies and applies a delegation of the staking precompile
+	DelegateFunc func(StateDB, *staking.Delegate) error
+	// UndelegateFunc verifies and applies an undelegation of the staking precompile
+	UndelegateFunc func(StateDB, *staking.Undelegate) error
+	// CollectRewardsFunc verifies and applies a reward collection of the staking
+	// precompile, and returns the collected rewards
+	CollectRewardsFunc func(StateDB, *staking.CollectRewards) (*big.Int, error)
 )
 
 // run runs the given contract and takes care of running precompiles with a fallback to the byte code interpreter.
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
-		precompiles := PrecompiledContractsHomestead
-		if evm.ChainConfig().IsS3(evm.EpochNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if evm.chainRules.IsVRF {
-			precompiles = PrecompiledContractsVRF
-		}
-		if evm.chainRules.IsSHA3 {
-			precompiles = PrecompiledContractsSHA3FIPS
-		}
+		precompiles := activePrecompiledContracts(evm.chainRules)
 		if p := precompiles[*contract.CodeAddr]; p != nil {
 			if _, ok := p.(*vrf); ok {
 				if evm.chainRules.IsPrevVRF {
 					requestedBlockNum := big.NewInt(0).SetBytes(input)
-					minBlockNum := big.NewInt(0).Sub(evm.BlockNumber, common.Big257)
-
-					if requestedBlockNum.Cmp(evm.BlockNumber) == 0 {
-						input = evm.Context.VRF.Bytes()
-					} else if requestedBlockNum.Cmp(minBlockNum) > 0 && requestedBlockNum.Cmp(evm.BlockNumber) < 0 {
-						// requested block number is in range
-						input = evm.GetVRF(requestedBlockNum.Uint64()).Bytes()
+					if vrf, ok := evm.recentVRF(requestedBlockNum); ok {
+						input = vrf.Bytes()
 					} else {
 						// else default to the current block's VRF
 						input = evm.Context.VRF.Bytes()
@@ -84,6 +75,9 @@ func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, err
 				}
 
 			}
+			if sp, ok := p.(StatefulPrecompiledContract); ok {
+				return RunStatefulPrecompiledContract(evm, sp, input, contract, readOnly)
+			}
 			return RunPrecompiledContract(p, input, contract)
 		}
 	}
@@ -108,6 +102,20 @@ func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, err
 	return nil, ErrNoCompatibleInterpreter
 }
 
+// recentVRF returns the VRF of the block number if it is the current block or
+// one of the 256 blocks before it.
+func (evm *EVM) recentVRF(number *big.Int) (common.Hash, bool) {
+	minBlockNum := big.NewInt(0).Sub(evm.BlockNumber, common.Big257)
+
+	if number.Cmp(evm.BlockNumber) == 0 {
+		return evm.Context.VRF, true
+	} else if number.Cmp(minBlockNum) > 0 && number.Cmp(evm.BlockNumber) < 0 {
+		// requested block number is in range
+		return evm.GetVRF(number.Uint64()), true
+	}
+	return common.Hash{}, false
+}
+
 // Context provides the EVM with auxiliary information. Once provided
 // it shouldn't be modified.
 type Context struct {
@@ -125,6 +133,12 @@ type Context struct {
 	// true: is a validator address; false: is smart contract address
 	IsValidator IsValidatorFunc
 
+	// Delegate, Undelegate and CollectRewards apply the staking directives of
+	// the staking precompile. They are nil where staking is not supported.
+	Delegate       DelegateFunc
+	Undelegate     UndelegateFunc
+	CollectRewards CollectRewardsFunc
+
 	// Message information
 	Origin   common.Address // Provides information for ORIGIN
 	GasPrice *big.Int       // Provides information for GASPRICE
@@ -136,6 +150,8 @@ type Context struct {
 	EpochNumber *big.Int       // Provides information for EPOCH
 	Time        *big.Int       // Provides information for TIME
 	VRF         common.Hash    // Provides information for VRF
+	ShardID     uint32         // Provides information for the chain info precompile
+	BaseFee     *big.Int       // Provides information for BASEFEE
 
 	TxType types.TransactionType
 }
@@ -255,19 +271,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		snapshot = evm.StateDB.Snapshot()
 	)
 	if !evm.StateDB.Exist(addr) && txType != types.SubtractionOnly {
-		precompiles := PrecompiledContractsHomestead
-		if evm.ChainConfig().IsS3(evm.EpochNumber) {
-			precompiles = PrecompiledContractsByzantium
-		}
-		if evm.chainRules.IsIstanbul {
-			precompiles = PrecompiledContractsIstanbul
-		}
-		if evm.chainRules.IsVRF {
-			precompiles = PrecompiledContractsVRF
-		}
-		if evm.chainRules.IsSHA3 {
-			precompiles = PrecompiledContractsSHA3FIPS
-		}
+		precompiles := activePrecompiledContracts(evm.chainRules)
 
 		if precompiles[addr] == nil && evm.ChainConfig().IsS3(evm.EpochNumber) && value.Sign() == 0 {
 			// Calling a non existing account, don't do anything, but ping the tracer
@@ -459,6 +463,11 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 	nonce := evm.StateDB.GetNonce(caller.Address())
 	evm.StateDB.SetNonce(caller.Address(), nonce+1)
+	// We add this to the access list _before_ taking a snapshot. Even if the creation fails,
+	// the access-list change should not be rolled back
+	if evm.chainRules.IsEIP2930 {
+		evm.StateDB.AddAddressToAccessList(address)
+	}
 
 	// Ensure there's no existing contract already at the designated address
 	contractHash := evm.StateDB.GetCodeHash(address)
@@ -543,3 +552,6 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
+
+// Config returns the configuration of the virtual machine.
+func (evm *EVM) Config() Config { return evm.vmConfig }
@@ -0,0 +1,70 @@
+package vm
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FailureTracer is a Tracer that records the call depth at which the failure of
+// a message call originated, 1 being the outermost call. A failed inner call is
+// considered the origin of the failure of its caller, unless the caller makes
+// another call before failing itself.
+type FailureTracer struct {
+	pending map[int]int // origin of the failure of the last call made at a depth
+	depth   int         // origin of the last failure, 0 if none
+}
+
+// NewFailureTracer creates a new FailureTracer.
+func NewFailureTracer() *FailureTracer {
+	return &FailureTracer{pending: make(map[int]int)}
+}
+
+// CaptureStart implements the Tracer interface.
+func (t *FailureTracer) CaptureStart(env *EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	return nil
+}
+
+// CaptureState implements the Tracer interface, it forgets the failure of the
+// previous inner call whenever a new one is made and records the failures which
+// happen before an opcode is executed.
+func (t *FailureTracer) CaptureState(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	if err != nil {
+		t.fail(depth)
+		return nil
+	}
+	switch op {
+	case CALL, CALLCODE, DELEGATECALL, STATICCALL, CREATE, CREATE2:
+		delete(t.pending, depth)
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface, it records the failure of the
+// call at the given depth.
+func (t *FailureTracer) CaptureFault(env *EVM, pc uint64, op OpCode, gas, cost uint64, memory *Memory, stack *Stack, contract *Contract, depth int, err error) error {
+	t.fail(depth)
+	return nil
+}
+
+// CaptureEnd implements the Tracer interface.
+func (t *FailureTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+func (t *FailureTracer) fail(depth int) {
+	origin, ok := t.pending[depth]
+	if !ok {
+		origin = depth
+	}
+	delete(t.pending, depth)
+	t.pending[depth-1] = origin
+	t.depth = origin
+}
+
+// Depth returns the call depth at which the last failure originated, 0 if no
+// call failed.
+func (t *FailureTracer) Depth() int {
+	return t.depth
+}
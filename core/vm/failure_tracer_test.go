@@ -0,0 +1,67 @@
+package vm
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+// callCode returns the code calling the given address with all the gas left and
+// dropping the result.
+func callCode(addr common.Address) string {
+	return fmt.Sprintf("6000600060006000600073%x5af150", addr.Bytes())
+}
+
+func TestFailureTracer(t *testing.T) {
+	var (
+		reverter = common.HexToAddress("0xbb")
+		stopper  = common.HexToAddress("0xcc")
+		caller   = common.HexToAddress("0xdd")
+		revert   = "60006000fd"
+	)
+	tests := []struct {
+		code  string
+		depth int
+	}{
+		{"00", 0},                        // no failure
+		{"fe", 1},                        // invalid opcode
+		{revert, 1},                      // revert
+		{callCode(reverter) + revert, 2}, // reverted by the inner call
+		{callCode(reverter) + callCode(stopper) + revert, 1}, // another call made since
+		{callCode(caller) + revert, 3},                       // reverted by the inner call of the inner call
+	}
+	for i, test := range tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb.SetCode(reverter, hexutil.MustDecode("0x"+revert))
+		statedb.SetCode(stopper, []byte{byte(STOP)})
+		statedb.SetCode(caller, hexutil.MustDecode("0x"+callCode(reverter)+revert))
+		statedb.SetCode(address, hexutil.MustDecode("0x"+test.code))
+
+		vmctx := Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int, types.TransactionType) {},
+			IsValidator: func(StateDB, common.Address) bool { return false },
+			EpochNumber: big.NewInt(0),
+		}
+		tracer := NewFailureTracer()
+		vmenv := NewEVM(vmctx, statedb, params.AllProtocolChanges, Config{Debug: true, Tracer: tracer})
+
+		_, _, err := vmenv.Call(AccountRef(common.Address{}), address, nil, math.MaxUint64, new(big.Int))
+		if (err != nil) != (test.depth != 0) {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+		if depth := tracer.Depth(); depth != test.depth {
+			t.Errorf("test %d: failure depth mismatch: have %v, want %v", i, depth, test.depth)
+		}
+	}
+}
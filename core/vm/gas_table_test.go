@@ -108,3 +108,48 @@ func TestEIP2200(t *testing.T) {
 		}
 	}
 }
+
+var eip2929Tests = []struct {
+	input  string
+	warm   bool // whether the slot 0 is in the access list
+	used   uint64
+	failed bool
+}{
+	{"0x6000546000545050", false, 2210, false}, // cold and warm SLOAD
+	{"0x6000546000545050", true, 210, false},   // warm SLOAD twice
+	{"0x6001600055", false, 22106, false},      // cold SSTORE 0 -> 1
+	{"0x6001600055", true, 20006, false},       // warm SSTORE 0 -> 1
+}
+
+func TestEIP2929(t *testing.T) {
+	for i, tt := range eip2929Tests {
+		address := common.BytesToAddress([]byte("contract"))
+
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		statedb.CreateAccount(address)
+		statedb.SetCode(address, hexutil.MustDecode(tt.input))
+		statedb.Finalise(true)
+
+		var accessList types.AccessList
+		if tt.warm {
+			accessList = types.AccessList{{Address: address, StorageKeys: []common.Hash{{}}}}
+		}
+		statedb.PrepareAccessList(common.Address{}, &address, nil, accessList)
+
+		vmctx := Context{
+			CanTransfer: func(StateDB, common.Address, *big.Int) bool { return true },
+			Transfer:    func(StateDB, common.Address, common.Address, *big.Int, types.TransactionType) {},
+			IsValidator: func(StateDB, common.Address) bool { return false },
+			EpochNumber: big.NewInt(0),
+		}
+		vmenv := NewEVM(vmctx, statedb, params.AllProtocolChanges, Config{})
+
+		_, gas, err := vmenv.Call(AccountRef(common.Address{}), address, nil, math.MaxUint64, new(big.Int))
+		if (err != nil) != tt.failed {
+			t.Errorf("test %d: unexpected error %v", i, err)
+		}
+		if used := math.MaxUint64 - gas; used != tt.used {
+			t.Errorf("test %d: gas used mismatch: have %v, want %v", i, used, tt.used)
+		}
+	}
+}
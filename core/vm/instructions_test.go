@@ -521,6 +521,25 @@ func TestOpMstore(t *testing.T) {
 	poolOfIntPools.put(evmInterpreter.intPool)
 }
 
+func TestOpBaseFee(t *testing.T) {
+	if !newLondonInstructionSet()[BASEFEE].valid || newIstanbulInstructionSet()[BASEFEE].valid {
+		t.Fatal("BASEFEE should be only valid in the london instruction set")
+	}
+	var (
+		env            = NewEVM(Context{BaseFee: big.NewInt(1000)}, nil, params.TestChainConfig, Config{})
+		stack          = newstack()
+		evmInterpreter = NewEVMInterpreter(env, env.vmConfig)
+	)
+	env.interpreter = evmInterpreter
+	evmInterpreter.intPool = poolOfIntPools.get()
+	pc := uint64(0)
+	opBaseFee(&pc, evmInterpreter, nil, nil, stack)
+	if got := stack.pop(); got.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("BaseFee fail, got %v, expected 1000", got)
+	}
+	poolOfIntPools.put(evmInterpreter.intPool)
+}
+
 func BenchmarkOpMstore(bench *testing.B) {
 	var (
 		env            = NewEVM(Context{}, nil, params.TestChainConfig, Config{})
@@ -50,6 +50,10 @@ type Config struct {
 
 	// ExtraEips the additional EIPS that are to be enabled
 	ExtraEips []int
+
+	// NoBaseFee skips the EIP-1559 base fee check for the messages without any
+	// fee, which is used by the calls not in any block like eth_call
+	NoBaseFee bool
 }
 
 // Interpreter is used to run Ethereum based contracts and will utilise the
@@ -104,6 +108,10 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	if !cfg.JumpTable[STOP].valid {
 		var jt JumpTable
 		switch {
+		case evm.chainRules.IsEIP1559:
+			jt = londonInstructionSet
+		case evm.chainRules.IsEIP2930:
+			jt = eip2930InstructionSet
 		case evm.chainRules.IsIstanbul:
 			jt = istanbulInstructionSet
 		case evm.chainRules.IsS3:
@@ -61,11 +61,33 @@ var (
 	byzantiumInstructionSet        = newByzantiumInstructionSet()
 	constantinopleInstructionSet   = newConstantinopleInstructionSet()
 	istanbulInstructionSet         = newIstanbulInstructionSet()
+	eip2930InstructionSet          = newEIP2930InstructionSet()
+	londonInstructionSet           = newLondonInstructionSet()
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]operation
 
+// newLondonInstructionSet returns the EIP-2930 instructions with the BASEFEE
+// opcode of EIP-1559.
+func newLondonInstructionSet() JumpTable {
+	instructionSet := newEIP2930InstructionSet()
+
+	enable3198(&instructionSet) // Base fee opcode - https://eips.ethereum.org/EIPS/eip-3198
+
+	return instructionSet
+}
+
+// newEIP2930InstructionSet returns the istanbul instructions with the gas cost
+// changes of the state access opcodes.
+func newEIP2930InstructionSet() JumpTable {
+	instructionSet := newIstanbulInstructionSet()
+
+	enable2929(&instructionSet) // Access lists for trie accesses - https://eips.ethereum.org/EIPS/eip-2929
+
+	return instructionSet
+}
+
 // newIstanbulInstructionSet returns the frontier, homestead
 // byzantium, contantinople and petersburg instructions.
 func newIstanbulInstructionSet() JumpTable {
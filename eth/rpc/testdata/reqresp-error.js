@@ -0,0 +1,4 @@
+// This test checks that the data of data errors is included in the response.
+
+--> {"jsonrpc": "2.0", "id": 2, "method": "test_returnError", "params": []}
+<-- {"jsonrpc":"2.0","id":2,"error":{"code":444,"message":"testError","data":"testError data"}}
@@ -229,6 +229,94 @@ func (hmy *Harmony) GetBalance(ctx context.Context, address common.Address, bloc
 	return s.GetBalance(address), s.Error()
 }
 
+// AccountProof is the EIP-1186 style merkle proof of an account and a set of its
+// storage slots against the state root of a block header.
+type AccountProof struct {
+	Address      common.Address
+	AccountProof [][]byte
+	Balance      *big.Int
+	CodeHash     common.Hash
+	Nonce        uint64
+	StorageHash  common.Hash
+	IsValidator  bool
+	// Code is the RLP encoded validator wrapper kept as the code of a validator
+	// account, nil for the other accounts. Its hash is the proven code hash.
+	Code         []byte
+	StorageProof []StorageProof
+
+	BlockHash   common.Hash
+	BlockNumber uint64
+	StateRoot   common.Hash
+}
+
+// StorageProof is the merkle proof of a single storage slot against the storage
+// root of the account.
+type StorageProof struct {
+	Key   common.Hash
+	Value *big.Int
+	Proof [][]byte
+}
+
+// GetProof returns the merkle proof of the given account and storage keys at the
+// given block. The account leaf in the state trie is the RLP encoding of
+// [nonce, balance, storageHash, codeHash]. The validator information is RLP
+// encoded as the account code, which is returned for validators so that it can be
+// verified against the code hash.
+func (hmy *Harmony) GetProof(
+	ctx context.Context, address common.Address, storageKeys []common.Hash, blockNum rpc.BlockNumber,
+) (*AccountProof, error) {
+	s, header, err := hmy.StateAndHeaderByNumber(ctx, blockNum)
+	if s == nil || err != nil {
+		return nil, err
+	}
+	return newAccountProof(s, header, address, storageKeys)
+}
+
+// newAccountProof returns the merkle proof of the given account and storage keys
+// in the state of the given header.
+func newAccountProof(
+	s *state.DB, header *block.Header, address common.Address, storageKeys []common.Hash,
+) (*AccountProof, error) {
+	accountProof, err := s.GetProof(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prove account")
+	}
+	result := &AccountProof{
+		Address:      address,
+		AccountProof: accountProof,
+		Balance:      s.GetBalance(address),
+		CodeHash:     s.GetCodeHash(address),
+		Nonce:        s.GetNonce(address),
+		StorageHash:  types.EmptyRootHash,
+		IsValidator:  s.IsValidator(address),
+		StorageProof: make([]StorageProof, 0, len(storageKeys)),
+		BlockHash:    header.Hash(),
+		BlockNumber:  header.Number().Uint64(),
+		StateRoot:    header.Root(),
+	}
+	if result.IsValidator {
+		result.Code = s.GetCode(address)
+	}
+	storageTrie := s.StorageTrie(address)
+	if storageTrie != nil {
+		result.StorageHash = storageTrie.Hash()
+	}
+	for _, key := range storageKeys {
+		var proof [][]byte
+		if storageTrie != nil {
+			if proof, err = s.GetStorageProof(address, key); err != nil {
+				return nil, errors.Wrapf(err, "failed to prove storage key %x", key)
+			}
+		}
+		result.StorageProof = append(result.StorageProof, StorageProof{
+			Key:   key,
+			Value: s.GetState(address, key).Big(),
+			Proof: proof,
+		})
+	}
+	return result, s.Error()
+}
+
 // BlockByNumber ...
 func (hmy *Harmony) BlockByNumber(ctx context.Context, blockNum rpc.BlockNumber) (*types.Block, error) {
 	// Pending block is only known by the miner
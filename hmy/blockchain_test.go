@@ -0,0 +1,114 @@
+package hmy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// proofAccount is the account leaf of the state trie
+type proofAccount struct {
+	Nonce    uint64
+	Balance  *big.Int
+	Root     common.Hash
+	CodeHash []byte
+}
+
+func TestNewAccountProof(t *testing.T) {
+	var (
+		validator = common.HexToAddress("0x01")
+		missing   = common.HexToAddress("0x02")
+		slot      = common.HexToHash("0x2a")
+		value     = common.HexToHash("0x2b")
+		code      = []byte("validator wrapper")
+	)
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	s, _ := state.New(common.Hash{}, db)
+	s.SetNonce(validator, 3)
+	s.SetBalance(validator, big.NewInt(100))
+	s.SetCode(validator, code)
+	s.SetState(validator, slot, value)
+	s.SetValidatorFlag(validator)
+	root, err := s.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	header := blockfactory.NewTestHeader().With().Root(root).Header()
+	s, _ = state.New(root, db)
+
+	proof, err := newAccountProof(s, header, validator, []common.Hash{slot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proof.IsValidator {
+		t.Error("validator not reported")
+	}
+	if crypto.Keccak256Hash(proof.Code) != proof.CodeHash {
+		t.Errorf("code does not match code hash %x", proof.CodeHash)
+	}
+
+	// Verify the account leaf against the state root of the header
+	leaf, err := verifyProof(header.Root(), crypto.Keccak256(validator.Bytes()), proof.AccountProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var account proofAccount
+	if err := rlp.DecodeBytes(leaf, &account); err != nil {
+		t.Fatal(err)
+	}
+	if account.Nonce != proof.Nonce || account.Balance.Cmp(proof.Balance) != 0 ||
+		account.Root != proof.StorageHash || common.BytesToHash(account.CodeHash) != proof.CodeHash {
+		t.Errorf("unexpected account %+v of proof %+v", account, proof)
+	}
+
+	// Verify the storage slot against the storage root of the account
+	if len(proof.StorageProof) != 1 {
+		t.Fatalf("unexpected storage proofs %v", proof.StorageProof)
+	}
+	enc, err := verifyProof(proof.StorageHash, crypto.Keccak256(slot.Bytes()), proof.StorageProof[0].Proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored []byte
+	if err := rlp.DecodeBytes(enc, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if common.BytesToHash(stored) != value || proof.StorageProof[0].Value.Cmp(value.Big()) != 0 {
+		t.Errorf("unexpected storage value %x", stored)
+	}
+
+	// The proof of absence of an account has no storage nor code
+	proof, err = newAccountProof(s, header, missing, []common.Hash{slot})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf, err := verifyProof(header.Root(), crypto.Keccak256(missing.Bytes()), proof.AccountProof); err != nil || leaf != nil {
+		t.Errorf("unexpected proof of absence: %x, %v", leaf, err)
+	}
+	if proof.IsValidator || proof.Code != nil || proof.StorageHash != types.EmptyRootHash {
+		t.Errorf("unexpected proof of missing account %+v", proof)
+	}
+}
+
+func verifyProof(root common.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, _, err := trie.VerifyProof(root, key, proofDB)
+	return value, err
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/harmony-one/harmony/consensus/engine"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/p2p/stream/common/streammanager"
 	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/harmony-one/harmony/shard"
 )
 
 type syncProtocol interface {
@@ -17,6 +19,12 @@ type syncProtocol interface {
 	GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error)
 	GetBlockHashes(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]common.Hash, sttypes.StreamID, error)
 	GetBlocksByHashes(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error)
+	GetReceipts(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([]types.Receipts, sttypes.StreamID, error)
+
+	GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, [][]byte, sttypes.StreamID, error)
+	GetStorageRanges(ctx context.Context, root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([][]common.Hash, [][][]byte, [][]byte, sttypes.StreamID, error)
+	GetByteCodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error)
+	GetTrieNodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error)
 
 	RemoveStream(stID sttypes.StreamID) // If a stream delivers invalid data, remove the stream
 	SubscribeAddStreamEvent(ch chan<- streammanager.EvtStreamAdded) event.Subscription
@@ -29,4 +37,7 @@ type blockChain interface {
 
 	InsertChain(chain types.Blocks, verifyHeaders bool) (int, error)
 	WriteCommitSig(blockNum uint64, lastCommits []byte) error
+
+	ChainDb() ethdb.Database
+	InsertFastSyncPivot(block *types.Block, receipts types.Receipts, commitSig []byte, epochShardState *shard.State) error
 }
@@ -2,11 +2,13 @@ package downloader
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 
 	"github.com/harmony-one/harmony/block"
@@ -99,6 +101,10 @@ func (bc *testBlockChain) ReadCommitSig(blockNum uint64) ([]byte, error)
 func (bc *testBlockChain) ReadBlockRewardAccumulator(uint64) (*big.Int, error)      { return nil, nil }
 func (bc *testBlockChain) ValidatorCandidates() []common.Address                    { return nil }
 func (bc *testBlockChain) Engine() engine.Engine                                    { return &dummyEngine{} }
+func (bc *testBlockChain) ChainDb() ethdb.Database                                  { return nil }
+func (bc *testBlockChain) InsertFastSyncPivot(*types.Block, types.Receipts, []byte, *shard.State) error {
+	return nil
+}
 func (cr *testBlockChain) ReadValidatorInformationAtState(
 	addr common.Address, state *state.DB,
 ) (*staking.ValidatorWrapper, error) {
@@ -263,6 +269,26 @@ func (sp *testSyncProtocol) GetBlocksByHashes(ctx context.Context, hs []common.H
 	return res, sp.nextStreamID(), nil
 }
 
+func (sp *testSyncProtocol) GetReceipts(ctx context.Context, hs []common.Hash, opts ...syncproto.Option) ([]types.Receipts, sttypes.StreamID, error) {
+	return nil, "", errors.New("not supported")
+}
+
+func (sp *testSyncProtocol) GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([]common.Hash, [][]byte, [][]byte, sttypes.StreamID, error) {
+	return nil, nil, nil, "", errors.New("not supported")
+}
+
+func (sp *testSyncProtocol) GetStorageRanges(ctx context.Context, root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64, opts ...syncproto.Option) ([][]common.Hash, [][][]byte, [][]byte, sttypes.StreamID, error) {
+	return nil, nil, nil, "", errors.New("not supported")
+}
+
+func (sp *testSyncProtocol) GetByteCodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error) {
+	return nil, "", errors.New("not supported")
+}
+
+func (sp *testSyncProtocol) GetTrieNodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...syncproto.Option) ([][]byte, sttypes.StreamID, error) {
+	return nil, "", errors.New("not supported")
+}
+
 func (sp *testSyncProtocol) RemoveStream(target sttypes.StreamID) {
 	sp.lock.Lock()
 	defer sp.lock.Unlock()
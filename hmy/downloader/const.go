@@ -27,6 +27,21 @@ const (
 	// shortRangeTimeout is the timeout for each short range sync, which allow short range sync
 	// to restart automatically when stuck in `getBlockHashes`
 	shortRangeTimeout = 1 * time.Minute
+
+	// numAccountRangeTasks is the number of hash ranges the account trie is split into
+	// for concurrent download in fast sync
+	numAccountRangeTasks = 16
+
+	// trieCommitThreshold is the number of leaves updated before flushing the trie
+	// being rebuilt to disk in fast sync
+	trieCommitThreshold = 10000
+
+	// fastSyncMaxRetries is the maximum number of continuous failed requests for a
+	// single fast sync task
+	fastSyncMaxRetries = 10
+
+	// fastSyncBloomSize is the memory in MB of the bloom filter used in trie heal
+	fastSyncBloomSize = 256
 )
 
 type (
@@ -36,6 +51,11 @@ type (
 		// TODO: remove this when stream sync is fully up.
 		ServerOnly bool
 
+		// FastSync downloads the state at the last block of the previous epoch
+		// instead of executing all blocks from genesis. Only applies to a fresh
+		// non-beacon chain.
+		FastSync bool
+
 		// parameters
 		Network     nodeconfig.NetworkType
 		Concurrency int // Number of concurrent sync requests
@@ -24,6 +24,7 @@ type (
 	// Downloader is responsible for sync task of one shard
 	Downloader struct {
 		bc           blockChain
+		beacon       blockChain // beacon chain used to verify fast sync pivot. nil for beacon downloader
 		syncProtocol syncProtocol
 		bh           *beaconHelper
 
@@ -41,8 +42,9 @@ type (
 	}
 )
 
-// NewDownloader creates a new downloader
-func NewDownloader(host p2p.Host, bc *core.BlockChain, config Config) *Downloader {
+// NewDownloader creates a new downloader. The beacon chain is used to verify the fast
+// sync pivot, and can be nil if fast sync is not needed.
+func NewDownloader(host p2p.Host, bc *core.BlockChain, beacon *core.BlockChain, config Config) *Downloader {
 	config.fixValues()
 
 	sp := sync.NewProtocol(sync.Config{
@@ -66,8 +68,14 @@ func NewDownloader(host p2p.Host, bc *core.BlockChain, config Config) *Downloade
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	var beaconChain blockChain
+	if beacon != nil && beacon != bc {
+		beaconChain = beacon
+	}
+
 	return &Downloader{
 		bc:           bc,
+		beacon:       beaconChain,
 		syncProtocol: sp,
 		bh:           bh,
 
@@ -229,6 +237,17 @@ func (d *Downloader) loop() {
 
 func (d *Downloader) doDownload(initSync bool) (n int, err error) {
 	if initSync {
+		if d.shouldFastSync() {
+			d.logger.Info().Uint32("shard ID", d.bc.ShardID()).Msg("start fast sync")
+
+			if err = d.doFastSync(); err != nil {
+				err = errors.Wrap(err, "fast sync")
+				pl := d.promLabels()
+				pl["error"] = err.Error()
+				numFailedDownloadCounterVec.With(pl).Inc()
+				return
+			}
+		}
 		d.logger.Info().Uint64("current number", d.bc.CurrentBlock().NumberU64()).
 			Uint32("shard ID", d.bc.ShardID()).Msg("start long range sync")
 
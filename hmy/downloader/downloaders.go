@@ -4,6 +4,7 @@ import (
 	"github.com/harmony-one/abool"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/p2p"
+	"github.com/harmony-one/harmony/shard"
 )
 
 // Downloaders is the set of downloaders
@@ -18,6 +19,12 @@ type Downloaders struct {
 func NewDownloaders(host p2p.Host, bcs []*core.BlockChain, config Config) *Downloaders {
 	ds := make(map[uint32]*Downloader)
 
+	var beacon *core.BlockChain
+	for _, bc := range bcs {
+		if bc != nil && bc.ShardID() == shard.BeaconChainShardID {
+			beacon = bc
+		}
+	}
 	for _, bc := range bcs {
 		if bc == nil {
 			continue
@@ -25,7 +32,7 @@ func NewDownloaders(host p2p.Host, bcs []*core.BlockChain, config Config) *Downl
 		if _, ok := ds[bc.ShardID()]; ok {
 			continue
 		}
-		ds[bc.ShardID()] = NewDownloader(host, bc, config)
+		ds[bc.ShardID()] = NewDownloader(host, bc, beacon, config)
 	}
 	return &Downloaders{
 		ds:     ds,
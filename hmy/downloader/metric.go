@@ -16,6 +16,7 @@ func init() {
 		numFailedDownloadCounterVec,
 		numBlocksInsertedShortRangeHistogramVec,
 		numBlocksInsertedBeaconHelperCounter,
+		fastSyncStateCounterVec,
 	)
 }
 
@@ -90,6 +91,16 @@ var (
 			Help:      "number of blocks inserted from beacon helper",
 		},
 	)
+
+	fastSyncStateCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "downloader",
+			Name:      "num_state_entries_fast_sync",
+			Help:      "number of state entries downloaded in fast sync",
+		},
+		[]string{"ShardID", "type"},
+	)
 )
 
 func (d *Downloader) promLabels() prometheus.Labels {
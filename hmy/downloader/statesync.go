@@ -0,0 +1,818 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+var (
+	maxHash       = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	emptyCodeHash = crypto.Keccak256Hash(nil)
+
+	errFastSyncNotAvailable = errors.New("fast sync not available")
+)
+
+// shouldFastSync returns whether the downloader shall do fast sync before long
+// range sync. Fast sync is only done on a fresh non-beacon chain, since the pivot
+// block is verified with the committee stored in beacon chain.
+func (d *Downloader) shouldFastSync() bool {
+	if !d.config.FastSync || d.beacon == nil {
+		return false
+	}
+	if d.bc.ShardID() == shard.BeaconChainShardID {
+		return false
+	}
+	return d.bc.CurrentBlock().NumberU64() == 0
+}
+
+// doFastSync picks the last block of the previous epoch as the pivot, downloads the
+// state of the pivot block and writes the pivot block as the chain head. Blocks after
+// the pivot will be synced with long range sync.
+func (d *Downloader) doFastSync() error {
+	d.startSyncing()
+	defer d.finishSyncing()
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	defer cancel()
+
+	fsi := &fastSyncIter{
+		bc:     d.bc,
+		beacon: d.beacon,
+		p:      d.syncProtocol,
+		d:      d,
+		ctx:    ctx,
+		config: d.config,
+		logger: d.logger.With().Str("mode", "fast sync").Logger(),
+	}
+	err := fsi.doFastSync()
+	if errors.Is(err, errFastSyncNotAvailable) {
+		fsi.logger.Info().Err(err).Msg("skip fast sync")
+		return nil
+	}
+	return err
+}
+
+// fastSyncIter runs a single fast sync
+type fastSyncIter struct {
+	bc     blockChain
+	beacon blockChain
+	p      syncProtocol
+	d      *Downloader
+
+	config Config
+	ctx    context.Context
+	logger zerolog.Logger
+}
+
+func (fsi *fastSyncIter) doFastSync() error {
+	lsi := &lrSyncIter{
+		bc:     fsi.bc,
+		p:      fsi.p,
+		d:      fsi.d,
+		ctx:    fsi.ctx,
+		config: fsi.config,
+		logger: fsi.logger,
+	}
+	if err := lsi.checkPrerequisites(); err != nil {
+		return err
+	}
+	bn, err := lsi.estimateCurrentNumber()
+	if err != nil {
+		return err
+	}
+	fsi.d.status.setTargetBN(bn)
+
+	pivot, err := fsi.findPivot(bn)
+	if err != nil {
+		return errors.Wrap(err, "find pivot")
+	}
+	fsi.logger.Info().Uint64("pivot", pivot.NumberU64()).Uint64("epoch", pivot.Epoch().Uint64()).
+		Str("root", pivot.Root().String()).Msg("fast sync pivot selected")
+
+	if err := fsi.verifyPivot(pivot); err != nil {
+		return errors.Wrap(err, "verify pivot")
+	}
+
+	ss := newStateSyncer(fsi.p, fsi.bc.ChainDb(), pivot.Root(), fsi.config.Concurrency,
+		fsi.ctx, fsi.logger, fsi.d.promLabels())
+	if err := ss.sync(); err != nil {
+		return errors.Wrap(err, "sync state")
+	}
+	receipts, err := fsi.getReceipts(pivot)
+	if err != nil {
+		return errors.Wrap(err, "get pivot receipts")
+	}
+	if err := fsi.insertPivot(pivot, receipts); err != nil {
+		return errors.Wrap(err, "insert pivot")
+	}
+	fsi.logger.Info().Uint64("pivot", pivot.NumberU64()).Msg("fast sync finished")
+	return nil
+}
+
+// findPivot finds the last block of the epoch before the epoch of the target block
+// with binary search.
+func (fsi *fastSyncIter) findPivot(target uint64) (*types.Block, error) {
+	head, err := fsi.getBlockByNumber(target)
+	if err != nil {
+		return nil, err
+	}
+	epoch := head.Epoch()
+	lo, hi := uint64(1), target
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		b, err := fsi.getBlockByNumber(mid)
+		if err != nil {
+			return nil, err
+		}
+		if b.Epoch().Cmp(epoch) >= 0 {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo <= 2 {
+		return nil, errors.Wrap(errFastSyncNotAvailable, "no finished epoch")
+	}
+	pivot, err := fsi.getBlockByNumber(lo - 1)
+	if err != nil {
+		return nil, err
+	}
+	if !pivot.IsLastBlockInEpoch() {
+		return nil, errors.Errorf("pivot %v is not the last block of epoch", pivot.NumberU64())
+	}
+	return pivot, nil
+}
+
+func (fsi *fastSyncIter) getBlockByNumber(bn uint64) (*types.Block, error) {
+	var err error
+	for i := 0; i != fastSyncMaxRetries; i++ {
+		var (
+			blocks []*types.Block
+			stid   sttypes.StreamID
+		)
+		ctx, cancel := context.WithTimeout(fsi.ctx, 10*time.Second)
+		blocks, stid, err = fsi.p.GetBlocksByNumber(ctx, []uint64{bn}, syncproto.WithHighPriority())
+		cancel()
+		if err == nil && (len(blocks) != 1 || blocks[0] == nil) {
+			err = errors.Errorf("block %v not delivered", bn)
+		}
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil, err
+			}
+			fsi.p.RemoveStream(stid)
+			continue
+		}
+		return blocks[0], nil
+	}
+	return nil, errors.Wrapf(err, "get block %v", bn)
+}
+
+// getReceipts downloads the receipts of the given block, which are verified against
+// the receipt root of the block.
+func (fsi *fastSyncIter) getReceipts(b *types.Block) (types.Receipts, error) {
+	var err error
+	for i := 0; i != fastSyncMaxRetries; i++ {
+		var (
+			receipts []types.Receipts
+			stid     sttypes.StreamID
+		)
+		ctx, cancel := context.WithTimeout(fsi.ctx, 10*time.Second)
+		receipts, stid, err = fsi.p.GetReceipts(ctx, []common.Hash{b.Hash()}, syncproto.WithHighPriority())
+		cancel()
+		if err == nil && len(receipts) != 1 {
+			err = errors.Errorf("receipts of block %v not delivered", b.NumberU64())
+		}
+		if err == nil {
+			if sha := types.DeriveSha(receipts[0]); sha != b.Header().ReceiptHash() {
+				err = errors.Errorf("invalid receipt root hash of block %v (remote: %x local: %x)",
+					b.NumberU64(), b.Header().ReceiptHash(), sha)
+			}
+		}
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return nil, err
+			}
+			fsi.p.RemoveStream(stid)
+			continue
+		}
+		return receipts[0], nil
+	}
+	return nil, errors.Wrapf(err, "get receipts of block %v", b.NumberU64())
+}
+
+// verifyPivot verifies the commit signature of the pivot block with the committee
+// stored in beacon chain. Wait for beacon chain to catch up if the shard state of
+// the pivot epoch is not available yet.
+func (fsi *fastSyncIter) verifyPivot(pivot *types.Block) error {
+	if err := fsi.waitBeaconShardState(pivot.Epoch()); err != nil {
+		return err
+	}
+	sig, bitmap, err := chain.ParseCommitSigAndBitmap(pivot.GetCurrentCommitSig())
+	if err != nil {
+		return errors.Wrap(err, "parse commitSigAndBitmap")
+	}
+	cl := types.CrossLink{
+		HashF:        pivot.Hash(),
+		BlockNumberF: pivot.Number(),
+		ViewIDF:      pivot.Header().ViewID(),
+		SignatureF:   sig,
+		BitmapF:      bitmap,
+		ShardIDF:     pivot.ShardID(),
+		EpochF:       pivot.Epoch(),
+	}
+	return fsi.beacon.Engine().VerifyCrossLink(fsi.beacon, cl)
+}
+
+// insertPivot writes the pivot block as the chain head together with the shard state
+// of the pivot epoch from beacon chain, which the commit signature of the pivot block
+// is verified with when the next block is inserted.
+func (fsi *fastSyncIter) insertPivot(pivot *types.Block, receipts types.Receipts) error {
+	epochShardState, err := fsi.beacon.ReadShardState(pivot.Epoch())
+	if err != nil {
+		return errors.Wrap(err, "read shard state of pivot epoch")
+	}
+	return fsi.bc.InsertFastSyncPivot(pivot, receipts, pivot.GetCurrentCommitSig(), epochShardState)
+}
+
+func (fsi *fastSyncIter) waitBeaconShardState(epoch *big.Int) error {
+	t := time.NewTicker(10 * time.Second)
+	defer t.Stop()
+	for {
+		if _, err := fsi.beacon.ReadShardState(epoch); err == nil {
+			return nil
+		}
+		fsi.logger.Info().Uint64("epoch", epoch.Uint64()).
+			Msg("waiting for beacon chain to sync to pivot epoch")
+		select {
+		case <-t.C:
+		case <-fsi.ctx.Done():
+			return fsi.ctx.Err()
+		}
+	}
+}
+
+// stateSyncer downloads the state of the given root. The leaves of account trie and
+// storage tries are downloaded in ranges, and the tries are rebuilt locally. The tries
+// which are not consistent with the expected root are then healed by downloading the
+// missing trie nodes.
+type stateSyncer struct {
+	p           syncProtocol
+	db          ethdb.Database
+	triedb      *trie.Database
+	root        common.Hash
+	concurrency int
+	bloom       *trie.SyncBloom
+
+	lock        sync.Mutex
+	accTrie     *trie.Trie
+	accUpdated  int
+	storages    []storageTask
+	codeHashes  map[common.Hash]struct{}
+	numAccounts int
+
+	ctx        context.Context
+	logger     zerolog.Logger
+	promLabels prometheus.Labels
+}
+
+// storageTask is a storage trie to be downloaded
+type storageTask struct {
+	account common.Hash
+	root    common.Hash
+}
+
+// hashRange is a range of hash [origin, limit]
+type hashRange struct {
+	origin common.Hash
+	limit  common.Hash
+}
+
+func newStateSyncer(p syncProtocol, db ethdb.Database, root common.Hash, concurrency int,
+	ctx context.Context, logger zerolog.Logger, promLabels prometheus.Labels) *stateSyncer {
+
+	return &stateSyncer{
+		p:           p,
+		db:          db,
+		triedb:      trie.NewDatabase(db),
+		root:        root,
+		concurrency: concurrency,
+		codeHashes:  make(map[common.Hash]struct{}),
+		ctx:         ctx,
+		logger:      logger,
+		promLabels:  promLabels,
+	}
+}
+
+func (ss *stateSyncer) sync() error {
+	ss.bloom = trie.NewSyncBloom(fastSyncBloomSize, ss.db)
+	defer ss.bloom.Close()
+
+	if err := ss.syncAccounts(); err != nil {
+		return errors.Wrap(err, "sync accounts")
+	}
+	ss.logger.Info().Int("accounts", ss.numAccounts).Int("storages", len(ss.storages)).
+		Int("codes", len(ss.codeHashes)).Msg("account ranges downloaded")
+
+	if err := ss.syncStorages(); err != nil {
+		return errors.Wrap(err, "sync storages")
+	}
+	if err := ss.syncCodes(); err != nil {
+		return errors.Wrap(err, "sync codes")
+	}
+	// Storage tries are already verified. Heal the account trie if the root is not
+	// expected, which also schedules the storage tries and codes of healed accounts.
+	return ss.heal(state.NewStateSync(ss.root, ss.db, ss.bloom))
+}
+
+func (ss *stateSyncer) syncAccounts() error {
+	accTrie, err := trie.New(common.Hash{}, ss.triedb)
+	if err != nil {
+		return err
+	}
+	ss.accTrie = accTrie
+
+	tasks := splitHashRange(numAccountRangeTasks)
+	taskC := make(chan hashRange, len(tasks))
+	for _, task := range tasks {
+		taskC <- task
+	}
+	close(taskC)
+
+	err = ss.runWorkers(func() error {
+		for task := range taskC {
+			if err := ss.syncAccountRange(task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	root, err := ss.commitAccountTrie()
+	if err != nil {
+		return err
+	}
+	if root != ss.root {
+		ss.logger.Warn().Str("expect", ss.root.String()).Str("got", root.String()).
+			Msg("account trie root mismatch. Need heal")
+	}
+	return nil
+}
+
+func (ss *stateSyncer) syncAccountRange(task hashRange) error {
+	var (
+		next     = task.origin
+		failures int
+	)
+	for {
+		if failures >= fastSyncMaxRetries {
+			return errors.Errorf("too many failures in account range [%x, %x]", next, task.limit)
+		}
+		ctx, cancel := context.WithTimeout(ss.ctx, 30*time.Second)
+		hashes, accounts, proof, stid, err := ss.p.GetAccountRange(ctx, ss.root, next, task.limit,
+			syncproto.StateResponseBytesCap)
+		cancel()
+		if err == nil {
+			err = verifyRangeEdge(ss.root, hashes, accounts, proof)
+		}
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			ss.logger.Warn().Err(err).Str("stream", string(stid)).Msg("account range request failed")
+			ss.p.RemoveStream(stid)
+			failures++
+			continue
+		}
+		failures = 0
+		if len(hashes) == 0 {
+			return nil
+		}
+		if err := ss.processAccounts(hashes, accounts); err != nil {
+			return err
+		}
+		last := hashes[len(hashes)-1]
+		if bytes.Compare(last[:], task.limit[:]) >= 0 {
+			return nil
+		}
+		next = incHash(last)
+	}
+}
+
+func (ss *stateSyncer) processAccounts(hashes []common.Hash, accounts [][]byte) error {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+
+	for i, h := range hashes {
+		var acc state.Account
+		if err := rlp.DecodeBytes(accounts[i], &acc); err != nil {
+			return errors.Wrapf(err, "decode account %x", h)
+		}
+		if err := ss.accTrie.TryUpdate(h[:], accounts[i]); err != nil {
+			return err
+		}
+		if acc.Root != types.EmptyRootHash {
+			ss.storages = append(ss.storages, storageTask{account: h, root: acc.Root})
+		}
+		if codeHash := common.BytesToHash(acc.CodeHash); codeHash != emptyCodeHash {
+			ss.codeHashes[codeHash] = struct{}{}
+		}
+		ss.numAccounts++
+		ss.accUpdated++
+	}
+	fastSyncStateCounterVec.With(ss.labels("account")).Add(float64(len(hashes)))
+
+	if ss.accUpdated >= trieCommitThreshold {
+		if _, err := ss.commitAccountTrie(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commitAccountTrie flush the account trie to disk. Caller shall hold the lock.
+func (ss *stateSyncer) commitAccountTrie() (common.Hash, error) {
+	root, err := ss.accTrie.Commit(nil)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := ss.triedb.Commit(root, false); err != nil {
+		return common.Hash{}, err
+	}
+	accTrie, err := trie.New(root, ss.triedb)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	ss.accTrie = accTrie
+	ss.accUpdated = 0
+	return root, nil
+}
+
+func (ss *stateSyncer) syncStorages() error {
+	taskC := make(chan []storageTask, len(ss.storages)/syncproto.GetStorageRangesAccountsCap+1)
+	for start := 0; start < len(ss.storages); start += syncproto.GetStorageRangesAccountsCap {
+		end := start + syncproto.GetStorageRangesAccountsCap
+		if end > len(ss.storages) {
+			end = len(ss.storages)
+		}
+		taskC <- ss.storages[start:end]
+	}
+	close(taskC)
+
+	return ss.runWorkers(func() error {
+		for tasks := range taskC {
+			if err := ss.syncStorageTasks(tasks); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (ss *stateSyncer) syncStorageTasks(tasks []storageTask) error {
+	var (
+		origin   common.Hash
+		stTrie   *trie.Trie
+		updated  int
+		failures int
+		err      error
+	)
+	for len(tasks) > 0 {
+		if failures >= fastSyncMaxRetries {
+			return errors.Errorf("too many failures in storage of %x", tasks[0].account)
+		}
+		accounts := make([]common.Hash, 0, len(tasks))
+		for _, task := range tasks {
+			accounts = append(accounts, task.account)
+		}
+		ctx, cancel := context.WithTimeout(ss.ctx, 30*time.Second)
+		hashes, slots, proof, stid, reqErr := ss.p.GetStorageRanges(ctx, ss.root, accounts, origin, maxHash,
+			syncproto.StateResponseBytesCap)
+		cancel()
+		if reqErr == nil && len(slots) == 0 {
+			reqErr = errors.New("empty storage ranges response")
+		}
+		if reqErr == nil && len(proof) != 0 {
+			last := len(hashes) - 1
+			reqErr = verifyRangeEdge(tasks[last].root, hashes[last], slots[last], proof)
+		}
+		if reqErr != nil {
+			if errors.Is(reqErr, context.Canceled) {
+				return reqErr
+			}
+			ss.logger.Warn().Err(reqErr).Str("stream", string(stid)).Msg("storage ranges request failed")
+			ss.p.RemoveStream(stid)
+			failures++
+			continue
+		}
+		failures = 0
+
+		var delivered int
+		for i := range hashes {
+			task := tasks[i]
+			if stTrie == nil {
+				if stTrie, err = trie.New(common.Hash{}, ss.triedb); err != nil {
+					return err
+				}
+			}
+			for j, h := range hashes[i] {
+				if err := stTrie.TryUpdate(h[:], slots[i][j]); err != nil {
+					return err
+				}
+			}
+			updated += len(hashes[i])
+			fastSyncStateCounterVec.With(ss.labels("storage")).Add(float64(len(hashes[i])))
+
+			partial := i == len(hashes)-1 && len(proof) != 0 && len(hashes[i]) != 0 &&
+				hashes[i][len(hashes[i])-1] != maxHash
+			if partial {
+				// The storage of this account is not fully delivered. Continue from the
+				// last delivered slot.
+				origin = incHash(hashes[i][len(hashes[i])-1])
+				if updated >= trieCommitThreshold {
+					if stTrie, err = ss.commitTrie(stTrie); err != nil {
+						return err
+					}
+					updated = 0
+				}
+				break
+			}
+			if err := ss.finishStorageTrie(task, stTrie); err != nil {
+				return err
+			}
+			stTrie, origin, updated = nil, common.Hash{}, 0
+			delivered++
+		}
+		tasks = tasks[delivered:]
+	}
+	return nil
+}
+
+// finishStorageTrie flush the storage trie to disk and heal the trie if the storage
+// root is not expected.
+func (ss *stateSyncer) finishStorageTrie(task storageTask, stTrie *trie.Trie) error {
+	root, err := stTrie.Commit(nil)
+	if err != nil {
+		return err
+	}
+	if err := ss.triedb.Commit(root, false); err != nil {
+		return err
+	}
+	if root == task.root {
+		return nil
+	}
+	ss.logger.Debug().Str("account", task.account.String()).Str("expect", task.root.String()).
+		Str("got", root.String()).Msg("storage root mismatch. Healing")
+	return ss.heal(trie.NewSync(task.root, ss.db, nil, ss.bloom))
+}
+
+func (ss *stateSyncer) commitTrie(tr *trie.Trie) (*trie.Trie, error) {
+	root, err := tr.Commit(nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := ss.triedb.Commit(root, false); err != nil {
+		return nil, err
+	}
+	return trie.New(root, ss.triedb)
+}
+
+func (ss *stateSyncer) syncCodes() error {
+	hashes := make([]common.Hash, 0, len(ss.codeHashes))
+	for h := range ss.codeHashes {
+		hashes = append(hashes, h)
+	}
+	taskC := make(chan []common.Hash, len(hashes)/syncproto.GetByteCodesAmountCap+1)
+	for start := 0; start < len(hashes); start += syncproto.GetByteCodesAmountCap {
+		end := start + syncproto.GetByteCodesAmountCap
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+		taskC <- hashes[start:end]
+	}
+	close(taskC)
+
+	return ss.runWorkers(func() error {
+		for hs := range taskC {
+			if err := ss.syncCodeHashes(hs); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (ss *stateSyncer) syncCodeHashes(hs []common.Hash) error {
+	var failures int
+	for len(hs) > 0 {
+		if failures >= fastSyncMaxRetries {
+			return errors.Errorf("too many failures in getting codes")
+		}
+		ctx, cancel := context.WithTimeout(ss.ctx, 30*time.Second)
+		codes, stid, err := ss.p.GetByteCodes(ctx, hs, syncproto.StateResponseBytesCap)
+		cancel()
+		if err == nil && len(codes) == 0 {
+			err = errors.New("empty byte codes response")
+		}
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			ss.logger.Warn().Err(err).Str("stream", string(stid)).Msg("byte codes request failed")
+			ss.p.RemoveStream(stid)
+			failures++
+			continue
+		}
+		failures = 0
+
+		var (
+			batch     = ss.db.NewBatch()
+			remaining []common.Hash
+		)
+		for i, h := range hs {
+			if i >= len(codes) || len(codes[i]) == 0 {
+				remaining = append(remaining, h)
+				continue
+			}
+			if err := batch.Put(h[:], codes[i]); err != nil {
+				return err
+			}
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		fastSyncStateCounterVec.With(ss.labels("code")).Add(float64(len(hs) - len(remaining)))
+		hs = remaining
+	}
+	return nil
+}
+
+// heal downloads the trie nodes which are scheduled by the trie sync scheduler
+// until all nodes are downloaded.
+func (ss *stateSyncer) heal(sched *trie.Sync) error {
+	var (
+		retry    []common.Hash
+		failures int
+	)
+	for sched.Pending() > 0 {
+		if failures >= fastSyncMaxRetries {
+			return errors.New("too many failures in healing trie")
+		}
+		hashes := retry
+		if len(hashes) < syncproto.GetTrieNodesAmountCap {
+			hashes = append(hashes, sched.Missing(syncproto.GetTrieNodesAmountCap-len(hashes))...)
+		}
+		if len(hashes) == 0 {
+			return errors.New("trie heal stuck with no missing nodes")
+		}
+		ctx, cancel := context.WithTimeout(ss.ctx, 30*time.Second)
+		nodes, stid, err := ss.p.GetTrieNodes(ctx, hashes, syncproto.StateResponseBytesCap)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			ss.logger.Warn().Err(err).Str("stream", string(stid)).Msg("trie nodes request failed")
+			ss.p.RemoveStream(stid)
+			retry = hashes
+			failures++
+			continue
+		}
+
+		var results []trie.SyncResult
+		retry = nil
+		for i, h := range hashes {
+			if i >= len(nodes) || len(nodes[i]) == 0 {
+				retry = append(retry, h)
+				continue
+			}
+			results = append(results, trie.SyncResult{Hash: h, Data: nodes[i]})
+		}
+		if len(results) == 0 {
+			ss.p.RemoveStream(stid)
+			failures++
+			continue
+		}
+		failures = 0
+		if _, index, err := sched.Process(results); err != nil {
+			return errors.Wrapf(err, "process trie node %x", results[index].Hash)
+		}
+		batch := ss.db.NewBatch()
+		if err := sched.Commit(batch); err != nil {
+			return err
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		fastSyncStateCounterVec.With(ss.labels("node")).Add(float64(len(results)))
+	}
+	return nil
+}
+
+// runWorkers runs the work function concurrently and returns the first error
+func (ss *stateSyncer) runWorkers(work func() error) error {
+	var (
+		wg       sync.WaitGroup
+		errLock  sync.Mutex
+		firstErr error
+	)
+	wg.Add(ss.concurrency)
+	for i := 0; i != ss.concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			if err := work(); err != nil {
+				errLock.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				errLock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (ss *stateSyncer) labels(typ string) prometheus.Labels {
+	pl := make(prometheus.Labels, len(ss.promLabels)+1)
+	for k, v := range ss.promLabels {
+		pl[k] = v
+	}
+	pl["type"] = typ
+	return pl
+}
+
+// verifyRangeEdge verifies the last delivered key value against the root with the
+// given merkle proof. The completeness of the range is not checked here, and is
+// guaranteed by the trie heal process.
+func verifyRangeEdge(root common.Hash, keys []common.Hash, values [][]byte, proof [][]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return err
+		}
+	}
+	last := len(keys) - 1
+	val, _, err := trie.VerifyProof(root, keys[last][:], proofDB)
+	if err != nil {
+		return errors.Wrap(err, "invalid range proof")
+	}
+	if !bytes.Equal(val, values[last]) {
+		return errors.Errorf("value of %x not match proof", keys[last])
+	}
+	return nil
+}
+
+// splitHashRange splits the whole hash space into n continuous ranges
+func splitHashRange(n int) []hashRange {
+	var (
+		ranges = make([]hashRange, 0, n)
+		step   = new(big.Int).Div(new(big.Int).Add(maxHash.Big(), common.Big1), big.NewInt(int64(n)))
+		next   = new(big.Int)
+	)
+	for i := 0; i != n; i++ {
+		limit := new(big.Int).Sub(new(big.Int).Add(next, step), common.Big1)
+		if i == n-1 {
+			limit = maxHash.Big()
+		}
+		ranges = append(ranges, hashRange{
+			origin: common.BigToHash(next),
+			limit:  common.BigToHash(limit),
+		})
+		next = new(big.Int).Add(limit, common.Big1)
+	}
+	return ranges
+}
+
+// incHash returns the next hash of h. The caller shall make sure h is not maxHash.
+func incHash(h common.Hash) common.Hash {
+	return common.BigToHash(new(big.Int).Add(h.Big(), common.Big1))
+}
@@ -0,0 +1,191 @@
+package downloader
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+func TestSplitHashRange(t *testing.T) {
+	tests := []int{1, 2, 3, 16}
+	for i, n := range tests {
+		ranges := splitHashRange(n)
+		if len(ranges) != n {
+			t.Fatalf("Test %v: unexpected size %v / %v", i, len(ranges), n)
+		}
+		if ranges[0].origin != (common.Hash{}) {
+			t.Errorf("Test %v: first origin not zero", i)
+		}
+		if ranges[n-1].limit != maxHash {
+			t.Errorf("Test %v: last limit not max hash", i)
+		}
+		for j := 1; j < n; j++ {
+			if incHash(ranges[j-1].limit) != ranges[j].origin {
+				t.Errorf("Test %v: range %v not continuous", i, j)
+			}
+		}
+	}
+}
+
+func TestVerifyRangeEdge(t *testing.T) {
+	tr, _ := trie.New(common.Hash{}, trie.NewDatabase(memorydb.New()))
+	var (
+		keys   []common.Hash
+		values [][]byte
+	)
+	for i := 0; i != 100; i++ {
+		key := crypto.Keccak256Hash([]byte(fmt.Sprintf("key %v", i)))
+		val := []byte(fmt.Sprintf("value %v", i))
+		tr.Update(key[:], val)
+		keys = append(keys, key)
+		values = append(values, val)
+	}
+	root := tr.Hash()
+
+	proof := newTestProof(tr, keys[10])
+	if err := verifyRangeEdge(root, keys[:11], values[:11], proof); err != nil {
+		t.Errorf("valid proof: %v", err)
+	}
+	if err := verifyRangeEdge(root, keys[:11], values[:11], nil); err == nil {
+		t.Errorf("missing proof: expect error")
+	}
+	badValues := append(append([][]byte{}, values[:10]...), []byte("bad value"))
+	if err := verifyRangeEdge(root, keys[:11], badValues, proof); err == nil {
+		t.Errorf("bad value: expect error")
+	}
+	if err := verifyRangeEdge(root, nil, nil, nil); err != nil {
+		t.Errorf("empty range: %v", err)
+	}
+}
+
+func newTestProof(tr *trie.Trie, key common.Hash) [][]byte {
+	proofDB := memorydb.New()
+	tr.Prove(key[:], 0, proofDB)
+
+	var proof [][]byte
+	it := proofDB.NewIterator()
+	for it.Next() {
+		proof = append(proof, common.CopyBytes(it.Value()))
+	}
+	return proof
+}
+
+func TestInsertPivot(t *testing.T) {
+	const pivotEpoch = 5
+	beacon := newFastSyncTestChain(0)
+	beacon.shardStates[pivotEpoch] = makeTestShardState(pivotEpoch)
+	bc := newFastSyncTestChain(0)
+	fsi := &fastSyncIter{bc: bc, beacon: beacon, logger: zerolog.Nop()}
+
+	pivot := makeTestEpochBlock(100, pivotEpoch, makeTestShardState(pivotEpoch+1))
+	if err := fsi.insertPivot(pivot, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, epoch := range []uint64{pivotEpoch, pivotEpoch + 1} {
+		if _, err := bc.ReadShardState(new(big.Int).SetUint64(epoch)); err != nil {
+			t.Errorf("shard state of epoch %v not written: %v", epoch, err)
+		}
+	}
+	// The block after the pivot is verified with the shard state of the pivot epoch
+	next := makeTestEpochBlock(101, pivotEpoch+1, nil)
+	if _, err := bc.InsertChain(types.Blocks{next}, true); err != nil {
+		t.Fatal(err)
+	}
+	if bn := bc.currentBlockNumber(); bn != 101 {
+		t.Errorf("unexpected current block number %v", bn)
+	}
+
+	// The pivot can't be inserted before the beacon chain has the pivot epoch
+	fsi = &fastSyncIter{bc: newFastSyncTestChain(0), beacon: newFastSyncTestChain(0), logger: zerolog.Nop()}
+	if err := fsi.insertPivot(pivot, nil); err == nil {
+		t.Error("pivot inserted without shard state of pivot epoch")
+	}
+}
+
+// fastSyncTestChain is a testBlockChain which keeps the shard states written with the
+// fast sync pivot, and like the engine requires the shard state of the epoch of the
+// parent block to verify the commit signature of the parent when inserting a block.
+type fastSyncTestChain struct {
+	*testBlockChain
+	shardStates map[uint64]*shard.State
+	epochs      map[uint64]*big.Int // epochs of the inserted blocks by number
+}
+
+func newFastSyncTestChain(curBN uint64) *fastSyncTestChain {
+	return &fastSyncTestChain{
+		testBlockChain: newTestBlockChain(curBN, nil),
+		shardStates:    make(map[uint64]*shard.State),
+		epochs:         make(map[uint64]*big.Int),
+	}
+}
+
+func (bc *fastSyncTestChain) ReadShardState(epoch *big.Int) (*shard.State, error) {
+	ss, ok := bc.shardStates[epoch.Uint64()]
+	if !ok {
+		return nil, fmt.Errorf("shard state of epoch %v not found", epoch)
+	}
+	return ss, nil
+}
+
+func (bc *fastSyncTestChain) InsertFastSyncPivot(
+	block *types.Block, receipts types.Receipts, commitSig []byte, epochShardState *shard.State,
+) error {
+	if epochShardState == nil {
+		return errors.New("shard state of pivot epoch not available")
+	}
+	nextShardState, err := shard.DecodeWrapper(block.Header().ShardState())
+	if err != nil {
+		return err
+	}
+	bc.shardStates[block.Epoch().Uint64()] = epochShardState
+	bc.shardStates[nextShardState.Epoch.Uint64()] = nextShardState
+	bc.epochs[block.NumberU64()] = block.Epoch()
+	bc.changeBlockNumber(block.NumberU64())
+	return nil
+}
+
+func (bc *fastSyncTestChain) InsertChain(chain types.Blocks, verifyHeaders bool) (int, error) {
+	for i, block := range chain {
+		parentEpoch, ok := bc.epochs[block.NumberU64()-1]
+		if !ok {
+			return i, errors.Errorf("parent of block %v not found", block.NumberU64())
+		}
+		if _, err := bc.ReadShardState(parentEpoch); err != nil {
+			return i, errors.Wrapf(err, "verify commit sig of block %v", block.NumberU64()-1)
+		}
+		if n, err := bc.testBlockChain.InsertChain(types.Blocks{block}, verifyHeaders); err != nil {
+			return i + n, err
+		}
+		bc.epochs[block.NumberU64()] = block.Epoch()
+	}
+	return len(chain), nil
+}
+
+func makeTestEpochBlock(bn uint64, epoch uint64, nextShardState *shard.State) *types.Block {
+	header := makeTestBlock(bn).Header()
+	header.SetEpoch(new(big.Int).SetUint64(epoch))
+	if nextShardState != nil {
+		enc, err := shard.EncodeWrapper(*nextShardState, true)
+		if err != nil {
+			panic(err)
+		}
+		header.SetShardState(enc)
+	}
+	return types.NewBlockWithHeader(header)
+}
+
+func makeTestShardState(epoch uint64) *shard.State {
+	return &shard.State{
+		Epoch:  new(big.Int).SetUint64(epoch),
+		Shards: []shard.Committee{{ShardID: 1}},
+	}
+}
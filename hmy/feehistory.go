@@ -0,0 +1,282 @@
+package hmy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/consensus/misc"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxFeeHistory is the maximum number of blocks that can be queried in a
+	// single fee history request.
+	maxFeeHistory = 1024
+	// maxFeeHistoryPercentiles is the maximum number of reward percentiles
+	// that can be queried in a single fee history request.
+	maxFeeHistoryPercentiles = 100
+	// feeHistoryCacheSize is the number of processed blocks kept by the oracle.
+	feeHistoryCacheSize = 2048
+)
+
+var (
+	errInvalidPercentile = errors.New("invalid reward percentile")
+	errRequestBeyondHead = errors.New("request beyond head block")
+)
+
+// FeeHistory is the gas price history of a range of consecutive blocks.
+// Rewards are the effective tips of plain transactions at the requested
+// percentiles weighted by gas used, and staking transactions are reported
+// separately in StakingReward and StakingGasUsedRatio. BaseFee has the base
+// fees of the blocks and of the next block, which are zero before EIP-1559.
+type FeeHistory struct {
+	OldestBlock         uint64
+	Reward              [][]*big.Int
+	BaseFee             []*big.Int
+	GasUsedRatio        []float64
+	StakingReward       [][]*big.Int
+	StakingGasUsedRatio []float64
+}
+
+// blockFees is the processed fee data of a single block kept in the oracle cache.
+type blockFees struct {
+	header         *block.Header
+	gasLimit       uint64
+	gasUsed        uint64          // gas used by plain transactions
+	stakingGasUsed uint64          // gas used by staking transactions
+	txs            []txGasAndPrice // plain transactions sorted by effective tip
+	stakingTxs     []txGasAndPrice // staking transactions sorted by effective tip
+}
+
+type txGasAndPrice struct {
+	gasUsed uint64
+	price   *big.Int
+}
+
+type txGasAndPriceSorter []txGasAndPrice
+
+func (s txGasAndPriceSorter) Len() int           { return len(s) }
+func (s txGasAndPriceSorter) Less(i, j int) bool { return s[i].price.Cmp(s[j].price) < 0 }
+func (s txGasAndPriceSorter) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// FeeHistory returns the gas price history of blockCount blocks ending at lastBlock.
+// The number of blocks is capped by maxFeeHistory and by the genesis block, so the
+// result might contain less blocks than requested.
+func (gpo *Oracle) FeeHistory(
+	ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64,
+) (*FeeHistory, error) {
+	if err := checkRewardPercentiles(rewardPercentiles); err != nil {
+		return nil, err
+	}
+	if blockCount == 0 {
+		return &FeeHistory{}, nil
+	}
+	if blockCount > maxFeeHistory {
+		blockCount = maxFeeHistory
+	}
+	head, err := gpo.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	headNumber := head.Number().Uint64()
+	last := headNumber
+	if lastBlock >= 0 {
+		if uint64(lastBlock) > headNumber {
+			return nil, errors.Wrapf(errRequestBeyondHead, "requested %v, head %v", lastBlock, headNumber)
+		}
+		last = uint64(lastBlock)
+	}
+	if blockCount > last+1 {
+		blockCount = last + 1
+	}
+	oldest := last + 1 - blockCount
+
+	history := &FeeHistory{
+		OldestBlock:         oldest,
+		BaseFee:             make([]*big.Int, 0, blockCount+1),
+		GasUsedRatio:        make([]float64, 0, blockCount),
+		StakingGasUsedRatio: make([]float64, 0, blockCount),
+	}
+	if len(rewardPercentiles) != 0 {
+		history.Reward = make([][]*big.Int, 0, blockCount)
+		history.StakingReward = make([][]*big.Int, 0, blockCount)
+	}
+	for bn := oldest; bn <= last; bn++ {
+		fees, err := gpo.getBlockFees(ctx, bn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "block %v", bn)
+		}
+		history.BaseFee = append(history.BaseFee, baseFeeOrZero(fees.header.BaseFee()))
+		history.GasUsedRatio = append(history.GasUsedRatio, fees.gasUsedRatio(fees.gasUsed))
+		history.StakingGasUsedRatio = append(history.StakingGasUsedRatio, fees.gasUsedRatio(fees.stakingGasUsed))
+		if len(rewardPercentiles) != 0 {
+			history.Reward = append(history.Reward, feePercentiles(fees.txs, rewardPercentiles))
+			history.StakingReward = append(history.StakingReward, feePercentiles(fees.stakingTxs, rewardPercentiles))
+		}
+		if bn == last {
+			history.BaseFee = append(history.BaseFee, gpo.nextBaseFee(fees.header))
+		}
+	}
+	return history, nil
+}
+
+// nextBaseFee returns the base fee of the block following the header, which is
+// zero if the header is before EIP-1559.
+func (gpo *Oracle) nextBaseFee(header *block.Header) *big.Int {
+	if header.BaseFee() == nil {
+		return new(big.Int)
+	}
+	return misc.CalcBaseFee(gpo.backend.ChainConfig(), header)
+}
+
+func baseFeeOrZero(baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int)
+	}
+	return baseFee
+}
+
+// getBlockFees returns the processed fee data of the block, either from the cache
+// or computed from the block and its receipts.
+func (gpo *Oracle) getBlockFees(ctx context.Context, bn uint64) (*blockFees, error) {
+	header, err := gpo.backend.HeaderByNumber(ctx, rpc.BlockNumber(bn))
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("header not found")
+	}
+	hash := header.Hash()
+	if cached, ok := gpo.historyCache.Get(hash); ok {
+		return cached.(*blockFees), nil
+	}
+	b, err := gpo.backend.GetBlock(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New("block not found")
+	}
+	receipts, err := gpo.backend.GetReceipts(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := processBlockFees(b, receipts)
+	if err != nil {
+		return nil, err
+	}
+	gpo.historyCache.Add(hash, fees)
+	return fees, nil
+}
+
+// processBlockFees computes the fee data of the block. The receipts of plain
+// transactions are followed by the receipts of staking transactions. The prices
+// are the effective tips above the base fee of the block, and the gas used by
+// plain and staking transactions is accounted separately.
+func processBlockFees(b *types.Block, receipts types.Receipts) (*blockFees, error) {
+	txs, stks := b.Transactions(), b.StakingTransactions()
+	if len(receipts) != len(txs)+len(stks) {
+		return nil, fmt.Errorf("receipts size not expected: %v / %v", len(receipts), len(txs)+len(stks))
+	}
+	baseFee := b.BaseFee()
+	fees := &blockFees{
+		header:     b.Header(),
+		gasLimit:   b.GasLimit(),
+		gasUsed:    b.GasUsed(),
+		txs:        make([]txGasAndPrice, 0, len(txs)),
+		stakingTxs: make([]txGasAndPrice, 0, len(stks)),
+	}
+	for i, tx := range txs {
+		// The included transactions always pay the base fee
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			tip = new(big.Int)
+		}
+		fees.txs = append(fees.txs, txGasAndPrice{
+			gasUsed: receipts[i].GasUsed,
+			price:   tip,
+		})
+	}
+	for i, stk := range stks {
+		gasUsed := receipts[len(txs)+i].GasUsed
+		fees.stakingGasUsed += gasUsed
+		price := stk.GasPrice()
+		if baseFee != nil {
+			price = new(big.Int).Sub(price, baseFee)
+			if price.Sign() < 0 {
+				price = new(big.Int)
+			}
+		}
+		fees.stakingTxs = append(fees.stakingTxs, txGasAndPrice{
+			gasUsed: gasUsed,
+			price:   price,
+		})
+	}
+	// The gas used by the block includes the gas used by staking transactions,
+	// which is reported separately.
+	if fees.gasUsed >= fees.stakingGasUsed {
+		fees.gasUsed -= fees.stakingGasUsed
+	} else {
+		fees.gasUsed = 0
+	}
+	sort.Stable(txGasAndPriceSorter(fees.txs))
+	sort.Stable(txGasAndPriceSorter(fees.stakingTxs))
+	return fees, nil
+}
+
+func (fees *blockFees) gasUsedRatio(gasUsed uint64) float64 {
+	if fees.gasLimit == 0 {
+		return 0
+	}
+	return float64(gasUsed) / float64(fees.gasLimit)
+}
+
+// feePercentiles returns the gas prices at the given percentiles of the gas used
+// by the sorted transactions. Zero is returned for all percentiles if there is
+// no transaction.
+func feePercentiles(txs []txGasAndPrice, percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	if len(txs) == 0 {
+		for i := range rewards {
+			rewards[i] = new(big.Int)
+		}
+		return rewards
+	}
+	var totalGasUsed uint64
+	for _, tx := range txs {
+		totalGasUsed += tx.gasUsed
+	}
+	var (
+		index      int
+		sumGasUsed = txs[0].gasUsed
+	)
+	for i, p := range percentiles {
+		threshold := uint64(float64(totalGasUsed) * p / 100)
+		for sumGasUsed < threshold && index < len(txs)-1 {
+			index++
+			sumGasUsed += txs[index].gasUsed
+		}
+		rewards[i] = new(big.Int).Set(txs[index].price)
+	}
+	return rewards
+}
+
+func checkRewardPercentiles(percentiles []float64) error {
+	if len(percentiles) > maxFeeHistoryPercentiles {
+		return fmt.Errorf("too many reward percentiles: %v > %v", len(percentiles), maxFeeHistoryPercentiles)
+	}
+	for i, p := range percentiles {
+		if p < 0 || p > 100 {
+			return errors.Wrapf(errInvalidPercentile, "%f", p)
+		}
+		if i > 0 && p < percentiles[i-1] {
+			return errors.Wrapf(errInvalidPercentile, "#%d:%f > #%d:%f", i-1, percentiles[i-1], i, p)
+		}
+	}
+	return nil
+}
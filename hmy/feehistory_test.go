@@ -0,0 +1,112 @@
+package hmy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+func TestFeePercentiles(t *testing.T) {
+	txs := []txGasAndPrice{
+		{gasUsed: 100, price: big.NewInt(1)},
+		{gasUsed: 200, price: big.NewInt(2)},
+		{gasUsed: 700, price: big.NewInt(3)},
+	}
+	tests := []struct {
+		txs         []txGasAndPrice
+		percentiles []float64
+		exp         []int64
+	}{
+		{txs, []float64{0, 10, 10.1, 30, 31, 100}, []int64{1, 1, 2, 2, 3, 3}},
+		{txs, []float64{50}, []int64{3}},
+		{txs[:1], []float64{0, 50, 100}, []int64{1, 1, 1}},
+		{nil, []float64{0, 100}, []int64{0, 0}},
+	}
+	for i, test := range tests {
+		rewards := feePercentiles(test.txs, test.percentiles)
+		if len(rewards) != len(test.exp) {
+			t.Fatalf("Test %v: unexpected rewards size %v / %v", i, len(rewards), len(test.exp))
+		}
+		for j := range rewards {
+			if rewards[j].Cmp(big.NewInt(test.exp[j])) != 0 {
+				t.Errorf("Test %v: unexpected reward at %v: %v / %v", i, test.percentiles[j], rewards[j], test.exp[j])
+			}
+		}
+	}
+}
+
+func TestProcessBlockFees(t *testing.T) {
+	var (
+		to       = common.HexToAddress("0x01")
+		baseFee  = big.NewInt(100)
+		gasPrice = big.NewInt(150)
+	)
+	txs := []*types.Transaction{
+		types.NewTransaction(0, to, 0, big.NewInt(0), 21000, gasPrice, nil),
+		types.NewDynamicFeeTransaction(big.NewInt(2), 1, &to, 0, 0, big.NewInt(0), 21000,
+			big.NewInt(20), big.NewInt(1000), nil, nil),
+	}
+	stks := []*staking.StakingTransaction{
+		newFeeTestStakingTx(t, gasPrice),
+		newFeeTestStakingTx(t, big.NewInt(50)), // below the base fee
+	}
+	receipts := types.Receipts{
+		{GasUsed: 21000}, {GasUsed: 30000}, {GasUsed: 40000}, {GasUsed: 50000},
+	}
+	header := blockfactory.NewTestHeader().With().
+		GasLimit(1000000).GasUsed(141000).BaseFee(baseFee).Header()
+	b := types.NewBlock(header, txs, receipts, nil, nil, stks)
+
+	fees, err := processBlockFees(b, receipts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fees.gasUsed != 51000 {
+		t.Errorf("unexpected gas used by plain transactions %v", fees.gasUsed)
+	}
+	if fees.stakingGasUsed != 90000 {
+		t.Errorf("unexpected gas used by staking transactions %v", fees.stakingGasUsed)
+	}
+	checkTxGasAndPrices(t, "plain", fees.txs, []txGasAndPrice{
+		{gasUsed: 30000, price: big.NewInt(20)},
+		{gasUsed: 21000, price: big.NewInt(50)},
+	})
+	checkTxGasAndPrices(t, "staking", fees.stakingTxs, []txGasAndPrice{
+		{gasUsed: 50000, price: big.NewInt(0)},
+		{gasUsed: 40000, price: big.NewInt(50)},
+	})
+
+	if _, err := processBlockFees(b, receipts[:3]); err == nil {
+		t.Error("expected error for missing receipts")
+	}
+}
+
+func newFeeTestStakingTx(t *testing.T, gasPrice *big.Int) *staking.StakingTransaction {
+	stk, err := staking.NewStakingTransaction(0, 50000, gasPrice, func() (staking.Directive, interface{}) {
+		return staking.DirectiveDelegate, staking.Delegate{
+			DelegatorAddress: common.HexToAddress("0x02"),
+			ValidatorAddress: common.HexToAddress("0x03"),
+			Amount:           big.NewInt(1),
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stk
+}
+
+func checkTxGasAndPrices(t *testing.T, kind string, got, exp []txGasAndPrice) {
+	t.Helper()
+	if len(got) != len(exp) {
+		t.Fatalf("unexpected %v transactions %v", kind, got)
+	}
+	for i := range got {
+		if got[i].gasUsed != exp[i].gasUsed || got[i].price.Cmp(exp[i].price) != 0 {
+			t.Errorf("unexpected %v transaction at %v: %v / %v", kind, i, got[i], exp[i])
+		}
+	}
+}
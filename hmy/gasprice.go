@@ -26,6 +26,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/internal/utils"
+	lru "github.com/hashicorp/golang-lru"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
@@ -62,6 +63,9 @@ type Oracle struct {
 
 	checkBlocks int
 	percentile  int
+
+	// historyCache caches the processed fee data of recent blocks by block hash
+	historyCache *lru.Cache
 }
 
 // NewOracle returns a new gasprice oracle which can recommend suitable
@@ -86,12 +90,14 @@ func NewOracle(backend *Harmony, params GasPriceConfig) *Oracle {
 		maxPrice = DefaultMaxPrice
 		utils.Logger().Warn().Msg(fmt.Sprint("Sanitizing invalid gasprice oracle price cap", "provided", params.MaxPrice, "updated", maxPrice))
 	}
+	historyCache, _ := lru.New(feeHistoryCacheSize)
 	return &Oracle{
-		backend:     backend,
-		lastPrice:   params.Default,
-		maxPrice:    maxPrice,
-		checkBlocks: blocks,
-		percentile:  percent,
+		backend:      backend,
+		lastPrice:    params.Default,
+		maxPrice:     maxPrice,
+		checkBlocks:  blocks,
+		percentile:   percent,
+		historyCache: historyCache,
 	}
 }
 
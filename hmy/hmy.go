@@ -52,6 +52,7 @@ type Harmony struct {
 	CxPool        *core.CxPool // CxPool is used to store the blockHashes of blocks containing cx receipts to be sent
 	// DB interfaces
 	BloomIndexer *core.ChainIndexer // Bloom indexer operating during block imports
+	TraceIndexer *TraceIndexer      // Call trace indexer of archival nodes, nil if disabled
 	NodeAPI      NodeAPI
 	// ChainID is used to identify which network we are using
 	ChainID uint64
@@ -89,6 +90,9 @@ type NodeAPI interface {
 	GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error)
 	GetTransactionsCount(address, txType string) (uint64, error)
 	GetStakingTransactionsCount(address, txType string) (uint64, error)
+	GetTransactionsHistoryPage(query commonRPC.TxHistoryQuery) ([]common.Hash, string, error)
+	GetTokenTransfers(address string, pageIndex, pageSize uint32) ([]commonRPC.TokenTransfer, error)
+	GetTokenHolders(token string, pageIndex, pageSize uint32) ([]commonRPC.TokenHolder, error)
 	IsCurrentlyLeader() bool
 	IsOutOfSync(shardID uint32) bool
 	SyncStatus(shardID uint32) (bool, uint64, uint64)
@@ -231,11 +235,25 @@ func (hmy *Harmony) GetNodeMetadata() commonRPC.NodeMetadata {
 	}
 }
 
-// GetEVM returns a new EVM entity
-func (hmy *Harmony) GetEVM(ctx context.Context, msg core.Message, state *state.DB, header *block.Header) (*vm.EVM, error) {
+// GetEVM returns a new EVM entity for the calls, which are not charged the base fee.
+// The VM config of the blockchain is used if vmConfig is nil. The block context
+// is overridden by overrideBlock if not nil, before the chain rules of the EVM
+// are set.
+func (hmy *Harmony) GetEVM(
+	ctx context.Context, msg core.Message, state *state.DB, header *block.Header,
+	vmConfig *vm.Config, overrideBlock func(*vm.Context),
+) (*vm.EVM, error) {
 	state.SetBalance(msg.From(), math.MaxBig256)
 	vmCtx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
-	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), *hmy.BlockChain.GetVMConfig()), nil
+	if overrideBlock != nil {
+		overrideBlock(&vmCtx)
+	}
+	if vmConfig == nil {
+		vmConfig = hmy.BlockChain.GetVMConfig()
+	}
+	config := *vmConfig
+	config.NoBaseFee = true
+	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), config), nil
 }
 
 // ChainDb ..
@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/harmony-one/harmony/core/types"
 )
 
@@ -28,6 +29,18 @@ func (hmy *Harmony) GetPendingCXReceipts() []*types.CXReceiptsProof {
 	return hmy.NodeAPI.PendingCXReceipts()
 }
 
+// GetPoolContent returns the pending and queued transactions of the pool,
+// grouped by account and sorted by nonce.
+func (hmy *Harmony) GetPoolContent() (map[common.Address]types.PoolTransactions, map[common.Address]types.PoolTransactions) {
+	return hmy.TxPool.Content()
+}
+
+// GetPoolContentFrom returns the pending and queued transactions of the pool
+// sent by the given account, sorted by nonce.
+func (hmy *Harmony) GetPoolContentFrom(addr common.Address) (types.PoolTransactions, types.PoolTransactions) {
+	return hmy.TxPool.ContentFrom(addr)
+}
+
 // GetPoolTransactions returns pool transactions.
 func (hmy *Harmony) GetPoolTransactions() (types.PoolTransactions, error) {
 	pending, err := hmy.TxPool.Pending()
@@ -51,3 +64,10 @@ func (hmy *Harmony) GetPoolTransactions() (types.PoolTransactions, error) {
 func (hmy *Harmony) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return hmy.gpo.SuggestPrice(ctx)
 }
+
+// FeeHistory returns the gas price history of blockCount blocks ending at lastBlock.
+func (hmy *Harmony) FeeHistory(
+	ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64,
+) (*FeeHistory, error) {
+	return hmy.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+}
@@ -0,0 +1,558 @@
+package hmy
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrTraceIndexDisabled is returned by the trace queries if the trace index
+	// is not enabled on the node.
+	ErrTraceIndexDisabled = errors.New("trace index is not enabled")
+	// ErrTraceIndexRange is returned if the queried blocks are not in the trace index
+	ErrTraceIndexRange = errors.New("block is out of the range of the trace index")
+	// ErrTraceFilterLimit is returned if the trace filter queries too many blocks
+	// or traces.
+	ErrTraceFilterLimit = errors.New("trace filter exceeds the limit")
+)
+
+const (
+	// maxTraceFilterCount is the max number of traces returned by a trace filter,
+	// and the number returned if no count is given.
+	maxTraceFilterCount = 1000
+	// maxTraceFilterBlocks is the max number of blocks queried by a trace filter.
+	// The query ends at the last block if no first block is given.
+	maxTraceFilterBlocks = 10000
+)
+
+// parityTrace is the part of a Parity-style call trace read by the trace index.
+type parityTrace struct {
+	TransactionHash     common.Hash `json:"transactionHash"`
+	TransactionPosition uint64      `json:"transactionPosition"`
+	TraceAddress        []uint64    `json:"traceAddress"`
+	Type                string      `json:"type"`
+	Action              struct {
+		From          *common.Address `json:"from"`
+		To            *common.Address `json:"to"`
+		Address       *common.Address `json:"address"`
+		RefundAddress *common.Address `json:"refundAddress"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+		Output  hexutil.Bytes   `json:"output"`
+		Code    hexutil.Bytes   `json:"code"`
+	} `json:"result"`
+}
+
+// sender returns the account initiating the call, or nil if unknown.
+func (t *parityTrace) sender() *common.Address {
+	if t.Type == "suicide" {
+		return t.Action.Address
+	}
+	return t.Action.From
+}
+
+// recipient returns the account receiving the call, or nil if unknown. The
+// recipient of a create is the created contract, which is only known if the
+// creation succeeded.
+func (t *parityTrace) recipient() *common.Address {
+	switch t.Type {
+	case "create":
+		if t.Result != nil {
+			return t.Result.Address
+		}
+		return nil
+	case "suicide":
+		return t.Action.RefundAddress
+	}
+	return t.Action.To
+}
+
+// output returns the output of the call, or the code of the created contract.
+func (t *parityTrace) output() hexutil.Bytes {
+	switch {
+	case t.Result == nil:
+		return hexutil.Bytes{}
+	case t.Type == "create":
+		return t.Result.Code
+	}
+	return t.Result.Output
+}
+
+// TraceFilter is the query of the traces in the trace index. The traces match
+// if they are sent by any of the FromAddresses and received by any of the
+// ToAddresses, where an empty list matches any account.
+type TraceFilter struct {
+	FromBlock     *rpc.BlockNumber // first block of the query, up to maxTraceFilterBlocks before ToBlock if nil
+	ToBlock       *rpc.BlockNumber // last block of the query, the head of the index if nil
+	FromAddresses []common.Address
+	ToAddresses   []common.Address
+	After         uint64  // number of the matched traces to skip
+	Count         *uint64 // max number of traces to return, or nil for maxTraceFilterCount
+}
+
+// TraceReplay is the replay of a transaction from the trace index, in the
+// format of Parity's trace_replayBlockTransactions.
+type TraceReplay struct {
+	Output          hexutil.Bytes     `json:"output"`
+	StateDiff       interface{}       `json:"stateDiff"`
+	Trace           []json.RawMessage `json:"trace"`
+	VMTrace         interface{}       `json:"vmTrace"`
+	TransactionHash common.Hash       `json:"transactionHash"`
+}
+
+// TraceIndexer stores the Parity-style call traces of the blocks of an archival
+// node, along with the index of the traces by their sender and recipient.
+// The blocks are indexed sequentially from the configured block to the head of
+// the chain, and the new blocks are indexed as they are inserted.
+type TraceIndexer struct {
+	hmy  *Harmony
+	from uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewTraceIndexer returns the trace indexer of the blocks since from.
+func NewTraceIndexer(hmy *Harmony, from uint64) *TraceIndexer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TraceIndexer{
+		hmy:    hmy,
+		from:   from,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start starts indexing the blocks in the background. If the trace index was
+// built from another block, it is deleted and rebuilt from the configured block.
+func (ti *TraceIndexer) Start() error {
+	db := ti.hmy.chainDb
+	if tail := rawdb.ReadTraceIndexTail(db); tail == nil || *tail != ti.from {
+		utils.Logger().Info().Uint64("from", ti.from).Msg("[TraceIndexer] resetting trace index")
+		if err := rawdb.DeleteTraceIndex(db); err != nil {
+			return errors.Wrap(err, "cannot reset trace index")
+		}
+		if err := rawdb.WriteTraceIndexTail(db, ti.from); err != nil {
+			return errors.Wrap(err, "cannot reset trace index")
+		}
+	}
+	ti.wg.Add(1)
+	go ti.loop()
+	return nil
+}
+
+// Stop stops indexing the blocks, and waits for the block being indexed.
+func (ti *TraceIndexer) Stop() {
+	ti.cancel()
+	ti.wg.Wait()
+}
+
+func (ti *TraceIndexer) loop() {
+	defer ti.wg.Done()
+
+	heads := make(chan core.ChainHeadEvent, 10)
+	sub := ti.hmy.BlockChain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	for {
+		ti.indexBlocks()
+		select {
+		case <-heads:
+		case <-sub.Err():
+			return
+		case <-ti.ctx.Done():
+			return
+		}
+	}
+}
+
+// indexBlocks indexes the blocks after the head of the index up to the head of
+// the chain, once the blocks no longer canonical are removed from the index.
+// The indexing is retried from the failed block on the next head.
+func (ti *TraceIndexer) indexBlocks() {
+	next, err := ti.rewind()
+	if err != nil {
+		utils.Logger().Warn().Err(err).Msg("[TraceIndexer] cannot rewind trace index")
+		return
+	}
+	for ; next <= ti.hmy.BlockChain.CurrentBlock().NumberU64(); next++ {
+		if ti.ctx.Err() != nil {
+			return
+		}
+		block := ti.hmy.BlockChain.GetBlockByNumber(next)
+		if block == nil {
+			return
+		}
+		if err := ti.indexBlock(block); err != nil {
+			utils.Logger().Warn().Err(err).
+				Uint64("number", next).
+				Msg("[TraceIndexer] cannot index block")
+			return
+		}
+	}
+}
+
+// rewind removes the indexed blocks which are no longer canonical, which happens
+// if the chain was rewound below the head of the index, and returns the number
+// of the next block to index.
+func (ti *TraceIndexer) rewind() (uint64, error) {
+	db := ti.hmy.chainDb
+	head := rawdb.ReadTraceIndexHead(db)
+	if head == nil {
+		return ti.from, nil
+	}
+	// Walk back to the fork point, the last indexed block still canonical
+	next := *head + 1
+	for next > ti.from && rawdb.ReadTraceIndexHash(db, next-1) != rawdb.ReadCanonicalHash(db, next-1) {
+		next--
+	}
+	if next == *head+1 {
+		return next, nil
+	}
+	utils.Logger().Info().
+		Uint64("head", *head).
+		Uint64("next", next).
+		Msg("[TraceIndexer] rewinding trace index")
+
+	batch := db.NewBatch()
+	for number := next; number <= *head; number++ {
+		var traces []parityTrace
+		if data := rawdb.ReadBlockTraces(db, number); len(data) > 0 {
+			if err := json.Unmarshal(data, &traces); err != nil {
+				return 0, errors.Wrap(err, "invalid call traces")
+			}
+		}
+		for i, trace := range traces {
+			entry := rawdb.TraceIndexEntry{BlockNumber: number, Index: uint32(i)}
+			if from := trace.sender(); from != nil {
+				if err := rawdb.DeleteTraceFromEntry(batch, *from, entry); err != nil {
+					return 0, err
+				}
+			}
+			if to := trace.recipient(); to != nil {
+				if err := rawdb.DeleteTraceToEntry(batch, *to, entry); err != nil {
+					return 0, err
+				}
+			}
+		}
+		if err := rawdb.DeleteBlockTraces(batch, number); err != nil {
+			return 0, err
+		}
+	}
+	if next == ti.from {
+		if err := rawdb.DeleteTraceIndexHead(batch); err != nil {
+			return 0, err
+		}
+	} else if err := rawdb.WriteTraceIndexHead(batch, next-1); err != nil {
+		return 0, err
+	}
+	return next, batch.Write()
+}
+
+// indexBlock stores the call traces of the block and indexes them by address,
+// along with the hash of the block and the new head of the index.
+func (ti *TraceIndexer) indexBlock(block *types.Block) error {
+	traces := make([]json.RawMessage, 0)
+	if len(block.Transactions()) > 0 {
+		var err error
+		if traces, err = ti.hmy.TraceBlockParity(ti.ctx, block); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(traces)
+	if err != nil {
+		return err
+	}
+	batch := ti.hmy.chainDb.NewBatch()
+	if err := rawdb.WriteBlockTraces(batch, block.NumberU64(), data); err != nil {
+		return err
+	}
+	if err := rawdb.WriteTraceIndexHash(batch, block.NumberU64(), block.Hash()); err != nil {
+		return err
+	}
+	for i, raw := range traces {
+		var trace parityTrace
+		if err := json.Unmarshal(raw, &trace); err != nil {
+			return errors.Wrap(err, "invalid call trace")
+		}
+		entry := rawdb.TraceIndexEntry{BlockNumber: block.NumberU64(), Index: uint32(i)}
+		if from := trace.sender(); from != nil {
+			if err := rawdb.WriteTraceFromEntry(batch, *from, entry); err != nil {
+				return err
+			}
+		}
+		if to := trace.recipient(); to != nil {
+			if err := rawdb.WriteTraceToEntry(batch, *to, entry); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rawdb.WriteTraceIndexHead(batch, block.NumberU64()); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// indexRange returns the first and the last indexed block.
+func (ti *TraceIndexer) indexRange() (uint64, uint64, error) {
+	db := ti.hmy.chainDb
+	tail, head := rawdb.ReadTraceIndexTail(db), rawdb.ReadTraceIndexHead(db)
+	if tail == nil || head == nil {
+		return 0, 0, errors.New("no block is indexed yet")
+	}
+	return *tail, *head, nil
+}
+
+// resolveBlock returns the number of the block in the trace index.
+func (ti *TraceIndexer) resolveBlock(number *rpc.BlockNumber, tail, head, def uint64) (uint64, error) {
+	switch {
+	case number == nil:
+		return def, nil
+	case *number == rpc.LatestBlockNumber || *number == rpc.PendingBlockNumber:
+		return head, nil
+	case uint64(*number) < tail || uint64(*number) > head:
+		return 0, errors.Wrapf(ErrTraceIndexRange, "block %d, index [%d, %d]", *number, tail, head)
+	}
+	return uint64(*number), nil
+}
+
+// blockTraces returns the stored call traces of the block.
+func (ti *TraceIndexer) blockTraces(number uint64) ([]json.RawMessage, error) {
+	var traces []json.RawMessage
+	if err := json.Unmarshal(rawdb.ReadBlockTraces(ti.hmy.chainDb, number), &traces); err != nil {
+		return nil, errors.Wrapf(err, "invalid call traces of block %d", number)
+	}
+	return traces, nil
+}
+
+// Filter returns the stored call traces matching the filter, in the order of
+// execution. At most maxTraceFilterBlocks blocks and maxTraceFilterCount traces
+// are queried.
+func (ti *TraceIndexer) Filter(filter TraceFilter) ([]json.RawMessage, error) {
+	tail, head, err := ti.indexRange()
+	if err != nil {
+		return nil, err
+	}
+	to, err := ti.resolveBlock(filter.ToBlock, tail, head, head)
+	if err != nil {
+		return nil, err
+	}
+	def := tail
+	if to-tail >= maxTraceFilterBlocks {
+		def = to - maxTraceFilterBlocks + 1
+	}
+	from, err := ti.resolveBlock(filter.FromBlock, tail, head, def)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, errors.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	if to-from >= maxTraceFilterBlocks {
+		return nil, errors.Wrapf(ErrTraceFilterLimit, "%d blocks, max %d", to-from+1, maxTraceFilterBlocks)
+	}
+	count := uint64(maxTraceFilterCount)
+	if filter.Count != nil {
+		if *filter.Count > maxTraceFilterCount {
+			return nil, errors.Wrapf(ErrTraceFilterLimit, "count %d, max %d", *filter.Count, maxTraceFilterCount)
+		}
+		count = *filter.Count
+	}
+
+	skip := filter.After
+	results := make([]json.RawMessage, 0)
+	full := func() bool {
+		return uint64(len(results)) >= count
+	}
+	if len(filter.FromAddresses) == 0 && len(filter.ToAddresses) == 0 {
+		for number := from; number <= to && !full(); number++ {
+			traces, err := ti.blockTraces(number)
+			if err != nil {
+				return nil, err
+			}
+			for _, trace := range traces {
+				if full() {
+					break
+				}
+				if skip > 0 {
+					skip--
+					continue
+				}
+				results = append(results, trace)
+			}
+		}
+		return results, nil
+	}
+
+	entries := ti.matchEntries(filter, from, to)
+	var (
+		number uint64
+		traces []json.RawMessage
+	)
+	for _, entry := range entries {
+		if full() {
+			break
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if traces == nil || entry.BlockNumber != number {
+			if traces, err = ti.blockTraces(entry.BlockNumber); err != nil {
+				return nil, err
+			}
+			number = entry.BlockNumber
+		}
+		if int(entry.Index) >= len(traces) {
+			return nil, errors.Errorf("missing call trace %d of block %d", entry.Index, number)
+		}
+		results = append(results, traces[entry.Index])
+	}
+	return results, nil
+}
+
+// matchEntries returns the positions of the traces in the blocks [from, to]
+// matching the addresses of the filter, in ascending order.
+func (ti *TraceIndexer) matchEntries(filter TraceFilter, from, to uint64) []rawdb.TraceIndexEntry {
+	db := ti.hmy.chainDb
+	lookup := func(addrs []common.Address, read func(common.Address) []rawdb.TraceIndexEntry) map[rawdb.TraceIndexEntry]struct{} {
+		matched := make(map[rawdb.TraceIndexEntry]struct{})
+		for _, addr := range addrs {
+			for _, entry := range read(addr) {
+				matched[entry] = struct{}{}
+			}
+		}
+		return matched
+	}
+	senders := lookup(filter.FromAddresses, func(addr common.Address) []rawdb.TraceIndexEntry {
+		return rawdb.ReadTraceFromEntries(db, addr, from, to)
+	})
+	recipients := lookup(filter.ToAddresses, func(addr common.Address) []rawdb.TraceIndexEntry {
+		return rawdb.ReadTraceToEntries(db, addr, from, to)
+	})
+
+	var entries []rawdb.TraceIndexEntry
+	switch {
+	case len(filter.ToAddresses) == 0:
+		for entry := range senders {
+			entries = append(entries, entry)
+		}
+	case len(filter.FromAddresses) == 0:
+		for entry := range recipients {
+			entries = append(entries, entry)
+		}
+	default:
+		for entry := range senders {
+			if _, ok := recipients[entry]; ok {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].BlockNumber != entries[j].BlockNumber {
+			return entries[i].BlockNumber < entries[j].BlockNumber
+		}
+		return entries[i].Index < entries[j].Index
+	})
+	return entries
+}
+
+// Get returns the stored call trace of the transaction at the trace address,
+// or nil if not found.
+func (ti *TraceIndexer) Get(hash common.Hash, traceAddress []uint64) (json.RawMessage, error) {
+	tail, head, err := ti.indexRange()
+	if err != nil {
+		return nil, err
+	}
+	tx, _, number, index := rawdb.ReadTransaction(ti.hmy.chainDb, hash)
+	if tx == nil {
+		return nil, nil
+	}
+	if number < tail || number > head {
+		return nil, errors.Wrapf(ErrTraceIndexRange, "block %d, index [%d, %d]", number, tail, head)
+	}
+	traces, err := ti.blockTraces(number)
+	if err != nil {
+		return nil, err
+	}
+	for _, raw := range traces {
+		var trace parityTrace
+		if err := json.Unmarshal(raw, &trace); err != nil {
+			return nil, errors.Wrap(err, "invalid call trace")
+		}
+		if trace.TransactionPosition == index && equalTraceAddress(trace.TraceAddress, traceAddress) {
+			return raw, nil
+		}
+	}
+	return nil, nil
+}
+
+func equalTraceAddress(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ReplayBlockTransactions returns the stored call traces of the block grouped
+// by transaction. Only the "trace" trace type is supported.
+func (ti *TraceIndexer) ReplayBlockTransactions(number rpc.BlockNumber, traceTypes []string) ([]*TraceReplay, error) {
+	for _, traceType := range traceTypes {
+		if traceType != "trace" {
+			return nil, errors.Errorf("unsupported trace type %q", traceType)
+		}
+	}
+	tail, head, err := ti.indexRange()
+	if err != nil {
+		return nil, err
+	}
+	block, err := ti.resolveBlock(&number, tail, head, head)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := ti.blockTraces(block)
+	if err != nil {
+		return nil, err
+	}
+	replays := make([]*TraceReplay, 0)
+	for _, raw := range traces {
+		var trace parityTrace
+		if err := json.Unmarshal(raw, &trace); err != nil {
+			return nil, errors.Wrap(err, "invalid call trace")
+		}
+		// The first trace of a transaction is the outer call
+		if len(trace.TraceAddress) == 0 {
+			replays = append(replays, &TraceReplay{
+				Output:          trace.output(),
+				Trace:           make([]json.RawMessage, 0),
+				TransactionHash: trace.TransactionHash,
+			})
+		}
+		if len(replays) == 0 {
+			return nil, errors.Errorf("invalid call traces of block %d", block)
+		}
+		replay := replays[len(replays)-1]
+		if len(traceTypes) > 0 {
+			replay.Trace = append(replay.Trace, raw)
+		}
+	}
+	return replays, nil
+}
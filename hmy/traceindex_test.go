@@ -0,0 +1,129 @@
+package hmy
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/rpc"
+	hmyrawdb "github.com/harmony-one/harmony/core/rawdb"
+	"github.com/pkg/errors"
+)
+
+func TestTraceIndexerFilterLimit(t *testing.T) {
+	const head = 2 * maxTraceFilterBlocks
+	db := rawdb.NewMemoryDatabase()
+	if err := hmyrawdb.WriteTraceIndexTail(db, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := hmyrawdb.WriteTraceIndexHead(db, head); err != nil {
+		t.Fatal(err)
+	}
+	for number := uint64(head - maxTraceFilterBlocks + 1); number <= head; number++ {
+		if err := hmyrawdb.WriteBlockTraces(db, number, []byte("[]")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ti := &TraceIndexer{hmy: &Harmony{chainDb: db}}
+
+	// The last blocks are queried if no first block is given
+	if traces, err := ti.Filter(TraceFilter{}); err != nil || len(traces) != 0 {
+		t.Errorf("unexpected traces %v, %v", traces, err)
+	}
+	var (
+		first = rpc.BlockNumber(0)
+		count = uint64(maxTraceFilterCount + 1)
+	)
+	if _, err := ti.Filter(TraceFilter{FromBlock: &first}); errors.Cause(err) != ErrTraceFilterLimit {
+		t.Errorf("expected %v, got %v", ErrTraceFilterLimit, err)
+	}
+	if _, err := ti.Filter(TraceFilter{Count: &count}); errors.Cause(err) != ErrTraceFilterLimit {
+		t.Errorf("expected %v, got %v", ErrTraceFilterLimit, err)
+	}
+}
+
+func TestTraceIndexerRewind(t *testing.T) {
+	var (
+		db     = rawdb.NewMemoryDatabase()
+		sender = common.BytesToAddress([]byte{0x11})
+		trace  = fmt.Sprintf(`[{"type":"call","action":{"from":"%s","to":"%s"}}]`, sender.Hex(), sender.Hex())
+	)
+	// Index the blocks [2, 5] of the chain
+	if err := hmyrawdb.WriteTraceIndexTail(db, 2); err != nil {
+		t.Fatal(err)
+	}
+	for number := uint64(2); number <= 5; number++ {
+		hash := common.Hash{byte(number)}
+		if err := hmyrawdb.WriteCanonicalHash(db, hash, number); err != nil {
+			t.Fatal(err)
+		}
+		if err := hmyrawdb.WriteBlockTraces(db, number, []byte(trace)); err != nil {
+			t.Fatal(err)
+		}
+		if err := hmyrawdb.WriteTraceIndexHash(db, number, hash); err != nil {
+			t.Fatal(err)
+		}
+		entry := hmyrawdb.TraceIndexEntry{BlockNumber: number}
+		if err := hmyrawdb.WriteTraceFromEntry(db, sender, entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := hmyrawdb.WriteTraceToEntry(db, sender, entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := hmyrawdb.WriteTraceIndexHead(db, 5); err != nil {
+		t.Fatal(err)
+	}
+	ti := &TraceIndexer{hmy: &Harmony{chainDb: db}, from: 2}
+
+	if next, err := ti.rewind(); err != nil || next != 6 {
+		t.Fatalf("unexpected next block %v, %v", next, err)
+	}
+	// Rewind the chain to the block 3 and replace the block 4
+	if err := hmyrawdb.WriteCanonicalHash(db, common.Hash{0x44}, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := hmyrawdb.DeleteCanonicalHash(db, 5); err != nil {
+		t.Fatal(err)
+	}
+	if next, err := ti.rewind(); err != nil || next != 4 {
+		t.Fatalf("unexpected next block %v, %v", next, err)
+	}
+	if head := hmyrawdb.ReadTraceIndexHead(db); head == nil || *head != 3 {
+		t.Errorf("unexpected trace index head %v", head)
+	}
+	for number := uint64(4); number <= 5; number++ {
+		if data := hmyrawdb.ReadBlockTraces(db, number); data != nil {
+			t.Errorf("block %d: traces returned after rewound: %s", number, data)
+		}
+		if hash := hmyrawdb.ReadTraceIndexHash(db, number); hash != (common.Hash{}) {
+			t.Errorf("block %d: hash returned after rewound: %x", number, hash)
+		}
+	}
+	want := []hmyrawdb.TraceIndexEntry{{BlockNumber: 2}, {BlockNumber: 3}}
+	if have := hmyrawdb.ReadTraceFromEntries(db, sender, 0, 10); !reflect.DeepEqual(have, want) {
+		t.Errorf("unexpected from entries %v, want %v", have, want)
+	}
+	if have := hmyrawdb.ReadTraceToEntries(db, sender, 0, 10); !reflect.DeepEqual(have, want) {
+		t.Errorf("unexpected to entries %v, want %v", have, want)
+	}
+
+	// Rewind the chain to the block 1 and replace the block 2
+	if err := hmyrawdb.WriteCanonicalHash(db, common.Hash{0x22}, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := hmyrawdb.DeleteCanonicalHash(db, 3); err != nil {
+		t.Fatal(err)
+	}
+	if next, err := ti.rewind(); err != nil || next != 2 {
+		t.Fatalf("unexpected next block %v, %v", next, err)
+	}
+	if head := hmyrawdb.ReadTraceIndexHead(db); head != nil {
+		t.Errorf("trace index head returned after rewound: %v", *head)
+	}
+	if have := hmyrawdb.ReadTraceFromEntries(db, sender, 0, 10); have != nil {
+		t.Errorf("from entries returned after rewound: %v", have)
+	}
+}
@@ -19,6 +19,7 @@ package hmy
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -56,9 +57,10 @@ const (
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
-	Tracer  *string
-	Timeout *string
-	Reexec  *uint64
+	Tracer       *string
+	TracerConfig json.RawMessage // Config specific to the native tracer
+	Timeout      *string
+	Reexec       *uint64
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -162,8 +164,8 @@ func (hmy *Harmony) TraceChain(ctx context.Context, start, end *types.Block, con
 
 			// Fetch and execute the next block trace tasks
 			for task := range tasks {
-				hmySigner := types.MakeSigner(hmy.BlockChain.Config(), task.block.Number())
-				ethSigner := types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+				hmySigner := types.MakeSigner(hmy.BlockChain.Config(), task.block.Epoch())
+				ethSigner := types.MakeEthSigner(hmy.BlockChain.Config(), task.block.Epoch())
 
 				// Trace all the transactions contained within
 				for i, tx := range task.block.Transactions() {
@@ -171,7 +173,7 @@ func (hmy *Harmony) TraceChain(ctx context.Context, start, end *types.Block, con
 					if tx.IsEthCompatible() {
 						signer = ethSigner
 					}
-					msg, _ := tx.AsMessage(signer)
+					msg, _ := tx.AsMessage(signer, task.block.BaseFee())
 					vmCtx := core.NewEVMContext(msg, task.block.Header(), hmy.BlockChain, nil)
 
 					res, err := hmy.TraceTx(ctx, msg, vmCtx, task.statedb, config)
@@ -362,8 +364,8 @@ func (hmy *Harmony) traceBlockNoThread(ctx context.Context, block *types.Block,
 	}
 	// Execute all the transaction contained within the block concurrently
 	var (
-		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-		ethSigner = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+		ethSigner = types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 		txs       = block.Transactions()
 		results   = make([]*TxTraceResult, len(txs))
 	)
@@ -378,7 +380,7 @@ traceLoop:
 			signer = ethSigner
 		}
 		// Generate the next state snapshot fast without tracing
-		msg, _ := tx.AsMessage(signer)
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
 
 		ethTx := tx.ConvertToEth()
 		statedb.Prepare(ethTx.Hash(), blockHash, i)
@@ -438,8 +440,8 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 	}
 	// Execute all the transaction contained within the block concurrently
 	var (
-		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-		ethSigner = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+		ethSigner = types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 		txs       = block.Transactions()
 		results   = make([]*TxTraceResult, len(txs))
 
@@ -463,7 +465,7 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 					signer = ethSigner
 				}
 
-				msg, _ := txs[task.index].AsMessage(signer)
+				msg, _ := txs[task.index].AsMessage(signer, block.BaseFee())
 				vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 				ethTx := txs[task.index].ConvertToEth()
 				task.statedb.Prepare(ethTx.Hash(), blockHash, task.index)
@@ -487,7 +489,7 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 			signer = ethSigner
 		}
 		// Generate the next state snapshot fast without tracing
-		msg, _ := tx.AsMessage(signer)
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
 		statedb.Prepare(tx.Hash(), block.Hash(), i)
 		vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 
@@ -509,6 +511,25 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 	return results, nil
 }
 
+// TraceBlockParity executes all the transactions contained within the block, and
+// returns their Parity-style call traces flattened in the order of execution.
+func (hmy *Harmony) TraceBlockParity(ctx context.Context, block *types.Block) ([]json.RawMessage, error) {
+	tracer := "ParityBlockTracer"
+	results, err := hmy.TraceBlock(ctx, block, &TraceConfig{Tracer: &tracer})
+	if err != nil {
+		return nil, err
+	}
+	traces := make([]json.RawMessage, 0)
+	for _, result := range results {
+		raw, ok := result.Result.([]json.RawMessage)
+		if !ok {
+			return nil, errors.New("tracer bug:expected []json.RawMessage")
+		}
+		traces = append(traces, raw...)
+	}
+	return traces, nil
+}
+
 // standardTraceBlockToFile configures a new tracer which uses standard JSON output,
 // and traces either a full block or an individual transaction. The return value will
 // be one filename per transaction traced.
@@ -550,8 +571,8 @@ func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.B
 
 	// Execute transaction, either tracing all or just the requested one
 	var (
-		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-		ethSigner = types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+		hmySigner = types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+		ethSigner = types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 		dumps     []string
 	)
 	for i, tx := range block.Transactions() {
@@ -561,7 +582,7 @@ func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.B
 		}
 		// Prepare the transaction for un-traced execution
 		var (
-			msg, _ = tx.AsMessage(signer)
+			msg, _ = tx.AsMessage(signer, block.BaseFee())
 			vmctx  = core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 
 			vmConf vm.Config
@@ -707,7 +728,7 @@ func (hmy *Harmony) ComputeStateDB(block *types.Block, reexec uint64) (*state.DB
 // be tracer dependent.
 // NOTE: Only support default StructLogger tracer
 func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.Context, statedb *state.DB, config *TraceConfig) (interface{}, error) {
-	// Assemble the structured logger or the JavaScript tracer
+	// Assemble the structured logger, the native or the JavaScript tracer
 	var (
 		tracer vm.Tracer
 		err    error
@@ -725,15 +746,23 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 				return nil, err
 			}
 		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
+		// Prefer the native tracer of the same name to the JavaScript one
+		native, ok, err := tracers.NewNative(*config.Tracer, config.TracerConfig)
+		if err != nil {
 			return nil, err
 		}
+		if !ok {
+			// Constuct the JavaScript tracer to execute with
+			if native, err = tracers.New(*config.Tracer); err != nil {
+				return nil, err
+			}
+		}
+		tracer = native
 		// Handle timeouts and RPC cancellations
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		go func() {
 			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
+			native.Stop(errors.New("execution timeout"))
 		}()
 		defer cancel()
 
@@ -760,7 +789,7 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 			StructLogs:  FormatLogs(tracer.StructLogs()),
 		}, nil
 
-	case *tracers.Tracer:
+	case tracers.NativeTracer:
 		return tracer.GetResult()
 	case *tracers.ParityBlockTracer:
 		return tracer.GetResult()
@@ -787,8 +816,8 @@ func (hmy *Harmony) ComputeTxEnv(block *types.Block, txIndex int, reexec uint64)
 	}
 
 	// Recompute transactions up to the target index.
-	hmySigner := types.MakeSigner(hmy.BlockChain.Config(), block.Number())
-	ethSigner := types.NewEIP155Signer(hmy.BlockChain.Config().EthCompatibleChainID)
+	hmySigner := types.MakeSigner(hmy.BlockChain.Config(), block.Epoch())
+	ethSigner := types.MakeEthSigner(hmy.BlockChain.Config(), block.Epoch())
 
 	for idx, tx := range block.Transactions() {
 		signer := hmySigner
@@ -797,7 +826,7 @@ func (hmy *Harmony) ComputeTxEnv(block *types.Block, txIndex int, reexec uint64)
 		}
 
 		// Assemble the transaction call message and return if the requested offset
-		msg, _ := tx.AsMessage(signer)
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
 		context := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 		if idx == txIndex {
 			return msg, context, statedb, nil
@@ -0,0 +1,170 @@
+package tracers
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// NativeTracer is a transaction tracer implemented in Go. The native tracers
+// replace the JavaScript tracers of the same name, and return the same output.
+// The JavaScript Tracer implements it as well.
+type NativeTracer interface {
+	vm.Tracer
+	// GetResult returns the JSON result of the trace, or any accumulated error
+	GetResult() (json.RawMessage, error)
+	// Stop terminates execution of the tracer at the first opportune moment
+	Stop(err error)
+}
+
+// natives contains the constructors of the native tracers by name.
+var natives = map[string]func(config json.RawMessage) (NativeTracer, error){
+	"callTracer":     newCallTracer,
+	"prestateTracer": newPrestateTracer,
+	"4byteTracer":    newFourByteTracer,
+}
+
+// NewNative returns the native tracer of the given name with the given tracer
+// specific configuration, and false if there is no native tracer of that name.
+func NewNative(name string, config json.RawMessage) (NativeTracer, bool, error) {
+	constructor, ok := natives[name]
+	if !ok {
+		return nil, false, nil
+	}
+	tracer, err := constructor(config)
+	if err != nil {
+		return nil, true, err
+	}
+	return tracer, true, nil
+}
+
+// interrupter implements the Stop method of the native tracers.
+type interrupter struct {
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (i *interrupter) Stop(err error) {
+	i.reason = err
+	atomic.StoreUint32(&i.interrupt, 1)
+}
+
+// interrupted returns the reason of the interruption if the tracer is stopped.
+func (i *interrupter) interrupted() error {
+	if atomic.LoadUint32(&i.interrupt) > 0 {
+		return i.reason
+	}
+	return nil
+}
+
+// isPrecompiled returns whether the address is a precompile skipped by the
+// tracers, as calls to them are just fancy opcodes.
+func isPrecompiled(addr common.Address) bool {
+	_, ok := vm.PrecompiledContractsVRF[addr]
+	return ok
+}
+
+// stackPeek returns the n-th element from the top of the stack, or zero if the
+// stack is not deep enough.
+func stackPeek(stack *vm.Stack, n int) *big.Int {
+	data := stack.Data()
+	if n < 0 || len(data) <= n {
+		return new(big.Int)
+	}
+	return data[len(data)-n-1]
+}
+
+// memorySlice returns a copy of the memory in [offset, offset+size), or nil
+// if it is out of the bounds of the memory, as the slice of the JavaScript
+// tracers.
+func memorySlice(memory *vm.Memory, offset, size *big.Int) []byte {
+	if !offset.IsUint64() || !size.IsUint64() {
+		return nil
+	}
+	begin, end := offset.Uint64(), offset.Uint64()+size.Uint64()
+	if end < begin || uint64(memory.Len()) < end {
+		return nil
+	}
+	return memory.GetCopy(int64(begin), int64(end-begin))
+}
+
+// orderedMap is a JSON object which keeps its keys in the order of insertion,
+// as the objects of the JavaScript tracers.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: make(map[string]interface{})}
+}
+
+func (m *orderedMap) get(key string) (interface{}, bool) {
+	value, ok := m.values[key]
+	return value, ok
+}
+
+func (m *orderedMap) set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *orderedMap) delete(key string) {
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+func (m *orderedMap) len() int {
+	return len(m.keys)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := marshalJSON(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := marshalJSON(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalJSON encodes the value without escaping HTML characters, as the JSON
+// encoder of the JavaScript tracers.
+func marshalJSON(v interface{}) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
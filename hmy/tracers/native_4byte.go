@@ -0,0 +1,98 @@
+package tracers
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// fourByteTracer is the native implementation of the JavaScript 4byteTracer,
+// which searches for 4byte-identifiers, and collects them for post-processing.
+// It collects the methods identifiers along with the size of the supplied data,
+// so a reversed signature can be matched against the size of the data.
+type fourByteTracer struct {
+	interrupter
+
+	ids   *orderedMap // the 4byte ids found, as "0x<id>-<size>" to count
+	input []byte      // the call data of the outer transaction
+	err   error
+}
+
+func newFourByteTracer(config json.RawMessage) (NativeTracer, error) {
+	return &fourByteTracer{ids: newOrderedMap()}, nil
+}
+
+// store saves the given identifier and data size.
+func (t *fourByteTracer) store(id []byte, size uint64) {
+	key := hexutil.Encode(id) + "-" + strconv.FormatUint(size, 10)
+	count, _ := t.ids.get(key)
+	n, _ := count.(int)
+	t.ids.set(key, n+1)
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *fourByteTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.input = common.CopyBytes(input)
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *fourByteTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.err != nil {
+		return nil
+	}
+	if t.err = t.interrupted(); t.err != nil {
+		return nil
+	}
+	// Skip any opcodes that are not internal calls, the stack index of the input
+	// offset is after the value of CALL and CALLCODE
+	var inOffIdx int
+	switch op {
+	case vm.CALL, vm.CALLCODE:
+		inOffIdx = 3
+	case vm.DELEGATECALL, vm.STATICCALL:
+		inOffIdx = 2
+	default:
+		return nil
+	}
+	// Skip any pre-compile invocations, those are just fancy opcodes
+	if isPrecompiled(common.BigToAddress(stackPeek(stack, 1))) {
+		return nil
+	}
+	// Gather internal call details
+	inSize := stackPeek(stack, inOffIdx+1)
+	if inSize.Cmp(big.NewInt(4)) >= 0 && inSize.IsUint64() {
+		id := memorySlice(memory, stackPeek(stack, inOffIdx), big.NewInt(4))
+		t.store(id, inSize.Uint64()-4)
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *fourByteTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *fourByteTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// GetResult returns the 4byte ids found with their number of occurrences, or
+// any accumulated error.
+func (t *fourByteTracer) GetResult() (json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	// Save the outer calldata also
+	if len(t.input) >= 4 {
+		t.store(t.input[:4], uint64(len(t.input)-4))
+	}
+	return marshalJSON(t.ids)
+}
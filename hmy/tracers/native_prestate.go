@@ -0,0 +1,248 @@
+package tracers
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/pkg/errors"
+)
+
+// prestateAccount is an account reported by the prestate tracer. In diff mode,
+// the accounts of the post state only have the fields which were changed.
+type prestateAccount struct {
+	Balance string      `json:"balance,omitempty"`
+	Nonce   *uint64     `json:"nonce,omitempty"`
+	Code    string      `json:"code,omitempty"`
+	Storage *orderedMap `json:"storage,omitempty"`
+
+	balance *big.Int
+}
+
+type prestateTracerConfig struct {
+	// DiffMode reports the changes made by the transaction, as the accounts of
+	// the pre and the post state which were modified.
+	DiffMode bool `json:"diffMode"`
+}
+
+// prestateTracer is the native implementation of the JavaScript prestateTracer,
+// which outputs sufficient information to create a local execution of the
+// transaction from a custom assembled genesis block.
+type prestateTracer struct {
+	interrupter
+
+	config   prestateTracerConfig
+	env      *vm.EVM
+	prestate *orderedMap // the genesis that we're building
+
+	create  bool
+	created *prestateAccount // the contract created by the transaction
+	from    common.Address
+	to      common.Address
+	value   *big.Int
+	err     error
+}
+
+func newPrestateTracer(config json.RawMessage) (NativeTracer, error) {
+	t := &prestateTracer{prestate: newOrderedMap()}
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &t.config); err != nil {
+			return nil, errors.Wrap(err, "invalid prestateTracer config")
+		}
+	}
+	return t, nil
+}
+
+func (t *prestateTracer) account(addr common.Address) *prestateAccount {
+	acc, ok := t.prestate.get(hexutil.Encode(addr.Bytes()))
+	if !ok {
+		return nil
+	}
+	return acc.(*prestateAccount)
+}
+
+// lookupAccount injects the specified account into the prestate.
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if t.account(addr) != nil {
+		return
+	}
+	db := t.env.StateDB
+	nonce := db.GetNonce(addr)
+	balance := db.GetBalance(addr)
+	t.prestate.set(hexutil.Encode(addr.Bytes()), &prestateAccount{
+		Balance: "0x" + balance.Text(16),
+		Nonce:   &nonce,
+		Code:    hexutil.Encode(db.GetCode(addr)),
+		Storage: newOrderedMap(),
+		balance: new(big.Int).Set(balance),
+	})
+}
+
+// lookupStorage injects the specified storage entry of the given account into
+// the prestate.
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	t.lookupAccount(addr)
+	storage := t.account(addr).Storage
+	if _, ok := storage.get(key.Hex()); !ok {
+		storage.set(key.Hex(), t.env.StateDB.GetState(addr, key).Hex())
+	}
+}
+
+// CaptureStart implements the Tracer interface to initialize the tracing operation.
+func (t *prestateTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.env = env
+	t.create = create
+	t.from = from
+	t.to = to
+	t.value = new(big.Int).Set(value)
+
+	// Balance will potentially be wrong here, since this will include the value
+	// sent along with the message. We fix that in GetResult.
+	t.lookupAccount(to)
+	return nil
+}
+
+// CaptureState implements the Tracer interface to trace a single step of VM execution.
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if t.err != nil {
+		return nil
+	}
+	if t.err = t.interrupted(); t.err != nil {
+		return nil
+	}
+	// Whenever new state is accessed, add it to the prestate
+	switch op {
+	case vm.EXTCODECOPY, vm.EXTCODESIZE, vm.BALANCE:
+		t.lookupAccount(common.BigToAddress(stackPeek(stack, 0)))
+	case vm.CREATE:
+		from := contract.Address()
+		t.lookupAccount(crypto.CreateAddress(from, env.StateDB.GetNonce(from)))
+	case vm.CREATE2:
+		// stack: salt, size, offset, endowment
+		from := contract.Address()
+		salt := common.BigToHash(stackPeek(stack, 3))
+		code := memorySlice(memory, stackPeek(stack, 1), stackPeek(stack, 2))
+		t.lookupAccount(crypto.CreateAddress2(from, salt, crypto.Keccak256(code)))
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		t.lookupAccount(common.BigToAddress(stackPeek(stack, 1)))
+	case vm.SSTORE, vm.SLOAD:
+		t.lookupStorage(contract.Address(), common.BigToHash(stackPeek(stack, 0)))
+	}
+	return nil
+}
+
+// CaptureFault implements the Tracer interface to trace an execution fault
+// while running an opcode.
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	return nil
+}
+
+// GetResult returns the assembled prestate, or the pre and post states in diff
+// mode, or any accumulated error.
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if t.env == nil {
+		return nil, errors.New("prestateTracer: no transaction traced")
+	}
+	// At this point, we need to deduct the 'value' from the outer transaction,
+	// and move it back to the origin
+	t.lookupAccount(t.from)
+
+	fromAcc, toAcc := t.account(t.from), t.account(t.to)
+	toAcc.balance.Sub(toAcc.balance, t.value)
+	toAcc.Balance = "0x" + toAcc.balance.Text(16)
+	fromAcc.balance.Add(fromAcc.balance, t.value)
+	fromAcc.Balance = "0x" + fromAcc.balance.Text(16)
+
+	// Decrement the caller's nonce, and remove empty create targets
+	*fromAcc.Nonce--
+	if t.create {
+		// We can blindly delete the contract prestate, as any existing state would
+		// have caused the transaction to be rejected as invalid in the first place.
+		t.created = t.account(t.to)
+		t.prestate.delete(hexutil.Encode(t.to.Bytes()))
+	}
+	if !t.config.DiffMode {
+		return marshalJSON(t.prestate)
+	}
+	return marshalJSON(t.diff())
+}
+
+// diff returns the accounts of the prestate which were modified by the
+// transaction, along with the changes made to them. Created contracts are only
+// in the post state.
+func (t *prestateTracer) diff() *orderedMap {
+	db := t.env.StateDB
+	pre, post := newOrderedMap(), newOrderedMap()
+
+	accounts := t.prestate.keys
+	if t.created != nil {
+		// The created contract had no code and nonce before the transaction
+		t.created.Nonce = new(uint64)
+		t.created.Code = "0x"
+		accounts = append(append([]string{}, accounts...), hexutil.Encode(t.to.Bytes()))
+	}
+	for _, key := range accounts {
+		addr := common.HexToAddress(key)
+		preAcc := t.account(addr)
+		if preAcc == nil {
+			preAcc = t.created
+		}
+		postAcc := &prestateAccount{}
+		modified := false
+
+		if balance := db.GetBalance(addr); balance.Cmp(preAcc.balance) != 0 {
+			postAcc.Balance = "0x" + balance.Text(16)
+			modified = true
+		}
+		if nonce := db.GetNonce(addr); nonce != *preAcc.Nonce {
+			postAcc.Nonce = &nonce
+			modified = true
+		}
+		if code := hexutil.Encode(db.GetCode(addr)); code != preAcc.Code {
+			postAcc.Code = code
+			modified = true
+		}
+		// Only keep the storage slots which were changed
+		preStorage, postStorage := newOrderedMap(), newOrderedMap()
+		for _, slot := range preAcc.Storage.keys {
+			value, _ := preAcc.Storage.get(slot)
+			newValue := db.GetState(addr, common.HexToHash(slot)).Hex()
+			if newValue != value.(string) {
+				preStorage.set(slot, value)
+				postStorage.set(slot, newValue)
+			}
+		}
+		if postStorage.len() > 0 {
+			postAcc.Storage = postStorage
+			modified = true
+		}
+		if !modified {
+			continue
+		}
+		if t.account(addr) != nil {
+			pre.set(key, &prestateAccount{
+				Balance: preAcc.Balance,
+				Nonce:   preAcc.Nonce,
+				Code:    preAcc.Code,
+				Storage: preStorage,
+			})
+		}
+		post.set(key, postAcc)
+	}
+	result := newOrderedMap()
+	result.set("pre", pre)
+	result.set("post", post)
+	return result
+}
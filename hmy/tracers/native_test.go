@@ -0,0 +1,193 @@
+package tracers
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var (
+	nativeTestSender   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	nativeTestCaller   = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	nativeTestCallee   = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	nativeTestReverter = common.HexToAddress("0x4000000000000000000000000000000000000004")
+	nativeTestFaulter  = common.HexToAddress("0x5000000000000000000000000000000000000005")
+	nativeTestOther    = common.HexToAddress("0x6000000000000000000000000000000000000006")
+
+	// nativeTestCallerCode stores 0xdeadbeef at memory 0, calls the callee with
+	// 36 bytes of input and 1 wei, static calls the reverter and the identity
+	// precompile with 4 bytes of input, reads the balance of the other account,
+	// then fails to create a contract from the invalid code at memory 0
+	nativeTestCallerCode = append(append(append(append(hexutil.MustDecode(
+		"0x7fdeadbeef00000000000000000000000000000000000000000000000000000000600052"+
+			"6020604060246000600173"), nativeTestCallee.Bytes()...), hexutil.MustDecode(
+		"0x5af150600060006004600073")...), nativeTestReverter.Bytes()...), hexutil.MustDecode(
+		"0x5afa50600060006004600060045afa5073"+common.Bytes2Hex(nativeTestOther.Bytes())+"3150"+
+			"601060006000f050"+"00")...)
+
+	// nativeTestCalleeCode stores 42 at slot 0 and returns the loaded slot 0
+	nativeTestCalleeCode = hexutil.MustDecode("0x602a60005560005460005260206000f3")
+
+	// nativeTestReverterCode reverts
+	nativeTestReverterCode = hexutil.MustDecode("0x60006000fd")
+
+	// nativeTestFaulterCode executes an invalid opcode
+	nativeTestFaulterCode = hexutil.MustDecode("0xfe")
+
+	errTestStop = errors.New("test stop")
+
+	nativeTestTime = regexp.MustCompile(`"time":"[^"]*"`)
+)
+
+// newNativeTestEVM returns a fresh EVM with the test contracts deployed.
+func newNativeTestEVM(tracer vm.Tracer) (*vm.EVM, *state.DB) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	statedb.SetBalance(nativeTestSender, big.NewInt(1000000))
+	statedb.SetNonce(nativeTestSender, 1)
+	statedb.SetBalance(nativeTestCaller, big.NewInt(100))
+	statedb.SetCode(nativeTestCaller, nativeTestCallerCode)
+	statedb.SetCode(nativeTestCallee, nativeTestCalleeCode)
+	statedb.SetCode(nativeTestReverter, nativeTestReverterCode)
+	statedb.SetCode(nativeTestFaulter, nativeTestFaulterCode)
+	statedb.SetBalance(nativeTestOther, big.NewInt(7))
+
+	ctx := vm.Context{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		IsValidator: core.IsValidator,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		GetVRF:      func(uint64) common.Hash { return common.Hash{} },
+		Origin:      nativeTestSender,
+		BlockNumber: big.NewInt(10),
+		EpochNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		GasLimit:    10000000,
+		GasPrice:    big.NewInt(1),
+	}
+	return vm.NewEVM(ctx, statedb, params.TestChainConfig, vm.Config{Debug: true, Tracer: tracer}), statedb
+}
+
+// applyNativeTest applies the test message with the given tracer.
+func applyNativeTest(tracer vm.Tracer, to *common.Address, input []byte) {
+	evm, statedb := newNativeTestEVM(tracer)
+	if to == nil {
+		evm.Create(vm.AccountRef(nativeTestSender), input, 1000000, big.NewInt(3))
+		return
+	}
+	// The nonce of the sender is incremented before the call, as in a transaction
+	statedb.SetNonce(nativeTestSender, statedb.GetNonce(nativeTestSender)+1)
+	evm.Call(vm.AccountRef(nativeTestSender), *to, input, 1000000, big.NewInt(3))
+}
+
+// runNativeTest runs the test message with the given tracer and returns its result
+func runNativeTest(t *testing.T, tracer NativeTracer, to *common.Address, input []byte) json.RawMessage {
+	applyNativeTest(tracer, to, input)
+	result, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return nativeTestTime.ReplaceAll(result, []byte(`"time":""`))
+}
+
+func TestNativeTracersMatchJavaScript(t *testing.T) {
+	caller, reverter, faulter, callee := nativeTestCaller, nativeTestReverter, nativeTestFaulter, nativeTestCallee
+	input := hexutil.MustDecode("0x12345678aabb")
+	tests := []struct {
+		name  string
+		to    *common.Address
+		input []byte
+	}{
+		{"nested calls", &caller, input},
+		{"reverted", &reverter, input},
+		{"faulted", &faulter, nil},
+		{"plain transfer", &nativeTestOther, input},
+		{"create", nil, append(hexutil.MustDecode("0x6010600c60003960106000f3"), nativeTestCalleeCode...)},
+		{"storage", &callee, nil},
+	}
+	for _, name := range []string{"callTracer", "prestateTracer", "4byteTracer"} {
+		for _, test := range tests {
+			native, ok, err := NewNative(name, nil)
+			if !ok || err != nil {
+				t.Fatalf("%s: failed to create native tracer: %v", name, err)
+			}
+			have := runNativeTest(t, native, test.to, test.input)
+
+			js, err := New(name)
+			if err != nil {
+				t.Fatalf("%s: failed to create JavaScript tracer: %v", name, err)
+			}
+			applyNativeTest(js, test.to, test.input)
+			want, err := js.GetResult()
+			if err != nil {
+				// The JavaScript prestateTracer fails without any executed opcode
+				if name == "prestateTracer" && test.name == "plain transfer" {
+					continue
+				}
+				t.Fatalf("%s %s: JavaScript tracer failed: %v", name, test.name, err)
+			}
+			want = nativeTestTime.ReplaceAll(want, []byte(`"time":""`))
+			if string(have) != string(want) {
+				t.Errorf("%s %s: result mismatch\nhave %s\nwant %s", name, test.name, have, want)
+			}
+		}
+	}
+}
+
+func TestPrestateTracerDiffMode(t *testing.T) {
+	tracer, _, err := NewNative("prestateTracer", json.RawMessage(`{"diffMode":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := runNativeTest(t, tracer, &nativeTestCaller, nil)
+
+	var result struct {
+		Pre  map[string]map[string]interface{}
+		Post map[string]map[string]interface{}
+	}
+	if err := json.Unmarshal(have, &result); err != nil {
+		t.Fatal(err)
+	}
+	key := func(addr common.Address) string { return hexutil.Encode(addr.Bytes()) }
+
+	// The balance of the other account is only read
+	if _, ok := result.Pre[key(nativeTestOther)]; ok {
+		t.Errorf("unmodified account in pre state: %s", have)
+	}
+	if _, ok := result.Post[key(nativeTestReverter)]; ok {
+		t.Errorf("unmodified account in post state: %s", have)
+	}
+	slot := common.Hash{}.Hex()
+	callee := result.Post[key(nativeTestCallee)]
+	if storage, _ := callee["storage"].(map[string]interface{}); storage[slot] != common.BigToHash(big.NewInt(42)).Hex() {
+		t.Errorf("unexpected callee post state %v", callee)
+	}
+	if _, ok := callee["code"]; ok {
+		t.Errorf("unmodified code in post state %v", callee)
+	}
+	if storage, _ := result.Pre[key(nativeTestCallee)]["storage"].(map[string]interface{}); storage[slot] != slot {
+		t.Errorf("unexpected callee pre state %v", result.Pre[key(nativeTestCallee)])
+	}
+	if sender := result.Post[key(nativeTestSender)]; sender["nonce"] != float64(2) || sender["balance"] != "0xf423d" {
+		t.Errorf("unexpected sender post state %v", sender)
+	}
+}
+
+func TestNativeTracerStop(t *testing.T) {
+	tracer, _, _ := NewNative("callTracer", nil)
+	tracer.Stop(errTestStop)
+	evm, _ := newNativeTestEVM(tracer)
+	evm.Call(vm.AccountRef(nativeTestSender), nativeTestCaller, nil, 1000000, new(big.Int))
+	if _, err := tracer.GetResult(); err != errTestStop {
+		t.Errorf("unexpected error %v", err)
+	}
+}
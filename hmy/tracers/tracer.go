@@ -399,8 +399,7 @@ func New(code string) (*Tracer, error) {
 		return 1
 	})
 	tracer.vm.PushGlobalGoFunction("isPrecompiled", func(ctx *duktape.Context) int {
-		_, ok := vm.PrecompiledContractsVRF[common.BytesToAddress(popSlice(ctx))]
-		ctx.PushBoolean(ok)
+		ctx.PushBoolean(isPrecompiled(common.BytesToAddress(popSlice(ctx))))
 		return 1
 	})
 	tracer.vm.PushGlobalGoFunction("slice", func(ctx *duktape.Context) int {
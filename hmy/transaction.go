@@ -8,6 +8,7 @@ import (
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/types"
+	commonRPC "github.com/harmony-one/harmony/rpc/common"
 )
 
 // SendTx ...
@@ -74,6 +75,22 @@ func (hmy *Harmony) GetTransactionsCount(address, txType string) (uint64, error)
 	return hmy.NodeAPI.GetTransactionsCount(address, txType)
 }
 
+// GetTransactionsHistoryPage returns a page of the transaction hashes of the
+// address in the query, and the continuation token of the next page.
+func (hmy *Harmony) GetTransactionsHistoryPage(query commonRPC.TxHistoryQuery) ([]common.Hash, string, error) {
+	return hmy.NodeAPI.GetTransactionsHistoryPage(query)
+}
+
+// GetTokenTransfers returns the token transfers of address in the page.
+func (hmy *Harmony) GetTokenTransfers(address string, pageIndex, pageSize uint32) ([]commonRPC.TokenTransfer, error) {
+	return hmy.NodeAPI.GetTokenTransfers(address, pageIndex, pageSize)
+}
+
+// GetTokenHolders returns the holders of token in the page.
+func (hmy *Harmony) GetTokenHolders(token string, pageIndex, pageSize uint32) ([]commonRPC.TokenHolder, error) {
+	return hmy.NodeAPI.GetTokenHolders(token, pageIndex, pageSize)
+}
+
 // GetCurrentTransactionErrorSink ..
 func (hmy *Harmony) GetCurrentTransactionErrorSink() types.TransactionErrorReports {
 	return hmy.NodeAPI.ReportPlainErrorSink()
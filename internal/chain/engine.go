@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"math/big"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/harmony-one/harmony/internal/params"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/consensus/misc"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/consensus/reward"
 	"github.com/harmony-one/harmony/consensus/signature"
@@ -39,12 +41,19 @@ const (
 	vrfProof         = 96 // 96 bytes proof (bls sig)
 )
 
+// EPoSStatusHook is notified of the EPoS status changes computed when finalizing
+// the committee selection block of the header. Note that the block is not
+// necessarily committed to the chain.
+type EPoSStatusHook func(header *block.Header, changes []availability.EPoSStatusChange)
+
 type engineImpl struct {
 	beacon engine.ChainReader
 
 	// Caching field
 	epochCtxCache    *lru.Cache // epochCtxKey -> epochCtx
 	verifiedSigCache *lru.Cache // verifiedSigKey -> struct{}{}
+
+	eposStatusHook atomic.Value // EPoSStatusHook
 }
 
 // NewEngine creates Engine with some cache
@@ -67,6 +76,11 @@ func (e *engineImpl) SetBeaconchain(beaconchain engine.ChainReader) {
 	e.beacon = beaconchain
 }
 
+// SetEPoSStatusHook sets the hook notified of the EPoS status changes
+func (e *engineImpl) SetEPoSStatusHook(hook EPoSStatusHook) {
+	e.eposStatusHook.Store(hook)
+}
+
 // VerifyHeader checks whether a header conforms to the consensus rules of the bft engine.
 // Note that each block header contains the bls signature of the parent block
 func (e *engineImpl) VerifyHeader(chain engine.ChainReader, header *block.Header, seal bool) error {
@@ -74,6 +88,9 @@ func (e *engineImpl) VerifyHeader(chain engine.ChainReader, header *block.Header
 	if parentHeader == nil {
 		return engine.ErrUnknownAncestor
 	}
+	if err := misc.VerifyEIP1559Header(chain.Config(), parentHeader, header); err != nil {
+		return err
+	}
 	if seal {
 		if err := e.VerifySeal(chain, header); err != nil {
 			return err
@@ -311,12 +328,20 @@ func (e *engineImpl) Finalize(
 		// ComputeAndMutateEPOSStatus depends on the signing counts that's
 		// consistent with the counts when the new shardState was proposed.
 		// Refer to committee.IsEligibleForEPoSAuction()
+		var changes []availability.EPoSStatusChange
 		for _, addr := range curShardState.StakedValidators().Addrs {
-			if err := availability.ComputeAndMutateEPOSStatus(
+			change, err := availability.ComputeAndMutateEPOSStatus(
 				chain, state, addr,
-			); err != nil {
+			)
+			if err != nil {
 				return nil, nil, err
 			}
+			if change != nil {
+				changes = append(changes, *change)
+			}
+		}
+		if hook, ok := e.eposStatusHook.Load().(EPoSStatusHook); ok && hook != nil && len(changes) != 0 {
+			hook(header, changes)
 		}
 		utils.Logger().Debug().Int64("elapsed time", time.Now().Sub(startTime).Milliseconds()).Msg("ComputeAndMutateEPOSStatus")
 	}
@@ -27,6 +27,9 @@ type HarmonyConfig struct {
 	Revert     *RevertConfig     `toml:",omitempty"`
 	Legacy     *LegacyConfig     `toml:",omitempty"`
 	Prometheus *PrometheusConfig `toml:",omitempty"`
+	Explorer   *ExplorerConfig   `toml:",omitempty"`
+	Freezer    *FreezerConfig    `toml:",omitempty"`
+	TraceIndex *TraceIndexConfig `toml:",omitempty"`
 	DNSSync    DnsSync
 }
 
@@ -62,6 +65,7 @@ type GeneralConfig struct {
 	IsBeaconArchival bool
 	IsOffline        bool
 	DataDir          string
+	DBEngine         string // leveldb or pebble
 }
 
 type ConsensusConfig struct {
@@ -183,10 +187,28 @@ type PrometheusConfig struct {
 	Gateway    string
 }
 
+type ExplorerConfig struct {
+	Backend   string // storage backend of the explorer node: leveldb or sql
+	SQLDriver string // driver of the sql backend: sqlite3 or postgres
+	SQLSource string // data source name of the sql backend
+}
+
+type FreezerConfig struct {
+	Enabled bool   // move the old blocks from the chain db into the freezer
+	Dir     string // directory of the freezers, the data directory by default
+	Depth   int    // number of the recent blocks kept in the chain db
+}
+
+type TraceIndexConfig struct {
+	Enabled bool // store the call traces of the blocks indexed by address, for trace_filter
+	From    int  // number of the first indexed block
+}
+
 type SyncConfig struct {
 	// TODO: Remove this bool after stream sync is fully up.
 	Enabled        bool // enable the stream sync protocol
 	Downloader     bool // start the sync downloader client
+	FastSync       bool // download the state at epoch pivot instead of executing all blocks
 	Concurrency    int  // concurrency used for stream sync protocol
 	MinPeers       int  // minimum streams to start a sync task.
 	InitStreams    int  // minimum streams in bootstrap to start sync loop.
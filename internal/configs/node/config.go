@@ -92,7 +92,8 @@ type ConfigType struct {
 	DNSZone          string
 	isArchival       map[uint32]bool
 	WebHooks         struct {
-		Hooks *webhooks.Hooks
+		Hooks     *webhooks.Hooks
+		Deliverer *webhooks.Deliverer
 	}
 }
 
@@ -64,6 +64,11 @@ var (
 		ReceiptLogEpoch:            big.NewInt(101),
 		SHA3Epoch:                  big.NewInt(725), // Around Mon Oct 11 2021, 19:00 UTC
 		HIP6And8Epoch:              big.NewInt(725), // Around Mon Oct 11 2021, 19:00 UTC
+		EIP2930Epoch:               EpochTBD,
+		EIP1559Epoch:               EpochTBD,
+		BLS12381Epoch:              EpochTBD,
+		StakingPrecompileEpoch:     EpochTBD,
+		ChainInfoPrecompileEpoch:   EpochTBD,
 	}
 
 	// TestnetChainConfig contains the chain parameters to run a node on the harmony test network.
@@ -96,6 +101,11 @@ var (
 		ReceiptLogEpoch:            big.NewInt(0),
 		SHA3Epoch:                  big.NewInt(74570),
 		HIP6And8Epoch:              big.NewInt(74570),
+		EIP2930Epoch:               EpochTBD,
+		EIP1559Epoch:               EpochTBD,
+		BLS12381Epoch:              EpochTBD,
+		StakingPrecompileEpoch:     EpochTBD,
+		ChainInfoPrecompileEpoch:   EpochTBD,
 	}
 
 	// PangaeaChainConfig contains the chain parameters for the Pangaea network.
@@ -129,6 +139,11 @@ var (
 		ReceiptLogEpoch:            big.NewInt(0),
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              big.NewInt(0),
+		EIP2930Epoch:               EpochTBD,
+		EIP1559Epoch:               EpochTBD,
+		BLS12381Epoch:              EpochTBD,
+		StakingPrecompileEpoch:     EpochTBD,
+		ChainInfoPrecompileEpoch:   EpochTBD,
 	}
 
 	// PartnerChainConfig contains the chain parameters for the Partner network.
@@ -162,6 +177,11 @@ var (
 		ReceiptLogEpoch:            big.NewInt(0),
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              big.NewInt(0),
+		EIP2930Epoch:               EpochTBD,
+		EIP1559Epoch:               EpochTBD,
+		BLS12381Epoch:              EpochTBD,
+		StakingPrecompileEpoch:     EpochTBD,
+		ChainInfoPrecompileEpoch:   EpochTBD,
 	}
 
 	// StressnetChainConfig contains the chain parameters for the Stress test network.
@@ -195,6 +215,11 @@ var (
 		ReceiptLogEpoch:            big.NewInt(0),
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              big.NewInt(0),
+		EIP2930Epoch:               EpochTBD,
+		EIP1559Epoch:               EpochTBD,
+		BLS12381Epoch:              EpochTBD,
+		StakingPrecompileEpoch:     EpochTBD,
+		ChainInfoPrecompileEpoch:   EpochTBD,
 	}
 
 	// LocalnetChainConfig contains the chain parameters to run for local development.
@@ -227,6 +252,11 @@ var (
 		ReceiptLogEpoch:            big.NewInt(0),
 		SHA3Epoch:                  big.NewInt(0),
 		HIP6And8Epoch:              EpochTBD, // Never enable it for localnet as localnet has no external validator setup
+		EIP2930Epoch:               big.NewInt(0),
+		EIP1559Epoch:               big.NewInt(0),
+		BLS12381Epoch:              big.NewInt(0),
+		StakingPrecompileEpoch:     big.NewInt(0),
+		ChainInfoPrecompileEpoch:   big.NewInt(0),
 	}
 
 	// AllProtocolChanges ...
@@ -261,6 +291,11 @@ var (
 		big.NewInt(0),                      // ReceiptLogEpoch
 		big.NewInt(0),                      // SHA3Epoch
 		big.NewInt(0),                      // HIP6And8Epoch
+		big.NewInt(0),                      // EIP2930Epoch
+		big.NewInt(0),                      // EIP1559Epoch
+		big.NewInt(0),                      // BLS12381Epoch
+		big.NewInt(0),                      // StakingPrecompileEpoch
+		big.NewInt(0),                      // ChainInfoPrecompileEpoch
 	}
 
 	// TestChainConfig ...
@@ -295,6 +330,11 @@ var (
 		big.NewInt(0),        // ReceiptLogEpoch
 		big.NewInt(0),        // SHA3Epoch
 		big.NewInt(0),        // HIP6And8Epoch
+		big.NewInt(0),        // EIP2930Epoch
+		big.NewInt(0),        // EIP1559Epoch
+		big.NewInt(0),        // BLS12381Epoch
+		big.NewInt(0),        // StakingPrecompileEpoch
+		big.NewInt(0),        // ChainInfoPrecompileEpoch
 	}
 
 	// TestRules ...
@@ -409,6 +449,28 @@ type ChainConfig struct {
 
 	// IsHIP6And8Epoch is the first epoch to support HIP-6 and HIP-8
 	HIP6And8Epoch *big.Int `json:"hip6_8-epoch,omitempty"`
+
+	// EIP2930Epoch is the first epoch to support the typed transactions of EIP-2718,
+	// the access list transactions of EIP-2930 and the gas cost changes of EIP-2929
+	EIP2930Epoch *big.Int `json:"eip2930-epoch,omitempty"`
+
+	// EIP1559Epoch is the first epoch to support the base fee of EIP-1559, which
+	// comes with the v4 block header, the dynamic fee transactions and the BASEFEE
+	// opcode of EIP-3198
+	EIP1559Epoch *big.Int `json:"eip1559-epoch,omitempty"`
+
+	// BLS12381Epoch is the first epoch to support the BLS12-381 curve operation
+	// precompiled contracts of EIP-2537
+	BLS12381Epoch *big.Int `json:"bls12381-epoch,omitempty"`
+
+	// StakingPrecompileEpoch is the first epoch to support the staking precompiled
+	// contract, which lets smart contracts delegate, undelegate and collect rewards
+	StakingPrecompileEpoch *big.Int `json:"staking-precompile-epoch,omitempty"`
+
+	// ChainInfoPrecompileEpoch is the first epoch to support the chain info
+	// precompiled contract, which returns the VRF of the recent blocks, the
+	// epoch and the shard ID to smart contracts
+	ChainInfoPrecompileEpoch *big.Int `json:"chain-info-precompile-epoch,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -562,6 +624,31 @@ func (c *ChainConfig) IsHIP6And8Epoch(epoch *big.Int) bool {
 	return isForked(c.HIP6And8Epoch, epoch)
 }
 
+// IsEIP2930 returns whether epoch is either equal to the EIP-2930 fork epoch or greater.
+func (c *ChainConfig) IsEIP2930(epoch *big.Int) bool {
+	return isForked(c.EIP2930Epoch, epoch)
+}
+
+// IsEIP1559 returns whether epoch is either equal to the EIP-1559 fork epoch or greater.
+func (c *ChainConfig) IsEIP1559(epoch *big.Int) bool {
+	return isForked(c.EIP1559Epoch, epoch)
+}
+
+// IsBLS12381 returns whether epoch is either equal to the BLS12-381 precompiles fork epoch or greater.
+func (c *ChainConfig) IsBLS12381(epoch *big.Int) bool {
+	return isForked(c.BLS12381Epoch, epoch)
+}
+
+// IsStakingPrecompile returns whether epoch is either equal to the staking precompile fork epoch or greater.
+func (c *ChainConfig) IsStakingPrecompile(epoch *big.Int) bool {
+	return isForked(c.StakingPrecompileEpoch, epoch)
+}
+
+// IsChainInfoPrecompile returns whether epoch is either equal to the chain info precompile fork epoch or greater.
+func (c *ChainConfig) IsChainInfoPrecompile(epoch *big.Int) bool {
+	return isForked(c.ChainInfoPrecompileEpoch, epoch)
+}
+
 // UpdateEthChainIDByShard update the ethChainID based on shard ID.
 func UpdateEthChainIDByShard(shardID uint32) {
 	once.Do(func() {
@@ -610,9 +697,9 @@ func isForked(s, epoch *big.Int) bool {
 // Rules is a one time interface meaning that it shouldn't be used in between transition
 // phases.
 type Rules struct {
-	ChainID                                                                         *big.Int
-	EthChainID                                                                      *big.Int
-	IsCrossLink, IsEIP155, IsS3, IsReceiptLog, IsIstanbul, IsVRF, IsPrevVRF, IsSHA3 bool
+	ChainID                                                                                                                                                       *big.Int
+	EthChainID                                                                                                                                                    *big.Int
+	IsCrossLink, IsEIP155, IsS3, IsReceiptLog, IsIstanbul, IsVRF, IsPrevVRF, IsSHA3, IsEIP2930, IsEIP1559, IsBLS12381, IsStakingPrecompile, IsChainInfoPrecompile bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -626,15 +713,20 @@ func (c *ChainConfig) Rules(epoch *big.Int) Rules {
 		ethChainID = new(big.Int)
 	}
 	return Rules{
-		ChainID:      new(big.Int).Set(chainID),
-		EthChainID:   new(big.Int).Set(ethChainID),
-		IsCrossLink:  c.IsCrossLink(epoch),
-		IsEIP155:     c.IsEIP155(epoch),
-		IsS3:         c.IsS3(epoch),
-		IsReceiptLog: c.IsReceiptLog(epoch),
-		IsIstanbul:   c.IsIstanbul(epoch),
-		IsVRF:        c.IsVRF(epoch),
-		IsPrevVRF:    c.IsPrevVRF(epoch),
-		IsSHA3:       c.IsSHA3(epoch),
+		ChainID:               new(big.Int).Set(chainID),
+		EthChainID:            new(big.Int).Set(ethChainID),
+		IsCrossLink:           c.IsCrossLink(epoch),
+		IsEIP155:              c.IsEIP155(epoch),
+		IsS3:                  c.IsS3(epoch),
+		IsReceiptLog:          c.IsReceiptLog(epoch),
+		IsIstanbul:            c.IsIstanbul(epoch),
+		IsVRF:                 c.IsVRF(epoch),
+		IsPrevVRF:             c.IsPrevVRF(epoch),
+		IsSHA3:                c.IsSHA3(epoch),
+		IsEIP2930:             c.IsEIP2930(epoch),
+		IsEIP1559:             c.IsEIP1559(epoch),
+		IsBLS12381:            c.IsBLS12381(epoch),
+		IsStakingPrecompile:   c.IsStakingPrecompile(epoch),
+		IsChainInfoPrecompile: c.IsChainInfoPrecompile(epoch),
 	}
 }
@@ -0,0 +1,81 @@
+package pebble
+
+import (
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// migrateLogInterval is the interval of the progress logs of the migration
+const migrateLogInterval = 8 * time.Second
+
+// MigrateLevelDB copies all the entries of the LevelDB in src into the new pebble
+// database in dst, and returns the number of the entries copied. The LevelDB is
+// not modified.
+func MigrateLevelDB(src, dst string) (uint64, error) {
+	if _, err := os.Stat(dst); err == nil {
+		return 0, errors.Errorf("pebble database %v already exists", dst)
+	}
+	// The leveldb is opened read only, so that it is not modified nor created
+	from, err := leveldb.OpenFile(src, &opt.Options{
+		ErrorIfMissing: true,
+		ReadOnly:       true,
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "open leveldb")
+	}
+	defer from.Close()
+	to, err := New(dst, 512, 64, false)
+	if err != nil {
+		return 0, errors.Wrap(err, "open pebble")
+	}
+	defer to.Close()
+
+	return copyKeyValues(to, from.NewIterator(nil, nil))
+}
+
+// copyKeyValues copies all the entries of the iterator into dst
+func copyKeyValues(dst ethdb.KeyValueStore, it ethdb.Iterator) (uint64, error) {
+	var (
+		batch   = dst.NewBatch()
+		count   uint64
+		size    common.StorageSize
+		lastLog = time.Now()
+	)
+	defer it.Release()
+
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		if err := batch.Put(key, value); err != nil {
+			return count, err
+		}
+		count++
+		size += common.StorageSize(len(key) + len(value))
+
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return count, err
+			}
+			batch.Reset()
+		}
+		if time.Since(lastLog) > migrateLogInterval {
+			utils.Logger().Info().Uint64("count", count).Str("size", size.String()).
+				Str("key", common.Bytes2Hex(key)).Msg("Migrating database")
+			lastLog = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return count, err
+	}
+	if err := batch.Write(); err != nil {
+		return count, err
+	}
+	utils.Logger().Info().Uint64("count", count).Str("size", size.String()).Msg("Migrated database")
+	return count, nil
+}
@@ -0,0 +1,378 @@
+// Package pebble implements the key-value store of the chain database on top of
+// the pebble database.
+package pebble
+
+import (
+	"bytes"
+	"sync"
+
+	cerrors "github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minCache is the minimum amount of memory in megabytes to allocate to pebble
+	// read and write caching, split half and half.
+	minCache = 16
+
+	// minHandles is the minimum number of files handles to allocate to the open
+	// database files.
+	minHandles = 16
+
+	// memTableLimit is the number of the memory tables before the writes are stopped
+	memTableLimit = 2
+
+	// maxMemTableSize is the maximum size of a memory table, which must be smaller
+	// than 4GB in pebble.
+	maxMemTableSize = 4<<30 - 1
+)
+
+// ErrNotFound is returned by Get if the key is not found
+var ErrNotFound = pebble.ErrNotFound
+
+var errClosed = errors.New("pebble: closed")
+
+// IsStorageError returns whether the error is a failure of the pebble storage,
+// which is either the database being closed or its data being corrupted. The
+// corruption errors of pebble are only recognized with the markers of cockroachdb
+// errors.
+func IsStorageError(err error) bool {
+	return errors.Is(err, errClosed) ||
+		cerrors.Is(err, pebble.ErrClosed) || cerrors.Is(err, pebble.ErrCorruption)
+}
+
+// Database is a persistent key-value store backed by pebble, which implements
+// ethdb.KeyValueStore.
+type Database struct {
+	file string
+	db   *pebble.DB
+
+	closeLock sync.RWMutex
+	closed    bool
+}
+
+// New returns the pebble database in file, creating it if not exist. The cache
+// is in megabytes, half of which is for the memory tables.
+func New(file string, cache int, handles int, readonly bool) (*Database, error) {
+	if cache < minCache {
+		cache = minCache
+	}
+	if handles < minHandles {
+		handles = minHandles
+	}
+	memTableSize := cache * 1024 * 1024 / 2 / memTableLimit
+	if memTableSize > maxMemTableSize {
+		memTableSize = maxMemTableSize
+	}
+	utils.Logger().Info().Str("database", file).Int("cache", cache).Int("handles", handles).
+		Msg("Allocated cache and file handles")
+
+	opt := &pebble.Options{
+		// Pebble has a single combined cache area and the write buffers are taken
+		// from this too.
+		Cache:                       pebble.NewCache(int64(cache * 1024 * 1024)),
+		MaxOpenFiles:                handles,
+		MemTableSize:                memTableSize,
+		MemTableStopWritesThreshold: memTableLimit,
+		ReadOnly:                    readonly,
+	}
+	// The target file size doubles on each level
+	for i, size := 0, int64(2*1024*1024); i < 7; i, size = i+1, size*2 {
+		opt.Levels = append(opt.Levels, pebble.LevelOptions{
+			TargetFileSize: size,
+			FilterPolicy:   bloom.FilterPolicy(10),
+		})
+	}
+	db, err := pebble.Open(file, opt)
+	if err != nil {
+		return nil, err
+	}
+	return &Database{file: file, db: db}, nil
+}
+
+// Close closes the database. All the operations afterwards return error.
+func (d *Database) Close() error {
+	d.closeLock.Lock()
+	defer d.closeLock.Unlock()
+
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
+
+// Has retrieves if a key is present in the key-value store.
+func (d *Database) Has(key []byte) (bool, error) {
+	d.closeLock.RLock()
+	defer d.closeLock.RUnlock()
+	if d.closed {
+		return false, errClosed
+	}
+	_, closer, err := d.db.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	closer.Close()
+	return true, nil
+}
+
+// Get retrieves the given key if it's present in the key-value store.
+func (d *Database) Get(key []byte) ([]byte, error) {
+	d.closeLock.RLock()
+	defer d.closeLock.RUnlock()
+	if d.closed {
+		return nil, errClosed
+	}
+	dat, closer, err := d.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	// The value is only valid until the closer is closed
+	ret := make([]byte, len(dat))
+	copy(ret, dat)
+	closer.Close()
+	return ret, nil
+}
+
+// Put inserts the given value into the key-value store.
+func (d *Database) Put(key []byte, value []byte) error {
+	d.closeLock.RLock()
+	defer d.closeLock.RUnlock()
+	if d.closed {
+		return errClosed
+	}
+	return d.db.Set(key, value, pebble.NoSync)
+}
+
+// Delete removes the key from the key-value store.
+func (d *Database) Delete(key []byte) error {
+	d.closeLock.RLock()
+	defer d.closeLock.RUnlock()
+	if d.closed {
+		return errClosed
+	}
+	return d.db.Delete(key, pebble.NoSync)
+}
+
+// NewBatch creates a write-only key-value store that buffers changes to its host
+// database until a final write is called.
+func (d *Database) NewBatch() ethdb.Batch {
+	return &batch{
+		b:  d.db.NewBatch(),
+		db: d,
+	}
+}
+
+// Stat returns the metrics of the database. The property is ignored.
+func (d *Database) Stat(property string) (string, error) {
+	return d.db.Metrics().String(), nil
+}
+
+// Compact flattens the underlying data store for the given key range. A nil start
+// is treated as a key before all keys in the data store, and a nil limit is
+// treated as a key after all keys in the data store.
+func (d *Database) Compact(start []byte, limit []byte) error {
+	// There is no special flag to represent the end of key range in pebble, so a
+	// large key is used. The trie node keys might start with a number of 0xff,
+	// so the 32 bytes of 0xff is larger than any key but a hash collision.
+	if limit == nil {
+		limit = bytes.Repeat([]byte{0xff}, 32)
+	}
+	return d.db.Compact(start, limit, true)
+}
+
+// NewIterator creates a binary-alphabetical iterator over the entire keyspace
+// contained within the key-value database.
+func (d *Database) NewIterator() ethdb.Iterator {
+	return d.newIterator(nil, nil, false)
+}
+
+// NewIteratorWithStart creates a binary-alphabetical iterator over a subset of
+// database content starting at a particular initial key (or after, if it does
+// not exist).
+func (d *Database) NewIteratorWithStart(start []byte) ethdb.Iterator {
+	return d.newIterator(start, nil, false)
+}
+
+// NewIteratorWithPrefix creates a binary-alphabetical iterator over a subset
+// of database content with a particular key prefix.
+func (d *Database) NewIteratorWithPrefix(prefix []byte) ethdb.Iterator {
+	return d.newIterator(prefix, UpperBound(prefix), false)
+}
+
+// NewRangeIterator creates an iterator over the keys in [start, limit) in the
+// ascending order, or in the descending order if reverse. Nil limit means no
+// upper bound.
+func (d *Database) NewRangeIterator(start, limit []byte, reverse bool) ethdb.Iterator {
+	return d.newIterator(start, limit, reverse)
+}
+
+func (d *Database) newIterator(lower, upper []byte, reverse bool) ethdb.Iterator {
+	iter := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	if reverse {
+		iter.Last()
+	} else {
+		iter.First()
+	}
+	return &iterator{iter: iter, moved: true, reverse: reverse}
+}
+
+// UpperBound returns the upper bound of the keys with prefix, which is the
+// smallest key larger than all of them, or nil if there is no such key.
+func UpperBound(prefix []byte) []byte {
+	var limit []byte
+	for i := len(prefix) - 1; i >= 0; i-- {
+		c := prefix[i]
+		if c == 0xff {
+			continue
+		}
+		limit = make([]byte, i+1)
+		copy(limit, prefix)
+		limit[i] = c + 1
+		break
+	}
+	return limit
+}
+
+// batch is a write-only batch that commits changes to its host database when
+// Write is called. A batch cannot be used concurrently.
+type batch struct {
+	b    *pebble.Batch
+	db   *Database
+	size int
+
+	// writes are kept to replay the batch
+	writes []keyvalue
+}
+
+type keyvalue struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *batch) Put(key, value []byte) error {
+	if err := b.b.Set(key, value, nil); err != nil {
+		return err
+	}
+	b.writes = append(b.writes, keyvalue{copyBytes(key), copyBytes(value), false})
+	b.size += len(value)
+	return nil
+}
+
+// Delete inserts the key removal into the batch for later committing.
+func (b *batch) Delete(key []byte) error {
+	if err := b.b.Delete(key, nil); err != nil {
+		return err
+	}
+	b.writes = append(b.writes, keyvalue{copyBytes(key), nil, true})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes any accumulated data to disk.
+func (b *batch) Write() error {
+	b.db.closeLock.RLock()
+	defer b.db.closeLock.RUnlock()
+	if b.db.closed {
+		return errClosed
+	}
+	return b.b.Commit(pebble.NoSync)
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	b.b.Reset()
+	b.writes = b.writes[:0]
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w ethdb.KeyValueWriter) error {
+	for _, kv := range b.writes {
+		if kv.delete {
+			if err := w.Delete(kv.key); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := w.Put(kv.key, kv.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyBytes returns a copy of b, since the caller might reuse the slice
+func copyBytes(b []byte) []byte {
+	return append([]byte{}, b...)
+}
+
+// iterator wraps the pebble iterator as ethdb.Iterator
+type iterator struct {
+	iter     *pebble.Iterator
+	moved    bool // whether the iterator is already moved to the first entry
+	reverse  bool
+	released bool
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted.
+func (it *iterator) Next() bool {
+	if it.released {
+		return false
+	}
+	if it.moved {
+		it.moved = false
+		return it.iter.Valid()
+	}
+	if it.reverse {
+		return it.iter.Prev()
+	}
+	return it.iter.Next()
+}
+
+// Error returns any accumulated error.
+func (it *iterator) Error() error {
+	return it.iter.Error()
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (it *iterator) Key() []byte {
+	if it.released || !it.iter.Valid() {
+		return nil
+	}
+	return it.iter.Key()
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (it *iterator) Value() []byte {
+	if it.released || !it.iter.Valid() {
+		return nil
+	}
+	return it.iter.Value()
+}
+
+// Release releases associated resources. Release can be called multiple times.
+func (it *iterator) Release() {
+	if !it.released {
+		it.iter.Close()
+		it.released = true
+	}
+}
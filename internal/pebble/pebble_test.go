@@ -0,0 +1,113 @@
+package pebble
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/dbtest"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+)
+
+func TestPebbleDB(t *testing.T) {
+	dbtest.TestDatabaseSuite(t, func() ethdb.KeyValueStore {
+		db, err := New(t.TempDir(), 0, 0, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return db
+	})
+}
+
+func TestUpperBound(t *testing.T) {
+	tests := []struct {
+		prefix, limit []byte
+	}{
+		{nil, nil},
+		{[]byte{0x01}, []byte{0x02}},
+		{[]byte{0x01, 0xff}, []byte{0x02}},
+		{[]byte{0xff, 0xff}, nil},
+	}
+	for i, test := range tests {
+		if limit := UpperBound(test.prefix); !bytes.Equal(limit, test.limit) {
+			t.Errorf("Test %v: unexpected upper bound %x / %x", i, limit, test.limit)
+		}
+	}
+}
+
+func TestMigrateLevelDB(t *testing.T) {
+	var (
+		dir = t.TempDir()
+		src = filepath.Join(dir, "leveldb")
+		dst = filepath.Join(dir, "pebble")
+	)
+	ldb, err := leveldb.New(src, 16, 16, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if err := ldb.Put([]byte(fmt.Sprintf("key%04d", i)), []byte(fmt.Sprint(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ldb.Close()
+
+	if count, err := MigrateLevelDB(src, dst); err != nil || count != n {
+		t.Fatalf("unexpected migration result %v, %v", count, err)
+	}
+	if _, err := MigrateLevelDB(src, dst); err == nil {
+		t.Error("expect error for existing pebble database")
+	}
+	if _, err := MigrateLevelDB(filepath.Join(dir, "missing"), dst+"2"); err == nil {
+		t.Error("expect error for missing leveldb")
+	}
+
+	db, err := New(dst, 0, 0, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for i := 0; i < n; i++ {
+		val, err := db.Get([]byte(fmt.Sprintf("key%04d", i)))
+		if err != nil || string(val) != fmt.Sprint(i) {
+			t.Errorf("key %v: unexpected value %s, %v", i, val, err)
+		}
+	}
+}
+
+func TestRangeIterator(t *testing.T) {
+	db, err := New(t.TempDir(), 0, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, k := range []string{"a", "b", "c", "d"} {
+		if err := db.Put([]byte(k), []byte(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		start, limit []byte
+		reverse      bool
+		exp          string
+	}{
+		{[]byte("b"), []byte("d"), false, "bc"},
+		{[]byte("b"), []byte("d"), true, "cb"},
+		{[]byte("b"), nil, true, "dcb"},
+		{nil, []byte("b"), false, "a"},
+	}
+	for i, test := range tests {
+		var got string
+		it := db.NewRangeIterator(test.start, test.limit, test.reverse)
+		for it.Next() {
+			got += string(it.Key())
+		}
+		it.Release()
+		if got != test.exp {
+			t.Errorf("Test %v: unexpected keys %v / %v", i, got, test.exp)
+		}
+	}
+}
@@ -4,9 +4,17 @@ import (
 	"fmt"
 	"path"
 
-	"github.com/ethereum/go-ethereum/core/rawdb"
-
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/pebble"
+	"github.com/pkg/errors"
+)
+
+// The database engines of the chain databases on disk
+const (
+	LevelDBEngine = "leveldb"
+	PebbleEngine  = "pebble"
 )
 
 // DBFactory is a blockchain database factory.
@@ -16,15 +24,83 @@ type DBFactory interface {
 	NewChainDB(shardID uint32) (ethdb.Database, error)
 }
 
+// DiskDBFactory is a factory of the blockchain databases on disk.
+type DiskDBFactory interface {
+	DBFactory
+
+	// ChainDBDir returns the directory of the database for the blockchain for
+	// given shard.
+	ChainDBDir(shardID uint32) string
+
+	// FreezerDBDir returns the directory of the freezer for the blockchain for
+	// given shard.
+	FreezerDBDir(shardID uint32) string
+}
+
+// NewDiskDBFactory returns the factory of the databases of the given engine, in
+// the directories of dirs.
+func NewDiskDBFactory(engine string, dirs LDBFactory) (DiskDBFactory, error) {
+	switch engine {
+	case LevelDBEngine, "":
+		return &dirs, nil
+	case PebbleEngine:
+		return &PebbleDBFactory{LDBFactory: dirs}, nil
+	default:
+		return nil, errors.Errorf("unknown database engine %v", engine)
+	}
+}
+
 // LDBFactory is a LDB-backed blockchain database factory.
 type LDBFactory struct {
 	RootDir string // directory in which to put shard databases in.
+
+	// FreezerDir is the directory in which to put shard freezers in. The blocks
+	// older than FreezerDepth are moved from the LDB into the freezer if set.
+	FreezerDir   string
+	FreezerDepth uint64
 }
 
 // NewChainDB returns a new LDB for the blockchain for given shard.
 func (f *LDBFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
-	dir := path.Join(f.RootDir, fmt.Sprintf("harmony_db_%d", shardID))
-	return rawdb.NewLevelDBDatabase(dir, 128, 64, "")
+	if f.FreezerDir != "" {
+		return rawdb.NewLevelDBDatabaseWithFreezer(
+			f.ChainDBDir(shardID), 128, 64, f.FreezerDBDir(shardID), f.FreezerDepth,
+		)
+	}
+	return ethRawDB.NewLevelDBDatabase(f.ChainDBDir(shardID), 128, 64, "")
+}
+
+// ChainDBDir returns the directory of the LDB for the blockchain for given shard.
+func (f *LDBFactory) ChainDBDir(shardID uint32) string {
+	return path.Join(f.RootDir, fmt.Sprintf("harmony_db_%d", shardID))
+}
+
+// FreezerDBDir returns the directory of the freezer for the blockchain for given shard.
+func (f *LDBFactory) FreezerDBDir(shardID uint32) string {
+	return path.Join(f.FreezerDir, fmt.Sprintf("harmony_ancient_%d", shardID))
+}
+
+// PebbleDBFactory is a pebble-backed blockchain database factory. The databases
+// are in the same directories as the LDBs.
+type PebbleDBFactory struct {
+	LDBFactory
+}
+
+// NewChainDB returns a new pebble database for the blockchain for given shard.
+func (f *PebbleDBFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
+	kvdb, err := pebble.New(f.ChainDBDir(shardID), 128, 64, false)
+	if err != nil {
+		return nil, err
+	}
+	if f.FreezerDir == "" {
+		return ethRawDB.NewDatabase(kvdb), nil
+	}
+	db, err := rawdb.NewDatabaseWithFreezer(kvdb, f.FreezerDBDir(shardID), f.FreezerDepth)
+	if err != nil {
+		kvdb.Close()
+		return nil, err
+	}
+	return db, nil
 }
 
 // MemDBFactory is a memory-backed blockchain database factory.
@@ -32,5 +108,5 @@ type MemDBFactory struct{}
 
 // NewChainDB returns a new memDB for the blockchain for given shard.
 func (f *MemDBFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
-	return rawdb.NewMemoryDatabase(), nil
+	return ethRawDB.NewMemoryDatabase(), nil
 }
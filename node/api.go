@@ -67,6 +67,13 @@ func (node *Node) ReportPlainErrorSink() types.TransactionErrorReports {
 func (node *Node) StartRPC() error {
 	harmony := hmy.New(node, node.TxPool, node.CxPool, node.Consensus.ShardID)
 
+	if cfg := node.HarmonyConfig; cfg != nil && cfg.TraceIndex != nil && cfg.TraceIndex.Enabled {
+		harmony.TraceIndexer = hmy.NewTraceIndexer(harmony, uint64(cfg.TraceIndex.From))
+		if err := harmony.TraceIndexer.Start(); err != nil {
+			return err
+		}
+	}
+
 	// Gather all the possible APIs to surface
 	apis := node.APIs(harmony)
 
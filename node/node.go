@@ -131,6 +131,8 @@ type Node struct {
 
 	Metrics metrics.Registry
 
+	webHookMonitor *webHookMonitor
+
 	// context control for pub-sub handling
 	psCtx    context.Context
 	psCancel func()
@@ -1044,12 +1046,7 @@ func New(
 				) {
 					return
 				}
-				if hooks := node.NodeConfig.WebHooks.Hooks; hooks != nil {
-					if s := hooks.Slashing; s != nil {
-						url := s.OnNoticeDoubleSign
-						go func() { webhooks.DoPost(url, &doubleSign) }()
-					}
-				}
+				node.postWebHook(webhooks.EventNoticeDoubleSign, &doubleSign)
 				if !node.IsRunningBeaconChain() {
 					go node.BroadcastSlash(&doubleSign)
 				} else {
@@ -1226,9 +1223,19 @@ func (node *Node) ShutDown() {
 		utils.Logger().Error().Err(err).Msg("failed to stop p2p host")
 	}
 
+	utils.Logger().Info().Msg("stopping webhook monitor")
+	node.StopWebHookMonitor()
+
 	node.Blockchain().Stop()
 	node.Beaconchain().Stop()
 
+	if d := node.NodeConfig.WebHooks.Deliverer; d != nil {
+		utils.Logger().Info().Msg("stopping webhook deliverer")
+		if err := d.Close(); err != nil {
+			utils.Logger().Error().Err(err).Msg("failed to stop webhook deliverer")
+		}
+	}
+
 	const msg = "Successfully shut down!\n"
 	utils.Logger().Print(msg)
 	fmt.Print(msg)
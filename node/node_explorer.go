@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 	msg_pb "github.com/harmony-one/harmony/api/proto/message"
 	"github.com/harmony-one/harmony/api/service"
@@ -12,7 +13,9 @@ import (
 	"github.com/harmony-one/harmony/consensus"
 	"github.com/harmony-one/harmony/consensus/signature"
 	"github.com/harmony-one/harmony/core/types"
+	common2 "github.com/harmony-one/harmony/internal/common"
 	"github.com/harmony-one/harmony/internal/utils"
+	commonRPC "github.com/harmony-one/harmony/rpc/common"
 	"github.com/pkg/errors"
 )
 
@@ -245,6 +248,78 @@ func (node *Node) GetStakingTransactionsCount(address, txType string) (uint64, e
 	return count, nil
 }
 
+// GetTransactionsHistoryPage returns a page of the transaction hashes of the
+// address in the query, and the continuation token of the next page.
+func (node *Node) GetTransactionsHistoryPage(query commonRPC.TxHistoryQuery) ([]common.Hash, string, error) {
+	exp, err := node.getExplorerService()
+	if err != nil {
+		return nil, "", err
+	}
+	return exp.GetTxHistory(explorer.TxHistoryQuery{
+		Address:   query.Address,
+		Staking:   query.Staking,
+		Direction: query.Direction,
+		FromBlock: query.FromBlock,
+		ToBlock:   query.ToBlock,
+		Desc:      query.Desc,
+		PageSize:  int(query.PageSize),
+		Offset:    int(query.Offset),
+		Cursor:    query.Cursor,
+	})
+}
+
+// GetTokenTransfers returns the token transfers from or to the address in the page.
+func (node *Node) GetTokenTransfers(address string, pageIndex, pageSize uint32) ([]commonRPC.TokenTransfer, error) {
+	exp, err := node.getExplorerService()
+	if err != nil {
+		return nil, err
+	}
+	tts, err := exp.GetTokenTransfersByAccount(address, int(pageIndex), int(pageSize))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]commonRPC.TokenTransfer, 0, len(tts))
+	for _, tt := range tts {
+		token, _ := common2.AddressToBech32(tt.Token)
+		from, _ := common2.AddressToBech32(tt.From)
+		to, _ := common2.AddressToBech32(tt.To)
+		res = append(res, commonRPC.TokenTransfer{
+			Token:       token,
+			Standard:    tt.Standard.String(),
+			From:        from,
+			To:          to,
+			TokenID:     (*hexutil.Big)(tt.TokenID),
+			Value:       (*hexutil.Big)(tt.Value),
+			TxHash:      tt.TxHash,
+			BlockNumber: tt.BlockNumber,
+			LogIndex:    tt.LogIndex,
+			BatchIndex:  tt.BatchIndex,
+		})
+	}
+	return res, nil
+}
+
+// GetTokenHolders returns the holders of the token in the page.
+func (node *Node) GetTokenHolders(token string, pageIndex, pageSize uint32) ([]commonRPC.TokenHolder, error) {
+	exp, err := node.getExplorerService()
+	if err != nil {
+		return nil, err
+	}
+	ths, err := exp.GetTokenHolders(token, int(pageIndex), int(pageSize))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]commonRPC.TokenHolder, 0, len(ths))
+	for _, th := range ths {
+		addr, _ := common2.AddressToBech32(th.Address)
+		res = append(res, commonRPC.TokenHolder{
+			Address: addr,
+			Balance: (*hexutil.Big)(th.Balance),
+		})
+	}
+	return res, nil
+}
+
 func (node *Node) getExplorerService() (*explorer.Service, error) {
 	rawService := node.serviceManager.GetService(service.SupportExplorer)
 	if rawService == nil {
@@ -302,17 +302,10 @@ func (node *Node) VerifyNewBlock(newBlock *types.Block) error {
 	}
 
 	if err := node.Blockchain().ValidateNewBlock(newBlock); err != nil {
-		if hooks := node.NodeConfig.WebHooks.Hooks; hooks != nil {
-			if p := hooks.ProtocolIssues; p != nil {
-				url := p.OnCannotCommit
-				go func() {
-					webhooks.DoPost(url, map[string]interface{}{
-						"bad-header": newBlock.Header(),
-						"reason":     err.Error(),
-					})
-				}()
-			}
-		}
+		node.postWebHook(webhooks.EventCannotCommitBlock, map[string]interface{}{
+			"bad-header": newBlock.Header(),
+			"reason":     err.Error(),
+		})
 		utils.Logger().Error().
 			Str("blockHash", newBlock.Hash().Hex()).
 			Int("numTx", len(newBlock.Transactions())).
@@ -413,10 +406,7 @@ func (node *Node) PostConsensusProcessing(newBlock *types.Block) error {
 				computed.BlocksLeftInEpoch = lastBlockOfEpoch - node.Beaconchain().CurrentBlock().Header().Number().Uint64()
 
 				if err != nil && computed.IsBelowThreshold {
-					url := h.Availability.OnDroppedBelowThreshold
-					go func() {
-						webhooks.DoPost(url, computed)
-					}()
+					node.postWebHook(webhooks.EventDroppedBelowThreshold, computed)
 				}
 			}
 		}
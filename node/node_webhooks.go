@@ -0,0 +1,392 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/consensus"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+	internal_common "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/multibls"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking/availability"
+	"github.com/harmony-one/harmony/staking/effective"
+	"github.com/harmony-one/harmony/webhooks"
+)
+
+const (
+	webHookSyncCheckInterval = 30 * time.Second
+	webHookChainEventBuffer  = 16
+)
+
+// postWebHook persists the record of the event in the webhook outbox to be
+// delivered asynchronously.
+func (node *Node) postWebHook(event string, record interface{}) {
+	d := node.NodeConfig.WebHooks.Deliverer
+	if d == nil {
+		return
+	}
+	if err := d.Post(event, record); err != nil {
+		utils.Logger().Error().Err(err).Str("event", event).Msg("failed to post webhook")
+	}
+}
+
+// isWebHookConfigured returns whether the webhook of the event is configured
+func (node *Node) isWebHookConfigured(event string) bool {
+	if node.NodeConfig.WebHooks.Deliverer == nil {
+		return false
+	}
+	return node.NodeConfig.WebHooks.Hooks.IsConfigured(event)
+}
+
+// webHookMonitor is the state of the goroutines watching for the webhook events
+type webHookMonitor struct {
+	stopC chan struct{}
+
+	// EPoS status changes of the beacon blocks which are finalized but not yet
+	// known to be committed, by block number
+	eposChanges map[uint64][]availability.EPoSStatusChange
+	eposLock    sync.Mutex
+}
+
+// StartWebHookMonitor starts watching the chain, the committee, the validator
+// status and the sync status to post the webhook events. Nothing is done if the
+// webhooks are not configured.
+func (node *Node) StartWebHookMonitor() {
+	if node.NodeConfig.WebHooks.Deliverer == nil {
+		return
+	}
+	node.webHookMonitor = &webHookMonitor{
+		stopC:       make(chan struct{}),
+		eposChanges: make(map[uint64][]availability.EPoSStatusChange),
+	}
+	if node.isWebHookConfigured(webhooks.EventEPoSStatusChanged) {
+		if e, ok := node.Beaconchain().Engine().(eposStatusHookSetter); ok {
+			e.SetEPoSStatusHook(node.onEPoSStatusChange)
+		}
+	}
+	if node.isWebHookConfigured(webhooks.EventNewEpoch) ||
+		node.isWebHookConfigured(webhooks.EventCommitteeElection) ||
+		node.isWebHookConfigured(webhooks.EventEPoSStatusChanged) {
+		go node.watchChainForWebHooks()
+	}
+	if node.isWebHookConfigured(webhooks.EventOutOfSync) {
+		go node.watchSyncStatusForWebHooks()
+	}
+}
+
+// StopWebHookMonitor stops the goroutines started by StartWebHookMonitor
+func (node *Node) StopWebHookMonitor() {
+	if node.webHookMonitor == nil {
+		return
+	}
+	if e, ok := node.Beaconchain().Engine().(eposStatusHookSetter); ok {
+		e.SetEPoSStatusHook(nil)
+	}
+	close(node.webHookMonitor.stopC)
+	node.webHookMonitor = nil
+}
+
+// eposStatusHookSetter is the engine which computes the EPoS status changes
+type eposStatusHookSetter interface {
+	SetEPoSStatusHook(hook chain.EPoSStatusHook)
+}
+
+// PostLeaderViewChange is called by consensus when view change starts while one
+// of the keys of the node is the leader.
+func (node *Node) PostLeaderViewChange(vc consensus.LeaderViewChange) {
+	if !node.isWebHookConfigured(webhooks.EventLeaderViewChange) {
+		return
+	}
+	node.postWebHook(webhooks.EventLeaderViewChange,
+		leaderViewChangePayload(node.NodeConfig.ShardID, vc))
+}
+
+// leaderViewChangePayload returns the payload of the view change of the shard,
+// where the unknown leader keys are left empty.
+func leaderViewChangePayload(shardID uint32, vc consensus.LeaderViewChange) webhooks.LeaderViewChangePayload {
+	payload := webhooks.LeaderViewChangePayload{
+		ShardID:     shardID,
+		BlockNumber: vc.BlockNum,
+		ViewID:      vc.ViewID,
+		NextViewID:  vc.NextViewID,
+	}
+	if vc.LeaderPubKey != nil {
+		payload.LeaderPubKey = vc.LeaderPubKey.Bytes.Hex()
+	}
+	if vc.NextLeaderKey != nil {
+		payload.NextLeaderPubKey = vc.NextLeaderKey.Bytes.Hex()
+	}
+	return payload
+}
+
+func (node *Node) watchChainForWebHooks() {
+	var (
+		stopC       = node.webHookMonitor.stopC
+		headC       = make(chan core.ChainHeadEvent, webHookChainEventBuffer)
+		headSub     = node.Blockchain().SubscribeChainHeadEvent(headC)
+		beaconHeadC chan core.ChainHeadEvent
+		beaconErrC  <-chan error
+		lastEpoch   = node.Blockchain().CurrentHeader().Epoch().Uint64()
+	)
+	defer headSub.Unsubscribe()
+
+	if !node.IsRunningBeaconChain() {
+		beaconHeadC = make(chan core.ChainHeadEvent, webHookChainEventBuffer)
+		beaconSub := node.Beaconchain().SubscribeChainHeadEvent(beaconHeadC)
+		defer beaconSub.Unsubscribe()
+		beaconErrC = beaconSub.Err()
+	}
+
+	for {
+		select {
+		case ev := <-headC:
+			if ev.Block == nil {
+				continue
+			}
+			if epoch := ev.Block.Epoch().Uint64(); epoch > lastEpoch {
+				lastEpoch = epoch
+				node.postNewEpochWebHook(ev.Block)
+			}
+			if len(ev.Block.Header().ShardState()) != 0 {
+				node.postCommitteeElectionWebHook(ev.Block)
+			}
+			if node.IsRunningBeaconChain() {
+				node.postEPoSStatusWebHooks(ev.Block)
+			}
+
+		case ev := <-beaconHeadC:
+			if ev.Block != nil {
+				node.postEPoSStatusWebHooks(ev.Block)
+			}
+
+		case <-headSub.Err():
+			return
+
+		case <-beaconErrC:
+			return
+
+		case <-stopC:
+			return
+		}
+	}
+}
+
+func (node *Node) postNewEpochWebHook(b *types.Block) {
+	if !node.isWebHookConfigured(webhooks.EventNewEpoch) {
+		return
+	}
+	node.postWebHook(webhooks.EventNewEpoch, webhooks.NewEpochPayload{
+		ShardID:     b.ShardID(),
+		Epoch:       b.Epoch().Uint64(),
+		BlockNumber: b.NumberU64(),
+		BlockHash:   b.Hash().Hex(),
+	})
+}
+
+// postCommitteeElectionWebHook posts whether the keys of the node are elected in the
+// shard state of the next epoch carried by the last block of epoch.
+func (node *Node) postCommitteeElectionWebHook(b *types.Block) {
+	if !node.isWebHookConfigured(webhooks.EventCommitteeElection) || node.Consensus == nil {
+		return
+	}
+	state, err := shard.DecodeWrapper(b.Header().ShardState())
+	if err != nil {
+		utils.Logger().Error().Err(err).Uint64("bn", b.NumberU64()).
+			Msg("[WebHooks] failed to decode shard state")
+		return
+	}
+	node.postWebHook(webhooks.EventCommitteeElection,
+		committeeElectionPayload(b, state, node.Consensus.GetPublicKeys()))
+}
+
+// committeeElectionPayload returns whether the keys are elected in the shard state
+// of the next epoch carried by the block.
+func committeeElectionPayload(
+	b *types.Block, state *shard.State, keys multibls.PublicKeys,
+) webhooks.CommitteeElectionPayload {
+	payload := webhooks.CommitteeElectionPayload{
+		BlockNumber: b.NumberU64(),
+		Elected:     []webhooks.ElectedSlot{},
+		NotElected:  []string{},
+	}
+	if state.Epoch != nil {
+		payload.Epoch = state.Epoch.Uint64()
+	} else {
+		payload.Epoch = b.Epoch().Uint64() + 1
+	}
+	for _, key := range keys {
+		elected := false
+		for _, committee := range state.Shards {
+			for _, slot := range committee.Slots {
+				if slot.BLSPublicKey != key.Bytes {
+					continue
+				}
+				addr, _ := internal_common.AddressToBech32(slot.EcdsaAddress)
+				payload.Elected = append(payload.Elected, webhooks.ElectedSlot{
+					BLSPublicKey:     key.Bytes.Hex(),
+					ShardID:          committee.ShardID,
+					ValidatorAddress: addr,
+				})
+				elected = true
+				break
+			}
+			if elected {
+				break
+			}
+		}
+		if !elected {
+			payload.NotElected = append(payload.NotElected, key.Bytes.Hex())
+		}
+	}
+	return payload
+}
+
+// onEPoSStatusChange is the hook of the engine, which is called when the EPoS
+// status changes are computed while finalizing a committee selection block of the
+// beacon chain. The changes are kept until the block is committed.
+func (node *Node) onEPoSStatusChange(header *block.Header, changes []availability.EPoSStatusChange) {
+	m := node.webHookMonitor
+	if m == nil || header.ShardID() != shard.BeaconChainShardID {
+		return
+	}
+	m.eposLock.Lock()
+	defer m.eposLock.Unlock()
+
+	m.eposChanges[header.Number().Uint64()] = changes
+}
+
+// postEPoSStatusWebHooks posts the EPoS status changes of the validators of the
+// node computed for the beacon blocks up to the given committed head. The changes
+// are checked against the committed state, since the finalized block might not be
+// the one committed.
+func (node *Node) postEPoSStatusWebHooks(head *types.Block) {
+	m := node.webHookMonitor
+	if m == nil || !node.isWebHookConfigured(webhooks.EventEPoSStatusChanged) {
+		return
+	}
+	for bn, changes := range m.takeEPoSChanges(head.NumberU64()) {
+		b := node.Beaconchain().GetBlockByNumber(bn)
+		if b == nil {
+			continue
+		}
+		committed := func(addr common.Address) (effective.Eligibility, error) {
+			wrapper, err := node.Beaconchain().ReadValidatorInformationAtRoot(addr, b.Root())
+			if err != nil {
+				return 0, err
+			}
+			return wrapper.Status, nil
+		}
+		for _, change := range filterEPoSChanges(changes, node.GetAddresses(b.Epoch()), committed) {
+			bech32, _ := internal_common.AddressToBech32(change.Address)
+			node.postWebHook(webhooks.EventEPoSStatusChanged, webhooks.EPoSStatusChangedPayload{
+				ValidatorAddress: bech32,
+				Epoch:            b.Epoch().Uint64(),
+				BlockNumber:      bn,
+				OldStatus:        change.OldStatus.String(),
+				NewStatus:        change.NewStatus.String(),
+			})
+		}
+	}
+}
+
+// takeEPoSChanges removes and returns the EPoS status changes of the blocks
+// up to the committed head, by block number.
+func (m *webHookMonitor) takeEPoSChanges(head uint64) map[uint64][]availability.EPoSStatusChange {
+	m.eposLock.Lock()
+	defer m.eposLock.Unlock()
+
+	taken := make(map[uint64][]availability.EPoSStatusChange)
+	for bn, changes := range m.eposChanges {
+		if bn <= head {
+			taken[bn] = changes
+			delete(m.eposChanges, bn)
+		}
+	}
+	return taken
+}
+
+// filterEPoSChanges returns the EPoS status changes of the validators of the node
+// which are in effect in the committed state, where committed returns the status
+// of a validator in the committed state.
+func filterEPoSChanges(
+	changes []availability.EPoSStatusChange, validators map[string]common.Address,
+	committed func(common.Address) (effective.Eligibility, error),
+) []availability.EPoSStatusChange {
+	addrs := make(map[common.Address]struct{}, len(validators))
+	for _, addr := range validators {
+		addrs[addr] = struct{}{}
+	}
+	var filtered []availability.EPoSStatusChange
+	for _, change := range changes {
+		if _, ok := addrs[change.Address]; !ok {
+			continue
+		}
+		if status, err := committed(change.Address); err != nil || status != change.NewStatus {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
+
+// watchSyncStatusForWebHooks periodically checks the sync status of the shard chain,
+// and posts when the node falls out of sync.
+func (node *Node) watchSyncStatusForWebHooks() {
+	ticker := time.NewTicker(webHookSyncCheckInterval)
+	defer ticker.Stop()
+
+	var (
+		stopC = node.webHookMonitor.stopC
+		edge  outOfSyncEdge
+	)
+	for {
+		select {
+		case <-ticker.C:
+		case <-stopC:
+			return
+		}
+		outOfSync, current, target := node.webHookSyncStatus()
+		if edge.update(outOfSync) {
+			node.postWebHook(webhooks.EventOutOfSync, webhooks.OutOfSyncPayload{
+				ShardID:      node.NodeConfig.ShardID,
+				CurrentBlock: current,
+				TargetBlock:  target,
+			})
+		}
+	}
+}
+
+// outOfSyncEdge detects the node falling out of sync, which is posted once until
+// the node is back in sync.
+type outOfSyncEdge struct {
+	outOfSync bool
+}
+
+// update records the sync status, and returns whether the node just fell out of
+// sync.
+func (e *outOfSyncEdge) update(outOfSync bool) bool {
+	fell := outOfSync && !e.outOfSync
+	e.outOfSync = outOfSync
+	return fell
+}
+
+// webHookSyncStatus returns whether the node is catching up the shard chain, with
+// the current and the target block number. The downloader status is used if the
+// stream downloader is active, else the legacy sync status.
+func (node *Node) webHookSyncStatus() (bool, uint64, uint64) {
+	shardID := node.NodeConfig.ShardID
+	current := node.Blockchain().CurrentBlock().NumberU64()
+
+	if ds := node.getDownloaders(); ds != nil && ds.IsActive() {
+		isSyncing, target, _ := ds.SyncStatus(shardID)
+		return isSyncing && target > current, current, target
+	}
+	inSync, target, _ := node.legacySyncStatus(shardID)
+	return !inSync && target > current, current, target
+}
@@ -0,0 +1,156 @@
+package node
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/consensus"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/crypto/bls"
+	internal_common "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/multibls"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking/availability"
+	"github.com/harmony-one/harmony/staking/effective"
+	"github.com/harmony-one/harmony/webhooks"
+)
+
+func TestCommitteeElectionPayload(t *testing.T) {
+	var (
+		key1  = bls.PublicKeyWrapper{Bytes: bls.SerializedPublicKey{1}}
+		key2  = bls.PublicKeyWrapper{Bytes: bls.SerializedPublicKey{2}}
+		key3  = bls.PublicKeyWrapper{Bytes: bls.SerializedPublicKey{3}}
+		addr1 = common.Address{1}
+		b     = types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+			Number(big.NewInt(100)).
+			Epoch(big.NewInt(3)).
+			Header())
+	)
+	state := &shard.State{
+		Epoch: big.NewInt(4),
+		Shards: []shard.Committee{
+			{ShardID: 0, Slots: shard.SlotList{{EcdsaAddress: common.Address{9}, BLSPublicKey: key2.Bytes}}},
+			{ShardID: 1, Slots: shard.SlotList{{EcdsaAddress: addr1, BLSPublicKey: key1.Bytes}}},
+		},
+	}
+	bech32, _ := internal_common.AddressToBech32(addr1)
+	want := webhooks.CommitteeElectionPayload{
+		Epoch:       4,
+		BlockNumber: 100,
+		Elected: []webhooks.ElectedSlot{
+			{BLSPublicKey: key1.Bytes.Hex(), ShardID: 1, ValidatorAddress: bech32},
+		},
+		NotElected: []string{key3.Bytes.Hex()},
+	}
+	if have := committeeElectionPayload(b, state, multibls.PublicKeys{key1, key3}); !reflect.DeepEqual(have, want) {
+		t.Errorf("unexpected payload %+v, want %+v", have, want)
+	}
+
+	// The epoch follows the block if the shard state has no epoch
+	state.Epoch = nil
+	have := committeeElectionPayload(b, state, multibls.PublicKeys{key3})
+	if have.Epoch != 4 || len(have.Elected) != 0 || !reflect.DeepEqual(have.NotElected, []string{key3.Bytes.Hex()}) {
+		t.Errorf("unexpected payload %+v", have)
+	}
+}
+
+func TestEPoSStatusChanges(t *testing.T) {
+	var (
+		ours1, ours2, others = common.Address{1}, common.Address{2}, common.Address{3}
+		changes              = []availability.EPoSStatusChange{
+			{Address: ours1, OldStatus: effective.Active, NewStatus: effective.Inactive},
+			{Address: ours2, OldStatus: effective.Active, NewStatus: effective.Inactive},
+			{Address: others, OldStatus: effective.Active, NewStatus: effective.Inactive},
+		}
+	)
+	beaconHeader := blockfactory.NewTestHeader().With().Number(big.NewInt(10)).Header()
+	shardHeader := blockfactory.NewTestHeader().With().Number(big.NewInt(11)).ShardID(1).Header()
+
+	node := &Node{webHookMonitor: &webHookMonitor{
+		eposChanges: make(map[uint64][]availability.EPoSStatusChange),
+	}}
+	node.onEPoSStatusChange(beaconHeader, changes)
+	node.onEPoSStatusChange(shardHeader, changes)
+
+	m := node.webHookMonitor
+	if taken := m.takeEPoSChanges(9); len(taken) != 0 {
+		t.Errorf("changes taken before committed: %v", taken)
+	}
+	want := map[uint64][]availability.EPoSStatusChange{10: changes}
+	if taken := m.takeEPoSChanges(20); !reflect.DeepEqual(taken, want) {
+		t.Errorf("unexpected changes %v, want %v", taken, want)
+	}
+	if taken := m.takeEPoSChanges(20); len(taken) != 0 {
+		t.Errorf("changes taken twice: %v", taken)
+	}
+
+	// Only the changes of the validators of the node in effect are posted
+	validators := map[string]common.Address{"key1": ours1, "key2": ours2}
+	committed := func(addr common.Address) (effective.Eligibility, error) {
+		switch addr {
+		case ours1:
+			return effective.Inactive, nil
+		case ours2:
+			return effective.Active, nil
+		}
+		return 0, errors.New("validator not found")
+	}
+	if have := filterEPoSChanges(changes, validators, committed); !reflect.DeepEqual(have, changes[:1]) {
+		t.Errorf("unexpected filtered changes %v", have)
+	}
+	notFound := func(common.Address) (effective.Eligibility, error) {
+		return 0, errors.New("validator not found")
+	}
+	if have := filterEPoSChanges(changes, validators, notFound); len(have) != 0 {
+		t.Errorf("unexpected filtered changes of unknown validators %v", have)
+	}
+}
+
+func TestOutOfSyncEdge(t *testing.T) {
+	var (
+		statuses = []bool{false, true, true, false, false, true}
+		want     = []bool{false, true, false, false, false, true}
+		edge     outOfSyncEdge
+	)
+	for i, outOfSync := range statuses {
+		if fell := edge.update(outOfSync); fell != want[i] {
+			t.Errorf("check %d: unexpected fell out of sync %v", i, fell)
+		}
+	}
+}
+
+func TestLeaderViewChangePayload(t *testing.T) {
+	var (
+		leader = bls.PublicKeyWrapper{Bytes: bls.SerializedPublicKey{1}}
+		next   = bls.PublicKeyWrapper{Bytes: bls.SerializedPublicKey{2}}
+	)
+	vc := consensus.LeaderViewChange{
+		BlockNum:      100,
+		ViewID:        105,
+		NextViewID:    106,
+		LeaderPubKey:  &leader,
+		NextLeaderKey: &next,
+	}
+	want := webhooks.LeaderViewChangePayload{
+		ShardID:          1,
+		BlockNumber:      100,
+		ViewID:           105,
+		NextViewID:       106,
+		LeaderPubKey:     leader.Bytes.Hex(),
+		NextLeaderPubKey: next.Bytes.Hex(),
+	}
+	if have := leaderViewChangePayload(1, vc); have != want {
+		t.Errorf("unexpected payload %+v, want %+v", have, want)
+	}
+
+	// The unknown leader keys are left empty
+	vc.LeaderPubKey, vc.NextLeaderKey = nil, nil
+	want.LeaderPubKey, want.NextLeaderPubKey = "", ""
+	if have := leaderViewChangePayload(1, vc); have != want {
+		t.Errorf("unexpected payload %+v, want %+v", have, want)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/harmony-one/harmony/consensus/reward"
 
 	"github.com/harmony-one/harmony/consensus"
+	"github.com/harmony-one/harmony/consensus/misc"
 
 	"github.com/harmony-one/harmony/crypto/bls"
 
@@ -143,7 +144,7 @@ func (w *Worker) CommitTransactions(
 	}
 
 	// HARMONY TXNS
-	normalTxns := types.NewTransactionsByPriceAndNonce(w.current.signer, w.current.ethSigner, pendingNormal)
+	normalTxns := types.NewTransactionsByPriceAndNonce(w.current.signer, w.current.ethSigner, pendingNormal, w.current.header.BaseFee())
 
 	w.CommitSortedTransactions(normalTxns, coinbase)
 
@@ -312,9 +313,20 @@ func (w *Worker) makeCurrent(parent *types.Block, header *block.Header) error {
 	if err != nil {
 		return err
 	}
+	var signer types.Signer = types.NewEIP155Signer(w.config.ChainID)
+	switch {
+	case w.config.IsEIP1559(header.Epoch()):
+		signer = types.NewLondonSigner(w.config.ChainID)
+	case w.config.IsEIP2930(header.Epoch()):
+		signer = types.NewEIP2930Signer(w.config.ChainID)
+	}
+	// The base fee of the proposed block follows from the parent block
+	if w.config.IsEIP1559(header.Epoch()) {
+		header.SetBaseFee(misc.CalcBaseFee(w.config, parent.Header()))
+	}
 	env := &environment{
-		signer:    types.NewEIP155Signer(w.config.ChainID),
-		ethSigner: types.NewEIP155Signer(w.config.EthCompatibleChainID),
+		signer:    signer,
+		ethSigner: types.MakeEthSigner(w.config, header.Epoch()),
 		state:     state,
 		header:    header,
 	}
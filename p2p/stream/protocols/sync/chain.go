@@ -1,11 +1,18 @@
 package sync
 
 import (
+	"bytes"
+	"math"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/consensus/engine"
+	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
 	shardingconfig "github.com/harmony-one/harmony/internal/configs/sharding"
+	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
 	"github.com/pkg/errors"
 )
 
@@ -15,6 +22,24 @@ type chainHelper interface {
 	getBlockHashes(bns []uint64) []common.Hash
 	getBlocksByNumber(bns []uint64) ([]*types.Block, error)
 	getBlocksByHashes(hs []common.Hash) ([]*types.Block, error)
+
+	getAccountRange(root, origin, limit common.Hash, maxBytes uint64) ([]*syncpb.AccountData, [][]byte, error)
+	getStorageRanges(root common.Hash, accounts []common.Hash, origin, limit common.Hash, maxBytes uint64) ([]*syncpb.StoragesPerAccount, [][]byte, error)
+	getByteCodes(hs []common.Hash, maxBytes uint64) ([][]byte, error)
+	getTrieNodes(hs []common.Hash, maxBytes uint64) ([][]byte, error)
+
+	getReceipts(hs []common.Hash) ([]types.Receipts, error)
+	getBlockHeaders(from, count, skip uint64, reverse bool) ([]*block.Header, [][]byte, error)
+}
+
+// stateChain is the chain which is able to serve the state sync requests.
+type stateChain interface {
+	StateCache() state.Database
+}
+
+// receiptChain is the chain which is able to serve the receipts.
+type receiptChain interface {
+	GetReceiptsByHash(hash common.Hash) types.Receipts
 }
 
 type chainHelperImpl struct {
@@ -133,3 +158,257 @@ func (ch *chainHelperImpl) getBlockSigFromNextBlock(header *block.Header) []byte
 func (ch *chainHelperImpl) getBlockSigFromDB(header *block.Header) ([]byte, error) {
 	return ch.chain.ReadCommitSig(header.Number().Uint64())
 }
+
+// getReceipts returns the receipts of the given block hashes. Receipts of unknown
+// blocks are returned as nil.
+func (ch *chainHelperImpl) getReceipts(hs []common.Hash) ([]types.Receipts, error) {
+	rc, ok := ch.chain.(receiptChain)
+	if !ok {
+		return nil, errReceiptsNotSupported
+	}
+	receipts := make([]types.Receipts, 0, len(hs))
+	for _, h := range hs {
+		receipts = append(receipts, rc.GetReceiptsByHash(h))
+	}
+	return receipts, nil
+}
+
+// getBlockHeaders returns the headers starting from the given number with step skip+1,
+// together with the commit signatures of the headers. The result stops at the first
+// unknown header.
+func (ch *chainHelperImpl) getBlockHeaders(from, count, skip uint64, reverse bool) ([]*block.Header, [][]byte, error) {
+	var (
+		headers = make([]*block.Header, 0, count)
+		sigs    = make([][]byte, 0, count)
+		step    = skip + 1
+		bn      = from
+	)
+	for i := uint64(0); i != count; i++ {
+		header := ch.chain.GetHeaderByNumber(bn)
+		if header == nil {
+			break
+		}
+		sig, err := ch.getBlockSigAndBitmap(header)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "missing commit signature of block %v", bn)
+		}
+		headers = append(headers, header)
+		sigs = append(sigs, sig)
+
+		// skip+1 overflows to zero if skip is the max uint64, and there is no next
+		// header in either direction.
+		if step == 0 {
+			break
+		}
+		if reverse {
+			if bn < step {
+				break
+			}
+			bn -= step
+		} else {
+			if bn > math.MaxUint64-step {
+				break
+			}
+			bn += step
+		}
+	}
+	return headers, sigs, nil
+}
+
+var (
+	errReceiptsNotSupported = errors.New("chain does not support serving receipts")
+	errStateNotSupported    = errors.New("chain does not support serving state")
+
+	maxHash = common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+)
+
+func (ch *chainHelperImpl) getAccountRange(root, origin, limit common.Hash, maxBytes uint64) ([]*syncpb.AccountData, [][]byte, error) {
+	triedb, err := ch.trieDB()
+	if err != nil {
+		return nil, nil, err
+	}
+	accTrie, err := trie.New(root, triedb)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "open account trie %v", root.String())
+	}
+	keys, values, err := readTrieRange(accTrie, origin, limit, capStateBytes(maxBytes))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read account range")
+	}
+	proof, err := proveTrieRange(accTrie, origin, keys)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "prove account range")
+	}
+	accounts := make([]*syncpb.AccountData, 0, len(keys))
+	for i := range keys {
+		accounts = append(accounts, &syncpb.AccountData{
+			Hash: keys[i].Bytes(),
+			Body: values[i],
+		})
+	}
+	return accounts, proof, nil
+}
+
+// getStorageRanges read the storage slots of the given accounts. The origin only applies
+// to the first account and limit only applies to the last account. Once the response
+// size hits the limit, the storage of the last delivered account might be incomplete, and
+// the proof of the range for the last account is attached.
+func (ch *chainHelperImpl) getStorageRanges(root common.Hash, accounts []common.Hash, origin, limit common.Hash, maxBytes uint64) ([]*syncpb.StoragesPerAccount, [][]byte, error) {
+	triedb, err := ch.trieDB()
+	if err != nil {
+		return nil, nil, err
+	}
+	accTrie, err := trie.New(root, triedb)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "open account trie %v", root.String())
+	}
+	var (
+		slots = make([]*syncpb.StoragesPerAccount, 0, len(accounts))
+		proof [][]byte
+		size  uint64
+	)
+	maxBytes = capStateBytes(maxBytes)
+	for i, account := range accounts {
+		accOrigin, accLimit := common.Hash{}, maxHash
+		if i == 0 {
+			accOrigin = origin
+		}
+		if i == len(accounts)-1 {
+			accLimit = limit
+		}
+		stTrie, err := ch.openStorageTrie(accTrie, triedb, account)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys, values, err := readTrieRange(stTrie, accOrigin, accLimit, maxBytes-size)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "read storage range of %v", account.String())
+		}
+		storage := &syncpb.StoragesPerAccount{
+			Slots: make([]*syncpb.StorageData, 0, len(keys)),
+		}
+		for j := range keys {
+			storage.Slots = append(storage.Slots, &syncpb.StorageData{
+				Hash: keys[j].Bytes(),
+				Body: values[j],
+			})
+			size += uint64(common.HashLength + len(values[j]))
+		}
+		slots = append(slots, storage)
+
+		if size >= maxBytes || accOrigin != (common.Hash{}) || accLimit != maxHash {
+			// Storage of the account might be partially delivered. Attach the proof
+			// and stop serving the rest of accounts.
+			proof, err = proveTrieRange(stTrie, accOrigin, keys)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "prove storage range of %v", account.String())
+			}
+			break
+		}
+	}
+	return slots, proof, nil
+}
+
+func (ch *chainHelperImpl) getByteCodes(hs []common.Hash, maxBytes uint64) ([][]byte, error) {
+	return ch.getNodeData(hs, maxBytes)
+}
+
+func (ch *chainHelperImpl) getTrieNodes(hs []common.Hash, maxBytes uint64) ([][]byte, error) {
+	return ch.getNodeData(hs, maxBytes)
+}
+
+// getNodeData read the trie nodes or contract codes of the given hashes. Unknown data
+// is returned as empty bytes.
+func (ch *chainHelperImpl) getNodeData(hs []common.Hash, maxBytes uint64) ([][]byte, error) {
+	triedb, err := ch.trieDB()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		res  = make([][]byte, 0, len(hs))
+		size uint64
+	)
+	maxBytes = capStateBytes(maxBytes)
+	for _, h := range hs {
+		data, _ := triedb.Node(h)
+		res = append(res, data)
+
+		size += uint64(len(data))
+		if size >= maxBytes {
+			break
+		}
+	}
+	return res, nil
+}
+
+func (ch *chainHelperImpl) trieDB() (*trie.Database, error) {
+	sc, ok := ch.chain.(stateChain)
+	if !ok {
+		return nil, errStateNotSupported
+	}
+	return sc.StateCache().TrieDB(), nil
+}
+
+func (ch *chainHelperImpl) openStorageTrie(accTrie *trie.Trie, triedb *trie.Database, account common.Hash) (*trie.Trie, error) {
+	var acc state.Account
+
+	blob, err := accTrie.TryGet(account[:])
+	if err != nil {
+		return nil, errors.Wrapf(err, "read account %v", account.String())
+	}
+	if len(blob) != 0 {
+		if err := rlp.DecodeBytes(blob, &acc); err != nil {
+			return nil, errors.Wrapf(err, "decode account %v", account.String())
+		}
+	}
+	stTrie, err := trie.New(acc.Root, triedb)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open storage trie of %v", account.String())
+	}
+	return stTrie, nil
+}
+
+// readTrieRange read the leaves of the trie within [origin, limit] in ascending order until
+// the total size reaches maxBytes.
+func readTrieRange(tr *trie.Trie, origin, limit common.Hash, maxBytes uint64) ([]common.Hash, [][]byte, error) {
+	var (
+		keys   []common.Hash
+		values [][]byte
+		size   uint64
+	)
+	it := trie.NewIterator(tr.NodeIterator(origin[:]))
+	for it.Next() {
+		if bytes.Compare(it.Key, limit[:]) > 0 {
+			break
+		}
+		keys = append(keys, common.BytesToHash(it.Key))
+		values = append(values, common.CopyBytes(it.Value))
+
+		size += uint64(common.HashLength + len(it.Value))
+		if size >= maxBytes {
+			break
+		}
+	}
+	return keys, values, it.Err
+}
+
+// proveTrieRange creates the merkle proof for the origin and the last delivered key.
+func proveTrieRange(tr *trie.Trie, origin common.Hash, keys []common.Hash) ([][]byte, error) {
+	proof := newProofList()
+	if err := tr.Prove(origin[:], 0, proof); err != nil {
+		return nil, err
+	}
+	if len(keys) > 0 {
+		if err := tr.Prove(keys[len(keys)-1][:], 0, proof); err != nil {
+			return nil, err
+		}
+	}
+	return proof.nodes, nil
+}
+
+func capStateBytes(maxBytes uint64) uint64 {
+	if maxBytes == 0 || maxBytes > StateResponseBytesCap {
+		return StateResponseBytesCap
+	}
+	return maxBytes
+}
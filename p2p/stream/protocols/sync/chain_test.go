@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	protobuf "github.com/golang/protobuf/proto"
 	"github.com/harmony-one/harmony/block"
@@ -46,6 +47,110 @@ func (tch *testChainHelper) getBlocksByHashes(hs []common.Hash) ([]*types.Block,
 	return bs, nil
 }
 
+func (tch *testChainHelper) getAccountRange(root, origin, limit common.Hash, maxBytes uint64) ([]*syncpb.AccountData, [][]byte, error) {
+	accounts := make([]*syncpb.AccountData, 0, len(testAccountHashes))
+	for i := range testAccountHashes {
+		accounts = append(accounts, &syncpb.AccountData{
+			Hash: testAccountHashes[i][:],
+			Body: testAccountBodies[i],
+		})
+	}
+	return accounts, nil, nil
+}
+
+func (tch *testChainHelper) getStorageRanges(root common.Hash, accounts []common.Hash, origin, limit common.Hash, maxBytes uint64) ([]*syncpb.StoragesPerAccount, [][]byte, error) {
+	slots := make([]*syncpb.StoragesPerAccount, 0, len(accounts))
+	for range accounts {
+		storage := &syncpb.StoragesPerAccount{}
+		for i := range testAccountHashes {
+			storage.Slots = append(storage.Slots, &syncpb.StorageData{
+				Hash: testAccountHashes[i][:],
+				Body: testAccountBodies[i],
+			})
+		}
+		slots = append(slots, storage)
+	}
+	return slots, nil, nil
+}
+
+func (tch *testChainHelper) getByteCodes(hs []common.Hash, maxBytes uint64) ([][]byte, error) {
+	return getTestStateData(hs), nil
+}
+
+func (tch *testChainHelper) getTrieNodes(hs []common.Hash, maxBytes uint64) ([][]byte, error) {
+	return getTestStateData(hs), nil
+}
+
+func (tch *testChainHelper) getReceipts(hs []common.Hash) ([]types.Receipts, error) {
+	receipts := make([]types.Receipts, 0, len(hs))
+	for _, h := range hs {
+		receipts = append(receipts, makeTestReceipts(hashToNumber(h)))
+	}
+	return receipts, nil
+}
+
+func (tch *testChainHelper) getBlockHeaders(from, count, skip uint64, reverse bool) ([]*block.Header, [][]byte, error) {
+	var (
+		headers []*block.Header
+		sigs    [][]byte
+		bn      = from
+	)
+	for i := uint64(0); i != count; i++ {
+		headers = append(headers, makeTestBlock(bn).Header())
+		sigs = append(sigs, make([]byte, 96))
+		if reverse {
+			bn -= skip + 1
+		} else {
+			bn += skip + 1
+		}
+	}
+	return headers, sigs, nil
+}
+
+func makeTestReceipts(bn uint64) types.Receipts {
+	return types.Receipts{
+		&types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: bn},
+	}
+}
+
+var (
+	testAccountHashes = []common.Hash{
+		numberToHash(1),
+		numberToHash(2),
+		numberToHash(3),
+	}
+	testAccountBodies = [][]byte{
+		[]byte("account 1"),
+		[]byte("account 2"),
+		[]byte("account 3"),
+	}
+
+	testStateData = [][]byte{
+		[]byte("state data 1"),
+		[]byte("state data 2"),
+		[]byte("state data 3"),
+	}
+	testStateDataHashes = []common.Hash{
+		crypto.Keccak256Hash(testStateData[0]),
+		crypto.Keccak256Hash(testStateData[1]),
+		crypto.Keccak256Hash(testStateData[2]),
+	}
+)
+
+func getTestStateData(hs []common.Hash) [][]byte {
+	res := make([][]byte, 0, len(hs))
+	for _, h := range hs {
+		var data []byte
+		for i, dh := range testStateDataHashes {
+			if dh == h {
+				data = testStateData[i]
+			}
+		}
+		res = append(res, data)
+	}
+	return res
+}
+
 func numberToHash(bn uint64) common.Hash {
 	var h common.Hash
 	binary.LittleEndian.PutUint64(h[:], bn)
@@ -164,3 +269,97 @@ func checkBlocksByHashesResult(b []byte, hs []common.Hash) error {
 	}
 	return nil
 }
+
+func checkAccountRangeResult(b []byte) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	arResp, err := msg.GetAccountRangeResponse()
+	if err != nil {
+		return err
+	}
+	if len(arResp.Accounts) != len(testAccountHashes) {
+		return errors.New("unexpected size")
+	}
+	for i, acc := range arResp.Accounts {
+		if !bytes.Equal(acc.Hash, testAccountHashes[i][:]) {
+			return errors.New("unexpected account hash")
+		}
+		if !bytes.Equal(acc.Body, testAccountBodies[i]) {
+			return errors.New("unexpected account body")
+		}
+	}
+	return nil
+}
+
+func checkByteCodesResult(b []byte, hs []common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	bcResp, err := msg.GetByteCodesResponse()
+	if err != nil {
+		return err
+	}
+	if len(bcResp.Codes) != len(hs) {
+		return errors.New("unexpected size")
+	}
+	for i, h := range hs {
+		if len(bcResp.Codes[i]) == 0 {
+			continue
+		}
+		if crypto.Keccak256Hash(bcResp.Codes[i]) != h {
+			return fmt.Errorf("unexpected code at %v", i)
+		}
+	}
+	return nil
+}
+
+func checkReceiptsResult(b []byte, hs []common.Hash) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	grResp, err := msg.GetReceiptsResponse()
+	if err != nil {
+		return err
+	}
+	if len(grResp.ReceiptsBytes) != len(hs) {
+		return errors.New("unexpected size")
+	}
+	for i, h := range hs {
+		var receipts types.Receipts
+		if err := rlp.DecodeBytes(grResp.ReceiptsBytes[i], &receipts); err != nil {
+			return err
+		}
+		if len(receipts) != 1 || receipts[0].CumulativeGasUsed != hashToNumber(h) {
+			return fmt.Errorf("unexpected receipts at %v", i)
+		}
+	}
+	return nil
+}
+
+func checkBlockHeadersResult(b []byte, bns []uint64) error {
+	var msg = &syncpb.Message{}
+	if err := protobuf.Unmarshal(b, msg); err != nil {
+		return err
+	}
+	bhResp, err := msg.GetBlockHeadersResponse()
+	if err != nil {
+		return err
+	}
+	if len(bhResp.HeadersBytes) != len(bns) || len(bhResp.CommitSig) != len(bns) {
+		return errors.New("unexpected size")
+	}
+	for i, bn := range bns {
+		var header *block.Header
+		if err := rlp.DecodeBytes(bhResp.HeadersBytes[i], &header); err != nil {
+			return err
+		}
+		if header.Number().Uint64() != bn {
+			return fmt.Errorf("unexpected number %v != %v", header.Number(), bn)
+		}
+	}
+	return nil
+}
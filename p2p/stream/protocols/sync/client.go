@@ -1,14 +1,17 @@
 package sync
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 	protobuf "github.com/golang/protobuf/proto"
+	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/core/types"
 	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
@@ -105,6 +108,147 @@ func (p *Protocol) GetBlocksByHashes(ctx context.Context, hs []common.Hash, opts
 	return
 }
 
+// GetAccountRange do getAccountRangeRequest through sync stream protocol.
+// Return the account hashes and RLP encoded accounts within [origin, limit] in the
+// account trie of the given root, and the merkle proof of the range edges.
+// The proof is not verified here, and shall be verified by the caller.
+func (p *Protocol) GetAccountRange(ctx context.Context, root, origin, limit common.Hash, bytes uint64, opts ...Option) (hashes []common.Hash, accounts [][]byte, proof [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getAccountRange")
+	defer p.doMetricPostClientRequest("getAccountRange", err, timer)
+
+	req := newGetAccountRangeRequest(root, origin, limit, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	hashes, accounts, proof, err = req.getAccountRangeFromResponse(resp)
+	return
+}
+
+// GetStorageRanges do getStorageRangesRequest through sync stream protocol.
+// Return the storage slot hashes and values for each of the delivered accounts, and
+// the merkle proof of the range edges of the last delivered account if the storage
+// of the last account is not fully delivered.
+func (p *Protocol) GetStorageRanges(ctx context.Context, root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64, opts ...Option) (hashes [][]common.Hash, slots [][][]byte, proof [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getStorageRanges")
+	defer p.doMetricPostClientRequest("getStorageRanges", err, timer)
+
+	if len(accounts) == 0 {
+		err = fmt.Errorf("zero accounts requested")
+		return
+	}
+	if len(accounts) > GetStorageRangesAccountsCap {
+		err = fmt.Errorf("number of requested accounts exceed limit")
+		return
+	}
+	req := newGetStorageRangesRequest(root, accounts, origin, limit, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	hashes, slots, proof, err = req.getStorageRangesFromResponse(resp)
+	return
+}
+
+// GetByteCodes do getByteCodesRequest through sync stream protocol.
+// Return the contract codes of the given code hashes. The code for unknown hash is
+// empty. The result might be truncated when the response size limit is reached.
+func (p *Protocol) GetByteCodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...Option) (codes [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getByteCodes")
+	defer p.doMetricPostClientRequest("getByteCodes", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero code hashes requested")
+		return
+	}
+	if len(hs) > GetByteCodesAmountCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetByteCodesRequest(hs, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	codes, err = req.getByteCodesFromResponse(resp)
+	return
+}
+
+// GetTrieNodes do getTrieNodesRequest through sync stream protocol.
+// Return the trie nodes of the given node hashes. The node for unknown hash is
+// empty. The result might be truncated when the response size limit is reached.
+func (p *Protocol) GetTrieNodes(ctx context.Context, hs []common.Hash, bytes uint64, opts ...Option) (nodes [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getTrieNodes")
+	defer p.doMetricPostClientRequest("getTrieNodes", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero node hashes requested")
+		return
+	}
+	if len(hs) > GetTrieNodesAmountCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetTrieNodesRequest(hs, bytes)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	nodes, err = req.getTrieNodesFromResponse(resp)
+	return
+}
+
+// GetReceipts do getReceiptsRequest through sync stream protocol.
+// Return the receipts of the given block hashes. Receipts of unknown blocks are nil.
+// The receipts only carry consensus fields, and shall be verified against the receipt
+// root in the block header by the caller.
+func (p *Protocol) GetReceipts(ctx context.Context, hs []common.Hash, opts ...Option) (receipts []types.Receipts, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getReceipts")
+	defer p.doMetricPostClientRequest("getReceipts", err, timer)
+
+	if len(hs) == 0 {
+		err = fmt.Errorf("zero block hashes requested")
+		return
+	}
+	if len(hs) > GetReceiptsAmountCap {
+		err = fmt.Errorf("number of requested hashes exceed limit")
+		return
+	}
+	req := newGetReceiptsRequest(hs)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	receipts, err = req.getReceiptsFromResponse(resp)
+	return
+}
+
+// GetBlockHeaders do getBlockHeadersRequest through sync stream protocol.
+// Return count headers starting from the block number from, with step skip+1. The
+// headers are in descending order if reverse is true. Commit signatures of the headers
+// are also returned. The result might be fewer than count if the remote node does not
+// have enough headers.
+func (p *Protocol) GetBlockHeaders(ctx context.Context, from, count, skip uint64, reverse bool, opts ...Option) (headers []*block.Header, sigs [][]byte, stid sttypes.StreamID, err error) {
+	timer := p.doMetricClientRequest("getBlockHeaders")
+	defer p.doMetricPostClientRequest("getBlockHeaders", err, timer)
+
+	if count == 0 {
+		err = fmt.Errorf("zero headers requested")
+		return
+	}
+	if count > GetBlockHeadersAmountCap {
+		err = fmt.Errorf("number of requested headers exceed limit")
+		return
+	}
+	req := newGetBlockHeadersRequest(from, count, skip, reverse)
+	resp, stid, err := p.rm.DoRequest(ctx, req, opts...)
+	if err != nil {
+		return
+	}
+	headers, sigs, err = req.getBlockHeadersFromResponse(resp)
+	return
+}
+
 // getBlocksByNumberRequest is the request for get block by numbers which implements
 // sttypes.Request interface
 type getBlocksByNumberRequest struct {
@@ -357,3 +501,493 @@ func (req *getBlocksByHashesRequest) getBlocksFromResponse(resp sttypes.Response
 	}
 	return blocks, nil
 }
+
+// getAccountRangeRequest is the request for getting accounts of a range in the account trie
+type getAccountRangeRequest struct {
+	root   common.Hash
+	origin common.Hash
+	limit  common.Hash
+	bytes  uint64
+	pbReq  *syncpb.Request
+}
+
+func newGetAccountRangeRequest(root, origin, limit common.Hash, bytes uint64) *getAccountRangeRequest {
+	pbReq := syncpb.MakeGetAccountRangeRequest(root, origin, limit, bytes)
+	return &getAccountRangeRequest{
+		root:   root,
+		origin: origin,
+		limit:  limit,
+		bytes:  bytes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getAccountRangeRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getAccountRangeRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getAccountRangeRequest) String() string {
+	return fmt.Sprintf("REQUEST [GetAccountRange: root %x, origin %x, limit %x, bytes %v]",
+		req.root[:], req.origin[:], req.limit[:], req.bytes)
+}
+
+func (req *getAccountRangeRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(StateSyncMinVersion)
+}
+
+func (req *getAccountRangeRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getAccountRangeRequest) getAccountRangeFromResponse(resp sttypes.Response) ([]common.Hash, [][]byte, [][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, nil, errors.New(errResp.Error)
+	}
+	arResp := sResp.pb.GetGetAccountRangeResponse()
+	if arResp == nil {
+		return nil, nil, nil, errors.New("response not GetAccountRange")
+	}
+	var (
+		hashes   = make([]common.Hash, 0, len(arResp.Accounts))
+		accounts = make([][]byte, 0, len(arResp.Accounts))
+	)
+	for _, acc := range arResp.Accounts {
+		hashes = append(hashes, common.BytesToHash(acc.Hash))
+		accounts = append(accounts, acc.Body)
+	}
+	if err := checkHashesInRange(hashes, req.origin, req.limit); err != nil {
+		return nil, nil, nil, errors.Wrap(err, "[GetAccountRangeResponse]")
+	}
+	return hashes, accounts, arResp.Proof, nil
+}
+
+// getStorageRangesRequest is the request for getting storage slots of the given accounts
+type getStorageRangesRequest struct {
+	root     common.Hash
+	accounts []common.Hash
+	origin   common.Hash
+	limit    common.Hash
+	bytes    uint64
+	pbReq    *syncpb.Request
+}
+
+func newGetStorageRangesRequest(root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64) *getStorageRangesRequest {
+	pbReq := syncpb.MakeGetStorageRangesRequest(root, accounts, origin, limit, bytes)
+	return &getStorageRangesRequest{
+		root:     root,
+		accounts: accounts,
+		origin:   origin,
+		limit:    limit,
+		bytes:    bytes,
+		pbReq:    pbReq,
+	}
+}
+
+func (req *getStorageRangesRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getStorageRangesRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getStorageRangesRequest) String() string {
+	accStrs := make([]string, 0, len(req.accounts))
+	for _, acc := range req.accounts {
+		accStrs = append(accStrs, fmt.Sprintf("%x", acc[:]))
+	}
+	return fmt.Sprintf("REQUEST [GetStorageRanges: root %x, accounts %v, origin %x, limit %x, bytes %v]",
+		req.root[:], strings.Join(accStrs, ", "), req.origin[:], req.limit[:], req.bytes)
+}
+
+func (req *getStorageRangesRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(StateSyncMinVersion)
+}
+
+func (req *getStorageRangesRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getStorageRangesRequest) getStorageRangesFromResponse(resp sttypes.Response) ([][]common.Hash, [][][]byte, [][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, nil, errors.New(errResp.Error)
+	}
+	srResp := sResp.pb.GetGetStorageRangesResponse()
+	if srResp == nil {
+		return nil, nil, nil, errors.New("response not GetStorageRanges")
+	}
+	if len(srResp.Slots) > len(req.accounts) {
+		return nil, nil, nil, fmt.Errorf("[GetStorageRangesResponse] unexpected accounts delivered: %v / %v",
+			len(srResp.Slots), len(req.accounts))
+	}
+	var (
+		hashes = make([][]common.Hash, 0, len(srResp.Slots))
+		slots  = make([][][]byte, 0, len(srResp.Slots))
+	)
+	for i, storage := range srResp.Slots {
+		var (
+			accHashes = make([]common.Hash, 0, len(storage.Slots))
+			accSlots  = make([][]byte, 0, len(storage.Slots))
+			origin    = common.Hash{}
+			limit     = maxHash
+		)
+		if i == 0 {
+			origin = req.origin
+		}
+		if i == len(req.accounts)-1 {
+			limit = req.limit
+		}
+		for _, slot := range storage.Slots {
+			accHashes = append(accHashes, common.BytesToHash(slot.Hash))
+			accSlots = append(accSlots, slot.Body)
+		}
+		if err := checkHashesInRange(accHashes, origin, limit); err != nil {
+			return nil, nil, nil, errors.Wrap(err, "[GetStorageRangesResponse]")
+		}
+		hashes = append(hashes, accHashes)
+		slots = append(slots, accSlots)
+	}
+	return hashes, slots, srResp.Proof, nil
+}
+
+// getByteCodesRequest is the request for getting contract codes by code hashes
+type getByteCodesRequest struct {
+	hashes []common.Hash
+	bytes  uint64
+	pbReq  *syncpb.Request
+}
+
+func newGetByteCodesRequest(hashes []common.Hash, bytes uint64) *getByteCodesRequest {
+	pbReq := syncpb.MakeGetByteCodesRequest(hashes, bytes)
+	return &getByteCodesRequest{
+		hashes: hashes,
+		bytes:  bytes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getByteCodesRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getByteCodesRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getByteCodesRequest) String() string {
+	hashStrs := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		hashStrs = append(hashStrs, fmt.Sprintf("%x", h[:]))
+	}
+	return fmt.Sprintf("REQUEST [GetByteCodes: %v]", strings.Join(hashStrs, ", "))
+}
+
+func (req *getByteCodesRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(StateSyncMinVersion)
+}
+
+func (req *getByteCodesRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getByteCodesRequest) getByteCodesFromResponse(resp sttypes.Response) ([][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	bcResp := sResp.pb.GetGetByteCodesResponse()
+	if bcResp == nil {
+		return nil, errors.New("response not GetByteCodes")
+	}
+	if err := checkDataHashes(bcResp.Codes, req.hashes); err != nil {
+		return nil, errors.Wrap(err, "[GetByteCodesResponse]")
+	}
+	return bcResp.Codes, nil
+}
+
+// getTrieNodesRequest is the request for getting trie nodes by node hashes
+type getTrieNodesRequest struct {
+	hashes []common.Hash
+	bytes  uint64
+	pbReq  *syncpb.Request
+}
+
+func newGetTrieNodesRequest(hashes []common.Hash, bytes uint64) *getTrieNodesRequest {
+	pbReq := syncpb.MakeGetTrieNodesRequest(hashes, bytes)
+	return &getTrieNodesRequest{
+		hashes: hashes,
+		bytes:  bytes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getTrieNodesRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getTrieNodesRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getTrieNodesRequest) String() string {
+	hashStrs := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		hashStrs = append(hashStrs, fmt.Sprintf("%x", h[:]))
+	}
+	return fmt.Sprintf("REQUEST [GetTrieNodes: %v]", strings.Join(hashStrs, ", "))
+}
+
+func (req *getTrieNodesRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(StateSyncMinVersion)
+}
+
+func (req *getTrieNodesRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getTrieNodesRequest) getTrieNodesFromResponse(resp sttypes.Response) ([][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	tnResp := sResp.pb.GetGetTrieNodesResponse()
+	if tnResp == nil {
+		return nil, errors.New("response not GetTrieNodes")
+	}
+	if err := checkDataHashes(tnResp.Nodes, req.hashes); err != nil {
+		return nil, errors.Wrap(err, "[GetTrieNodesResponse]")
+	}
+	return tnResp.Nodes, nil
+}
+
+// checkHashesInRange checks whether the hashes are in strictly ascending order and
+// within [origin, limit].
+func checkHashesInRange(hashes []common.Hash, origin, limit common.Hash) error {
+	for i, h := range hashes {
+		if bytes.Compare(h[:], origin[:]) < 0 || bytes.Compare(h[:], limit[:]) > 0 {
+			return fmt.Errorf("hash %x out of range", h[:])
+		}
+		if i > 0 && bytes.Compare(hashes[i-1][:], h[:]) >= 0 {
+			return fmt.Errorf("hashes not in ascending order at %v", i)
+		}
+	}
+	return nil
+}
+
+// checkDataHashes checks whether the delivered data matches the requested hashes.
+// Empty data stands for unknown data at the remote node.
+func checkDataHashes(data [][]byte, hashes []common.Hash) error {
+	if len(data) > len(hashes) {
+		return fmt.Errorf("unexpected data size: %v / %v", len(data), len(hashes))
+	}
+	for i, d := range data {
+		if len(d) == 0 {
+			continue
+		}
+		if h := crypto.Keccak256Hash(d); h != hashes[i] {
+			return fmt.Errorf("unexpected data hash at %v: %x / %x", i, h[:], hashes[i][:])
+		}
+	}
+	return nil
+}
+
+// getReceiptsRequest is the request for getting receipts by block hashes
+type getReceiptsRequest struct {
+	hashes []common.Hash
+	pbReq  *syncpb.Request
+}
+
+func newGetReceiptsRequest(hashes []common.Hash) *getReceiptsRequest {
+	pbReq := syncpb.MakeGetReceiptsRequest(hashes)
+	return &getReceiptsRequest{
+		hashes: hashes,
+		pbReq:  pbReq,
+	}
+}
+
+func (req *getReceiptsRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getReceiptsRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getReceiptsRequest) String() string {
+	hashStrs := make([]string, 0, len(req.hashes))
+	for _, h := range req.hashes {
+		hashStrs = append(hashStrs, fmt.Sprintf("%x", h[:]))
+	}
+	return fmt.Sprintf("REQUEST [GetReceipts: %v]", strings.Join(hashStrs, ", "))
+}
+
+func (req *getReceiptsRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getReceiptsRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getReceiptsRequest) getReceiptsFromResponse(resp sttypes.Response) ([]types.Receipts, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, errors.New(errResp.Error)
+	}
+	grResp := sResp.pb.GetGetReceiptsResponse()
+	if grResp == nil {
+		return nil, errors.New("response not GetReceipts")
+	}
+	if len(grResp.ReceiptsBytes) != len(req.hashes) {
+		return nil, fmt.Errorf("[GetReceiptsResponse] unexpected receipts size: %v / %v",
+			len(grResp.ReceiptsBytes), len(req.hashes))
+	}
+	receipts := make([]types.Receipts, 0, len(grResp.ReceiptsBytes))
+	for _, rb := range grResp.ReceiptsBytes {
+		if len(rb) == 0 {
+			receipts = append(receipts, nil)
+			continue
+		}
+		var rs types.Receipts
+		if err := rlp.DecodeBytes(rb, &rs); err != nil {
+			return nil, errors.Wrap(err, "[GetReceiptsResponse]")
+		}
+		receipts = append(receipts, rs)
+	}
+	return receipts, nil
+}
+
+// getBlockHeadersRequest is the request for getting block headers in a range
+type getBlockHeadersRequest struct {
+	from    uint64
+	count   uint64
+	skip    uint64
+	reverse bool
+	pbReq   *syncpb.Request
+}
+
+func newGetBlockHeadersRequest(from, count, skip uint64, reverse bool) *getBlockHeadersRequest {
+	pbReq := syncpb.MakeGetBlockHeadersRequest(from, count, skip, reverse)
+	return &getBlockHeadersRequest{
+		from:    from,
+		count:   count,
+		skip:    skip,
+		reverse: reverse,
+		pbReq:   pbReq,
+	}
+}
+
+func (req *getBlockHeadersRequest) ReqID() uint64 {
+	return req.pbReq.GetReqId()
+}
+
+func (req *getBlockHeadersRequest) SetReqID(val uint64) {
+	req.pbReq.ReqId = val
+}
+
+func (req *getBlockHeadersRequest) String() string {
+	return fmt.Sprintf("REQUEST [GetBlockHeaders: from %v, count %v, skip %v, reverse %v]",
+		req.from, req.count, req.skip, req.reverse)
+}
+
+func (req *getBlockHeadersRequest) IsSupportedByProto(target sttypes.ProtoSpec) bool {
+	return target.Version.GreaterThanOrEqual(version110)
+}
+
+func (req *getBlockHeadersRequest) Encode() ([]byte, error) {
+	msg := syncpb.MakeMessageFromRequest(req.pbReq)
+	return protobuf.Marshal(msg)
+}
+
+func (req *getBlockHeadersRequest) getBlockHeadersFromResponse(resp sttypes.Response) ([]*block.Header, [][]byte, error) {
+	sResp, ok := resp.(*syncResponse)
+	if !ok || sResp == nil {
+		return nil, nil, errors.New("not sync response")
+	}
+	if errResp := sResp.pb.GetErrorResponse(); errResp != nil {
+		return nil, nil, errors.New(errResp.Error)
+	}
+	bhResp := sResp.pb.GetGetBlockHeadersResponse()
+	if bhResp == nil {
+		return nil, nil, errors.New("response not GetBlockHeaders")
+	}
+	if uint64(len(bhResp.HeadersBytes)) > req.count {
+		return nil, nil, fmt.Errorf("[GetBlockHeadersResponse] unexpected headers size: %v / %v",
+			len(bhResp.HeadersBytes), req.count)
+	}
+	if len(bhResp.CommitSig) != len(bhResp.HeadersBytes) {
+		return nil, nil, errors.New("[GetBlockHeadersResponse] commit sigs size not expected")
+	}
+	headers := make([]*block.Header, 0, len(bhResp.HeadersBytes))
+	for i, hb := range bhResp.HeadersBytes {
+		var header *block.Header
+		if err := rlp.DecodeBytes(hb, &header); err != nil {
+			return nil, nil, errors.Wrap(err, "[GetBlockHeadersResponse]")
+		}
+		if header == nil {
+			return nil, nil, errors.New("[GetBlockHeadersResponse] nil header delivered")
+		}
+		if err := req.checkHeader(headers, header, i); err != nil {
+			return nil, nil, errors.Wrap(err, "[GetBlockHeadersResponse]")
+		}
+		headers = append(headers, header)
+	}
+	return headers, bhResp.CommitSig, nil
+}
+
+// checkHeader checks whether the i-th delivered header has the expected number, and
+// is linked to the previous header when no header is skipped.
+func (req *getBlockHeadersRequest) checkHeader(prevs []*block.Header, header *block.Header, i int) error {
+	var (
+		offset = uint64(i) * (req.skip + 1)
+		expBN  uint64
+	)
+	if req.reverse {
+		if offset > req.from {
+			return fmt.Errorf("unexpected header %v", header.Number())
+		}
+		expBN = req.from - offset
+	} else {
+		expBN = req.from + offset
+	}
+	if header.Number().Uint64() != expBN {
+		return fmt.Errorf("unexpected header number: %v / %v", header.Number(), expBN)
+	}
+	if req.skip != 0 || i == 0 {
+		return nil
+	}
+	prev := prevs[i-1]
+	if req.reverse && prev.ParentHash() != header.Hash() {
+		return fmt.Errorf("header %v not linked", header.Number())
+	}
+	if !req.reverse && header.ParentHash() != prev.Hash() {
+		return fmt.Errorf("header %v not linked", header.Number())
+	}
+	return nil
+}
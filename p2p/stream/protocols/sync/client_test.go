@@ -22,6 +22,12 @@ import (
 var (
 	_ sttypes.Request  = &getBlocksByNumberRequest{}
 	_ sttypes.Request  = &getBlockNumberRequest{}
+	_ sttypes.Request  = &getAccountRangeRequest{}
+	_ sttypes.Request  = &getStorageRangesRequest{}
+	_ sttypes.Request  = &getByteCodesRequest{}
+	_ sttypes.Request  = &getTrieNodesRequest{}
+	_ sttypes.Request  = &getReceiptsRequest{}
+	_ sttypes.Request  = &getBlockHeadersRequest{}
 	_ sttypes.Response = &syncResponse{&syncpb.Response{}}
 )
 
@@ -49,9 +55,36 @@ var (
 
 	testBlocksByHashesResponse = syncpb.MakeGetBlocksByHashesResponse(0, [][]byte{testBlockBytes}, make([][]byte, 1))
 
-	testErrorResponse = syncpb.MakeErrorResponse(0, errors.New("test error"))
+	testAccountRangeResponse = syncpb.MakeGetAccountRangeResponse(0, []*syncpb.AccountData{
+		{Hash: testAccountHashes[0][:], Body: testAccountBodies[0]},
+		{Hash: testAccountHashes[1][:], Body: testAccountBodies[1]},
+	}, nil)
+	testDisorderedAccountRangeResponse = syncpb.MakeGetAccountRangeResponse(0, []*syncpb.AccountData{
+		{Hash: testAccountHashes[1][:], Body: testAccountBodies[1]},
+		{Hash: testAccountHashes[0][:], Body: testAccountBodies[0]},
+	}, nil)
+
+	testByteCodesResponse           = syncpb.MakeGetByteCodesResponse(0, [][]byte{testStateData[0], nil})
+	testMismatchedByteCodesResponse = syncpb.MakeGetByteCodesResponse(0, [][]byte{testStateData[1]})
+
+	testReceiptsBytes, _ = rlp.EncodeToBytes(makeTestReceipts(100))
+	testReceiptsResponse = syncpb.MakeGetReceiptsResponse(0, [][]byte{testReceiptsBytes, nil})
+
+	testHeadersResponse         = makeTestHeadersResponse([]uint64{100, 102, 104})
+	testMismatchHeadersResponse = makeTestHeadersResponse([]uint64{100, 101, 102})
+	testErrorResponse           = syncpb.MakeErrorResponse(0, errors.New("test error"))
 )
 
+func makeTestHeadersResponse(bns []uint64) *syncpb.Response {
+	var headersBytes, sigs [][]byte
+	for _, bn := range bns {
+		hb, _ := rlp.EncodeToBytes(makeTestBlock(bn).Header())
+		headersBytes = append(headersBytes, hb)
+		sigs = append(sigs, make([]byte, 96))
+	}
+	return syncpb.MakeGetBlockHeadersResponse(0, headersBytes, sigs)
+}
+
 func TestProtocol_GetBlocksByNumber(t *testing.T) {
 	tests := []struct {
 		getResponse getResponseFn
@@ -289,6 +322,272 @@ func TestProtocol_GetBlocksByHashes(t *testing.T) {
 	}
 }
 
+func TestProtocol_GetReceipts(t *testing.T) {
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testReceiptsResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("not GetReceipts"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		receipts, stid, err := protocol.GetReceipts(context.Background(), []common.Hash{numberToHash(100), numberToHash(101)})
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(receipts) != 2 {
+				t.Errorf("Test %v: size not 2", i)
+			}
+			if len(receipts[0]) != 1 || receipts[1] != nil {
+				t.Errorf("Test %v: unexpected receipts", i)
+			}
+		}
+	}
+}
+
+func TestProtocol_GetBlockHeaders(t *testing.T) {
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testHeadersResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testMismatchHeadersResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("unexpected header number"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("not GetBlockHeaders"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		headers, sigs, stid, err := protocol.GetBlockHeaders(context.Background(), 100, 3, 1, false)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(headers) != 3 || len(sigs) != 3 {
+				t.Errorf("Test %v: size not 3", i)
+			}
+		}
+	}
+}
+
+func TestProtocol_GetAccountRange(t *testing.T) {
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testAccountRangeResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testDisorderedAccountRangeResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("not in ascending order"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("not GetAccountRange"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		hashes, accounts, _, stid, err := protocol.GetAccountRange(context.Background(), common.Hash{}, common.Hash{}, maxHash, StateResponseBytesCap)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(hashes) != 2 || len(accounts) != 2 {
+				t.Errorf("Test %v: size not 2", i)
+			}
+		}
+	}
+}
+
+func TestProtocol_GetByteCodes(t *testing.T) {
+	tests := []struct {
+		getResponse getResponseFn
+		expErr      error
+		expStID     sttypes.StreamID
+	}{
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testByteCodesResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  nil,
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testMismatchedByteCodesResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("unexpected data hash"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testBlockResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("not GetByteCodes"),
+			expStID: makeTestStreamID(0),
+		},
+		{
+			getResponse: nil,
+			expErr:      errors.New("get response error"),
+			expStID:     "",
+		},
+		{
+			getResponse: func(request sttypes.Request) (sttypes.Response, sttypes.StreamID) {
+				return &syncResponse{
+					pb: testErrorResponse,
+				}, makeTestStreamID(0)
+			},
+			expErr:  errors.New("test error"),
+			expStID: makeTestStreamID(0),
+		},
+	}
+
+	for i, test := range tests {
+		protocol := makeTestProtocol(test.getResponse)
+		codes, stid, err := protocol.GetByteCodes(context.Background(), testStateDataHashes[:2], StateResponseBytesCap)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+			continue
+		}
+		if stid != test.expStID {
+			t.Errorf("Test %v: unexpected st id: %v / %v", i, stid, test.expStID)
+		}
+		if test.expErr == nil {
+			if len(codes) != 2 {
+				t.Errorf("Test %v: size not 2", i)
+			}
+		}
+	}
+}
+
 type getResponseFn func(request sttypes.Request) (sttypes.Response, sttypes.StreamID)
 
 type testHostRequestManager struct {
@@ -17,6 +17,29 @@ const (
 	// See comments for GetBlocksByNumAmountCap.
 	GetBlocksByHashesAmountCap = 10
 
+	// GetReceiptsAmountCap is the cap of block hashes in a single GetReceipts request.
+	// Receipts of a block are assumed to be no larger than the block itself, so the cap
+	// is the same as GetBlocksByHashesAmountCap. See comments for GetBlocksByNumAmountCap.
+	GetReceiptsAmountCap = 10
+
+	// GetBlockHeadersAmountCap is the cap of headers in a single GetBlockHeaders request
+	GetBlockHeadersAmountCap = 50
+
+	// GetStorageRangesAccountsCap is the cap of accounts in a single GetStorageRanges request
+	GetStorageRangesAccountsCap = 128
+
+	// GetByteCodesAmountCap is the cap of code hashes in a single GetByteCodes request
+	GetByteCodesAmountCap = 128
+
+	// GetTrieNodesAmountCap is the cap of trie node hashes in a single GetTrieNodes request
+	GetTrieNodesAmountCap = 512
+
+	// StateResponseBytesCap is the soft cap of the payload size of a single response of the
+	// state sync requests (GetAccountRange, GetStorageRanges, GetByteCodes, GetTrieNodes).
+	// The server stops filling the response once the cap is reached, so the actual size of
+	// the response might slightly exceed this value, but is far below maxMsgBytes.
+	StateResponseBytesCap = 2 * 1024 * 1024
+
 	// minAdvertiseInterval is the minimum advertise interval
 	minAdvertiseInterval = 1 * time.Minute
 
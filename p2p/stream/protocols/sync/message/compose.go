@@ -46,6 +46,84 @@ func MakeGetBlocksByHashesRequest(hashes []common.Hash) *Request {
 	}
 }
 
+// MakeGetAccountRangeRequest makes the GetAccountRange request
+func MakeGetAccountRangeRequest(root, origin, limit common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetAccountRangeRequest{
+			GetAccountRangeRequest: &GetAccountRangeRequest{
+				Root:   root[:],
+				Origin: origin[:],
+				Limit:  limit[:],
+				Bytes:  bytes,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangesRequest makes the GetStorageRanges request
+func MakeGetStorageRangesRequest(root common.Hash, accounts []common.Hash, origin, limit common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetStorageRangesRequest{
+			GetStorageRangesRequest: &GetStorageRangesRequest{
+				Root:     root[:],
+				Accounts: hashesToBytes(accounts),
+				Origin:   origin[:],
+				Limit:    limit[:],
+				Bytes:    bytes,
+			},
+		},
+	}
+}
+
+// MakeGetByteCodesRequest makes the GetByteCodes request
+func MakeGetByteCodesRequest(hashes []common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetByteCodesRequest{
+			GetByteCodesRequest: &GetByteCodesRequest{
+				Hashes: hashesToBytes(hashes),
+				Bytes:  bytes,
+			},
+		},
+	}
+}
+
+// MakeGetTrieNodesRequest makes the GetTrieNodes request
+func MakeGetTrieNodesRequest(hashes []common.Hash, bytes uint64) *Request {
+	return &Request{
+		Request: &Request_GetTrieNodesRequest{
+			GetTrieNodesRequest: &GetTrieNodesRequest{
+				Hashes: hashesToBytes(hashes),
+				Bytes:  bytes,
+			},
+		},
+	}
+}
+
+// MakeGetReceiptsRequest makes the GetReceipts request
+func MakeGetReceiptsRequest(hashes []common.Hash) *Request {
+	return &Request{
+		Request: &Request_GetReceiptsRequest{
+			GetReceiptsRequest: &GetReceiptsRequest{
+				BlockHashes: hashesToBytes(hashes),
+			},
+		},
+	}
+}
+
+// MakeGetBlockHeadersRequest makes the GetBlockHeaders request
+func MakeGetBlockHeadersRequest(from, count, skip uint64, reverse bool) *Request {
+	return &Request{
+		Request: &Request_GetBlockHeadersRequest{
+			GetBlockHeadersRequest: &GetBlockHeadersRequest{
+				From:    from,
+				Count:   count,
+				Skip:    skip,
+				Reverse: reverse,
+			},
+		},
+	}
+}
+
 // MakeErrorResponse makes the error response
 func MakeErrorResponseMessage(rid uint64, err error) *Message {
 	resp := MakeErrorResponse(rid, err)
@@ -138,6 +216,80 @@ func MakeGetBlocksByHashesResponse(rid uint64, blocksBytes [][]byte, sigs [][]by
 	}
 }
 
+// MakeGetAccountRangeResponseMessage makes the GetAccountRangeResponse of Message type
+func MakeGetAccountRangeResponseMessage(rid uint64, accounts []*AccountData, proof [][]byte) *Message {
+	resp := MakeGetAccountRangeResponse(rid, accounts, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetAccountRangeResponse makes the GetAccountRangeResponse of Response type
+func MakeGetAccountRangeResponse(rid uint64, accounts []*AccountData, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetAccountRangeResponse{
+			GetAccountRangeResponse: &GetAccountRangeResponse{
+				Accounts: accounts,
+				Proof:    proof,
+			},
+		},
+	}
+}
+
+// MakeGetStorageRangesResponseMessage makes the GetStorageRangesResponse of Message type
+func MakeGetStorageRangesResponseMessage(rid uint64, slots []*StoragesPerAccount, proof [][]byte) *Message {
+	resp := MakeGetStorageRangesResponse(rid, slots, proof)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetStorageRangesResponse makes the GetStorageRangesResponse of Response type
+func MakeGetStorageRangesResponse(rid uint64, slots []*StoragesPerAccount, proof [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetStorageRangesResponse{
+			GetStorageRangesResponse: &GetStorageRangesResponse{
+				Slots: slots,
+				Proof: proof,
+			},
+		},
+	}
+}
+
+// MakeGetByteCodesResponseMessage makes the GetByteCodesResponse of Message type
+func MakeGetByteCodesResponseMessage(rid uint64, codes [][]byte) *Message {
+	resp := MakeGetByteCodesResponse(rid, codes)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetByteCodesResponse makes the GetByteCodesResponse of Response type
+func MakeGetByteCodesResponse(rid uint64, codes [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetByteCodesResponse{
+			GetByteCodesResponse: &GetByteCodesResponse{
+				Codes: codes,
+			},
+		},
+	}
+}
+
+// MakeGetTrieNodesResponseMessage makes the GetTrieNodesResponse of Message type
+func MakeGetTrieNodesResponseMessage(rid uint64, nodes [][]byte) *Message {
+	resp := MakeGetTrieNodesResponse(rid, nodes)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetTrieNodesResponse makes the GetTrieNodesResponse of Response type
+func MakeGetTrieNodesResponse(rid uint64, nodes [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetTrieNodesResponse{
+			GetTrieNodesResponse: &GetTrieNodesResponse{
+				Nodes: nodes,
+			},
+		},
+	}
+}
+
 // MakeMessageFromRequest makes a message from the request
 func MakeMessageFromRequest(req *Request) *Message {
 	return &Message{
@@ -147,6 +299,43 @@ func MakeMessageFromRequest(req *Request) *Message {
 	}
 }
 
+// MakeGetReceiptsResponseMessage makes the GetReceiptsResponse of Message type
+func MakeGetReceiptsResponseMessage(rid uint64, receiptsBytes [][]byte) *Message {
+	resp := MakeGetReceiptsResponse(rid, receiptsBytes)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetReceiptsResponse makes the GetReceiptsResponse of Response type
+func MakeGetReceiptsResponse(rid uint64, receiptsBytes [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetReceiptsResponse{
+			GetReceiptsResponse: &GetReceiptsResponse{
+				ReceiptsBytes: receiptsBytes,
+			},
+		},
+	}
+}
+
+// MakeGetBlockHeadersResponseMessage makes the GetBlockHeadersResponse of Message type
+func MakeGetBlockHeadersResponseMessage(rid uint64, headersBytes [][]byte, sigs [][]byte) *Message {
+	resp := MakeGetBlockHeadersResponse(rid, headersBytes, sigs)
+	return makeMessageFromResponse(resp)
+}
+
+// MakeGetBlockHeadersResponse makes the GetBlockHeadersResponse of Response type
+func MakeGetBlockHeadersResponse(rid uint64, headersBytes [][]byte, sigs [][]byte) *Response {
+	return &Response{
+		ReqId: rid,
+		Response: &Response_GetBlockHeadersResponse{
+			GetBlockHeadersResponse: &GetBlockHeadersResponse{
+				HeadersBytes: headersBytes,
+				CommitSig:    sigs,
+			},
+		},
+	}
+}
+
 func makeMessageFromResponse(resp *Response) *Message {
 	return &Message{
 		ReqOrResp: &Message_Resp{
@@ -79,3 +79,99 @@ func (msg *Message) GetBlocksByHashesResponse() (*GetBlocksByHashesResponse, err
 	}
 	return gbResp, nil
 }
+
+// GetAccountRangeResponse parse the message to GetAccountRangeResponse
+func (msg *Message) GetAccountRangeResponse() (*GetAccountRangeResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gsResp := resp.GetGetAccountRangeResponse()
+	if gsResp == nil {
+		return nil, errors.New("not GetAccountRangeResponse")
+	}
+	return gsResp, nil
+}
+
+// GetStorageRangesResponse parse the message to GetStorageRangesResponse
+func (msg *Message) GetStorageRangesResponse() (*GetStorageRangesResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gsResp := resp.GetGetStorageRangesResponse()
+	if gsResp == nil {
+		return nil, errors.New("not GetStorageRangesResponse")
+	}
+	return gsResp, nil
+}
+
+// GetByteCodesResponse parse the message to GetByteCodesResponse
+func (msg *Message) GetByteCodesResponse() (*GetByteCodesResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gsResp := resp.GetGetByteCodesResponse()
+	if gsResp == nil {
+		return nil, errors.New("not GetByteCodesResponse")
+	}
+	return gsResp, nil
+}
+
+// GetTrieNodesResponse parse the message to GetTrieNodesResponse
+func (msg *Message) GetTrieNodesResponse() (*GetTrieNodesResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	gsResp := resp.GetGetTrieNodesResponse()
+	if gsResp == nil {
+		return nil, errors.New("not GetTrieNodesResponse")
+	}
+	return gsResp, nil
+}
+
+// GetReceiptsResponse parse the message to GetReceiptsResponse
+func (msg *Message) GetReceiptsResponse() (*GetReceiptsResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	grResp := resp.GetGetReceiptsResponse()
+	if grResp == nil {
+		return nil, errors.New("not GetReceiptsResponse")
+	}
+	return grResp, nil
+}
+
+// GetBlockHeadersResponse parse the message to GetBlockHeadersResponse
+func (msg *Message) GetBlockHeadersResponse() (*GetBlockHeadersResponse, error) {
+	resp := msg.GetResp()
+	if resp == nil {
+		return nil, errors.New("not response message")
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, &ResponseError{errResp.Error}
+	}
+	bhResp := resp.GetGetBlockHeadersResponse()
+	if bhResp == nil {
+		return nil, errors.New("not GetBlockHeadersResponse")
+	}
+	return bhResp, nil
+}
@@ -28,12 +28,17 @@ const (
 
 var (
 	version100, _ = version.NewVersion("1.0.0")
+	version110, _ = version.NewVersion("1.1.0")
 
 	// MyVersion is the version of sync protocol of the local node
-	MyVersion = version100
+	MyVersion = version110
 
 	// MinVersion is the minimum version for matching function
 	MinVersion = version100
+
+	// StateSyncMinVersion is the minimum version which supports the state sync requests,
+	// which are GetAccountRange, GetStorageRanges, GetByteCodes and GetTrieNodes.
+	StateSyncMinVersion = version110
 )
 
 type (
@@ -210,7 +215,7 @@ func (p *Protocol) supportedProtoIDs() []sttypes.ProtoID {
 }
 
 func (p *Protocol) supportedVersions() []*version.Version {
-	return []*version.Version{version100}
+	return []*version.Version{version100, version110}
 }
 
 func (p *Protocol) protoIDByVersion(v *version.Version) sttypes.ProtoID {
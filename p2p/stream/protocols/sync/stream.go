@@ -182,6 +182,24 @@ func (st *syncStream) handleReq(req *syncpb.Request) error {
 	if bhReq := req.GetGetBlocksByHashesRequest(); bhReq != nil {
 		return st.handleGetBlocksByHashesRequest(req.ReqId, bhReq)
 	}
+	if arReq := req.GetGetAccountRangeRequest(); arReq != nil {
+		return st.handleGetAccountRangeRequest(req.ReqId, arReq)
+	}
+	if srReq := req.GetGetStorageRangesRequest(); srReq != nil {
+		return st.handleGetStorageRangesRequest(req.ReqId, srReq)
+	}
+	if bcReq := req.GetGetByteCodesRequest(); bcReq != nil {
+		return st.handleGetByteCodesRequest(req.ReqId, bcReq)
+	}
+	if tnReq := req.GetGetTrieNodesRequest(); tnReq != nil {
+		return st.handleGetTrieNodesRequest(req.ReqId, tnReq)
+	}
+	if grReq := req.GetGetReceiptsRequest(); grReq != nil {
+		return st.handleGetReceiptsRequest(req.ReqId, grReq)
+	}
+	if bhReq := req.GetGetBlockHeadersRequest(); bhReq != nil {
+		return st.handleGetBlockHeadersRequest(req.ReqId, bhReq)
+	}
 	// unsupported request type
 	return st.handleUnknownRequest(req.ReqId)
 }
@@ -260,6 +278,104 @@ func (st *syncStream) handleGetBlocksByHashesRequest(rid uint64, req *syncpb.Get
 	return errors.Wrap(err, "[GetBlocksByHashes]")
 }
 
+func (st *syncStream) handleGetAccountRangeRequest(rid uint64, req *syncpb.GetAccountRangeRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getAccountRange",
+	}).Inc()
+
+	resp, err := st.computeGetAccountRangeResp(rid, req)
+	if err != nil {
+		// The requested state might not be available at local node. Reply with the
+		// error without closing the stream.
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if err := st.writeMsg(resp); err != nil {
+		return errors.Wrap(err, "[GetAccountRange]: writeMsg")
+	}
+	return nil
+}
+
+func (st *syncStream) handleGetStorageRangesRequest(rid uint64, req *syncpb.GetStorageRangesRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getStorageRanges",
+	}).Inc()
+
+	resp, err := st.computeGetStorageRangesResp(rid, req)
+	if err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if err := st.writeMsg(resp); err != nil {
+		return errors.Wrap(err, "[GetStorageRanges]: writeMsg")
+	}
+	return nil
+}
+
+func (st *syncStream) handleGetByteCodesRequest(rid uint64, req *syncpb.GetByteCodesRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getByteCodes",
+	}).Inc()
+
+	resp, err := st.computeGetByteCodesResp(rid, req)
+	if err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if err := st.writeMsg(resp); err != nil {
+		return errors.Wrap(err, "[GetByteCodes]: writeMsg")
+	}
+	return nil
+}
+
+func (st *syncStream) handleGetTrieNodesRequest(rid uint64, req *syncpb.GetTrieNodesRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getTrieNodes",
+	}).Inc()
+
+	resp, err := st.computeGetTrieNodesResp(rid, req)
+	if err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if err := st.writeMsg(resp); err != nil {
+		return errors.Wrap(err, "[GetTrieNodes]: writeMsg")
+	}
+	return nil
+}
+
+func (st *syncStream) handleGetReceiptsRequest(rid uint64, req *syncpb.GetReceiptsRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getReceipts",
+	}).Inc()
+
+	resp, err := st.computeGetReceiptsResp(rid, req)
+	if err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if err := st.writeMsg(resp); err != nil {
+		return errors.Wrap(err, "[GetReceipts]: writeMsg")
+	}
+	return nil
+}
+
+func (st *syncStream) handleGetBlockHeadersRequest(rid uint64, req *syncpb.GetBlockHeadersRequest) error {
+	serverRequestCounterVec.With(prometheus.Labels{
+		"topic":        string(st.ProtoID()),
+		"request_type": "getBlockHeaders",
+	}).Inc()
+
+	resp, err := st.computeGetBlockHeadersResp(rid, req)
+	if err != nil {
+		resp = syncpb.MakeErrorResponseMessage(rid, err)
+	}
+	if err := st.writeMsg(resp); err != nil {
+		return errors.Wrap(err, "[GetBlockHeaders]: writeMsg")
+	}
+	return nil
+}
+
 func (st *syncStream) handleUnknownRequest(rid uint64) error {
 	serverRequestCounterVec.With(prometheus.Labels{
 		"topic":        string(st.ProtoID()),
@@ -367,6 +483,103 @@ func (st *syncStream) computeRespFromBlockHashes(rid uint64, hs []common.Hash) (
 	return syncpb.MakeGetBlocksByHashesResponseMessage(rid, blocksBytes, sigs), nil
 }
 
+func (st *syncStream) computeGetAccountRangeResp(rid uint64, req *syncpb.GetAccountRangeRequest) (*syncpb.Message, error) {
+	var (
+		root   = common.BytesToHash(req.Root)
+		origin = common.BytesToHash(req.Origin)
+		limit  = common.BytesToHash(req.Limit)
+	)
+	accounts, proof, err := st.chain.getAccountRange(root, origin, limit, req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetAccountRangeResponseMessage(rid, accounts, proof), nil
+}
+
+func (st *syncStream) computeGetStorageRangesResp(rid uint64, req *syncpb.GetStorageRangesRequest) (*syncpb.Message, error) {
+	if len(req.Accounts) > GetStorageRangesAccountsCap {
+		err := fmt.Errorf("GetStorageRanges amount exceed cap: %v > %v", len(req.Accounts), GetStorageRangesAccountsCap)
+		return nil, err
+	}
+	var (
+		root     = common.BytesToHash(req.Root)
+		accounts = bytesToHashes(req.Accounts)
+		origin   = common.BytesToHash(req.Origin)
+		limit    = common.BytesToHash(req.Limit)
+	)
+	slots, proof, err := st.chain.getStorageRanges(root, accounts, origin, limit, req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetStorageRangesResponseMessage(rid, slots, proof), nil
+}
+
+func (st *syncStream) computeGetByteCodesResp(rid uint64, req *syncpb.GetByteCodesRequest) (*syncpb.Message, error) {
+	if len(req.Hashes) > GetByteCodesAmountCap {
+		err := fmt.Errorf("GetByteCodes amount exceed cap: %v > %v", len(req.Hashes), GetByteCodesAmountCap)
+		return nil, err
+	}
+	codes, err := st.chain.getByteCodes(bytesToHashes(req.Hashes), req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetByteCodesResponseMessage(rid, codes), nil
+}
+
+func (st *syncStream) computeGetTrieNodesResp(rid uint64, req *syncpb.GetTrieNodesRequest) (*syncpb.Message, error) {
+	if len(req.Hashes) > GetTrieNodesAmountCap {
+		err := fmt.Errorf("GetTrieNodes amount exceed cap: %v > %v", len(req.Hashes), GetTrieNodesAmountCap)
+		return nil, err
+	}
+	nodes, err := st.chain.getTrieNodes(bytesToHashes(req.Hashes), req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return syncpb.MakeGetTrieNodesResponseMessage(rid, nodes), nil
+}
+
+func (st *syncStream) computeGetReceiptsResp(rid uint64, req *syncpb.GetReceiptsRequest) (*syncpb.Message, error) {
+	if len(req.BlockHashes) > GetReceiptsAmountCap {
+		err := fmt.Errorf("GetReceipts amount exceed cap: %v > %v", len(req.BlockHashes), GetReceiptsAmountCap)
+		return nil, err
+	}
+	receipts, err := st.chain.getReceipts(bytesToHashes(req.BlockHashes))
+	if err != nil {
+		return nil, err
+	}
+	receiptsBytes := make([][]byte, 0, len(receipts))
+	for _, rs := range receipts {
+		var rb []byte
+		if rs != nil {
+			if rb, err = rlp.EncodeToBytes(rs); err != nil {
+				return nil, err
+			}
+		}
+		receiptsBytes = append(receiptsBytes, rb)
+	}
+	return syncpb.MakeGetReceiptsResponseMessage(rid, receiptsBytes), nil
+}
+
+func (st *syncStream) computeGetBlockHeadersResp(rid uint64, req *syncpb.GetBlockHeadersRequest) (*syncpb.Message, error) {
+	if req.Count > GetBlockHeadersAmountCap {
+		err := fmt.Errorf("GetBlockHeaders amount exceed cap: %v > %v", req.Count, GetBlockHeadersAmountCap)
+		return nil, err
+	}
+	headers, sigs, err := st.chain.getBlockHeaders(req.From, req.Count, req.Skip, req.Reverse)
+	if err != nil {
+		return nil, err
+	}
+	headersBytes := make([][]byte, 0, len(headers))
+	for _, header := range headers {
+		hb, err := rlp.EncodeToBytes(header)
+		if err != nil {
+			return nil, err
+		}
+		headersBytes = append(headersBytes, hb)
+	}
+	return syncpb.MakeGetBlockHeadersResponseMessage(rid, headersBytes, sigs), nil
+}
+
 func bytesToHashes(bs [][]byte) []common.Hash {
 	hs := make([]common.Hash, 0, len(bs))
 	for _, b := range bs {
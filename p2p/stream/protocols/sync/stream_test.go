@@ -40,6 +40,20 @@ var (
 	}
 	testGetBlocksByHashesRequest    = syncpb.MakeGetBlocksByHashesRequest(testGetBlockByHashes)
 	testGetBlocksByHashesRequestMsg = syncpb.MakeMessageFromRequest(testGetBlocksByHashesRequest)
+
+	testGetReceiptsRequest    = syncpb.MakeGetReceiptsRequest(testGetBlockByHashes)
+	testGetReceiptsRequestMsg = syncpb.MakeMessageFromRequest(testGetReceiptsRequest)
+
+	testGetBlockHeadersRequest    = syncpb.MakeGetBlockHeadersRequest(10, 5, 1, true)
+	testGetBlockHeadersRequestMsg = syncpb.MakeMessageFromRequest(testGetBlockHeadersRequest)
+	testGetBlockHeadersNums       = []uint64{10, 8, 6, 4, 2}
+
+	testGetAccountRangeRequest    = syncpb.MakeGetAccountRangeRequest(common.Hash{}, common.Hash{}, maxHash, StateResponseBytesCap)
+	testGetAccountRangeRequestMsg = syncpb.MakeMessageFromRequest(testGetAccountRangeRequest)
+
+	testGetByteCodesHashes     = append(testStateDataHashes, numberToHash(100))
+	testGetByteCodesRequest    = syncpb.MakeGetByteCodesRequest(testGetByteCodesHashes, StateResponseBytesCap)
+	testGetByteCodesRequestMsg = syncpb.MakeMessageFromRequest(testGetByteCodesRequest)
 )
 
 func TestSyncStream_HandleGetBlocksByRequest(t *testing.T) {
@@ -126,6 +140,90 @@ func TestSyncStream_HandleGetBlocksByHashes(t *testing.T) {
 	}
 }
 
+func TestSyncStream_HandleGetReceipts(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetReceiptsRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkReceiptsResult(receivedBytes, testGetBlockByHashes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetBlockHeaders(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetBlockHeadersRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkBlockHeadersResult(receivedBytes, testGetBlockHeadersNums); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetAccountRange(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetAccountRangeRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkAccountRangeResult(receivedBytes); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncStream_HandleGetByteCodes(t *testing.T) {
+	st, remoteSt := makeTestSyncStream()
+
+	go st.run()
+	defer close(st.closeC)
+
+	req := testGetByteCodesRequestMsg
+	b, _ := protobuf.Marshal(req)
+	err := remoteSt.WriteBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	receivedBytes, _ := remoteSt.ReadBytes()
+
+	if err := checkByteCodesResult(receivedBytes, testGetByteCodesHashes); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func makeTestSyncStream() (*syncStream, *testRemoteBaseStream) {
 	localRaw, remoteRaw := makePairP2PStreams()
 	remote := newTestRemoteBaseStream(remoteRaw)
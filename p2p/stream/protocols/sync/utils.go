@@ -3,6 +3,7 @@ package sync
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	protobuf "github.com/golang/protobuf/proto"
 	"github.com/harmony-one/harmony/p2p/stream/common/requestmanager"
 	syncpb "github.com/harmony-one/harmony/p2p/stream/protocols/sync/message"
@@ -50,3 +51,31 @@ var (
 	// given streamID
 	WithWhitelist = requestmanager.WithWhitelist
 )
+
+// proofList is the list of the merkle proof nodes which implements ethdb.KeyValueWriter.
+// Duplicate nodes are only recorded once.
+type proofList struct {
+	nodes [][]byte
+	keys  map[string]struct{}
+}
+
+func newProofList() *proofList {
+	return &proofList{
+		keys: make(map[string]struct{}),
+	}
+}
+
+// Put add the proof node to the list
+func (pl *proofList) Put(key []byte, value []byte) error {
+	if _, ok := pl.keys[string(key)]; ok {
+		return nil
+	}
+	pl.keys[string(key)] = struct{}{}
+	pl.nodes = append(pl.nodes, common.CopyBytes(value))
+	return nil
+}
+
+// Delete is not supported by proofList
+func (pl *proofList) Delete(key []byte) error {
+	return errors.New("delete not supported")
+}
@@ -232,15 +232,18 @@ func (s *ConstructAPI) ConstructionMetadata(
 	var estGasUsed uint64
 	if !isStakingOperation(options.OperationType) {
 		if options.OperationType == common.ContractCreationOperation {
-			estGasUsed, err = rpc.EstimateGas(ctx, s.hmy, rpc.CallArgs{From: senderAddr, Data: &data}, nil)
+			estGasUsed, err = rpc.EstimateGas(
+				ctx, s.hmy, rpc.CallArgs{From: senderAddr, Data: &data}, ethRpc.LatestBlockNumber, nil, nil,
+			)
 			estGasUsed *= 2 // HACK to account for imperfect contract creation estimation
 		} else {
 			estGasUsed, err = rpc.EstimateGas(
-				ctx, s.hmy, rpc.CallArgs{From: senderAddr, To: &contractAddress, Data: &data}, nil,
+				ctx, s.hmy, rpc.CallArgs{From: senderAddr, To: &contractAddress, Data: &data},
+				ethRpc.LatestBlockNumber, nil, nil,
 			)
 		}
 	} else {
-		estGasUsed, err = core.IntrinsicGas(data, false, false,
+		estGasUsed, err = core.IntrinsicGas(data, nil, false, false,
 			false, options.OperationType == common.CreateValidatorOperation)
 		estGasUsed *= 2
 
@@ -270,7 +273,7 @@ func (s *ConstructAPI) ConstructionMetadata(
 			callArgs.To = &contractAddress
 		}
 		evmExe, err := rpc.DoEVMCall(
-			ctx, s.hmy, callArgs, ethRpc.LatestBlockNumber, rpc.CallTimeout,
+			ctx, s.hmy, callArgs, ethRpc.LatestBlockNumber, nil, nil, rpc.CallTimeout,
 		)
 		if err != nil {
 			return nil, common.NewError(common.CatchAllError, map[string]interface{}{
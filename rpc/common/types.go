@@ -3,6 +3,9 @@ package common
 import (
 	"encoding/json"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 
@@ -84,6 +87,40 @@ type NodePeerInfo struct {
 	P            []P       `json:"connected-peers"`
 }
 
+// TxHistoryQuery is the query of a page of the transaction history of an address
+// indexed by the explorer node
+type TxHistoryQuery struct {
+	Address   string
+	Staking   bool
+	Direction string
+	FromBlock uint64
+	ToBlock   uint64
+	Desc      bool
+	PageSize  uint32
+	Offset    uint32 // number of entries skipped before the page
+	Cursor    string
+}
+
+// TokenTransfer is a HRC20, HRC721 or HRC1155 token transfer indexed by the explorer node
+type TokenTransfer struct {
+	Token       string       `json:"token"`
+	Standard    string       `json:"standard"`
+	From        string       `json:"from"`
+	To          string       `json:"to"`
+	TokenID     *hexutil.Big `json:"tokenId"`
+	Value       *hexutil.Big `json:"value"`
+	TxHash      common.Hash  `json:"transactionHash"`
+	BlockNumber uint64       `json:"blockNumber"`
+	LogIndex    uint64       `json:"logIndex"`
+	BatchIndex  uint64       `json:"batchIndex"`
+}
+
+// TokenHolder is a holder of the token with the balance indexed by the explorer node
+type TokenHolder struct {
+	Address string       `json:"address"`
+	Balance *hexutil.Big `json:"balance"`
+}
+
 type Config struct {
 	HarmonyConfig harmonyconfig.HarmonyConfig
 	NodeConfig    nodeconfig.ConfigType
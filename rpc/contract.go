@@ -9,16 +9,26 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/harmony-one/harmony/accounts/abi"
+	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/hmy"
 	hmyCommon "github.com/harmony-one/harmony/internal/common"
 	"github.com/harmony-one/harmony/internal/utils"
+	eth "github.com/harmony-one/harmony/rpc/eth"
+	v1 "github.com/harmony-one/harmony/rpc/v1"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
+	"github.com/pkg/errors"
 )
 
 const (
 	defaultGasPrice    = denominations.Nano
 	defaultFromAddress = "0x0000000000000000000000000000000000000000"
+	// maxBundleSize is the max number of the items of a simulated bundle
+	maxBundleSize = 100
 )
 
 // PublicContractService provides an API to access Harmony's contract services.
@@ -40,14 +50,18 @@ func NewPublicContractAPI(hmy *hmy.Harmony, version Version) rpc.API {
 
 // Call executes the given transaction on the state for the given block number.
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
+//
+// Additionally, the caller can specify a batch of contract for fields overriding,
+// and the fields of the block to run the call in.
 func (s *PublicContractService) Call(
 	ctx context.Context, args CallArgs, blockNumber BlockNumber,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
 ) (hexutil.Bytes, error) {
 	// Process number based on version
 	blockNum := blockNumber.EthBlockNumber()
 
 	// Execute call
-	result, err := DoEVMCall(ctx, s.hmy, args, blockNum, CallTimeout)
+	result, err := DoEVMCall(ctx, s.hmy, args, blockNum, overrides, blockOverrides, CallTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -111,10 +125,194 @@ func (s *PublicContractService) GetStorageAt(
 	return res[:], state.Error()
 }
 
-// DoEVMCall executes an EVM call
+// GetProof returns the EIP-1186 merkle proof of the account and the given storage
+// keys at the given block number. The proof can be verified against the state
+// root of the block header.
+func (s *PublicContractService) GetProof(
+	ctx context.Context, addr string, storageKeys []string, blockNumber BlockNumber,
+) (interface{}, error) {
+	timer := DoMetricRPCRequest(GetProof)
+	defer DoRPCRequestDuration(GetProof, timer)
+
+	// Process number based on version
+	blockNum := blockNumber.EthBlockNumber()
+
+	// Fetch proof
+	address, err := hmyCommon.ParseAddr(addr)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetProof, FailedNumber)
+		return nil, err
+	}
+	keys := make([]common.Hash, 0, len(storageKeys))
+	for _, key := range storageKeys {
+		keys = append(keys, common.HexToHash(key))
+	}
+	proof, err := s.hmy.GetProof(ctx, address, keys, blockNum)
+	if proof == nil || err != nil {
+		DoMetricRPCQueryInfo(GetProof, FailedNumber)
+		return nil, err
+	}
+
+	// Format the response according to version
+	switch s.version {
+	case V1:
+		return v1.NewAccountResult(proof)
+	case V2:
+		return v2.NewAccountResult(proof)
+	case Eth:
+		return eth.NewAccountResult(proof), nil
+	default:
+		return nil, ErrUnknownRPCVersion
+	}
+}
+
+// SimulateBundle executes the calls and the signed transactions of the bundle in
+// order on top of the state for the given block number, and returns the result
+// of each of them. It doesn't make any changes in the state/blockchain, and is
+// useful to preview the transactions depending on the previous ones. Unlike
+// Call, the senders are not funded, so that the transfers are previewed as well.
+func (s *PublicContractService) SimulateBundle(
+	ctx context.Context, bundle []BundleItem, blockNumber BlockNumber,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
+) ([]*BundleResult, error) {
+	timer := DoMetricRPCRequest(SimulateBundle)
+	defer DoRPCRequestDuration(SimulateBundle, timer)
+
+	if len(bundle) == 0 {
+		return nil, errors.New("empty bundle")
+	}
+	if len(bundle) > maxBundleSize {
+		return nil, errors.Errorf("bundle size %d exceeds the limit %d", len(bundle), maxBundleSize)
+	}
+
+	// Process number based on version
+	blockNum := blockNumber.EthBlockNumber()
+
+	// Fetch state
+	state, header, err := s.hmy.StateAndHeaderByNumber(ctx, blockNum)
+	if err != nil {
+		DoMetricRPCQueryInfo(SimulateBundle, FailedNumber)
+		return nil, err
+	}
+	if state == nil {
+		DoMetricRPCQueryInfo(SimulateBundle, FailedNumber)
+		return nil, errors.New("block not found")
+	}
+	if err := overrides.Apply(state); err != nil {
+		DoMetricRPCQueryInfo(SimulateBundle, FailedNumber)
+		return nil, err
+	}
+
+	// The whole bundle is given the timeout of a call
+	ctx, cancel := context.WithTimeout(ctx, CallTimeout)
+	defer cancel()
+
+	vmConfig := *s.hmy.BlockChain.GetVMConfig()
+	vmConfig.NoBaseFee = true
+	results := make([]*BundleResult, 0, len(bundle))
+	for i, item := range bundle {
+		msg, txHash, err := s.bundleMessage(item, header)
+		if err != nil {
+			DoMetricRPCQueryInfo(SimulateBundle, FailedNumber)
+			return nil, errors.Wrapf(err, "bundle item %d", i)
+		}
+		// The logs of the calls are all kept under the empty hash
+		logsOffset := len(state.GetLogs(txHash))
+		state.Prepare(txHash, header.Hash(), i)
+
+		vmCtx := core.NewEVMContext(msg, header, s.hmy.BlockChain, nil)
+		blockOverrides.Apply(&vmCtx)
+		evm := vm.NewEVM(vmCtx, state, s.hmy.BlockChain.Config(), vmConfig)
+
+		// Cancel the evm on timeout, until the message is applied
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				evm.Cancel()
+			case <-done:
+			}
+		}()
+		result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(math.MaxUint64))
+		close(done)
+		if err != nil {
+			DoMetricRPCQueryInfo(SimulateBundle, FailedNumber)
+			return nil, errors.Wrapf(err, "bundle item %d", i)
+		}
+		if evm.Cancelled() {
+			DoMetricRPCQueryInfo(SimulateBundle, FailedNumber)
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", CallTimeout)
+		}
+		state.Finalise(true)
+
+		res := &BundleResult{
+			ReturnData: result.ReturnData,
+			GasUsed:    hexutil.Uint64(result.UsedGas),
+			Logs:       state.GetLogs(txHash)[logsOffset:],
+		}
+		if item.Raw != nil {
+			res.TxHash = &txHash
+		}
+		if result.VMErr != nil {
+			res.Error = result.VMErr.Error()
+			if reason, err := abi.UnpackRevert(result.Revert()); err == nil {
+				res.RevertReason = reason
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// bundleMessage returns the message of the bundle item to apply on top of the
+// header, along with the transaction hash, or the empty hash for a call.
+func (s *PublicContractService) bundleMessage(
+	item BundleItem, header *block.Header,
+) (core.Message, common.Hash, error) {
+	if item.Raw == nil {
+		return item.ToMessage(s.hmy.RPCGasCap), common.Hash{}, nil
+	}
+	var tx *types.Transaction
+	var txHash common.Hash
+	if s.version == Eth {
+		ethTx := new(types.EthTransaction)
+		if err := ethTx.UnmarshalBinary(*item.Raw); err != nil {
+			return nil, common.Hash{}, err
+		}
+		txHash = ethTx.Hash()
+		tx = ethTx.ConvertToHmy()
+	} else {
+		tx = new(types.Transaction)
+		if err := tx.UnmarshalBinary(*item.Raw); err != nil {
+			return nil, common.Hash{}, err
+		}
+		txHash = tx.Hash()
+	}
+	signer := types.MakeSigner(s.hmy.ChainConfig(), header.Epoch())
+	if tx.IsEthCompatible() {
+		signer = types.MakeEthSigner(s.hmy.ChainConfig(), header.Epoch())
+	}
+	msg, err := tx.AsMessage(signer, header.BaseFee())
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	return msg, txHash, nil
+}
+
+// DoEVMCall executes an EVM call on the state of the block with the optional
+// overrides of the accounts and of the block.
 func DoEVMCall(
 	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNum rpc.BlockNumber,
-	timeout time.Duration,
+	overrides *StateOverride, blockOverrides *BlockOverrides, timeout time.Duration,
+) (core.ExecutionResult, error) {
+	return doEVMCall(ctx, hmy, args, blockNum, overrides, blockOverrides, timeout, nil)
+}
+
+// doEVMCall is DoEVMCall with the given vm config, the chain's one if nil.
+func doEVMCall(
+	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNum rpc.BlockNumber,
+	overrides *StateOverride, blockOverrides *BlockOverrides, timeout time.Duration,
+	vmConfig *vm.Config,
 ) (core.ExecutionResult, error) {
 	timer := DoMetricRPCRequest(DoEvmCall)
 	defer DoRPCRequestDuration(DoEvmCall, timer)
@@ -148,12 +346,19 @@ func DoEVMCall(
 	// this makes sure resources are cleaned up.
 	defer cancel()
 
-	// Get a new instance of the EVM.
-	evm, err := hmy.GetEVM(ctx, msg, state, header)
+	// Get a new instance of the EVM. The block overrides are applied to the
+	// block context before the chain rules of the EVM are set.
+	evm, err := hmy.GetEVM(ctx, msg, state, header, vmConfig, blockOverrides.Apply)
 	if err != nil {
 		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
 		return core.ExecutionResult{}, err
 	}
+	// Apply the overrides after the EVM setup, so that the overridden balance
+	// of the sender is kept.
+	if err := overrides.Apply(state); err != nil {
+		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
+		return core.ExecutionResult{}, err
+	}
 
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -0,0 +1,153 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var (
+	// logger logs the word 1 and stops
+	testLoggerCode = hexutil.MustDecode("0x600160005260206000a000")
+	// reverter reverts with Error("oops")
+	testReverterCode = hexutil.MustDecode("0x6064600c60003960646000fd" +
+		"08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"6f6f707300000000000000000000000000000000000000000000000000000000")
+	// balancer returns the balance of the caller
+	testBalancerCode = hexutil.MustDecode("0x333160005260206000f3")
+)
+
+// newTestContractService returns the contract service of the genesis block, where
+// the sender is funded and the test contracts are deployed.
+func newTestContractService(t *testing.T, sender common.Address) *PublicContractService {
+	gspec := core.Genesis{
+		Config:  params.TestChainConfig,
+		Factory: blockfactory.ForTest,
+		Alloc: core.GenesisAlloc{
+			sender:               {Balance: big.NewInt(1e18)},
+			common.Address{0xaa}: {Code: testLoggerCode, Balance: new(big.Int)},
+			common.Address{0xbb}: {Code: testReverterCode, Balance: new(big.Int)},
+			common.Address{0xcc}: {Code: testBalancerCode, Balance: new(big.Int)},
+		},
+		GasLimit: 1e18,
+	}
+	db := rawdb.NewMemoryDatabase()
+	gspec.MustCommit(db)
+	bc, err := core.NewBlockChain(db, nil, gspec.Config, chain.NewEngine(), vm.Config{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bc.Stop)
+	return &PublicContractService{
+		hmy:     &hmy.Harmony{BlockChain: bc, RPCGasCap: big.NewInt(1e8)},
+		version: V2,
+	}
+}
+
+func TestDoEVMCallBalanceOverride(t *testing.T) {
+	var (
+		from    = common.Address{0xf}
+		to      = common.Address{0xcc}
+		balance = (*hexutil.Big)(big.NewInt(12345))
+	)
+	s := newTestContractService(t, common.Address{})
+
+	// The overridden balance of the sender is kept by the call
+	args := CallArgs{From: &from, To: &to}
+	overrides := &StateOverride{from: {Balance: &balance}}
+	result, err := DoEVMCall(context.Background(), s.hmy, args, 0, overrides, nil, CallTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := new(big.Int).SetBytes(result.ReturnData); have.Cmp(big.NewInt(12345)) != 0 {
+		t.Errorf("unexpected balance of the sender %v", have)
+	}
+}
+
+func TestSimulateBundle(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	s := newTestContractService(t, sender)
+
+	var (
+		logger   = common.Address{0xaa}
+		reverter = common.Address{0xbb}
+		tx0      = signTestTx(t, key, 0, logger)
+		tx1      = signTestTx(t, key, 1, logger)
+	)
+	bundle := []BundleItem{
+		{CallArgs: CallArgs{To: &logger}},
+		{CallArgs: CallArgs{To: &reverter}},
+		{CallArgs: CallArgs{To: &logger}},
+		{Raw: encodeTestTx(t, tx0)},
+		{Raw: encodeTestTx(t, tx1)},
+	}
+	results, err := s.SimulateBundle(context.Background(), bundle, 0, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(bundle) {
+		t.Fatalf("unexpected number of results %v", len(results))
+	}
+	// Each item only has its own logs, even if the calls share the empty hash
+	for _, i := range []int{0, 2, 3, 4} {
+		if len(results[i].Logs) != 1 || results[i].Error != "" {
+			t.Errorf("item %d: unexpected result %+v", i, results[i])
+		}
+	}
+	if r := results[1]; len(r.Logs) != 0 || r.Error != vm.ErrExecutionReverted.Error() || r.RevertReason != "oops" {
+		t.Errorf("unexpected result of the reverted call %+v", r)
+	}
+	for i, tx := range map[int]*types.Transaction{3: tx0, 4: tx1} {
+		if r := results[i]; r.TxHash == nil || *r.TxHash != tx.Hash() || r.Logs[0].TxHash != tx.Hash() {
+			t.Errorf("item %d: unexpected transaction hash of result %+v", i, r)
+		}
+	}
+	if results[0].TxHash != nil {
+		t.Errorf("unexpected transaction hash of call %v", results[0].TxHash.Hex())
+	}
+
+	// The nonces of the signed transactions are checked in order
+	bundle = []BundleItem{{Raw: encodeTestTx(t, tx0)}, {Raw: encodeTestTx(t, tx0)}}
+	if _, err := s.SimulateBundle(context.Background(), bundle, 0, nil, nil); err == nil ||
+		!strings.Contains(err.Error(), "bundle item 1") {
+		t.Errorf("expected nonce error of bundle item 1, got %v", err)
+	}
+	bundle = []BundleItem{{Raw: encodeTestTx(t, tx1)}}
+	if _, err := s.SimulateBundle(context.Background(), bundle, 0, nil, nil); err == nil {
+		t.Error("expected nonce error of transaction with future nonce")
+	}
+}
+
+func signTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, to common.Address) *types.Transaction {
+	tx := types.NewTransaction(nonce, to, 0, new(big.Int), 100000, big.NewInt(params.InitialBaseFee), nil)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(params.TestChainConfig.ChainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func encodeTestTx(t *testing.T, tx *types.Transaction) *hexutil.Bytes {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return (*hexutil.Bytes)(&data)
+}
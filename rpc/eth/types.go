@@ -9,6 +9,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/harmony-one/harmony/core/types"
 	hmytypes "github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy"
 )
 
 // Block represents a basic block which is further amended by BlockWithTxHash or BlockWithFullTx
@@ -27,6 +28,7 @@ type Block struct {
 	Size             hexutil.Uint64      `json:"size"`
 	GasLimit         hexutil.Uint64      `json:"gasLimit"`
 	GasUsed          hexutil.Uint64      `json:"gasUsed"`
+	BaseFee          *hexutil.Big        `json:"baseFeePerGas,omitempty"`
 	VRF              common.Hash         `json:"vrf"`
 	VRFProof         hexutil.Bytes       `json:"vrfProof"`
 	Timestamp        hexutil.Uint64      `json:"timestamp"`
@@ -53,21 +55,26 @@ type BlockWithFullTx struct {
 
 // Transaction represents a transaction that will serialize to the RPC representation of a transaction
 type Transaction struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Timestamp        hexutil.Uint64  `json:"timestamp"` // Not exposed by Ethereum anymore
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
+	BlockHash        *common.Hash      `json:"blockHash"`
+	BlockNumber      *hexutil.Big      `json:"blockNumber"`
+	From             common.Address    `json:"from"`
+	Timestamp        hexutil.Uint64    `json:"timestamp"` // Not exposed by Ethereum anymore
+	Gas              hexutil.Uint64    `json:"gas"`
+	GasPrice         *hexutil.Big      `json:"gasPrice"`
+	Hash             common.Hash       `json:"hash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            hexutil.Uint64    `json:"nonce"`
+	To               *common.Address   `json:"to"`
+	TransactionIndex *hexutil.Uint64   `json:"transactionIndex"`
+	Value            *hexutil.Big      `json:"value"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
+	Type             hexutil.Uint64    `json:"type"`
+	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
+	Accesses         *types.AccessList `json:"accessList,omitempty"`
+	GasFeeCap        *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	GasTipCap        *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // NewTransaction returns a transaction that will serialize to the RPC
@@ -75,7 +82,7 @@ type Transaction struct {
 // Note that all txs on Harmony are replay protected (post EIP155 epoch).
 func NewTransaction(
 	tx *types.EthTransaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64,
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int,
 ) (*Transaction, error) {
 	from := common.Address{}
 	var err error
@@ -102,11 +109,25 @@ func NewTransaction(
 		V:         (*hexutil.Big)(v),
 		R:         (*hexutil.Big)(r),
 		S:         (*hexutil.Big)(s),
+		Type:      hexutil.Uint64(tx.Type()),
+	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = (*hexutil.Big)(tx.ChainID())
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
 	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
 		result.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
 		result.TransactionIndex = (*hexutil.Uint64)(&index)
+		// The gas price of the included transaction is the price actually paid
+		if baseFee != nil {
+			result.GasPrice = (*hexutil.Big)(tx.EffectiveGasPrice(baseFee))
+		}
 	}
 	return result, nil
 }
@@ -136,6 +157,7 @@ func NewReceipt(tx *types.EthTransaction, blockHash common.Hash, blockNumber, bl
 		"contractAddress":   nil,
 		"logs":              receipt.Logs,
 		"logsBloom":         receipt.Bloom,
+		"type":              hexutil.Uint(tx.Type()),
 	}
 
 	// Assign receipt status or post state.
@@ -179,6 +201,7 @@ func newBlock(b *types.Block) *Block {
 		Size:             hexutil.Uint64(b.Size()),
 		GasLimit:         hexutil.Uint64(head.GasLimit()),
 		GasUsed:          hexutil.Uint64(head.GasUsed()),
+		BaseFee:          (*hexutil.Big)(head.BaseFee()),
 		VRF:              vrf,
 		VRFProof:         vrfProof,
 		Timestamp:        hexutil.Uint64(head.Time().Uint64()),
@@ -209,7 +232,7 @@ func blockWithFullTxFromBlock(b *types.Block) (*BlockWithFullTx, error) {
 	}
 
 	for idx, tx := range b.Transactions() {
-		fmtTx, err := NewTransaction(tx.ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), uint64(idx))
+		fmtTx, err := NewTransaction(tx.ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), uint64(idx), b.BaseFee())
 		if err != nil {
 			return nil, err
 		}
@@ -226,5 +249,104 @@ func NewTransactionFromBlockIndex(b *types.Block, index uint64) (*Transaction, e
 			"tx index %v greater than or equal to number of transactions on block %v", index, b.Hash().String(),
 		)
 	}
-	return NewTransaction(txs[index].ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), index)
+	return NewTransaction(txs[index].ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), index, b.BaseFee())
+}
+
+// AccountResult represents the EIP-1186 proof of an account and its storage
+// slots that will serialize to the RPC representation.
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	IsValidator  bool            `json:"isValidator"`
+	Code         hexutil.Bytes   `json:"code,omitempty"`
+	StorageProof []StorageResult `json:"storageProof"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	StateRoot    common.Hash     `json:"stateRoot"`
+}
+
+// StorageResult represents the proof of a single storage slot that will serialize
+// to the RPC representation.
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// NewAccountResult returns the account proof that will serialize to the RPC representation.
+func NewAccountResult(proof *hmy.AccountProof) *AccountResult {
+	result := &AccountResult{
+		Address:      proof.Address,
+		AccountProof: toHexSlice(proof.AccountProof),
+		Balance:      (*hexutil.Big)(proof.Balance),
+		CodeHash:     proof.CodeHash,
+		Nonce:        hexutil.Uint64(proof.Nonce),
+		StorageHash:  proof.StorageHash,
+		IsValidator:  proof.IsValidator,
+		Code:         proof.Code,
+		StorageProof: make([]StorageResult, 0, len(proof.StorageProof)),
+		BlockHash:    proof.BlockHash,
+		BlockNumber:  hexutil.Uint64(proof.BlockNumber),
+		StateRoot:    proof.StateRoot,
+	}
+	for _, sp := range proof.StorageProof {
+		result.StorageProof = append(result.StorageProof, StorageResult{
+			Key:   sp.Key.Hex(),
+			Value: (*hexutil.Big)(sp.Value),
+			Proof: toHexSlice(sp.Proof),
+		})
+	}
+	return result
+}
+
+// toHexSlice creates a slice of hex-strings based on []byte.
+func toHexSlice(b [][]byte) []string {
+	r := make([]string, len(b))
+	for i := range b {
+		r[i] = hexutil.Encode(b[i])
+	}
+	return r
+}
+
+// FeeHistory represents the fee history of a range of blocks that will serialize
+// to the RPC representation.
+type FeeHistory struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// NewFeeHistory returns the fee history that will serialize to the RPC representation.
+func NewFeeHistory(history *hmy.FeeHistory) *FeeHistory {
+	result := &FeeHistory{
+		OldestBlock:  (*hexutil.Big)(new(big.Int).SetUint64(history.OldestBlock)),
+		GasUsedRatio: history.GasUsedRatio,
+	}
+	if history.Reward != nil {
+		result.Reward = toHexBigMatrix(history.Reward)
+	}
+	if len(history.BaseFee) != 0 {
+		result.BaseFee = make([]*hexutil.Big, len(history.BaseFee))
+		for i := range history.BaseFee {
+			result.BaseFee[i] = (*hexutil.Big)(history.BaseFee[i])
+		}
+	}
+	return result
+}
+
+// toHexBigMatrix converts a matrix of big ints to hex big ints.
+func toHexBigMatrix(m [][]*big.Int) [][]*hexutil.Big {
+	r := make([][]*hexutil.Big, len(m))
+	for i := range m {
+		r[i] = make([]*hexutil.Big, len(m[i]))
+		for j := range m[i] {
+			r[i][j] = (*hexutil.Big)(m[i][j])
+		}
+	}
+	return r
 }
@@ -0,0 +1,218 @@
+package rpc
+
+import (
+	"context"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	internal_common "github.com/harmony-one/harmony/internal/common"
+	commonRPC "github.com/harmony-one/harmony/rpc/common"
+	"github.com/pkg/errors"
+)
+
+// maxExplorerPageSize is the max number of the entries returned in a page
+const maxExplorerPageSize = 1000
+
+// PublicExplorerService provides the transaction and token indexes of the explorer node
+type PublicExplorerService struct {
+	hmy *hmy.Harmony
+}
+
+// NewPublicExplorerAPI creates a new API for the RPC interface
+func NewPublicExplorerAPI(hmy *hmy.Harmony) rpc.API {
+	return rpc.API{
+		Namespace: explorerNamespace,
+		Version:   APIVersion,
+		Service:   &PublicExplorerService{hmy},
+		Public:    true,
+	}
+}
+
+// TxHistoryPageArgs is the args of explorer_getTransactionsHistory
+type TxHistoryPageArgs struct {
+	Address   string  `json:"address"`
+	TxType    string  `json:"txType"`    // NORMAL or STAKING, NORMAL by default
+	Direction string  `json:"direction"` // SENT, RECEIVED or ALL, ALL by default
+	FromBlock *uint64 `json:"fromBlock"`
+	ToBlock   *uint64 `json:"toBlock"`
+	Order     string  `json:"order"` // ASC or DESC, ASC by default
+	PageSize  uint32  `json:"pageSize"`
+	Cursor    string  `json:"cursor"`
+	FullTx    bool    `json:"fullTx"`
+}
+
+// TokenTransfersArgs is the args of explorer_getTokenTransfers
+type TokenTransfersArgs struct {
+	Address   string `json:"address"`
+	PageIndex uint32 `json:"pageIndex"`
+	PageSize  uint32 `json:"pageSize"`
+}
+
+// TokenHoldersArgs is the args of explorer_getTokenHolders
+type TokenHoldersArgs struct {
+	Token     string `json:"token"`
+	PageIndex uint32 `json:"pageIndex"`
+	PageSize  uint32 `json:"pageSize"`
+}
+
+// GetTransactionsHistory returns a page of the transactions of the address within
+// the filters, and the opaque cursor to query the next page with the same filters.
+// The cursor is omitted for the last page.
+func (s *PublicExplorerService) GetTransactionsHistory(
+	ctx context.Context, args TxHistoryPageArgs,
+) (StructuredResponse, error) {
+	timer := DoMetricRPCRequest(GetTransactionsHistoryPage)
+	defer DoRPCRequestDuration(GetTransactionsHistoryPage, timer)
+
+	query, err := args.toQuery()
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTransactionsHistoryPage, FailedNumber)
+		return nil, err
+	}
+	hashes, cursor, err := s.hmy.GetTransactionsHistoryPage(query)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTransactionsHistoryPage, FailedNumber)
+		return nil, err
+	}
+	resp := StructuredResponse{"transactions": hashes}
+	if hashes == nil {
+		resp["transactions"] = []common.Hash{}
+	}
+	if cursor != "" {
+		resp["nextCursor"] = cursor
+	}
+	if !args.FullTx {
+		return resp, nil
+	}
+
+	txService := &PublicTransactionService{hmy: s.hmy, version: V2}
+	txs := []StructuredResponse{}
+	for _, hash := range hashes {
+		var (
+			tx  StructuredResponse
+			err error
+		)
+		if query.Staking {
+			tx, err = txService.GetStakingTransactionByHash(ctx, hash)
+		} else {
+			tx, err = txService.GetTransactionByHash(ctx, hash)
+		}
+		if err != nil {
+			DoMetricRPCQueryInfo(GetTransactionsHistoryPage, FailedNumber)
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	resp["transactions"] = txs
+	return resp, nil
+}
+
+func (args TxHistoryPageArgs) toQuery() (commonRPC.TxHistoryQuery, error) {
+	query := commonRPC.TxHistoryQuery{
+		Direction: args.Direction,
+		ToBlock:   math.MaxUint64,
+		Cursor:    args.Cursor,
+	}
+	var err error
+	if query.Address, err = toBech32Address(args.Address); err != nil {
+		return query, err
+	}
+	switch args.TxType {
+	case "", "NORMAL":
+	case "STAKING":
+		query.Staking = true
+	default:
+		return query, errors.Errorf("unknown transaction type: %v", args.TxType)
+	}
+	switch args.Order {
+	case "", "ASC":
+	case "DESC":
+		query.Desc = true
+	default:
+		return query, errors.Errorf("unknown order: %v", args.Order)
+	}
+	if args.FromBlock != nil {
+		query.FromBlock = *args.FromBlock
+	}
+	if args.ToBlock != nil {
+		query.ToBlock = *args.ToBlock
+	}
+	if query.PageSize, err = explorerPageSize(args.PageSize); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// GetTokenTransfers returns the HRC20, HRC721 and HRC1155 token transfers from or
+// to the address, in the order of block number and log index.
+func (s *PublicExplorerService) GetTokenTransfers(
+	ctx context.Context, args TokenTransfersArgs,
+) (StructuredResponse, error) {
+	timer := DoMetricRPCRequest(GetTokenTransfers)
+	defer DoRPCRequestDuration(GetTokenTransfers, timer)
+
+	address, err := toBech32Address(args.Address)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTokenTransfers, FailedNumber)
+		return nil, err
+	}
+	pageSize, err := explorerPageSize(args.PageSize)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTokenTransfers, FailedNumber)
+		return nil, err
+	}
+	transfers, err := s.hmy.GetTokenTransfers(address, args.PageIndex, pageSize)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTokenTransfers, FailedNumber)
+		return nil, err
+	}
+	return StructuredResponse{"transfers": transfers}, nil
+}
+
+// GetTokenHolders returns the holders of the token with the balances computed from
+// the indexed transfers, in the order of the holder address.
+func (s *PublicExplorerService) GetTokenHolders(
+	ctx context.Context, args TokenHoldersArgs,
+) (StructuredResponse, error) {
+	timer := DoMetricRPCRequest(GetTokenHolders)
+	defer DoRPCRequestDuration(GetTokenHolders, timer)
+
+	token, err := toBech32Address(args.Token)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTokenHolders, FailedNumber)
+		return nil, err
+	}
+	pageSize, err := explorerPageSize(args.PageSize)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTokenHolders, FailedNumber)
+		return nil, err
+	}
+	holders, err := s.hmy.GetTokenHolders(token, args.PageIndex, pageSize)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetTokenHolders, FailedNumber)
+		return nil, err
+	}
+	return StructuredResponse{"holders": holders}, nil
+}
+
+// toBech32Address converts the one1 or hex address to the one1 address which is
+// the key of the explorer db.
+func toBech32Address(address string) (string, error) {
+	addr, err := internal_common.ParseAddr(address)
+	if err != nil {
+		return "", err
+	}
+	return internal_common.AddressToBech32(addr)
+}
+
+func explorerPageSize(pageSize uint32) (uint32, error) {
+	if pageSize == 0 {
+		return defaultPageSize, nil
+	}
+	if pageSize > maxExplorerPageSize {
+		return 0, errors.Errorf("page size %v exceeds the limit %v", pageSize, maxExplorerPageSize)
+	}
+	return pageSize, nil
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/harmony-one/harmony/hmy"
+	eth "github.com/harmony-one/harmony/rpc/eth"
+	v1 "github.com/harmony-one/harmony/rpc/v1"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
 )
 
 // PublicHarmonyService provides an API to access Harmony related information.
@@ -74,6 +77,33 @@ func (s *PublicHarmonyService) GasPrice(ctx context.Context) (interface{}, error
 	}
 }
 
+// FeeHistory returns the gas price history of blockCount blocks ending at lastBlock,
+// with the gas prices at the given reward percentiles for each block.
+// Note that the return type is an interface to account for the different versions
+func (s *PublicHarmonyService) FeeHistory(
+	ctx context.Context, blockCount DecimalOrHex, lastBlock BlockNumber, rewardPercentiles []float64,
+) (interface{}, error) {
+	timer := DoMetricRPCRequest(FeeHistory)
+	defer DoRPCRequestDuration(FeeHistory, timer)
+
+	history, err := s.hmy.FeeHistory(ctx, uint64(blockCount), lastBlock.EthBlockNumber(), rewardPercentiles)
+	if err != nil {
+		DoMetricRPCQueryInfo(FeeHistory, FailedNumber)
+		return nil, err
+	}
+	// Format response according to version
+	switch s.version {
+	case V1:
+		return v1.NewFeeHistory(history), nil
+	case V2:
+		return v2.NewFeeHistory(history), nil
+	case Eth:
+		return eth.NewFeeHistory(history), nil
+	default:
+		return nil, ErrUnknownRPCVersion
+	}
+}
+
 // GetNodeMetadata produces a NodeMetadata record, data is from the answering RPC node
 func (s *PublicHarmonyService) GetNodeMetadata(
 	ctx context.Context,
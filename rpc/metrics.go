@@ -25,9 +25,19 @@ const (
 	GetStakingNetworkInfo    = "GetStakingNetworkInfo"
 
 	// contract
-	GetCode      = "GetCode"
-	GetStorageAt = "GetStorageAt"
-	DoEvmCall    = "DoEVMCall"
+	GetCode        = "GetCode"
+	GetStorageAt   = "GetStorageAt"
+	GetProof       = "GetProof"
+	DoEvmCall      = "DoEVMCall"
+	SimulateBundle = "SimulateBundle"
+
+	// explorer
+	GetTransactionsHistoryPage = "GetTransactionsHistoryPage"
+	GetTokenTransfers          = "GetTokenTransfers"
+	GetTokenHolders            = "GetTokenHolders"
+
+	// harmony
+	FeeHistory = "FeeHistory"
 
 	// net
 	PeerCount = "PeerCount"
@@ -42,6 +52,12 @@ const (
 	GetCurrentStakingErrorSink     = "GetCurrentStakingErrorSink"
 	GetPendingCXReceipts           = "GetPendingCXReceipts"
 
+	// txpool
+	TxPoolContent     = "TxPoolContent"
+	TxPoolContentFrom = "TxPoolContentFrom"
+	TxPoolInspect     = "TxPoolInspect"
+	TxPoolStatus      = "TxPoolStatus"
+
 	// staking
 	GetAllValidatorInformation              = "GetAllValidatorInformation"
 	GetAllValidatorInformationByBlockNumber = "GetAllValidatorInformationByBlockNumber"
@@ -0,0 +1,111 @@
+package rpc
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/shard"
+)
+
+func TestStateOverrideApply(t *testing.T) {
+	var (
+		addr       = common.Address{1}
+		key1, key2 = common.Hash{1}, common.Hash{2}
+	)
+	newState := func() *state.DB {
+		db, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+		db.SetState(addr, key1, common.Hash{1})
+		db.SetState(addr, key2, common.Hash{2})
+		return db
+	}
+	storage := map[common.Hash]common.Hash{key1: {9}}
+
+	// The state replaces the whole storage of the account
+	db := newState()
+	if err := (&StateOverride{addr: {State: &storage}}).Apply(db); err != nil {
+		t.Fatal(err)
+	}
+	if v1, v2 := db.GetState(addr, key1), db.GetState(addr, key2); v1 != (common.Hash{9}) || v2 != (common.Hash{}) {
+		t.Errorf("unexpected storage after state override %x, %x", v1, v2)
+	}
+
+	// The state diff is applied on top of the storage of the account
+	db = newState()
+	if err := (&StateOverride{addr: {StateDiff: &storage}}).Apply(db); err != nil {
+		t.Fatal(err)
+	}
+	if v1, v2 := db.GetState(addr, key1), db.GetState(addr, key2); v1 != (common.Hash{9}) || v2 != (common.Hash{2}) {
+		t.Errorf("unexpected storage after state diff override %x, %x", v1, v2)
+	}
+
+	db = newState()
+	if err := (&StateOverride{addr: {State: &storage, StateDiff: &storage}}).Apply(db); err == nil {
+		t.Error("expected error for both state and state diff overridden")
+	}
+
+	var (
+		nonce   = hexutil.Uint64(5)
+		code    = hexutil.Bytes{0x60, 0x00}
+		balance = (*hexutil.Big)(big.NewInt(1000))
+	)
+	db = newState()
+	if err := (&StateOverride{addr: {Nonce: &nonce, Code: &code, Balance: &balance}}).Apply(db); err != nil {
+		t.Fatal(err)
+	}
+	if db.GetNonce(addr) != 5 || db.GetBalance(addr).Cmp(big.NewInt(1000)) != 0 || !bytes.Equal(db.GetCode(addr), code) {
+		t.Errorf("unexpected account after override: nonce %v, balance %v, code %x",
+			db.GetNonce(addr), db.GetBalance(addr), db.GetCode(addr))
+	}
+
+	// Nothing is overridden by the nil override
+	var none *StateOverride
+	if err := none.Apply(db); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBlockOverridesApply(t *testing.T) {
+	var (
+		number   = uint64(50000000)
+		time     = hexutil.Uint64(1234)
+		gasLimit = hexutil.Uint64(5000000)
+		coinbase = common.Address{1}
+	)
+	ctx := vm.Context{
+		BlockNumber: big.NewInt(1),
+		EpochNumber: big.NewInt(0),
+		Time:        big.NewInt(1),
+		GasLimit:    1,
+	}
+	overrides := &BlockOverrides{
+		Number:   (*hexutil.Big)(new(big.Int).SetUint64(number)),
+		Time:     &time,
+		GasLimit: &gasLimit,
+		Coinbase: &coinbase,
+	}
+	overrides.Apply(&ctx)
+
+	if ctx.BlockNumber.Uint64() != number {
+		t.Errorf("unexpected block number %v", ctx.BlockNumber)
+	}
+	// The epoch follows the overridden block number
+	if epoch := shard.Schedule.CalcEpochNumber(number); ctx.EpochNumber.Cmp(epoch) != 0 {
+		t.Errorf("unexpected epoch %v, want %v", ctx.EpochNumber, epoch)
+	}
+	if ctx.Time.Uint64() != 1234 || ctx.GasLimit != 5000000 || ctx.Coinbase != coinbase {
+		t.Errorf("unexpected block context %v, %v, %v", ctx.Time, ctx.GasLimit, ctx.Coinbase.Hex())
+	}
+
+	// Nothing is overridden by the nil overrides
+	overrides = nil
+	overrides.Apply(&ctx)
+	if ctx.BlockNumber.Uint64() != number {
+		t.Errorf("unexpected block number %v", ctx.BlockNumber)
+	}
+}
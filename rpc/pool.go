@@ -58,14 +58,14 @@ func (s *PublicPoolService) SendRawTransaction(
 
 	if s.version == Eth {
 		ethTx := new(types.EthTransaction)
-		if err := rlp.DecodeBytes(encodedTx, ethTx); err != nil {
+		if err := ethTx.UnmarshalBinary(encodedTx); err != nil {
 			return common.Hash{}, err
 		}
 		txHash = ethTx.Hash()
 		tx = ethTx.ConvertToHmy()
 	} else {
 		tx = new(types.Transaction)
-		if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		if err := tx.UnmarshalBinary(encodedTx); err != nil {
 			return common.Hash{}, err
 		}
 		txHash = tx.Hash()
@@ -85,7 +85,7 @@ func (s *PublicPoolService) SendRawTransaction(
 	// Log submission
 	if tx.To() == nil {
 		signer := types.MakeSigner(s.hmy.ChainConfig(), s.hmy.CurrentBlock().Epoch())
-		ethSigner := types.NewEIP155Signer(s.hmy.ChainConfig().EthCompatibleChainID)
+		ethSigner := types.MakeEthSigner(s.hmy.ChainConfig(), s.hmy.CurrentBlock().Epoch())
 
 		if tx.IsEthCompatible() {
 			signer = ethSigner
@@ -204,7 +204,7 @@ func (s *PublicPoolService) PendingTransactions(
 			var tx interface{}
 			switch s.version {
 			case V1:
-				tx, err = v1.NewTransaction(plainTx, common.Hash{}, 0, 0, 0)
+				tx, err = v1.NewTransaction(plainTx, common.Hash{}, 0, 0, 0, nil)
 				if err != nil {
 					utils.Logger().Debug().
 						Err(err).
@@ -212,7 +212,7 @@ func (s *PublicPoolService) PendingTransactions(
 					continue // Legacy behavior is to not return error here
 				}
 			case V2:
-				tx, err = v2.NewTransaction(plainTx, common.Hash{}, 0, 0, 0)
+				tx, err = v2.NewTransaction(plainTx, common.Hash{}, 0, 0, 0, nil)
 				if err != nil {
 					utils.Logger().Debug().
 						Err(err).
@@ -220,7 +220,7 @@ func (s *PublicPoolService) PendingTransactions(
 					continue // Legacy behavior is to not return error here
 				}
 			case Eth:
-				tx, err = eth.NewTransaction(plainTx.ConvertToEth(), common.Hash{}, 0, 0, 0)
+				tx, err = eth.NewTransaction(plainTx.ConvertToEth(), common.Hash{}, 0, 0, 0, nil)
 				if err != nil {
 					utils.Logger().Debug().
 						Err(err).
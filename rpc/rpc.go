@@ -40,13 +40,16 @@ const (
 	netV1Namespace = "netv1"
 	netV2Namespace = "netv2"
 	web3Namespace  = "web3"
+
+	explorerNamespace = "explorer"
+	txPoolNamespace   = "txpool"
 )
 
 var (
 	// HTTPModules ..
-	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "explorer"}
+	HTTPModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, explorerNamespace, txPoolNamespace}
 	// WSModules ..
-	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "web3"}
+	WSModules = []string{"hmy", "hmyv2", "eth", "debug", "trace", netNamespace, netV1Namespace, netV2Namespace, web3Namespace, "web3", txPoolNamespace}
 
 	httpListener     net.Listener
 	httpHandler      *rpc.Server
@@ -163,6 +166,8 @@ func getAPIs(hmy *hmy.Harmony, debugEnable bool, rateLimiterEnable bool, ratelim
 		NewPublicStakingAPI(hmy, V2),
 		NewPublicDebugAPI(hmy, V1),
 		NewPublicDebugAPI(hmy, V2),
+		NewPublicExplorerAPI(hmy),
+		NewPublicTxPoolAPI(hmy),
 		// Legacy methods (subject to removal)
 		v1.NewPublicLegacyAPI(hmy, "hmy"),
 		eth.NewPublicEthService(hmy, "eth"),
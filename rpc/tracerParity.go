@@ -3,9 +3,9 @@ package rpc
 import (
 	"context"
 	"encoding/json"
-	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/harmony-one/harmony/hmy"
 )
@@ -28,17 +28,53 @@ func (s *PublicParityTracerService) Block(ctx context.Context, number rpc.BlockN
 	if block == nil {
 		return nil, nil
 	}
-	results, err := s.hmy.TraceBlock(ctx, block, &hmy.TraceConfig{Tracer: &parityTraceGO})
-	if err != nil {
-		return results, err
+	return s.hmy.TraceBlockParity(ctx, block)
+}
+
+// TraceFilterArgs are the arguments of trace_filter
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// trace_filter RPC, served from the trace index
+func (s *PublicParityTracerService) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	if s.hmy.TraceIndexer == nil {
+		return nil, hmy.ErrTraceIndexDisabled
+	}
+	filter := hmy.TraceFilter{
+		FromBlock:     args.FromBlock,
+		ToBlock:       args.ToBlock,
+		FromAddresses: args.FromAddress,
+		ToAddresses:   args.ToAddress,
+		Count:         args.Count,
+	}
+	if args.After != nil {
+		filter.After = *args.After
 	}
-	var resultArray = make([]json.RawMessage, 0)
-	for _, result := range results {
-		raw, ok := result.Result.([]json.RawMessage)
-		if !ok {
-			return results, errors.New("tracer bug:expected []json.RawMessage")
-		}
-		resultArray = append(resultArray, raw...)
+	return s.hmy.TraceIndexer.Filter(filter)
+}
+
+// trace_get RPC, served from the trace index
+func (s *PublicParityTracerService) Get(ctx context.Context, hash common.Hash, indices []hexutil.Uint64) (json.RawMessage, error) {
+	if s.hmy.TraceIndexer == nil {
+		return nil, hmy.ErrTraceIndexDisabled
+	}
+	traceAddress := make([]uint64, len(indices))
+	for i, index := range indices {
+		traceAddress[i] = uint64(index)
+	}
+	return s.hmy.TraceIndexer.Get(hash, traceAddress)
+}
+
+// trace_replayBlockTransactions RPC, served from the trace index
+func (s *PublicParityTracerService) ReplayBlockTransactions(ctx context.Context, number rpc.BlockNumber, traceTypes []string) ([]*hmy.TraceReplay, error) {
+	if s.hmy.TraceIndexer == nil {
+		return nil, hmy.ErrTraceIndexDisabled
 	}
-	return resultArray, nil
+	return s.hmy.TraceIndexer.ReplayBlockTransactions(number, traceTypes)
 }
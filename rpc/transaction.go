@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/harmony-one/harmony/accounts/abi"
 	"github.com/harmony-one/harmony/core"
@@ -20,6 +22,7 @@ import (
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/utils"
+	commonRPC "github.com/harmony-one/harmony/rpc/common"
 	eth "github.com/harmony-one/harmony/rpc/eth"
 	v1 "github.com/harmony-one/harmony/rpc/v1"
 	v2 "github.com/harmony-one/harmony/rpc/v2"
@@ -151,11 +154,16 @@ func (s *PublicTransactionService) GetStakingTransactionsCount(
 }
 
 // EstimateGas returns an estimate of the amount of gas needed to execute the
-// given transaction against the current pending block.
+// given transaction against the given block, the latest block by default, with
+// the optional overrides of the accounts.
 func (s *PublicTransactionService) EstimateGas(
-	ctx context.Context, args CallArgs,
+	ctx context.Context, args CallArgs, blockNumber *BlockNumber, overrides *StateOverride,
 ) (hexutil.Uint64, error) {
-	gas, err := EstimateGas(ctx, s.hmy, args, nil)
+	blockNum := rpc.LatestBlockNumber
+	if blockNumber != nil {
+		blockNum = blockNumber.EthBlockNumber()
+	}
+	gas, err := EstimateGas(ctx, s.hmy, args, blockNum, overrides, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -164,6 +172,36 @@ func (s *PublicTransactionService) EstimateGas(
 	return (hexutil.Uint64)(gas), nil
 }
 
+// AccessListResult is the result of CreateAccessList.
+type AccessListResult struct {
+	Accesslist *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// CreateAccessList creates an access list of the accounts and storage slots the
+// given transaction touches, along with the gas it uses, against the given
+// block, the latest block by default.
+func (s *PublicTransactionService) CreateAccessList(
+	ctx context.Context, args CallArgs, blockNumber *BlockNumber,
+) (*AccessListResult, error) {
+	blockNum := rpc.LatestBlockNumber
+	if blockNumber != nil {
+		blockNum = blockNumber.EthBlockNumber()
+	}
+	acl, gasUsed, vmErr, err := AccessList(ctx, s.hmy, args, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	result := &AccessListResult{Accesslist: &acl, GasUsed: hexutil.Uint64(gasUsed)}
+	if vmErr != nil {
+		result.Error = vmErr.Error()
+	}
+
+	// Response output is the same for all versions
+	return result, nil
+}
+
 // GetTransactionByHash returns the plain transaction for the given hash
 func (s *PublicTransactionService) GetTransactionByHash(
 	ctx context.Context, hash common.Hash,
@@ -176,7 +214,7 @@ func (s *PublicTransactionService) GetTransactionByHash(
 		// Try to return a pending transaction
 		if tx := s.hmy.TxPool.Get(hash); tx != nil {
 			if plainTx, ok := tx.(*types.Transaction); ok {
-				return s.newRPCTransaction(plainTx, common.Hash{}, 0, 0, 0)
+				return s.newRPCTransaction(plainTx, common.Hash{}, 0, 0, 0, nil)
 			}
 		}
 
@@ -197,30 +235,30 @@ func (s *PublicTransactionService) GetTransactionByHash(
 		return nil, nil
 	}
 
-	return s.newRPCTransaction(tx, blockHash, blockNumber, block.Time().Uint64(), index)
+	return s.newRPCTransaction(tx, blockHash, blockNumber, block.Time().Uint64(), index, block.BaseFee())
 }
 
 func (s *PublicTransactionService) newRPCTransaction(tx *types.Transaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64) (StructuredResponse, error) {
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int) (StructuredResponse, error) {
 
 	// Format the response according to the version
 	switch s.version {
 	case V1:
-		tx, err := v1.NewTransaction(tx, blockHash, blockNumber, timestamp, index)
+		tx, err := v1.NewTransaction(tx, blockHash, blockNumber, timestamp, index, baseFee)
 		if err != nil {
 			DoMetricRPCQueryInfo(GetTransactionByHash, FailedNumber)
 			return nil, err
 		}
 		return NewStructuredResponse(tx)
 	case V2:
-		tx, err := v2.NewTransaction(tx, blockHash, blockNumber, timestamp, index)
+		tx, err := v2.NewTransaction(tx, blockHash, blockNumber, timestamp, index, baseFee)
 		if err != nil {
 			DoMetricRPCQueryInfo(GetTransactionByHash, FailedNumber)
 			return nil, err
 		}
 		return NewStructuredResponse(tx)
 	case Eth:
-		tx, err := eth.NewTransaction(tx.ConvertToEth(), blockHash, blockNumber, timestamp, index)
+		tx, err := eth.NewTransaction(tx.ConvertToEth(), blockHash, blockNumber, timestamp, index, baseFee)
 		if err != nil {
 			DoMetricRPCQueryInfo(GetTransactionByHash, FailedNumber)
 			return nil, err
@@ -315,14 +353,12 @@ func (s *PublicTransactionService) GetTransactionsHistory(
 			return nil, err
 		}
 	}
-	hashes, err := s.hmy.GetTransactionsHistory(address, args.TxType, args.Order)
+	result, err = s.getTxHistoryPage(address, false, args)
 	if err != nil {
 		DoMetricRPCQueryInfo(GetTransactionsHistory, FailedNumber)
 		return nil, err
 	}
 
-	result = returnHashesWithPagination(hashes, args.PageIndex, args.PageSize)
-
 	// Just hashes have same response format for all versions
 	if !args.FullTx {
 		return StructuredResponse{"transactions": result}, nil
@@ -373,7 +409,7 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 			return nil, nil
 		}
 	}
-	hashes, err := s.hmy.GetStakingTransactionsHistory(address, args.TxType, args.Order)
+	result, err = s.getTxHistoryPage(address, true, args)
 	if err != nil {
 		utils.Logger().Debug().
 			Err(err).
@@ -383,8 +419,6 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 		return nil, nil
 	}
 
-	result = returnHashesWithPagination(hashes, args.PageIndex, args.PageSize)
-
 	// Just hashes have same response format for all versions
 	if !args.FullTx {
 		return StructuredResponse{"staking_transactions": result}, nil
@@ -772,30 +806,50 @@ func (s *PublicTransactionService) ResendCx(ctx context.Context, txID common.Has
 	return success, nil
 }
 
-// returnHashesWithPagination returns result with pagination (offset, page in TxHistoryArgs).
-func returnHashesWithPagination(hashes []common.Hash, pageIndex uint32, pageSize uint32) []common.Hash {
+// getTxHistoryPage returns the page of the transaction hashes of the address in
+// the page index and size of the args. The page is read from the explorer index
+// without loading the whole history of the address.
+func (s *PublicTransactionService) getTxHistoryPage(
+	address string, staking bool, args TxHistoryArgs,
+) ([]common.Hash, error) {
 	size := defaultPageSize
-	if pageSize > 0 {
-		size = pageSize
+	if args.PageSize > 0 {
+		size = args.PageSize
+	}
+	offset := uint64(size) * uint64(args.PageIndex)
+	if offset > math.MaxUint32 {
+		return make([]common.Hash, 0), nil
 	}
-	if uint64(size)*uint64(pageIndex) >= uint64(len(hashes)) {
-		return make([]common.Hash, 0)
+	hashes, _, err := s.hmy.GetTransactionsHistoryPage(commonRPC.TxHistoryQuery{
+		Address:   address,
+		Staking:   staking,
+		Direction: args.TxType,
+		ToBlock:   math.MaxUint64,
+		Desc:      args.Order == "DESC",
+		PageSize:  size,
+		Offset:    uint32(offset),
+	})
+	if err != nil {
+		return nil, err
 	}
-	if uint64(size)*uint64(pageIndex)+uint64(size) > uint64(len(hashes)) {
-		return hashes[size*pageIndex:]
+	if hashes == nil {
+		hashes = make([]common.Hash, 0)
 	}
-	return hashes[size*pageIndex : size*pageIndex+size]
+	return hashes, nil
 }
 
-// EstimateGas - estimate gas cost for a given operation
-func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *big.Int) (uint64, error) {
+// EstimateGas - estimate gas cost for a given operation on the state of the block
+// with the optional overrides of the accounts
+func EstimateGas(
+	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNum rpc.BlockNumber,
+	overrides *StateOverride, gasCap *big.Int,
+) (uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  uint64 = params.TxGas - 1
 		hi  uint64
 		cap uint64
 	)
-	blockNum := rpc.LatestBlockNumber
 	// Use zero address if sender unspecified.
 	if args.From == nil {
 		args.From = new(common.Address)
@@ -810,6 +864,9 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *b
 		if err != nil {
 			return 0, err
 		}
+		if blk == nil {
+			return 0, errors.New("block not found")
+		}
 		hi = blk.GasLimit()
 	}
 	// Recap the highest gas limit with account's available balance.
@@ -818,6 +875,12 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *b
 		if err != nil {
 			return 0, err
 		}
+		if state == nil {
+			return 0, errors.New("block not found")
+		}
+		if err := overrides.Apply(state); err != nil {
+			return 0, err
+		}
 		balance := state.GetBalance(*args.From) // from can't be nil
 		available := new(big.Int).Set(balance)
 		if args.Value != nil {
@@ -847,10 +910,10 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *b
 	cap = hi
 
 	// Create a helper to check if a gas allowance results in an executable transaction
-	executable := func(gas uint64) (bool, *core.ExecutionResult, error) {
+	executable := func(gas uint64, vmConfig *vm.Config) (bool, *core.ExecutionResult, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
 
-		result, err := DoEVMCall(ctx, hmy, args, blockNum, 0)
+		result, err := doEVMCall(ctx, hmy, args, blockNum, overrides, nil, 0, vmConfig)
 		if err != nil {
 			if errors.Is(err, core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -862,7 +925,7 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *b
 	// Execute the binary search and hone in on an executable gas limit
 	for lo+1 < hi {
 		mid := (hi + lo) / 2
-		failed, _, err := executable(mid)
+		failed, _, err := executable(mid, nil)
 
 		// If the error is not nil(consensus error), it means the provided message
 		// call or transaction will never be accepted no matter how much gas it is
@@ -878,16 +941,23 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *b
 	}
 	// Reject the transaction as invalid if it still fails at the highest allowance
 	if hi == cap {
-		failed, result, err := executable(hi)
+		// Trace the last execution to find out where the call failed
+		tracer := vm.NewFailureTracer()
+		vmConfig := *hmy.BlockChain.GetVMConfig()
+		vmConfig.Debug = true
+		vmConfig.Tracer = tracer
+		failed, result, err := executable(hi, &vmConfig)
 		if err != nil {
 			return 0, err
 		}
 		if failed {
 			if result != nil && result.VMErr != vm.ErrOutOfGas {
-				if len(result.Revert()) > 0 {
-					return 0, newRevertError(result)
-				}
-				return 0, result.VMErr
+				err := newExecutionError(result)
+				utils.Logger().Debug().
+					Err(err).
+					Int("depth", tracer.Depth()).
+					Msgf("%v error at %v", LogTag, "EstimateGas")
+				return 0, err
 			}
 			// Otherwise, the specified gas cap is too low
 			return 0, fmt.Errorf("gas required exceeds allowance (%d)", cap)
@@ -896,32 +966,101 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, gasCap *b
 	return hi, nil
 }
 
-func newRevertError(result *core.ExecutionResult) *revertError {
-	reason, errUnpack := abi.UnpackRevert(result.Revert())
+// AccessList creates an access list for the given transaction by tracing it
+// repeatedly until the access list of the accounts and storage slots it touches
+// stops changing. It returns the access list, the gas used and the execution
+// error of the transaction with that access list.
+func AccessList(
+	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNum rpc.BlockNumber,
+) (acl types.AccessList, gasUsed uint64, vmErr error, err error) {
+	db, header, err := hmy.StateAndHeaderByNumber(ctx, blockNum)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if db == nil || header == nil {
+		return nil, 0, nil, errors.New("block not found")
+	}
+	// Use zero address if sender unspecified.
+	if args.From == nil {
+		args.From = new(common.Address)
+	}
+	// Retrieve the precompiles since they don't need to be added to the access
+	// list, nor do the sender and the recipient
+	precompiles := vm.ActivePrecompiles(hmy.ChainConfig().Rules(header.Epoch()))
+	var to common.Address
+	if args.To != nil {
+		to = *args.To
+	} else {
+		to = crypto.CreateAddress(*args.From, db.GetNonce(*args.From))
+	}
+
+	// Retrieve the current access list to expand
+	prevTracer := vm.NewAccessListTracer(nil, *args.From, to, precompiles)
+	if args.AccessList != nil {
+		prevTracer = vm.NewAccessListTracer(*args.AccessList, *args.From, to, precompiles)
+	}
+	for {
+		// Copy the original db so we don't modify it
+		statedb := db.Copy()
+		accessList := prevTracer.AccessList()
+		args.AccessList = &accessList
+		msg := args.ToMessage(hmy.RPCGasCap)
+
+		// Apply the transaction with the access list tracer
+		tracer := vm.NewAccessListTracer(accessList, *args.From, to, precompiles)
+		vmConfig := *hmy.BlockChain.GetVMConfig()
+		vmConfig.Debug = true
+		vmConfig.Tracer = tracer
+		evm, err := hmy.GetEVM(ctx, msg, statedb, header, &vmConfig, nil)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(msg.Gas()))
+		if err != nil {
+			return nil, 0, nil, errors.Wrapf(err, "failed to apply transaction with access list")
+		}
+		if tracer.Equal(prevTracer) {
+			return accessList, result.UsedGas, result.VMErr, nil
+		}
+		prevTracer = tracer
+	}
+}
+
+func newExecutionError(result *core.ExecutionResult) *executionError {
+	if len(result.Revert()) == 0 {
+		return &executionError{error: result.VMErr}
+	}
 	err := errors.New("execution reverted")
-	if errUnpack == nil {
+	if reason, errUnpack := abi.UnpackRevert(result.Revert()); errUnpack == nil {
 		err = fmt.Errorf("execution reverted: %v", reason)
 	}
-	return &revertError{
-		error:  err,
-		reason: hexutil.Encode(result.Revert()),
-	}
+	return &executionError{error: err, code: 3, revert: hexutil.Encode(result.Revert())}
 }
 
-// revertError is an API error that encompassas an EVM revertal with JSON error
-// code and a binary data blob.
-type revertError struct {
+// executionError is an API error that encompasses a failed EVM execution with
+// JSON error code and the hex encoded revert data as error data, the
+// ABI-decoded revert reason being part of the message.
+type executionError struct {
 	error
-	reason string // revert reason hex encoded
+	code   int
+	revert string
 }
 
-// ErrorCode returns the JSON error code for a revertal.
+// ErrorCode returns the JSON error code for a revertal, or the default server
+// error code for other failures.
 // See: https://github.com/ethereum/wiki/wiki/JSON-RPC-Error-Codes-Improvement-Proposal
-func (e *revertError) ErrorCode() int {
-	return 3
+func (e *executionError) ErrorCode() int {
+	if e.code == 0 {
+		return -32000
+	}
+	return e.code
 }
 
-// ErrorData returns the hex encoded revert reason.
-func (e *revertError) ErrorData() interface{} {
-	return e.reason
+// ErrorData returns the hex encoded revert data, nil if the execution did not
+// revert.
+func (e *executionError) ErrorData() interface{} {
+	if e.revert == "" {
+		return nil
+	}
+	return e.revert
 }
@@ -0,0 +1,52 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func TestNewExecutionError(t *testing.T) {
+	// Error("oops") as encoded by solidity
+	reasonData := hexutil.MustDecode("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"6f6f707300000000000000000000000000000000000000000000000000000000")
+	tests := []struct {
+		result  *core.ExecutionResult
+		code    int
+		message string
+		data    interface{}
+	}{
+		{
+			&core.ExecutionResult{ReturnData: reasonData, VMErr: vm.ErrExecutionReverted},
+			3, "execution reverted: oops", hexutil.Encode(reasonData),
+		},
+		{
+			&core.ExecutionResult{ReturnData: []byte{0xde, 0xad}, VMErr: vm.ErrExecutionReverted},
+			3, "execution reverted", "0xdead",
+		},
+		{
+			&core.ExecutionResult{VMErr: vm.ErrExecutionReverted},
+			-32000, vm.ErrExecutionReverted.Error(), nil,
+		},
+		{
+			&core.ExecutionResult{ReturnData: []byte{0xde, 0xad}, VMErr: vm.ErrDepth},
+			-32000, vm.ErrDepth.Error(), nil,
+		},
+	}
+	for i, test := range tests {
+		err := newExecutionError(test.result)
+		if err.ErrorCode() != test.code {
+			t.Errorf("test %d: unexpected code %v, want %v", i, err.ErrorCode(), test.code)
+		}
+		if err.Error() != test.message {
+			t.Errorf("test %d: unexpected message %q, want %q", i, err.Error(), test.message)
+		}
+		if err.ErrorData() != test.data {
+			t.Errorf("test %d: unexpected data %v, want %v", i, err.ErrorData(), test.data)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/internal/utils"
+	eth "github.com/harmony-one/harmony/rpc/eth"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// PublicTxPoolService provides the geth compatible txpool API to inspect the
+// plain and staking transactions of the transaction pool.
+type PublicTxPoolService struct {
+	hmy *hmy.Harmony
+}
+
+// NewPublicTxPoolAPI creates a new API for the RPC interface
+func NewPublicTxPoolAPI(hmy *hmy.Harmony) rpc.API {
+	return rpc.API{
+		Namespace: txPoolNamespace,
+		Version:   APIVersion,
+		Service:   &PublicTxPoolService{hmy},
+		Public:    true,
+	}
+}
+
+// Content returns the pending and queued transactions of the pool, grouped by
+// sender and nonce. Plain transactions are in the eth format and staking
+// transactions in the hmyv2 format.
+func (s *PublicTxPoolService) Content(
+	ctx context.Context,
+) (map[string]map[string]map[string]interface{}, error) {
+	timer := DoMetricRPCRequest(TxPoolContent)
+	defer DoRPCRequestDuration(TxPoolContent, timer)
+
+	pending, queued := s.hmy.GetPoolContent()
+	content := map[string]map[string]map[string]interface{}{
+		"pending": make(map[string]map[string]interface{}),
+		"queued":  make(map[string]map[string]interface{}),
+	}
+	for addr, txs := range pending {
+		formatted, err := formatPoolTransactions(txs)
+		if err != nil {
+			DoMetricRPCQueryInfo(TxPoolContent, FailedNumber)
+			return nil, err
+		}
+		content["pending"][addr.Hex()] = formatted
+	}
+	for addr, txs := range queued {
+		formatted, err := formatPoolTransactions(txs)
+		if err != nil {
+			DoMetricRPCQueryInfo(TxPoolContent, FailedNumber)
+			return nil, err
+		}
+		content["queued"][addr.Hex()] = formatted
+	}
+	return content, nil
+}
+
+// ContentFrom returns the pending and queued transactions of the pool sent by
+// the given address, grouped by nonce.
+func (s *PublicTxPoolService) ContentFrom(
+	ctx context.Context, addr common.Address,
+) (map[string]map[string]interface{}, error) {
+	timer := DoMetricRPCRequest(TxPoolContentFrom)
+	defer DoRPCRequestDuration(TxPoolContentFrom, timer)
+
+	pending, queued := s.hmy.GetPoolContentFrom(addr)
+	formattedPending, err := formatPoolTransactions(pending)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolContentFrom, FailedNumber)
+		return nil, err
+	}
+	formattedQueued, err := formatPoolTransactions(queued)
+	if err != nil {
+		DoMetricRPCQueryInfo(TxPoolContentFrom, FailedNumber)
+		return nil, err
+	}
+	return map[string]map[string]interface{}{
+		"pending": formattedPending,
+		"queued":  formattedQueued,
+	}, nil
+}
+
+// Status returns the number of pending and queued transactions of the pool.
+func (s *PublicTxPoolService) Status(ctx context.Context) map[string]hexutil.Uint {
+	timer := DoMetricRPCRequest(TxPoolStatus)
+	defer DoRPCRequestDuration(TxPoolStatus, timer)
+
+	pending, queued := s.hmy.GetPoolStats()
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(pending),
+		"queued":  hexutil.Uint(queued),
+	}
+}
+
+// Inspect returns a textual summary of the pending and queued transactions of
+// the pool, grouped by sender and nonce, for a quick overview.
+func (s *PublicTxPoolService) Inspect(
+	ctx context.Context,
+) (map[string]map[string]map[string]string, error) {
+	timer := DoMetricRPCRequest(TxPoolInspect)
+	defer DoRPCRequestDuration(TxPoolInspect, timer)
+
+	pending, queued := s.hmy.GetPoolContent()
+	content := map[string]map[string]map[string]string{
+		"pending": make(map[string]map[string]string),
+		"queued":  make(map[string]map[string]string),
+	}
+	for addr, txs := range pending {
+		summaries, err := inspectPoolTransactions(txs)
+		if err != nil {
+			DoMetricRPCQueryInfo(TxPoolInspect, FailedNumber)
+			return nil, err
+		}
+		content["pending"][addr.Hex()] = summaries
+	}
+	for addr, txs := range queued {
+		summaries, err := inspectPoolTransactions(txs)
+		if err != nil {
+			DoMetricRPCQueryInfo(TxPoolInspect, FailedNumber)
+			return nil, err
+		}
+		content["queued"][addr.Hex()] = summaries
+	}
+	return content, nil
+}
+
+// formatPoolTransactions formats the given pool transactions keyed by nonce,
+// skipping the ones which can't be formatted.
+func formatPoolTransactions(txs types.PoolTransactions) (map[string]interface{}, error) {
+	formatted := make(map[string]interface{}, len(txs))
+	for _, poolTx := range txs {
+		var (
+			tx  interface{}
+			err error
+		)
+		switch poolTx := poolTx.(type) {
+		case *types.Transaction:
+			tx, err = eth.NewTransaction(poolTx.ConvertToEth(), common.Hash{}, 0, 0, 0, nil)
+		case *staking.StakingTransaction:
+			tx, err = v2.NewStakingTransaction(poolTx, common.Hash{}, 0, 0, 0, true)
+		default:
+			return nil, types.ErrUnknownPoolTxType
+		}
+		if err != nil {
+			utils.Logger().Debug().
+				Err(err).
+				Msgf("%v error at %v", LogTag, "formatPoolTransactions")
+			continue
+		}
+		formatted[fmt.Sprintf("%d", poolTx.Nonce())] = tx
+	}
+	return formatted, nil
+}
+
+// inspectPoolTransactions summarizes the given pool transactions keyed by nonce.
+func inspectPoolTransactions(txs types.PoolTransactions) (map[string]string, error) {
+	summaries := make(map[string]string, len(txs))
+	for _, poolTx := range txs {
+		var summary string
+		switch poolTx := poolTx.(type) {
+		case *types.Transaction:
+			if to := poolTx.To(); to != nil {
+				summary = fmt.Sprintf("%s: %v wei + %v gas × %v wei",
+					to.Hex(), poolTx.Value(), poolTx.GasLimit(), poolTx.GasPrice())
+			} else {
+				summary = fmt.Sprintf("contract creation: %v wei + %v gas × %v wei",
+					poolTx.Value(), poolTx.GasLimit(), poolTx.GasPrice())
+			}
+		case *staking.StakingTransaction:
+			summary = fmt.Sprintf("%s: %v gas × %v wei",
+				poolTx.StakingType(), poolTx.GasLimit(), poolTx.GasPrice())
+		default:
+			return nil, types.ErrUnknownPoolTxType
+		}
+		summaries[fmt.Sprintf("%d", poolTx.Nonce())] = summary
+	}
+	return summaries, nil
+}
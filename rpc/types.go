@@ -34,6 +34,12 @@ type CallArgs struct {
 	GasPrice *hexutil.Big    `json:"gasPrice"`
 	Value    *hexutil.Big    `json:"value"`
 	Data     *hexutil.Bytes  `json:"data"`
+
+	AccessList *types.AccessList `json:"accessList,omitempty"`
+
+	// Fee caps of the dynamic fee transaction, exclusive with GasPrice
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // ToMessage converts CallArgs to the Message type used by the core evm
@@ -57,9 +63,20 @@ func (args *CallArgs) ToMessage(globalGasCap *big.Int) types.Message {
 			Msg("Caller gas above allowance, capping")
 		gas = globalGasCap.Uint64()
 	}
-	gasPrice := new(big.Int)
+	// The gas price takes precedence over the fee caps, and the fee cap is paid
+	// in full since the base fee is not known here.
+	gasPrice, gasFeeCap, gasTipCap := new(big.Int), new(big.Int), new(big.Int)
 	if args.GasPrice != nil {
 		gasPrice = args.GasPrice.ToInt()
+		gasFeeCap, gasTipCap = gasPrice, gasPrice
+	} else {
+		if args.MaxFeePerGas != nil {
+			gasFeeCap = args.MaxFeePerGas.ToInt()
+		}
+		if args.MaxPriorityFeePerGas != nil {
+			gasTipCap = args.MaxPriorityFeePerGas.ToInt()
+		}
+		gasPrice = gasFeeCap
 	}
 
 	value := new(big.Int)
@@ -72,10 +89,31 @@ func (args *CallArgs) ToMessage(globalGasCap *big.Int) types.Message {
 		data = []byte(*args.Data)
 	}
 
-	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, data, false)
+	msg := types.NewMessage(addr, args.To, 0, value, gas, gasPrice, gasFeeCap, gasTipCap, data, false)
+	if args.AccessList != nil {
+		msg.SetAccessList(*args.AccessList)
+	}
 	return msg
 }
 
+// BundleItem is an item of a simulated bundle, either a call or a signed
+// transaction.
+type BundleItem struct {
+	CallArgs
+	// Raw is the encoded signed transaction, exclusive with the call fields
+	Raw *hexutil.Bytes `json:"raw,omitempty"`
+}
+
+// BundleResult is the result of a simulated bundle item.
+type BundleResult struct {
+	TxHash       *common.Hash   `json:"txHash,omitempty"`
+	ReturnData   hexutil.Bytes  `json:"returnData"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Logs         []*types.Log   `json:"logs"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+}
+
 // StakingNetworkInfo returns global staking info.
 type StakingNetworkInfo struct {
 	TotalSupply       numeric.Dec `json:"total-supply"`
@@ -186,6 +224,31 @@ func (i *TransactionIndex) UnmarshalJSON(data []byte) (err error) {
 	return nil
 }
 
+// DecimalOrHex is an uint64 that unmarshals from a hex string, a decimal string
+// or an integer
+type DecimalOrHex uint64
+
+// UnmarshalJSON converts a hex string, a decimal string or an integer to DecimalOrHex
+func (dh *DecimalOrHex) UnmarshalJSON(data []byte) (err error) {
+	input := strings.TrimSpace(string(data))
+	if len(input) >= 2 && input[0] == '"' && input[len(input)-1] == '"' {
+		input = input[1 : len(input)-1]
+	}
+
+	var num uint64
+	if strings.HasPrefix(input, "0x") {
+		num, err = strconv.ParseUint(strings.TrimPrefix(input, "0x"), 16, 64)
+	} else {
+		num, err = strconv.ParseUint(input, 10, 64)
+	}
+	if err != nil {
+		return err
+	}
+
+	*dh = DecimalOrHex(num)
+	return nil
+}
+
 // TxHistoryArgs is struct to include optional transaction formatting params.
 type TxHistoryArgs struct {
 	Address   string `json:"address"`
@@ -70,3 +70,28 @@ func checkAddressOrListEqual(a, b *AddressOrList) error {
 	}
 	return nil
 }
+
+func TestDecimalOrHex_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input  string
+		exp    DecimalOrHex
+		expErr bool
+	}{
+		{input: `10`, exp: 10},
+		{input: `"10"`, exp: 10},
+		{input: `"0x10"`, exp: 16},
+		{input: `"0xzz"`, expErr: true},
+		{input: `-1`, expErr: true},
+	}
+	for i, test := range tests {
+		var dh DecimalOrHex
+		err := json.Unmarshal([]byte(test.input), &dh)
+		if (err != nil) != test.expErr {
+			t.Errorf("Test %v: unexpected error: %v", i, err)
+			continue
+		}
+		if err == nil && dh != test.exp {
+			t.Errorf("Test %v: unexpected value: %v / %v", i, dh, test.exp)
+		}
+	}
+}
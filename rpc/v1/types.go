@@ -12,6 +12,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/hmy"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	staking "github.com/harmony-one/harmony/staking/types"
 )
@@ -34,6 +35,7 @@ type BlockWithTxHash struct {
 	Size             hexutil.Uint64 `json:"size"`
 	GasLimit         hexutil.Uint64 `json:"gasLimit"`
 	GasUsed          hexutil.Uint64 `json:"gasUsed"`
+	BaseFee          *hexutil.Big   `json:"baseFeePerGas,omitempty"`
 	VRF              common.Hash    `json:"vrf"`
 	VRFProof         hexutil.Bytes  `json:"vrfProof"`
 	Timestamp        hexutil.Uint64 `json:"timestamp"`
@@ -64,6 +66,7 @@ type BlockWithFullTx struct {
 	Size             hexutil.Uint64        `json:"size"`
 	GasLimit         hexutil.Uint64        `json:"gasLimit"`
 	GasUsed          hexutil.Uint64        `json:"gasUsed"`
+	BaseFee          *hexutil.Big          `json:"baseFeePerGas,omitempty"`
 	VRF              common.Hash           `json:"vrf"`
 	VRFProof         hexutil.Bytes         `json:"vrfProof"`
 	Timestamp        hexutil.Uint64        `json:"timestamp"`
@@ -77,24 +80,29 @@ type BlockWithFullTx struct {
 
 // Transaction represents a transaction that will serialize to the RPC representation of a transaction
 type Transaction struct {
-	BlockHash        common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big   `json:"blockNumber"`
-	From             string         `json:"from"`
-	Timestamp        hexutil.Uint64 `json:"timestamp"`
-	Gas              hexutil.Uint64 `json:"gas"`
-	GasPrice         *hexutil.Big   `json:"gasPrice"`
-	Hash             common.Hash    `json:"hash"`
-	EthHash          common.Hash    `json:"ethHash"`
-	Input            hexutil.Bytes  `json:"input"`
-	Nonce            hexutil.Uint64 `json:"nonce"`
-	To               string         `json:"to"`
-	TransactionIndex hexutil.Uint   `json:"transactionIndex"`
-	Value            *hexutil.Big   `json:"value"`
-	ShardID          uint32         `json:"shardID"`
-	ToShardID        uint32         `json:"toShardID"`
-	V                *hexutil.Big   `json:"v"`
-	R                *hexutil.Big   `json:"r"`
-	S                *hexutil.Big   `json:"s"`
+	BlockHash        common.Hash       `json:"blockHash"`
+	BlockNumber      *hexutil.Big      `json:"blockNumber"`
+	From             string            `json:"from"`
+	Timestamp        hexutil.Uint64    `json:"timestamp"`
+	Gas              hexutil.Uint64    `json:"gas"`
+	GasPrice         *hexutil.Big      `json:"gasPrice"`
+	Hash             common.Hash       `json:"hash"`
+	EthHash          common.Hash       `json:"ethHash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            hexutil.Uint64    `json:"nonce"`
+	To               string            `json:"to"`
+	TransactionIndex hexutil.Uint      `json:"transactionIndex"`
+	Value            *hexutil.Big      `json:"value"`
+	ShardID          uint32            `json:"shardID"`
+	ToShardID        uint32            `json:"toShardID"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
+	Type             hexutil.Uint64    `json:"type"`
+	AccessList       *types.AccessList `json:"accessList,omitempty"`
+
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // StakingTransaction represents a staking transaction that will serialize to the
@@ -254,7 +262,7 @@ func NewCxReceipt(cx *types.CXReceipt, blockHash common.Hash, blockNumber uint64
 // Note that all txs on Harmony are replay protected (post EIP155 epoch).
 func NewTransaction(
 	tx *types.Transaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64,
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int,
 ) (*Transaction, error) {
 	from, err := tx.SenderAddress()
 	if err != nil {
@@ -276,11 +284,24 @@ func NewTransaction(
 		V:         (*hexutil.Big)(v),
 		R:         (*hexutil.Big)(r),
 		S:         (*hexutil.Big)(s),
+		Type:      hexutil.Uint64(tx.Type()),
+	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.AccessList = &al
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		result.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
 	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = blockHash
 		result.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
 		result.TransactionIndex = hexutil.Uint(index)
+		// The gas price of the included transaction is the price actually paid
+		if baseFee != nil {
+			result.GasPrice = (*hexutil.Big)(tx.EffectiveGasPrice(baseFee))
+		}
 	}
 
 	fromAddr, err := internal_common.AddressToBech32(from)
@@ -599,6 +620,7 @@ func blockWithTxHashFromBlock(b *types.Block) *BlockWithTxHash {
 		Size:             hexutil.Uint64(b.Size()),
 		GasLimit:         hexutil.Uint64(head.GasLimit()),
 		GasUsed:          hexutil.Uint64(head.GasUsed()),
+		BaseFee:          (*hexutil.Big)(head.BaseFee()),
 		VRF:              vrf,
 		VRFProof:         vrfProof,
 		Timestamp:        hexutil.Uint64(head.Time().Uint64()),
@@ -642,6 +664,7 @@ func blockWithFullTxFromBlock(b *types.Block) (*BlockWithFullTx, error) {
 		Size:             hexutil.Uint64(b.Size()),
 		GasLimit:         hexutil.Uint64(head.GasLimit()),
 		GasUsed:          hexutil.Uint64(head.GasUsed()),
+		BaseFee:          (*hexutil.Big)(head.BaseFee()),
 		VRF:              vrf,
 		VRFProof:         vrfProof,
 		Timestamp:        hexutil.Uint64(head.Time().Uint64()),
@@ -680,7 +703,7 @@ func NewTransactionFromBlockIndex(b *types.Block, index uint64) (*Transaction, e
 			"tx index %v greater than or equal to number of transactions on block %v", index, b.Hash().String(),
 		)
 	}
-	return NewTransaction(txs[index], b.Hash(), b.NumberU64(), b.Time().Uint64(), index)
+	return NewTransaction(txs[index], b.Hash(), b.NumberU64(), b.Time().Uint64(), index, b.BaseFee())
 }
 
 // StakingTransactionsFromBlock return rpc staking transactions from a block
@@ -717,3 +740,113 @@ func NewStakingTransactionFromBlockIndex(b *types.Block, index uint64) (*Staking
 	}
 	return NewStakingTransaction(txs[index], b.Hash(), b.NumberU64(), b.Time().Uint64(), index)
 }
+
+// AccountResult represents the EIP-1186 proof of an account and its storage
+// slots that will serialize to the RPC representation.
+type AccountResult struct {
+	Address      string          `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	IsValidator  bool            `json:"isValidator"`
+	Code         hexutil.Bytes   `json:"code,omitempty"`
+	StorageProof []StorageResult `json:"storageProof"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	StateRoot    common.Hash     `json:"stateRoot"`
+}
+
+// StorageResult represents the proof of a single storage slot that will serialize
+// to the RPC representation.
+type StorageResult struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []string     `json:"proof"`
+}
+
+// NewAccountResult returns the account proof that will serialize to the RPC representation.
+func NewAccountResult(proof *hmy.AccountProof) (*AccountResult, error) {
+	addr, err := internal_common.AddressToBech32(proof.Address)
+	if err != nil {
+		return nil, err
+	}
+	result := &AccountResult{
+		Address:      addr,
+		AccountProof: toHexSlice(proof.AccountProof),
+		Balance:      (*hexutil.Big)(proof.Balance),
+		CodeHash:     proof.CodeHash,
+		Nonce:        hexutil.Uint64(proof.Nonce),
+		StorageHash:  proof.StorageHash,
+		IsValidator:  proof.IsValidator,
+		Code:         proof.Code,
+		StorageProof: make([]StorageResult, 0, len(proof.StorageProof)),
+		BlockHash:    proof.BlockHash,
+		BlockNumber:  hexutil.Uint64(proof.BlockNumber),
+		StateRoot:    proof.StateRoot,
+	}
+	for _, sp := range proof.StorageProof {
+		result.StorageProof = append(result.StorageProof, StorageResult{
+			Key:   sp.Key.Hex(),
+			Value: (*hexutil.Big)(sp.Value),
+			Proof: toHexSlice(sp.Proof),
+		})
+	}
+	return result, nil
+}
+
+// toHexSlice creates a slice of hex-strings based on []byte.
+func toHexSlice(b [][]byte) []string {
+	r := make([]string, len(b))
+	for i := range b {
+		r[i] = hexutil.Encode(b[i])
+	}
+	return r
+}
+
+// FeeHistory represents the fee history of a range of blocks that will serialize
+// to the RPC representation. Staking transactions are reported separately from
+// plain transactions.
+type FeeHistory struct {
+	OldestBlock         *hexutil.Big     `json:"oldestBlock"`
+	Reward              [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee             []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio        []float64        `json:"gasUsedRatio"`
+	StakingReward       [][]*hexutil.Big `json:"stakingReward,omitempty"`
+	StakingGasUsedRatio []float64        `json:"stakingGasUsedRatio"`
+}
+
+// NewFeeHistory returns the fee history that will serialize to the RPC representation.
+func NewFeeHistory(history *hmy.FeeHistory) *FeeHistory {
+	result := &FeeHistory{
+		OldestBlock:         (*hexutil.Big)(new(big.Int).SetUint64(history.OldestBlock)),
+		GasUsedRatio:        history.GasUsedRatio,
+		StakingGasUsedRatio: history.StakingGasUsedRatio,
+	}
+	if history.Reward != nil {
+		result.Reward = toHexBigMatrix(history.Reward)
+	}
+	if history.StakingReward != nil {
+		result.StakingReward = toHexBigMatrix(history.StakingReward)
+	}
+	if len(history.BaseFee) != 0 {
+		result.BaseFee = make([]*hexutil.Big, len(history.BaseFee))
+		for i := range history.BaseFee {
+			result.BaseFee[i] = (*hexutil.Big)(history.BaseFee[i])
+		}
+	}
+	return result
+}
+
+// toHexBigMatrix converts a matrix of big ints to hex big ints.
+func toHexBigMatrix(m [][]*big.Int) [][]*hexutil.Big {
+	r := make([][]*hexutil.Big, len(m))
+	for i := range m {
+		r[i] = make([]*hexutil.Big, len(m[i]))
+		for j := range m[i] {
+			r[i][j] = (*hexutil.Big)(m[i][j])
+		}
+	}
+	return r
+}
@@ -12,6 +12,7 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/hmy"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	staking "github.com/harmony-one/harmony/staking/types"
 )
@@ -34,6 +35,7 @@ type BlockWithTxHash struct {
 	Size             uint64         `json:"size"`
 	GasLimit         uint64         `json:"gasLimit"`
 	GasUsed          uint64         `json:"gasUsed"`
+	BaseFee          *big.Int       `json:"baseFeePerGas,omitempty"`
 	VRF              common.Hash    `json:"vrf"`
 	VRFProof         hexutil.Bytes  `json:"vrfProof"`
 	Timestamp        *big.Int       `json:"timestamp"`
@@ -64,6 +66,7 @@ type BlockWithFullTx struct {
 	Size             uint64                `json:"size"`
 	GasLimit         uint64                `json:"gasLimit"`
 	GasUsed          uint64                `json:"gasUsed"`
+	BaseFee          *big.Int              `json:"baseFeePerGas,omitempty"`
 	VRF              common.Hash           `json:"vrf"`
 	VRFProof         hexutil.Bytes         `json:"vrfProof"`
 	Timestamp        *big.Int              `json:"timestamp"`
@@ -77,24 +80,29 @@ type BlockWithFullTx struct {
 
 // Transaction represents a transaction that will serialize to the RPC representation of a transaction
 type Transaction struct {
-	BlockHash        common.Hash   `json:"blockHash"`
-	BlockNumber      *big.Int      `json:"blockNumber"`
-	From             string        `json:"from"`
-	Timestamp        uint64        `json:"timestamp"`
-	Gas              uint64        `json:"gas"`
-	GasPrice         *big.Int      `json:"gasPrice"`
-	Hash             common.Hash   `json:"hash"`
-	EthHash          common.Hash   `json:"ethHash"`
-	Input            hexutil.Bytes `json:"input"`
-	Nonce            uint64        `json:"nonce"`
-	To               string        `json:"to"`
-	TransactionIndex uint64        `json:"transactionIndex"`
-	Value            *big.Int      `json:"value"`
-	ShardID          uint32        `json:"shardID"`
-	ToShardID        uint32        `json:"toShardID"`
-	V                *hexutil.Big  `json:"v"`
-	R                *hexutil.Big  `json:"r"`
-	S                *hexutil.Big  `json:"s"`
+	BlockHash        common.Hash       `json:"blockHash"`
+	BlockNumber      *big.Int          `json:"blockNumber"`
+	From             string            `json:"from"`
+	Timestamp        uint64            `json:"timestamp"`
+	Gas              uint64            `json:"gas"`
+	GasPrice         *big.Int          `json:"gasPrice"`
+	Hash             common.Hash       `json:"hash"`
+	EthHash          common.Hash       `json:"ethHash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            uint64            `json:"nonce"`
+	To               string            `json:"to"`
+	TransactionIndex uint64            `json:"transactionIndex"`
+	Value            *big.Int          `json:"value"`
+	ShardID          uint32            `json:"shardID"`
+	ToShardID        uint32            `json:"toShardID"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
+	Type             uint64            `json:"type"`
+	AccessList       *types.AccessList `json:"accessList,omitempty"`
+
+	MaxFeePerGas         *big.Int `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"maxPriorityFeePerGas,omitempty"`
 }
 
 // StakingTransaction represents a transaction that will serialize to the RPC representation of a staking transaction
@@ -255,7 +263,7 @@ func NewCxReceipt(cx *types.CXReceipt, blockHash common.Hash, blockNumber uint64
 // Note that all txs on Harmony are replay protected (post EIP155 epoch).
 func NewTransaction(
 	tx *types.Transaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64,
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int,
 ) (*Transaction, error) {
 	from, err := tx.SenderAddress()
 	if err != nil {
@@ -277,11 +285,24 @@ func NewTransaction(
 		V:         (*hexutil.Big)(v),
 		R:         (*hexutil.Big)(r),
 		S:         (*hexutil.Big)(s),
+		Type:      uint64(tx.Type()),
+	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.AccessList = &al
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.MaxFeePerGas = tx.GasFeeCap()
+		result.MaxPriorityFeePerGas = tx.GasTipCap()
 	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = blockHash
 		result.BlockNumber = new(big.Int).SetUint64(blockNumber)
 		result.TransactionIndex = index
+		// The gas price of the included transaction is the price actually paid
+		if baseFee != nil {
+			result.GasPrice = tx.EffectiveGasPrice(baseFee)
+		}
 	}
 
 	fromAddr, err := internal_common.AddressToBech32(from)
@@ -614,6 +635,7 @@ func blockWithTxHashFromBlock(b *types.Block) *BlockWithTxHash {
 		Size:             uint64(b.Size()),
 		GasLimit:         head.GasLimit(),
 		GasUsed:          head.GasUsed(),
+		BaseFee:          head.BaseFee(),
 		VRF:              vrf,
 		VRFProof:         vrfProof,
 		Timestamp:        head.Time(),
@@ -659,6 +681,7 @@ func blockWithFullTxFromBlock(b *types.Block) (*BlockWithFullTx, error) {
 		Size:             uint64(b.Size()),
 		GasLimit:         head.GasLimit(),
 		GasUsed:          head.GasUsed(),
+		BaseFee:          head.BaseFee(),
 		VRF:              vrf,
 		VRFProof:         vrfProof,
 		Timestamp:        head.Time(),
@@ -697,7 +720,7 @@ func NewTransactionFromBlockIndex(b *types.Block, index uint64) (*Transaction, e
 			"tx index %v greater than or equal to number of transactions on block %v", index, b.Hash().String(),
 		)
 	}
-	return NewTransaction(txs[index], b.Hash(), b.NumberU64(), b.Time().Uint64(), index)
+	return NewTransaction(txs[index], b.Hash(), b.NumberU64(), b.Time().Uint64(), index, b.BaseFee())
 }
 
 // StakingTransactionsFromBlock return rpc staking transactions from a block
@@ -734,3 +757,91 @@ func NewStakingTransactionFromBlockIndex(b *types.Block, index uint64) (*Staking
 	}
 	return NewStakingTransaction(txs[index], b.Hash(), b.NumberU64(), b.Time().Uint64(), index, true)
 }
+
+// AccountResult represents the EIP-1186 proof of an account and its storage
+// slots that will serialize to the RPC representation.
+type AccountResult struct {
+	Address      string          `json:"address"`
+	AccountProof []string        `json:"accountProof"`
+	Balance      *big.Int        `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        uint64          `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	IsValidator  bool            `json:"isValidator"`
+	Code         hexutil.Bytes   `json:"code,omitempty"`
+	StorageProof []StorageResult `json:"storageProof"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	BlockNumber  uint64          `json:"blockNumber"`
+	StateRoot    common.Hash     `json:"stateRoot"`
+}
+
+// StorageResult represents the proof of a single storage slot that will serialize
+// to the RPC representation.
+type StorageResult struct {
+	Key   string   `json:"key"`
+	Value *big.Int `json:"value"`
+	Proof []string `json:"proof"`
+}
+
+// NewAccountResult returns the account proof that will serialize to the RPC representation.
+func NewAccountResult(proof *hmy.AccountProof) (*AccountResult, error) {
+	addr, err := internal_common.AddressToBech32(proof.Address)
+	if err != nil {
+		return nil, err
+	}
+	result := &AccountResult{
+		Address:      addr,
+		AccountProof: toHexSlice(proof.AccountProof),
+		Balance:      proof.Balance,
+		CodeHash:     proof.CodeHash,
+		Nonce:        proof.Nonce,
+		StorageHash:  proof.StorageHash,
+		IsValidator:  proof.IsValidator,
+		Code:         proof.Code,
+		StorageProof: make([]StorageResult, 0, len(proof.StorageProof)),
+		BlockHash:    proof.BlockHash,
+		BlockNumber:  proof.BlockNumber,
+		StateRoot:    proof.StateRoot,
+	}
+	for _, sp := range proof.StorageProof {
+		result.StorageProof = append(result.StorageProof, StorageResult{
+			Key:   sp.Key.Hex(),
+			Value: sp.Value,
+			Proof: toHexSlice(sp.Proof),
+		})
+	}
+	return result, nil
+}
+
+// toHexSlice creates a slice of hex-strings based on []byte.
+func toHexSlice(b [][]byte) []string {
+	r := make([]string, len(b))
+	for i := range b {
+		r[i] = hexutil.Encode(b[i])
+	}
+	return r
+}
+
+// FeeHistory represents the fee history of a range of blocks that will serialize
+// to the RPC representation. Staking transactions are reported separately from
+// plain transactions.
+type FeeHistory struct {
+	OldestBlock         uint64       `json:"oldestBlock"`
+	Reward              [][]*big.Int `json:"reward,omitempty"`
+	BaseFee             []*big.Int   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio        []float64    `json:"gasUsedRatio"`
+	StakingReward       [][]*big.Int `json:"stakingReward,omitempty"`
+	StakingGasUsedRatio []float64    `json:"stakingGasUsedRatio"`
+}
+
+// NewFeeHistory returns the fee history that will serialize to the RPC representation.
+func NewFeeHistory(history *hmy.FeeHistory) *FeeHistory {
+	return &FeeHistory{
+		OldestBlock:         history.OldestBlock,
+		Reward:              history.Reward,
+		BaseFee:             history.BaseFee,
+		GasUsedRatio:        history.GasUsedRatio,
+		StakingReward:       history.StakingReward,
+		StakingGasUsedRatio: history.StakingGasUsedRatio,
+	}
+}
@@ -171,31 +171,41 @@ func IsBelowSigningThreshold(quotient numeric.Dec) bool {
 	return quotient.LTE(measure)
 }
 
+// EPoSStatusChange is a change of the EPoS status of a validator made by
+// ComputeAndMutateEPOSStatus.
+type EPoSStatusChange struct {
+	Address   common.Address
+	OldStatus effective.Eligibility
+	NewStatus effective.Eligibility
+}
+
 // ComputeAndMutateEPOSStatus sets the validator to
 // inactive and thereby keeping it out of
 // consideration in the pool of validators for
 // whenever committee selection happens in future, the
-// signing threshold is 66%
+// signing threshold is 66%. The change of the status
+// is returned, nil if the status is not changed.
 func ComputeAndMutateEPOSStatus(
 	bc Reader,
 	state ValidatorState,
 	addr common.Address,
-) error {
+) (*EPoSStatusChange, error) {
 	utils.Logger().Info().Msg("begin compute for availability")
 
 	wrapper, err := state.ValidatorWrapper(addr)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if wrapper.Status == effective.Banned {
 		utils.Logger().Debug().Msg("Can't update EPoS status on a banned validator")
-		return nil
+		return nil, nil
 	}
 
 	snapshot, err := bc.ReadValidatorSnapshot(wrapper.Address)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	oldStatus := wrapper.Status
 
 	computed := ComputeCurrentSigning(snapshot.Validator, wrapper)
 
@@ -217,7 +227,14 @@ func ComputeAndMutateEPOSStatus(
 		// to leave the committee can actually leave.
 	}
 
-	return nil
+	if wrapper.Status == oldStatus {
+		return nil, nil
+	}
+	return &EPoSStatusChange{
+		Address:   wrapper.Address,
+		OldStatus: oldStatus,
+		NewStatus: wrapper.Status,
+	}, nil
 }
 
 // UpdateMinimumCommissionFee update the validator commission fee to the minimum 5%
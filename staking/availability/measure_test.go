@@ -321,7 +321,7 @@ func TestComputeAndMutateEPOSStatus(t *testing.T) {
 		ctx := test.ctx
 		ctx.makeStateAndReader()
 
-		err := ComputeAndMutateEPOSStatus(ctx.reader, ctx.state, ctx.addr)
+		change, err := ComputeAndMutateEPOSStatus(ctx.reader, ctx.state, ctx.addr)
 		if err != nil {
 			if test.expErr == nil {
 				t.Errorf("Test %v: unexpected error: %v", i, err)
@@ -332,6 +332,12 @@ func TestComputeAndMutateEPOSStatus(t *testing.T) {
 		if err := ctx.checkWrapperStatus(test.expStatus); err != nil {
 			t.Errorf("Test %v: %v", i, err)
 		}
+		if changed := test.expStatus != ctx.curEli; changed != (change != nil) {
+			t.Errorf("Test %v: unexpected status change %+v", i, change)
+		} else if change != nil && (change.Address != ctx.addr ||
+			change.OldStatus != ctx.curEli || change.NewStatus != test.expStatus) {
+			t.Errorf("Test %v: unexpected status change %+v", i, change)
+		}
 	}
 }
 
@@ -0,0 +1,354 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+// Headers of the webhook requests
+const (
+	HeaderEvent     = "X-Harmony-Event"
+	HeaderDelivery  = "X-Harmony-Delivery"
+	HeaderTimestamp = "X-Harmony-Timestamp"
+	// HeaderSignature is the hex encoded HMAC-SHA256 of "{timestamp}.{body}",
+	// prefixed with "sha256=".
+	HeaderSignature = "X-Harmony-Signature"
+)
+
+const (
+	defaultMaxAttempts    = 10
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 10 * time.Minute
+	defaultTimeout        = 10 * time.Second
+
+	deliveryCheckInterval   = time.Second
+	maxConcurrentDeliveries = 8
+	maxResponseBodySize     = 64 * 1024
+)
+
+// DeliveryConfig is the config of the webhook delivery. Zero values are replaced
+// with the default values.
+type DeliveryConfig struct {
+	// MaxAttempts is the number of attempts before a delivery is dropped
+	MaxAttempts int `yaml:"max-attempts"`
+	// InitialBackoff is the delay before the first retry. The delay is doubled
+	// for each following retry, capped by MaxBackoff.
+	InitialBackoff time.Duration `yaml:"initial-backoff"`
+	MaxBackoff     time.Duration `yaml:"max-backoff"`
+	// Timeout is the default timeout of a single request
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+func (c DeliveryConfig) sanitize() DeliveryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultMaxAttempts
+	}
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = defaultInitialBackoff
+	}
+	if c.MaxBackoff < c.InitialBackoff {
+		c.MaxBackoff = defaultMaxBackoff
+		if c.MaxBackoff < c.InitialBackoff {
+			c.MaxBackoff = c.InitialBackoff
+		}
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	return c
+}
+
+// backoff returns the delay before the next attempt after the given number of
+// failed attempts.
+func (c DeliveryConfig) backoff(attempts int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= c.MaxBackoff {
+			return c.MaxBackoff
+		}
+	}
+	return delay
+}
+
+// Deliverer delivers the webhook payloads reliably. Each payload is persisted in
+// a disk-backed outbox before delivery, and is retried with exponential backoff
+// until the receiver responds with 2xx status or the max attempts is reached.
+// Pending deliveries in the outbox are resumed after the node restarts.
+type Deliverer struct {
+	hooks  *Hooks
+	config DeliveryConfig
+	outbox *outbox
+	client *http.Client
+
+	pending  map[string]*delivery
+	inflight map[string]struct{}
+	seq      uint64
+	lock     sync.Mutex
+
+	ctx     context.Context
+	cancel  func()
+	notifyC chan struct{}
+	wg      sync.WaitGroup
+	log     zerolog.Logger
+}
+
+// NewDeliverer creates a new Deliverer of the hooks with the outbox stored in
+// outboxDir.
+func NewDeliverer(hooks *Hooks, outboxDir string) (*Deliverer, error) {
+	var config DeliveryConfig
+	if hooks != nil && hooks.Delivery != nil {
+		config = *hooks.Delivery
+	}
+	ob, err := newOutbox(outboxDir)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := ob.loadAll()
+	if err != nil {
+		ob.close()
+		return nil, errors.Wrap(err, "load webhook outbox")
+	}
+	pending := make(map[string]*delivery, len(ds))
+	for _, d := range ds {
+		pending[d.ID] = d
+	}
+	outboxPendingGauge.Set(float64(len(pending)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Deliverer{
+		hooks:    hooks,
+		config:   config.sanitize(),
+		outbox:   ob,
+		client:   &http.Client{},
+		pending:  pending,
+		inflight: make(map[string]struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+		notifyC:  make(chan struct{}, 1),
+		log:      utils.Logger().With().Str("module", "webhooks").Logger(),
+	}, nil
+}
+
+// Start starts the delivery loop
+func (d *Deliverer) Start() {
+	d.wg.Add(1)
+	go d.run()
+}
+
+// Close stops the delivery and closes the outbox. Deliveries not finished are
+// kept in the outbox and resumed next time.
+func (d *Deliverer) Close() error {
+	d.cancel()
+	d.wg.Wait()
+	return d.outbox.close()
+}
+
+// Post persists the record of the event in the outbox, and delivers it to the
+// hook of the event asynchronously. Nothing is done if the hook of the event
+// is not configured.
+func (d *Deliverer) Post(event string, record interface{}) error {
+	if d.hooks.hookForEvent(event) == nil {
+		return nil
+	}
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "marshal %v record", event)
+	}
+	now := time.Now()
+
+	d.lock.Lock()
+	d.seq++
+	dl := &delivery{
+		ID:          makeDeliveryID(now, d.seq),
+		Event:       event,
+		Payload:     payload,
+		CreatedAt:   now,
+		NextAttempt: now,
+	}
+	if err := d.outbox.put(dl); err != nil {
+		d.lock.Unlock()
+		return errors.Wrapf(err, "persist %v delivery", event)
+	}
+	d.pending[dl.ID] = dl
+	outboxPendingGauge.Set(float64(len(d.pending)))
+	d.lock.Unlock()
+
+	select {
+	case d.notifyC <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// makeDeliveryID returns an ID which sorts the deliveries by creation time
+func makeDeliveryID(t time.Time, seq uint64) string {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b[:8], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(b[8:], seq)
+	return hex.EncodeToString(b)
+}
+
+func (d *Deliverer) run() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(deliveryCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverDue()
+
+		select {
+		case <-d.notifyC:
+		case <-ticker.C:
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// deliverDue starts the deliveries whose next attempt is due
+func (d *Deliverer) deliverDue() {
+	now := time.Now()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for id, dl := range d.pending {
+		if len(d.inflight) >= maxConcurrentDeliveries {
+			return
+		}
+		if _, ok := d.inflight[id]; ok || dl.NextAttempt.After(now) {
+			continue
+		}
+		d.inflight[id] = struct{}{}
+		d.wg.Add(1)
+		go d.deliver(*dl)
+	}
+}
+
+func (d *Deliverer) deliver(dl delivery) {
+	defer d.wg.Done()
+
+	hook := d.hooks.hookForEvent(dl.Event)
+	var err error
+	if hook != nil {
+		err = d.send(hook, &dl)
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.inflight, dl.ID)
+
+	if d.ctx.Err() != nil {
+		// Shutting down. Keep the delivery in the outbox for the next start.
+		return
+	}
+	dl.Attempts++
+	switch {
+	case hook == nil:
+		d.log.Warn().Str("event", dl.Event).Str("id", dl.ID).
+			Msg("webhook not configured, drop delivery")
+		d.finish(&dl, statusDropped)
+
+	case err == nil:
+		d.finish(&dl, statusSuccess)
+
+	case dl.Attempts >= d.config.MaxAttempts:
+		d.log.Error().Err(err).Str("event", dl.Event).Str("id", dl.ID).
+			Int("attempts", dl.Attempts).
+			Msg("webhook delivery failed, drop delivery")
+		d.finish(&dl, statusDropped)
+
+	default:
+		dl.NextAttempt = time.Now().Add(d.config.backoff(dl.Attempts))
+		d.log.Warn().Err(err).Str("event", dl.Event).Str("id", dl.ID).
+			Int("attempts", dl.Attempts).Time("next", dl.NextAttempt).
+			Msg("webhook delivery failed, retry later")
+		if err := d.outbox.put(&dl); err != nil {
+			d.log.Error().Err(err).Str("id", dl.ID).Msg("failed to update webhook outbox")
+		}
+		d.pending[dl.ID] = &dl
+		deliveryCounterVec.With(prometheusLabels(dl.Event, statusRetry)).Inc()
+	}
+}
+
+// finish removes the delivery from the outbox. Called with lock held.
+func (d *Deliverer) finish(dl *delivery, status string) {
+	if err := d.outbox.delete(dl.ID); err != nil {
+		d.log.Error().Err(err).Str("id", dl.ID).Msg("failed to delete from webhook outbox")
+	}
+	delete(d.pending, dl.ID)
+	outboxPendingGauge.Set(float64(len(d.pending)))
+	deliveryCounterVec.With(prometheusLabels(dl.Event, status)).Inc()
+}
+
+// send does a single delivery attempt of the payload to the hook
+func (d *Deliverer) send(hook *Hook, dl *delivery) error {
+	timeout := hook.Timeout
+	if timeout <= 0 {
+		timeout = d.config.Timeout
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, dl.Event)
+	req.Header.Set(HeaderDelivery, dl.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if len(hook.Secret) != 0 {
+		req.Header.Set(HeaderSignature, Sign(hook.Secret, timestamp, dl.Payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBodySize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the value of the signature header of the request. Receivers shall
+// compute the same value with the shared secret, and compare it with the header
+// in constant time.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func prometheusLabels(event, status string) map[string]string {
+	return map[string]string{
+		"event":  event,
+		"status": status,
+	}
+}
@@ -0,0 +1,209 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestHook_UnmarshalYAML(t *testing.T) {
+	raw := `
+delivery:
+  max-attempts: 3
+slashing-hooks:
+  on-notice-double-sign:
+    url: http://localhost:5430/double-sign
+    secret: secret
+    timeout: 5s
+protocol-hooks:
+  on-cannot-commit-block: http://localhost:5430/cannot-commit
+`
+	var hooks Hooks
+	if err := yaml.UnmarshalStrict([]byte(raw), &hooks); err != nil {
+		t.Fatal(err)
+	}
+	if hooks.Delivery == nil || hooks.Delivery.MaxAttempts != 3 {
+		t.Errorf("unexpected delivery config: %+v", hooks.Delivery)
+	}
+	exp := Hook{URL: "http://localhost:5430/double-sign", Secret: "secret", Timeout: 5 * time.Second}
+	if hook := hooks.hookForEvent(EventNoticeDoubleSign); hook == nil || *hook != exp {
+		t.Errorf("unexpected double sign hook: %+v", hook)
+	}
+	exp = Hook{URL: "http://localhost:5430/cannot-commit"}
+	if hook := hooks.hookForEvent(EventCannotCommitBlock); hook == nil || *hook != exp {
+		t.Errorf("unexpected cannot commit hook: %+v", hook)
+	}
+	if hook := hooks.hookForEvent(EventDroppedBelowThreshold); hook != nil {
+		t.Errorf("unexpected availability hook: %+v", hook)
+	}
+}
+
+func TestNewWebHooksFromPath(t *testing.T) {
+	hooks, err := NewWebHooksFromPath("webhook.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []string{
+		EventNoticeDoubleSign,
+		EventDroppedBelowThreshold,
+		EventCannotCommitBlock,
+		EventEPoSStatusChanged,
+		EventNewEpoch,
+		EventOutOfSync,
+		EventCommitteeElection,
+		EventLeaderViewChange,
+	}
+	for _, event := range events {
+		if !hooks.IsConfigured(event) {
+			t.Errorf("event %v not configured", event)
+		}
+	}
+}
+
+func TestDeliveryConfig_backoff(t *testing.T) {
+	c := DeliveryConfig{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}.sanitize()
+	tests := []struct {
+		attempts int
+		exp      time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for i, test := range tests {
+		if got := c.backoff(test.attempts); got != test.exp {
+			t.Errorf("Test %v: unexpected backoff %v / %v", i, got, test.exp)
+		}
+	}
+}
+
+func TestDeliverer_Post(t *testing.T) {
+	var (
+		lock     sync.Mutex
+		requests int
+		bodies   []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		lock.Lock()
+		defer lock.Unlock()
+
+		requests++
+		if r.Header.Get(HeaderEvent) != EventNoticeDoubleSign {
+			t.Errorf("unexpected event header %v", r.Header.Get(HeaderEvent))
+		}
+		expSig := Sign("secret", r.Header.Get(HeaderTimestamp), body)
+		if sig := r.Header.Get(HeaderSignature); sig != expSig {
+			t.Errorf("unexpected signature %v / %v", sig, expSig)
+		}
+		// Fail the first attempt to trigger retry
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		bodies = append(bodies, string(body))
+	}))
+	defer srv.Close()
+
+	hooks := &Hooks{
+		Delivery: &DeliveryConfig{InitialBackoff: 10 * time.Millisecond},
+		Slashing: &DoubleSignWebHooks{
+			OnNoticeDoubleSign: &Hook{URL: srv.URL, Secret: "secret"},
+		},
+	}
+	dir, err := ioutil.TempDir("", "webhooks-outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	d, err := NewDeliverer(hooks, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Start()
+	defer d.Close()
+
+	record := map[string]string{"key": "value"}
+	if err := d.Post(EventNoticeDoubleSign, record); err != nil {
+		t.Fatal(err)
+	}
+	// Not configured hook shall not be delivered
+	if err := d.Post(EventCannotCommitBlock, record); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		d.lock.Lock()
+		numPending := len(d.pending)
+		d.lock.Unlock()
+		if numPending == 0 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if requests != 2 {
+		t.Fatalf("unexpected number of requests: %v", requests)
+	}
+	expBody, _ := json.Marshal(record)
+	if len(bodies) != 1 || bodies[0] != string(expBody) {
+		t.Fatalf("unexpected bodies: %v", bodies)
+	}
+	ds, err := d.outbox.loadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ds) != 0 {
+		t.Fatalf("outbox not empty: %v", len(ds))
+	}
+}
+
+func TestDeliverer_resume(t *testing.T) {
+	hooks := &Hooks{
+		Slashing: &DoubleSignWebHooks{
+			OnNoticeDoubleSign: &Hook{URL: "http://localhost:1"},
+		},
+	}
+	dir, err := ioutil.TempDir("", "webhooks-outbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// Post without starting the delivery loop
+	d, err := NewDeliverer(hooks, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Post(EventNoticeDoubleSign, "record"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err = NewDeliverer(hooks, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if len(d.pending) != 1 {
+		t.Fatalf("unexpected pending deliveries: %v", len(d.pending))
+	}
+	for _, dl := range d.pending {
+		if dl.Event != EventNoticeDoubleSign || string(dl.Payload) != `"record"` {
+			t.Errorf("unexpected delivery: %+v", dl)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package webhooks
+
+// NewEpochPayload is the JSON payload of EventNewEpoch, posted when the first
+// block of a new epoch is inserted into the chain of the node.
+//
+//	{
+//	  "shard-id": 0,
+//	  "epoch": 100,
+//	  "block-number": 3276800,
+//	  "block-hash": "0x..."
+//	}
+type NewEpochPayload struct {
+	ShardID     uint32 `json:"shard-id"`
+	Epoch       uint64 `json:"epoch"`
+	BlockNumber uint64 `json:"block-number"`
+	BlockHash   string `json:"block-hash"`
+}
+
+// CommitteeElectionPayload is the JSON payload of EventCommitteeElection, posted
+// when the last block of an epoch carrying the shard state of the next epoch is
+// inserted into the chain of the node. Each BLS key of the node is listed either
+// in elected or in not-elected.
+//
+//	{
+//	  "epoch": 101,
+//	  "block-number": 3276799,
+//	  "elected": [
+//	    {"bls-public-key": "0x...", "shard-id": 0, "validator-address": "one1..."}
+//	  ],
+//	  "not-elected": ["0x..."]
+//	}
+type CommitteeElectionPayload struct {
+	Epoch       uint64        `json:"epoch"`
+	BlockNumber uint64        `json:"block-number"`
+	Elected     []ElectedSlot `json:"elected"`
+	NotElected  []string      `json:"not-elected"`
+}
+
+// ElectedSlot is an elected BLS key of the node in CommitteeElectionPayload
+type ElectedSlot struct {
+	BLSPublicKey     string `json:"bls-public-key"`
+	ShardID          uint32 `json:"shard-id"`
+	ValidatorAddress string `json:"validator-address"`
+}
+
+// EPoSStatusChangedPayload is the JSON payload of EventEPoSStatusChanged, posted
+// when the EPoS status of the validator of the node changes in the beacon chain,
+// e.g. set to inactive by availability.ComputeAndMutateEPOSStatus at the end of
+// epoch for not meeting the signing threshold.
+//
+//	{
+//	  "validator-address": "one1...",
+//	  "epoch": 100,
+//	  "block-number": 3276799,
+//	  "old-status": "active",
+//	  "new-status": "inactive"
+//	}
+type EPoSStatusChangedPayload struct {
+	ValidatorAddress string `json:"validator-address"`
+	Epoch            uint64 `json:"epoch"`
+	BlockNumber      uint64 `json:"block-number"`
+	OldStatus        string `json:"old-status"`
+	NewStatus        string `json:"new-status"`
+}
+
+// OutOfSyncPayload is the JSON payload of EventOutOfSync, posted when the node
+// falls out of sync of the shard chain.
+//
+//	{
+//	  "shard-id": 0,
+//	  "current-block": 3276700,
+//	  "target-block": 3276800
+//	}
+type OutOfSyncPayload struct {
+	ShardID      uint32 `json:"shard-id"`
+	CurrentBlock uint64 `json:"current-block"`
+	TargetBlock  uint64 `json:"target-block"`
+}
+
+// LeaderViewChangePayload is the JSON payload of EventLeaderViewChange, posted
+// when the view change starts while one of the BLS keys of the node is the leader.
+//
+//	{
+//	  "shard-id": 0,
+//	  "block-number": 3276800,
+//	  "view-id": 3276900,
+//	  "next-view-id": 3276901,
+//	  "leader-bls-public-key": "0x...",
+//	  "next-leader-bls-public-key": "0x..."
+//	}
+type LeaderViewChangePayload struct {
+	ShardID          uint32 `json:"shard-id"`
+	BlockNumber      uint64 `json:"block-number"`
+	ViewID           uint64 `json:"view-id"`
+	NextViewID       uint64 `json:"next-view-id"`
+	LeaderPubKey     string `json:"leader-bls-public-key"`
+	NextLeaderPubKey string `json:"next-leader-bls-public-key"`
+}
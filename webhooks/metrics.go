@@ -0,0 +1,41 @@
+package webhooks
+
+import (
+	prom "github.com/harmony-one/harmony/api/service/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// delivery status
+const (
+	statusSuccess = "success"
+	statusRetry   = "retry"
+	statusDropped = "dropped"
+)
+
+func init() {
+	prom.PromRegistry().MustRegister(
+		deliveryCounterVec,
+		outboxPendingGauge,
+	)
+}
+
+var (
+	deliveryCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "webhooks",
+			Name:      "delivery",
+			Help:      "number of webhook delivery attempts by event and status",
+		},
+		[]string{"event", "status"},
+	)
+
+	outboxPendingGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "hmy",
+			Subsystem: "webhooks",
+			Name:      "outbox_pending",
+			Help:      "number of webhook deliveries pending in the outbox",
+		},
+	)
+)
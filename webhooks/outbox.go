@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// DefaultOutboxDir is the name of the outbox directory in the node data dir
+const DefaultOutboxDir = "webhooks_outbox"
+
+// delivery is a webhook payload pending to be delivered. It is persisted in the
+// outbox until the delivery succeeds or is dropped.
+type delivery struct {
+	ID          string          `json:"id"`
+	Event       string          `json:"event"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	CreatedAt   time.Time       `json:"created-at"`
+	NextAttempt time.Time       `json:"next-attempt"`
+}
+
+// outbox is the disk-backed storage of the pending deliveries, keyed by
+// delivery ID.
+type outbox struct {
+	db *leveldb.DB
+}
+
+func newOutbox(dir string) (*outbox, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "open webhook outbox %v", dir)
+	}
+	return &outbox{db: db}, nil
+}
+
+func (ob *outbox) put(d *delivery) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return ob.db.Put([]byte(d.ID), b, nil)
+}
+
+func (ob *outbox) delete(id string) error {
+	return ob.db.Delete([]byte(id), nil)
+}
+
+// loadAll returns all pending deliveries in the outbox. Entries that cannot be
+// decoded are removed from the outbox.
+func (ob *outbox) loadAll() ([]*delivery, error) {
+	var (
+		ds     []*delivery
+		broken [][]byte
+	)
+	it := ob.db.NewIterator(nil, nil)
+	for it.Next() {
+		var d delivery
+		if err := json.Unmarshal(it.Value(), &d); err != nil {
+			broken = append(broken, append([]byte{}, it.Key()...))
+			continue
+		}
+		ds = append(ds, &d)
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	for _, key := range broken {
+		if err := ob.db.Delete(key, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ds, nil
+}
+
+func (ob *outbox) close() error {
+	return ob.db.Close()
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -14,26 +15,129 @@ const (
 	DefaultWebHookPath = "webhooks/webhook.example.yaml"
 )
 
+// Events of the webhooks. The payload schema of the new events are documented
+// in events.go.
+const (
+	EventNoticeDoubleSign      = "on-notice-double-sign"
+	EventDroppedBelowThreshold = "on-dropped-below-threshold"
+	EventCannotCommitBlock     = "on-cannot-commit-block"
+	EventEPoSStatusChanged     = "on-epos-status-changed"
+	EventNewEpoch              = "on-new-epoch"
+	EventOutOfSync             = "on-out-of-sync"
+	EventCommitteeElection     = "on-committee-election"
+	EventLeaderViewChange      = "on-leader-view-change"
+)
+
+// Hook is the endpoint of a webhook. It can be configured either with the URL only,
+// or with a mapping of url, secret and timeout.
+type Hook struct {
+	URL string `yaml:"url"`
+	// Secret is the key to sign the request body with HMAC-SHA256. Requests are
+	// not signed if empty.
+	Secret string `yaml:"secret"`
+	// Timeout of a single request. DeliveryConfig.Timeout is used if zero.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// UnmarshalYAML unmarshal the hook either from an URL string or a mapping
+func (h *Hook) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var url string
+	if err := unmarshal(&url); err == nil {
+		*h = Hook{URL: url}
+		return nil
+	}
+	type plain Hook
+	return unmarshal((*plain)(h))
+}
+
 // AvailabilityHooks ..
 type AvailabilityHooks struct {
-	OnDroppedBelowThreshold string `yaml:"on-dropped-below-threshold"`
+	OnDroppedBelowThreshold *Hook `yaml:"on-dropped-below-threshold"`
+	OnEPoSStatusChanged     *Hook `yaml:"on-epos-status-changed"`
+}
+
+// ChainHooks ..
+type ChainHooks struct {
+	OnNewEpoch  *Hook `yaml:"on-new-epoch"`
+	OnOutOfSync *Hook `yaml:"on-out-of-sync"`
+}
+
+// CommitteeHooks ..
+type CommitteeHooks struct {
+	OnCommitteeElection *Hook `yaml:"on-committee-election"`
+	OnLeaderViewChange  *Hook `yaml:"on-leader-view-change"`
 }
 
 // DoubleSignWebHooks ..
 type DoubleSignWebHooks struct {
-	OnNoticeDoubleSign string `yaml:"on-notice-double-sign"`
+	OnNoticeDoubleSign *Hook `yaml:"on-notice-double-sign"`
 }
 
 // BadBlockHooks ..
 type BadBlockHooks struct {
-	OnCannotCommit string `yaml:"on-cannot-commit-block"`
+	OnCannotCommit *Hook `yaml:"on-cannot-commit-block"`
 }
 
 // Hooks ..
 type Hooks struct {
+	Delivery       *DeliveryConfig     `yaml:"delivery"`
 	Slashing       *DoubleSignWebHooks `yaml:"slashing-hooks"`
 	Availability   *AvailabilityHooks  `yaml:"availability-hooks"`
 	ProtocolIssues *BadBlockHooks      `yaml:"protocol-hooks"`
+	Chain          *ChainHooks         `yaml:"chain-hooks"`
+	Committee      *CommitteeHooks     `yaml:"committee-hooks"`
+}
+
+// IsConfigured returns whether the hook of the event is configured
+func (h *Hooks) IsConfigured(event string) bool {
+	return h.hookForEvent(event) != nil
+}
+
+// hookForEvent returns the hook configured for the event. Nil is returned if
+// the hook of the event is not configured.
+func (h *Hooks) hookForEvent(event string) *Hook {
+	if h == nil {
+		return nil
+	}
+	var hook *Hook
+	switch event {
+	case EventNoticeDoubleSign:
+		if h.Slashing != nil {
+			hook = h.Slashing.OnNoticeDoubleSign
+		}
+	case EventDroppedBelowThreshold:
+		if h.Availability != nil {
+			hook = h.Availability.OnDroppedBelowThreshold
+		}
+	case EventCannotCommitBlock:
+		if h.ProtocolIssues != nil {
+			hook = h.ProtocolIssues.OnCannotCommit
+		}
+	case EventEPoSStatusChanged:
+		if h.Availability != nil {
+			hook = h.Availability.OnEPoSStatusChanged
+		}
+	case EventNewEpoch:
+		if h.Chain != nil {
+			hook = h.Chain.OnNewEpoch
+		}
+	case EventOutOfSync:
+		if h.Chain != nil {
+			hook = h.Chain.OnOutOfSync
+		}
+	case EventCommitteeElection:
+		if h.Committee != nil {
+			hook = h.Committee.OnCommitteeElection
+		}
+	case EventLeaderViewChange:
+		if h.Committee != nil {
+			hook = h.Committee.OnLeaderViewChange
+		}
+	}
+	if hook == nil || len(hook.URL) == 0 {
+		return nil
+	}
+	return hook
 }
 
 // ReportResult ..